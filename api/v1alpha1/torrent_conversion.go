@@ -0,0 +1,439 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+// AnnotationSource keeps the v1beta1 sources v1alpha1 cannot represent, such
+// as .torrent files, so that they survive a round trip through v1alpha1
+const AnnotationSource = "torrent.qbittorrent.io/v1beta1-source"
+
+// ConvertTo converts this Torrent (v1alpha1) to the Hub version (v1beta1).
+func (src *Torrent) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*torrentv1beta1.Torrent)
+	dst.ObjectMeta = src.ObjectMeta
+
+	// Spec
+	dst.Spec.Source.MagnetURI = src.Spec.MagnetURI
+	if source, ok := src.Annotations[AnnotationSource]; ok {
+		if err := json.Unmarshal([]byte(source), &dst.Spec.Source); err != nil {
+			return fmt.Errorf("invalid %s annotation: %w", AnnotationSource, err)
+		}
+		dst.Annotations = maps.Clone(src.Annotations)
+		delete(dst.Annotations, AnnotationSource)
+	}
+	dst.Spec.ServerName = src.Spec.ServerName
+	dst.Spec.Category = src.Spec.Category
+	dst.Spec.SavePath = src.Spec.SavePath
+	dst.Spec.DeletionPolicy = torrentv1beta1.DeletionPolicy(src.Spec.DeletionPolicy)
+	dst.Spec.DeletionProtection = torrentv1beta1.DeletionProtection(src.Spec.DeletionProtection)
+	dst.Spec.MissingFilesPolicy = torrentv1beta1.MissingFilesPolicy(src.Spec.MissingFilesPolicy)
+	dst.Spec.Priority = torrentv1beta1.TorrentPriority(src.Spec.Priority)
+	dst.Spec.StatusDetail = torrentv1beta1.StatusDetail(src.Spec.StatusDetail)
+	dst.Spec.ReportPeers = src.Spec.ReportPeers
+	dst.Spec.WebSeeds = src.Spec.WebSeeds
+	dst.Spec.Limits = convertLimitsTo(src.Spec.Limits)
+	dst.Spec.DailyTransferBudget = src.Spec.DailyTransferBudget
+	if src.Spec.DriftPolicy != nil {
+		dst.Spec.DriftPolicy = &torrentv1beta1.DriftPolicy{
+			Category: torrentv1beta1.DriftAction(src.Spec.DriftPolicy.Category),
+			Limits:   torrentv1beta1.DriftAction(src.Spec.DriftPolicy.Limits),
+		}
+	}
+	if src.Spec.ContentVolume != nil {
+		dst.Spec.ContentVolume = &torrentv1beta1.ContentVolume{
+			ClaimName: src.Spec.ContentVolume.ClaimName,
+			MountPath: src.Spec.ContentVolume.MountPath,
+		}
+	}
+	if src.Spec.Checksums != nil {
+		dst.Spec.Checksums = &torrentv1beta1.ChecksumSpec{
+			Enabled: src.Spec.Checksums.Enabled,
+			Image:   src.Spec.Checksums.Image,
+		}
+	}
+	if src.Spec.ContentVerification != nil {
+		dst.Spec.ContentVerification = &torrentv1beta1.ContentVerificationSpec{
+			Enabled: src.Spec.ContentVerification.Enabled,
+			Image:   src.Spec.ContentVerification.Image,
+		}
+	}
+	if src.Spec.HardlinkCheck != nil {
+		dst.Spec.HardlinkCheck = &torrentv1beta1.HardlinkCheckSpec{
+			Enabled: src.Spec.HardlinkCheck.Enabled,
+			Image:   src.Spec.HardlinkCheck.Image,
+		}
+	}
+	for _, source := range src.Spec.CrossSeed {
+		dst.Spec.CrossSeed = append(dst.Spec.CrossSeed, torrentv1beta1.CrossSeedSource{
+			Name:       source.Name,
+			MagnetURI:  source.MagnetURI,
+			TorrentURL: source.TorrentURL,
+		})
+	}
+	dst.Spec.DependsOn = src.Spec.DependsOn
+	dst.Spec.ApprovalRequired = src.Spec.ApprovalRequired
+	dst.Spec.MetadataOnly = src.Spec.MetadataOnly
+	dst.Spec.ExcludeFiles = src.Spec.ExcludeFiles
+	dst.Spec.CompletionDeadline = src.Spec.CompletionDeadline
+	if src.Spec.DeadlineEscalation != nil {
+		dst.Spec.DeadlineEscalation = &torrentv1beta1.DeadlineEscalation{
+			Before:        src.Spec.DeadlineEscalation.Before,
+			BoostPriority: src.Spec.DeadlineEscalation.BoostPriority,
+			RemoveLimits:  src.Spec.DeadlineEscalation.RemoveLimits,
+		}
+	}
+
+	// Status
+	dst.Status.Hash = src.Status.Hash
+	dst.Status.Name = src.Status.Name
+	dst.Status.State = torrentv1beta1.TorrentState(src.Status.State)
+	dst.Status.Phase = torrentv1beta1.TorrentPhase(src.Status.Phase)
+	if src.Status.AllowedActions != nil {
+		dst.Status.AllowedActions = &torrentv1beta1.AllowedActions{
+			CanPause:   src.Status.AllowedActions.CanPause,
+			CanResume:  src.Status.AllowedActions.CanResume,
+			CanRecheck: src.Status.AllowedActions.CanRecheck,
+		}
+	}
+	dst.Status.ContentPath = src.Status.ContentPath
+	dst.Status.AddedOn = src.Status.AddedOn
+	dst.Status.TotalSize = src.Status.TotalSize
+	dst.Status.AmountLeft = src.Status.AmountLeft
+	dst.Status.TimeActive = src.Status.TimeActive
+	dst.Status.LastSyncedTime = src.Status.LastSyncedTime
+	dst.Status.Category = src.Status.Category
+	dst.Status.Tags = src.Status.Tags
+	dst.Status.ChecksumConfigMap = src.Status.ChecksumConfigMap
+	dst.Status.ContentVerifiedTime = src.Status.ContentVerifiedTime
+	dst.Status.QueuedPriority = torrentv1beta1.TorrentPriority(src.Status.QueuedPriority)
+	dst.Status.Preempted = src.Status.Preempted
+	for _, drift := range src.Status.Drift {
+		dst.Status.Drift = append(dst.Status.Drift, torrentv1beta1.FieldDrift{
+			Field:   drift.Field,
+			Desired: drift.Desired,
+			Actual:  drift.Actual,
+		})
+	}
+	for _, crossSeed := range src.Status.CrossSeeds {
+		dst.Status.CrossSeeds = append(dst.Status.CrossSeeds, torrentv1beta1.CrossSeedStatus{
+			Name:  crossSeed.Name,
+			Hash:  crossSeed.Hash,
+			State: torrentv1beta1.TorrentState(crossSeed.State),
+		})
+	}
+	for _, file := range src.Status.Files {
+		dst.Status.Files = append(dst.Status.Files, torrentv1beta1.TorrentFileStatus{
+			Name:     file.Name,
+			Size:     file.Size,
+			Progress: file.Progress,
+			Priority: file.Priority,
+		})
+	}
+	dst.Status.TruncatedFiles = src.Status.TruncatedFiles
+	if src.Status.Transfer != nil {
+		dst.Status.Transfer = &torrentv1beta1.TransferStatus{
+			Downloaded:        src.Status.Transfer.Downloaded,
+			DownloadedSession: src.Status.Transfer.DownloadedSession,
+			Uploaded:          src.Status.Transfer.Uploaded,
+			UploadedSession:   src.Status.Transfer.UploadedSession,
+			DownloadSpeed:     src.Status.Transfer.DownloadSpeed,
+			UploadSpeed:       src.Status.Transfer.UploadSpeed,
+			LastActivityTime:  src.Status.Transfer.LastActivityTime,
+		}
+	}
+	if src.Status.TransferBudget != nil {
+		dst.Status.TransferBudget = &torrentv1beta1.TransferBudgetStatus{
+			WindowStart:   src.Status.TransferBudget.WindowStart,
+			Consumed:      src.Status.TransferBudget.Consumed,
+			ExhaustedTime: src.Status.TransferBudget.ExhaustedTime,
+		}
+	}
+	if src.Status.Peers != nil {
+		dst.Status.Peers = &torrentv1beta1.PeerSummary{
+			Connected: src.Status.Peers.Connected,
+			Encrypted: src.Status.Peers.Encrypted,
+		}
+		for _, count := range src.Status.Peers.Clients {
+			dst.Status.Peers.Clients = append(dst.Status.Peers.Clients, torrentv1beta1.PeerCount{Name: count.Name, Count: count.Count})
+		}
+		for _, count := range src.Status.Peers.Countries {
+			dst.Status.Peers.Countries = append(dst.Status.Peers.Countries, torrentv1beta1.PeerCount{Name: count.Name, Count: count.Count})
+		}
+	}
+	dst.Status.WebSeeds = src.Status.WebSeeds
+	if src.Status.TrackerRecovery != nil {
+		dst.Status.TrackerRecovery = &torrentv1beta1.TrackerRecoveryStatus{
+			StalledSince:  src.Status.TrackerRecovery.StalledSince,
+			AddedTrackers: src.Status.TrackerRecovery.AddedTrackers,
+			LastAddedTime: src.Status.TrackerRecovery.LastAddedTime,
+			RevivedBy:     src.Status.TrackerRecovery.RevivedBy,
+		}
+	}
+	dst.Status.MissingFilesRedownloadTime = src.Status.MissingFilesRedownloadTime
+	if src.Status.Deadline != nil {
+		dst.Status.Deadline = &torrentv1beta1.DeadlineStatus{
+			DeadlineTime:   src.Status.Deadline.DeadlineTime,
+			EscalatedTime:  src.Status.Deadline.EscalatedTime,
+			CompletionTime: src.Status.Deadline.CompletionTime,
+		}
+	}
+	if src.Status.Throttle != nil {
+		dst.Status.Throttle = &torrentv1beta1.ThrottleStatus{
+			DownloadLimit: src.Status.Throttle.DownloadLimit,
+			UploadLimit:   src.Status.Throttle.UploadLimit,
+			Since:         src.Status.Throttle.Since,
+		}
+	}
+	if src.Status.ReconcileStats != nil {
+		dst.Status.ReconcileStats = &torrentv1beta1.ReconcileStats{
+			LastReconcileTime:   src.Status.ReconcileStats.LastReconcileTime,
+			ConsecutiveFailures: src.Status.ReconcileStats.ConsecutiveFailures,
+			LastError:           src.Status.ReconcileStats.LastError,
+		}
+	}
+	dst.Status.SpecHash = src.Status.SpecHash
+	dst.Status.Conditions = src.Status.Conditions
+
+	return nil
+}
+
+// ConvertFrom converts the Hub version (v1beta1) to this version (v1alpha1).
+func (dst *Torrent) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*torrentv1beta1.Torrent)
+	dst.ObjectMeta = src.ObjectMeta
+
+	// Spec
+	dst.Spec.MagnetURI = src.Spec.Source.MagnetURI
+	if src.Spec.Source.MagnetURI == "" {
+		source, err := json.Marshal(src.Spec.Source)
+		if err != nil {
+			return fmt.Errorf("failed to marshal the source: %w", err)
+		}
+		dst.Annotations = maps.Clone(src.Annotations)
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[AnnotationSource] = string(source)
+	}
+	dst.Spec.ServerName = src.Spec.ServerName
+	dst.Spec.Category = src.Spec.Category
+	dst.Spec.SavePath = src.Spec.SavePath
+	dst.Spec.DeletionPolicy = DeletionPolicy(src.Spec.DeletionPolicy)
+	dst.Spec.DeletionProtection = DeletionProtection(src.Spec.DeletionProtection)
+	dst.Spec.MissingFilesPolicy = MissingFilesPolicy(src.Spec.MissingFilesPolicy)
+	dst.Spec.Priority = TorrentPriority(src.Spec.Priority)
+	dst.Spec.StatusDetail = StatusDetail(src.Spec.StatusDetail)
+	dst.Spec.ReportPeers = src.Spec.ReportPeers
+	dst.Spec.WebSeeds = src.Spec.WebSeeds
+	dst.Spec.Limits = convertLimitsFrom(src.Spec.Limits)
+	dst.Spec.DailyTransferBudget = src.Spec.DailyTransferBudget
+	if src.Spec.DriftPolicy != nil {
+		dst.Spec.DriftPolicy = &DriftPolicy{
+			Category: DriftAction(src.Spec.DriftPolicy.Category),
+			Limits:   DriftAction(src.Spec.DriftPolicy.Limits),
+		}
+	}
+	if src.Spec.ContentVolume != nil {
+		dst.Spec.ContentVolume = &ContentVolume{
+			ClaimName: src.Spec.ContentVolume.ClaimName,
+			MountPath: src.Spec.ContentVolume.MountPath,
+		}
+	}
+	if src.Spec.Checksums != nil {
+		dst.Spec.Checksums = &ChecksumSpec{
+			Enabled: src.Spec.Checksums.Enabled,
+			Image:   src.Spec.Checksums.Image,
+		}
+	}
+	if src.Spec.ContentVerification != nil {
+		dst.Spec.ContentVerification = &ContentVerificationSpec{
+			Enabled: src.Spec.ContentVerification.Enabled,
+			Image:   src.Spec.ContentVerification.Image,
+		}
+	}
+	if src.Spec.HardlinkCheck != nil {
+		dst.Spec.HardlinkCheck = &HardlinkCheckSpec{
+			Enabled: src.Spec.HardlinkCheck.Enabled,
+			Image:   src.Spec.HardlinkCheck.Image,
+		}
+	}
+	for _, source := range src.Spec.CrossSeed {
+		dst.Spec.CrossSeed = append(dst.Spec.CrossSeed, CrossSeedSource{
+			Name:       source.Name,
+			MagnetURI:  source.MagnetURI,
+			TorrentURL: source.TorrentURL,
+		})
+	}
+	dst.Spec.DependsOn = src.Spec.DependsOn
+	dst.Spec.ApprovalRequired = src.Spec.ApprovalRequired
+	dst.Spec.MetadataOnly = src.Spec.MetadataOnly
+	dst.Spec.ExcludeFiles = src.Spec.ExcludeFiles
+	dst.Spec.CompletionDeadline = src.Spec.CompletionDeadline
+	if src.Spec.DeadlineEscalation != nil {
+		dst.Spec.DeadlineEscalation = &DeadlineEscalation{
+			Before:        src.Spec.DeadlineEscalation.Before,
+			BoostPriority: src.Spec.DeadlineEscalation.BoostPriority,
+			RemoveLimits:  src.Spec.DeadlineEscalation.RemoveLimits,
+		}
+	}
+
+	// Status
+	dst.Status.Hash = src.Status.Hash
+	dst.Status.Name = src.Status.Name
+	dst.Status.State = TorrentState(src.Status.State)
+	dst.Status.Phase = TorrentPhase(src.Status.Phase)
+	if src.Status.AllowedActions != nil {
+		dst.Status.AllowedActions = &AllowedActions{
+			CanPause:   src.Status.AllowedActions.CanPause,
+			CanResume:  src.Status.AllowedActions.CanResume,
+			CanRecheck: src.Status.AllowedActions.CanRecheck,
+		}
+	}
+	dst.Status.ContentPath = src.Status.ContentPath
+	dst.Status.AddedOn = src.Status.AddedOn
+	dst.Status.TotalSize = src.Status.TotalSize
+	dst.Status.AmountLeft = src.Status.AmountLeft
+	dst.Status.TimeActive = src.Status.TimeActive
+	dst.Status.LastSyncedTime = src.Status.LastSyncedTime
+	dst.Status.Category = src.Status.Category
+	dst.Status.Tags = src.Status.Tags
+	dst.Status.ChecksumConfigMap = src.Status.ChecksumConfigMap
+	dst.Status.ContentVerifiedTime = src.Status.ContentVerifiedTime
+	dst.Status.QueuedPriority = TorrentPriority(src.Status.QueuedPriority)
+	dst.Status.Preempted = src.Status.Preempted
+	for _, drift := range src.Status.Drift {
+		dst.Status.Drift = append(dst.Status.Drift, FieldDrift{
+			Field:   drift.Field,
+			Desired: drift.Desired,
+			Actual:  drift.Actual,
+		})
+	}
+	for _, crossSeed := range src.Status.CrossSeeds {
+		dst.Status.CrossSeeds = append(dst.Status.CrossSeeds, CrossSeedStatus{
+			Name:  crossSeed.Name,
+			Hash:  crossSeed.Hash,
+			State: TorrentState(crossSeed.State),
+		})
+	}
+	for _, file := range src.Status.Files {
+		dst.Status.Files = append(dst.Status.Files, TorrentFileStatus{
+			Name:     file.Name,
+			Size:     file.Size,
+			Progress: file.Progress,
+			Priority: file.Priority,
+		})
+	}
+	dst.Status.TruncatedFiles = src.Status.TruncatedFiles
+	if src.Status.Transfer != nil {
+		dst.Status.Transfer = &TransferStatus{
+			Downloaded:        src.Status.Transfer.Downloaded,
+			DownloadedSession: src.Status.Transfer.DownloadedSession,
+			Uploaded:          src.Status.Transfer.Uploaded,
+			UploadedSession:   src.Status.Transfer.UploadedSession,
+			DownloadSpeed:     src.Status.Transfer.DownloadSpeed,
+			UploadSpeed:       src.Status.Transfer.UploadSpeed,
+			LastActivityTime:  src.Status.Transfer.LastActivityTime,
+		}
+	}
+	if src.Status.TransferBudget != nil {
+		dst.Status.TransferBudget = &TransferBudgetStatus{
+			WindowStart:   src.Status.TransferBudget.WindowStart,
+			Consumed:      src.Status.TransferBudget.Consumed,
+			ExhaustedTime: src.Status.TransferBudget.ExhaustedTime,
+		}
+	}
+	if src.Status.Peers != nil {
+		dst.Status.Peers = &PeerSummary{
+			Connected: src.Status.Peers.Connected,
+			Encrypted: src.Status.Peers.Encrypted,
+		}
+		for _, count := range src.Status.Peers.Clients {
+			dst.Status.Peers.Clients = append(dst.Status.Peers.Clients, PeerCount{Name: count.Name, Count: count.Count})
+		}
+		for _, count := range src.Status.Peers.Countries {
+			dst.Status.Peers.Countries = append(dst.Status.Peers.Countries, PeerCount{Name: count.Name, Count: count.Count})
+		}
+	}
+	dst.Status.WebSeeds = src.Status.WebSeeds
+	if src.Status.TrackerRecovery != nil {
+		dst.Status.TrackerRecovery = &TrackerRecoveryStatus{
+			StalledSince:  src.Status.TrackerRecovery.StalledSince,
+			AddedTrackers: src.Status.TrackerRecovery.AddedTrackers,
+			LastAddedTime: src.Status.TrackerRecovery.LastAddedTime,
+			RevivedBy:     src.Status.TrackerRecovery.RevivedBy,
+		}
+	}
+	dst.Status.MissingFilesRedownloadTime = src.Status.MissingFilesRedownloadTime
+	if src.Status.Deadline != nil {
+		dst.Status.Deadline = &DeadlineStatus{
+			DeadlineTime:   src.Status.Deadline.DeadlineTime,
+			EscalatedTime:  src.Status.Deadline.EscalatedTime,
+			CompletionTime: src.Status.Deadline.CompletionTime,
+		}
+	}
+	if src.Status.Throttle != nil {
+		dst.Status.Throttle = &ThrottleStatus{
+			DownloadLimit: src.Status.Throttle.DownloadLimit,
+			UploadLimit:   src.Status.Throttle.UploadLimit,
+			Since:         src.Status.Throttle.Since,
+		}
+	}
+	if src.Status.ReconcileStats != nil {
+		dst.Status.ReconcileStats = &ReconcileStats{
+			LastReconcileTime:   src.Status.ReconcileStats.LastReconcileTime,
+			ConsecutiveFailures: src.Status.ReconcileStats.ConsecutiveFailures,
+			LastError:           src.Status.ReconcileStats.LastError,
+		}
+	}
+	dst.Status.SpecHash = src.Status.SpecHash
+	dst.Status.Conditions = src.Status.Conditions
+
+	return nil
+}
+
+func convertLimitsTo(src *TorrentLimits) *torrentv1beta1.TorrentLimits {
+	if src == nil {
+		return nil
+	}
+	return &torrentv1beta1.TorrentLimits{
+		DownloadLimit:    src.DownloadLimit,
+		UploadLimit:      src.UploadLimit,
+		RatioLimit:       src.RatioLimit,
+		SeedingTimeLimit: src.SeedingTimeLimit,
+	}
+}
+
+func convertLimitsFrom(src *torrentv1beta1.TorrentLimits) *TorrentLimits {
+	if src == nil {
+		return nil
+	}
+	return &TorrentLimits{
+		DownloadLimit:    src.DownloadLimit,
+		UploadLimit:      src.UploadLimit,
+		RatioLimit:       src.RatioLimit,
+		SeedingTimeLimit: src.SeedingTimeLimit,
+	}
+}
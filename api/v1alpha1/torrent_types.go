@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -25,11 +26,429 @@ import (
 
 // TorrentSpec defines the desired state of Torrent.
 // This is what users will define in their YAML
+// +kubebuilder:validation:XValidation:rule="!has(self.checksums) || !self.checksums.enabled || has(self.content_volume)",message="checksums require content_volume to be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.deadline_escalation) || has(self.completion_deadline)",message="deadline_escalation requires completion_deadline to be set"
 type TorrentSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
+	// MagnetURI of the torrent to download. It cannot be changed once the
+	// Torrent is created, create a new Torrent to download something else.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="magnet_uri is immutable"
+	// +kubebuilder:validation:MaxLength=8192
+	// +kubebuilder:validation:XValidation:rule="self.matches('^magnet:\\\\?(.*&)?xt=urn:(btih:([0-9a-fA-F]{40}|[a-zA-Z2-7]{32})|btmh:1220[0-9a-fA-F]{64})(&.*)?$')",message="magnet_uri must contain a valid btih or btmh info hash"
 	MagnetURI string `json:"magnet_uri,omitempty"`
+
+	// ServerName is the name of the QBittorrentServer the torrent is
+	// downloaded on, the server of the --server-name of the operator if empty.
+	// It cannot be changed once the Torrent is created.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="server_name is immutable"
+	// +optional
+	ServerName string `json:"server_name,omitempty"`
+
+	// Category assigned to the torrent when it is added to qBittorrent, and
+	// kept when drift_policy.category is Enforce
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:XValidation:rule="!self.contains('\\\\')",message="category must not contain backslashes"
+	// +kubebuilder:validation:XValidation:rule="!self.startsWith('/') && !self.endsWith('/') && !self.contains('//')",message="category must not start or end with a slash or contain empty subcategories"
+	// +optional
+	Category string `json:"category,omitempty"`
+
+	// SavePath is the download folder, the qBittorrent default is used if empty
+	// +optional
+	SavePath string `json:"save_path,omitempty"`
+
+	// DeletionPolicy controls what happens on qBittorrent when the Torrent
+	// is deleted. Defaults to Delete.
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletion_policy,omitempty"`
+
+	// DeletionProtection holds the deletion of the Torrent, keeping it on
+	// qBittorrent, while the torrent is incomplete or seeding below its ratio
+	// limit. Set it to Never to let the deletion proceed. Ignored when
+	// deletion_policy is Orphan. Defaults to Never.
+	// +optional
+	DeletionProtection DeletionProtection `json:"deletion_protection,omitempty"`
+
+	// MissingFilesPolicy declares what is done when files of the torrent are
+	// deleted out-of-band, as reported by the missingFiles state of
+	// qBittorrent or by a recheck. Redownload rechecks and resumes the
+	// torrent to download the missing pieces again. Defaults to Ignore.
+	// +optional
+	MissingFilesPolicy MissingFilesPolicy `json:"missing_files_policy,omitempty"`
+
+	// Priority ranks the torrent among the ones sharing the qBittorrent
+	// bandwidth. High priority torrents are moved to the top of the download
+	// queue and low priority ones to the bottom. Low priority torrents are also
+	// throttled while high priority ones are downloading, when the operator
+	// runs with --low-priority-download-limit. Defaults to Normal.
+	// +optional
+	Priority TorrentPriority `json:"priority,omitempty"`
+
+	// StatusDetail controls how much of the torrent is reported in status.
+	// Files also reports the progress of each file of the torrent, in
+	// status.files. Defaults to Basic.
+	// +optional
+	StatusDetail StatusDetail `json:"status_detail,omitempty"`
+
+	// ReportPeers summarizes the connected peers in status.peers, by client,
+	// by country and by encryption, to help diagnosing poor speeds
+	// +optional
+	ReportPeers bool `json:"report_peers,omitempty"`
+
+	// WebSeeds are HTTP(S) URLs serving the content, added to the torrent so
+	// that an HTTP origin backs the swarm, e.g. for internally hosted
+	// datasets. Web seeds removed from the list are removed from the torrent.
+	// Requires qBittorrent 5.0 or later.
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MaxLength=2048
+	// +kubebuilder:validation:XValidation:rule="self.all(url, url.startsWith('http://') || url.startsWith('https://'))",message="web_seeds must be http or https URLs"
+	// +optional
+	WebSeeds []string `json:"web_seeds,omitempty"`
+
+	// Limits applied to the torrent when it is added to qBittorrent, and
+	// kept when drift_policy.limits is Enforce
+	// +optional
+	Limits *TorrentLimits `json:"limits,omitempty"`
+
+	// DailyTransferBudget is the number of bytes the torrent may download
+	// and upload per day, in UTC, for metered connections. The operator stops
+	// the torrent once the budget is consumed and starts it again the next
+	// day. Unlimited if unset.
+	// +optional
+	DailyTransferBudget *resource.Quantity `json:"daily_transfer_budget,omitempty"`
+
+	// DriftPolicy declares whether the category and limits changed on
+	// qBittorrent, e.g. from the WebUI, are reverted to the spec or accepted.
+	// Both are accepted by default.
+	// +optional
+	DriftPolicy *DriftPolicy `json:"drift_policy,omitempty"`
+
+	// ContentVolume is the volume qBittorrent downloads into. It is only
+	// needed by features that run Jobs against the downloaded content.
+	// +optional
+	ContentVolume *ContentVolume `json:"content_volume,omitempty"`
+
+	// Checksums enables publishing SHA-256 checksums of the downloaded files
+	// once the torrent is complete. Requires content_volume.
+	// +optional
+	Checksums *ChecksumSpec `json:"checksums,omitempty"`
+
+	// ContentVerification holds a complete torrent back from Available until
+	// a Job finds its content on the volume, catching a volume remounted
+	// empty under qBittorrent. Requires content_volume.
+	// +optional
+	ContentVerification *ContentVerificationSpec `json:"content_verification,omitempty"`
+
+	// HardlinkCheck runs a Job before the files of the deleted Torrent are
+	// deleted, keeping them when some have other hardlinks, e.g. imported
+	// into a media library: the torrent is then only removed from
+	// qBittorrent. Requires content_volume.
+	// +optional
+	HardlinkCheck *HardlinkCheckSpec `json:"hardlink_check,omitempty"`
+
+	// CrossSeed lists additional torrents for the same content, typically
+	// the same release on other trackers. Once this torrent is complete they
+	// are added against its save path with hash checking skipped.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	CrossSeed []CrossSeedSource `json:"cross_seed,omitempty"`
+
+	// DependsOn lists Torrents of the namespace this torrent waits for. It is
+	// only added to qBittorrent once they are all complete, to download
+	// multi-part datasets in order when the disk cannot hold them all.
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MaxLength=253
+	// +optional
+	DependsOn []string `json:"depends_on,omitempty"`
+
+	// ApprovalRequired adds the torrent to qBittorrent stopped, and only
+	// starts it once the qbittorrent.io/approved annotation is set to "true",
+	// e.g. by a reviewer or an external system, for review workflows on
+	// shared infrastructure
+	// +optional
+	ApprovalRequired bool `json:"approval_required,omitempty"`
+
+	// MetadataOnly adds a magnet to qBittorrent only to fetch its metadata,
+	// stopping it once received, and publishes its name, size and files in
+	// status without downloading its content, to preview it before using
+	// disk space. Setting it to false starts the download.
+	// +optional
+	MetadataOnly bool `json:"metadata_only,omitempty"`
+
+	// ExcludeFiles are glob patterns of the files of the torrent not to
+	// download, e.g. "*.nfo", applied once qBittorrent knows the files. The
+	// patterns without a slash match the names of the files, the others
+	// their paths in the torrent.
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:MaxLength=256
+	// +optional
+	ExcludeFiles []string `json:"exclude_files,omitempty"`
+
+	// CompletionDeadline is how long after its creation the torrent has to
+	// be complete, e.g. "6h", for pipelines depending on the data arriving on
+	// time. Past it an incomplete torrent gets the DeadlineExceeded condition
+	// and a Warning Event.
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="completion_deadline must be a positive duration such as 6h"
+	// +optional
+	CompletionDeadline *metav1.Duration `json:"completion_deadline,omitempty"`
+
+	// DeadlineEscalation speeds up the torrent still incomplete shortly
+	// before its completion_deadline
+	// +optional
+	DeadlineEscalation *DeadlineEscalation `json:"deadline_escalation,omitempty"`
+}
+
+// DeletionPolicy controls the cleanup on qBittorrent when a Torrent is deleted
+// +kubebuilder:validation:Enum=Delete;KeepFiles;Orphan
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete removes the torrent and its downloaded files
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyKeepFiles removes the torrent but keeps the downloaded files
+	DeletionPolicyKeepFiles DeletionPolicy = "KeepFiles"
+	// DeletionPolicyOrphan leaves the torrent on qBittorrent untouched
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// DeletionProtection declares when the deletion of a Torrent is held
+// +kubebuilder:validation:Enum=WhileIncomplete;WhileSeedingBelowRatio;Never
+type DeletionProtection string
+
+const (
+	// DeletionProtectionWhileIncomplete holds the deletion until the download is complete
+	DeletionProtectionWhileIncomplete DeletionProtection = "WhileIncomplete"
+	// DeletionProtectionWhileSeedingBelowRatio also holds the deletion of a
+	// complete torrent until it reached its ratio limit
+	DeletionProtectionWhileSeedingBelowRatio DeletionProtection = "WhileSeedingBelowRatio"
+	// DeletionProtectionNever deletes the torrent right away
+	DeletionProtectionNever DeletionProtection = "Never"
+)
+
+// MissingFilesPolicy declares what is done with the files of a torrent
+// deleted out-of-band
+// +kubebuilder:validation:Enum=Ignore;Redownload
+type MissingFilesPolicy string
+
+const (
+	// MissingFilesPolicyIgnore leaves the torrent as qBittorrent reports it
+	MissingFilesPolicyIgnore MissingFilesPolicy = "Ignore"
+	// MissingFilesPolicyRedownload rechecks and resumes the torrent to
+	// download the missing pieces again
+	MissingFilesPolicyRedownload MissingFilesPolicy = "Redownload"
+)
+
+// TorrentState is the state of a torrent in qBittorrent. It is not validated,
+// as newer qBittorrent releases may report states not listed here.
+type TorrentState string
+
+const (
+	TorrentStateError              TorrentState = "error"
+	TorrentStateMissingFiles       TorrentState = "missingFiles"
+	TorrentStateUploading          TorrentState = "uploading"
+	TorrentStatePausedUP           TorrentState = "pausedUP"
+	TorrentStateStoppedUP          TorrentState = "stoppedUP"
+	TorrentStateQueuedUP           TorrentState = "queuedUP"
+	TorrentStateStalledUP          TorrentState = "stalledUP"
+	TorrentStateCheckingUP         TorrentState = "checkingUP"
+	TorrentStateForcedUP           TorrentState = "forcedUP"
+	TorrentStateAllocating         TorrentState = "allocating"
+	TorrentStateDownloading        TorrentState = "downloading"
+	TorrentStateMetaDL             TorrentState = "metaDL"
+	TorrentStateForcedMetaDL       TorrentState = "forcedMetaDL"
+	TorrentStatePausedDL           TorrentState = "pausedDL"
+	TorrentStateStoppedDL          TorrentState = "stoppedDL"
+	TorrentStateQueuedDL           TorrentState = "queuedDL"
+	TorrentStateStalledDL          TorrentState = "stalledDL"
+	TorrentStateCheckingDL         TorrentState = "checkingDL"
+	TorrentStateForcedDL           TorrentState = "forcedDL"
+	TorrentStateCheckingResumeData TorrentState = "checkingResumeData"
+	TorrentStateMoving             TorrentState = "moving"
+	TorrentStateUnknown            TorrentState = "unknown"
+)
+
+// TorrentPhase summarizes the state of a torrent in qBittorrent
+// +kubebuilder:validation:Enum=Pending;Downloading;Seeding;Completed;Paused;Error
+type TorrentPhase string
+
+const (
+	// TorrentPhasePending torrents are being added to qBittorrent, or loaded by it
+	TorrentPhasePending TorrentPhase = "Pending"
+	// TorrentPhaseDownloading torrents are downloading, queued or stalled
+	TorrentPhaseDownloading TorrentPhase = "Downloading"
+	// TorrentPhaseSeeding torrents are complete and seeding, queued or stalled
+	TorrentPhaseSeeding TorrentPhase = "Seeding"
+	// TorrentPhaseCompleted torrents are complete and stopped
+	TorrentPhaseCompleted TorrentPhase = "Completed"
+	// TorrentPhasePaused torrents are incomplete and stopped
+	TorrentPhasePaused TorrentPhase = "Paused"
+	// TorrentPhaseError torrents failed, or miss their files
+	TorrentPhaseError TorrentPhase = "Error"
+)
+
+// TorrentPriority ranks the torrents sharing the qBittorrent bandwidth
+// +kubebuilder:validation:Enum=Low;Normal;High
+type TorrentPriority string
+
+const (
+	// TorrentPriorityLow torrents yield the bandwidth to the high priority ones
+	TorrentPriorityLow TorrentPriority = "Low"
+	// TorrentPriorityNormal torrents keep their queue position and limits
+	TorrentPriorityNormal TorrentPriority = "Normal"
+	// TorrentPriorityHigh torrents preempt the bandwidth of the low priority ones
+	TorrentPriorityHigh TorrentPriority = "High"
+)
+
+// StatusDetail controls how much of the torrent is reported in status
+// +kubebuilder:validation:Enum=Basic;Files
+type StatusDetail string
+
+const (
+	// StatusDetailBasic reports the state of the torrent as a whole
+	StatusDetailBasic StatusDetail = "Basic"
+	// StatusDetailFiles also reports the progress of each file
+	StatusDetailFiles StatusDetail = "Files"
+)
+
+// TorrentLimits are the transfer and seeding limits of a single torrent
+type TorrentLimits struct {
+	// DownloadLimit in bytes per second, 0 means unlimited
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DownloadLimit *int64 `json:"download_limit,omitempty"`
+
+	// UploadLimit in bytes per second, 0 means unlimited
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	UploadLimit *int64 `json:"upload_limit,omitempty"`
+
+	// RatioLimit stops seeding once the share ratio is reached, e.g. "2.0"
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	RatioLimit string `json:"ratio_limit,omitempty"`
+
+	// SeedingTimeLimit stops seeding after the torrent seeded for this long, e.g. "72h"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s')",message="seeding_time_limit must be a non-negative duration such as 72h"
+	// +optional
+	SeedingTimeLimit *metav1.Duration `json:"seeding_time_limit,omitempty"`
+}
+
+// DeadlineEscalation is how a torrent at risk of missing its completion
+// deadline is sped up. The priority and limits of the spec are restored once
+// the torrent is complete.
+type DeadlineEscalation struct {
+	// Before is how long before the completion_deadline the torrent is escalated
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="before must be a positive duration such as 1h"
+	Before metav1.Duration `json:"before"`
+
+	// BoostPriority raises the torrent to the High priority
+	// +optional
+	BoostPriority bool `json:"boost_priority,omitempty"`
+
+	// RemoveLimits lifts the download and upload limits of the torrent
+	// +optional
+	RemoveLimits bool `json:"remove_limits,omitempty"`
+}
+
+// DriftPolicy declares, per group of settings, whether the spec or qBittorrent
+// wins when they differ. Only the settings set in the spec are compared.
+type DriftPolicy struct {
+	// Category of the torrent. Defaults to Accept.
+	// +optional
+	Category DriftAction `json:"category,omitempty"`
+
+	// Limits of the torrent, see limits. Defaults to Accept.
+	// +optional
+	Limits DriftAction `json:"limits,omitempty"`
+}
+
+// DriftAction is what the controller does when qBittorrent differs from the spec
+// +kubebuilder:validation:Enum=Enforce;Accept
+type DriftAction string
+
+const (
+	// DriftActionEnforce reverts qBittorrent to the spec
+	DriftActionEnforce DriftAction = "Enforce"
+	// DriftActionAccept keeps the qBittorrent setting and records the drift in status
+	DriftActionAccept DriftAction = "Accept"
+)
+
+// CrossSeedSource is an additional torrent seeding the same content.
+// Exactly one of magnet_uri and torrent_url must be set.
+// Sources are immutable, remove the entry and add a new one to change them.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="cross_seed sources are immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.magnet_uri) != has(self.torrent_url)",message="exactly one of magnet_uri and torrent_url must be set"
+type CrossSeedSource struct {
+	// Name identifies the source in status
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// MagnetURI of the cross-seeded torrent
+	// +kubebuilder:validation:MaxLength=8192
+	// +kubebuilder:validation:XValidation:rule="self.matches('^magnet:\\\\?(.*&)?xt=urn:(btih:([0-9a-fA-F]{40}|[a-zA-Z2-7]{32})|btmh:1220[0-9a-fA-F]{64})(&.*)?$')",message="magnet_uri must contain a valid btih or btmh info hash"
+	// +optional
+	MagnetURI string `json:"magnet_uri,omitempty"`
+
+	// TorrentURL is an HTTP(S) URL of the .torrent file, fetched by qBittorrent
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:XValidation:rule="self.startsWith('http://') || self.startsWith('https://')",message="torrent_url must be an http or https URL"
+	// +optional
+	TorrentURL string `json:"torrent_url,omitempty"`
+}
+
+// ContentVolume references the PersistentVolumeClaim holding the downloaded content
+type ContentVolume struct {
+	// ClaimName is the name of the PersistentVolumeClaim mounted by qBittorrent
+	// +kubebuilder:validation:MinLength=1
+	ClaimName string `json:"claim_name"`
+
+	// MountPath is the path where qBittorrent mounts the claim. Jobs mount it
+	// at the same path so that content_path can be used as-is.
+	// +kubebuilder:default="/downloads"
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	MountPath string `json:"mount_path,omitempty"`
+}
+
+// ChecksumSpec configures the checksum Job run on completion
+type ChecksumSpec struct {
+	// Enabled turns checksum publication on
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Image used by the checksum Job. It must provide sh, find and sha256sum.
+	// +kubebuilder:default="busybox:1.36"
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// ContentVerificationSpec configures the Job verifying the content on completion
+type ContentVerificationSpec struct {
+	// Enabled turns content verification on
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Image used by the verification Job. It must provide sh, find, stat and awk.
+	// +kubebuilder:default="busybox:1.36"
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// HardlinkCheckSpec configures the Job checking the content for other
+// hardlinks before deleting it
+type HardlinkCheckSpec struct {
+	// Enabled turns the hardlink check on
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Image used by the check Job. It must provide sh and find.
+	// +kubebuilder:default="busybox:1.36"
+	// +optional
+	Image string `json:"image,omitempty"`
 }
 
 // TorrentStatus defines the observed state of Torrent.
@@ -38,23 +457,285 @@ type TorrentStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
-	ContentPath string `json:"content_path,omitempty"`
-	AddedOn     int64  `json:"added_on,omitempty"`
-	State       string `json:"state,omitempty"`
-	TotalSize   int64  `json:"total_size,omitempty"`
-	Name        string `json:"name,omitempty"`
-	TimeActive  int64  `json:"time_active,omitempty"`
-	AmountLeft  int64  `json:"amount_left,omitempty"`
-	Hash        string `json:"hash,omitempty"`
+	ContentPath string       `json:"content_path,omitempty"`
+	AddedOn     int64        `json:"added_on,omitempty"`
+	State       TorrentState `json:"state,omitempty"`
+	TotalSize   int64        `json:"total_size,omitempty"`
+	Name        string       `json:"name,omitempty"`
+	TimeActive  int64        `json:"time_active,omitempty"`
+	AmountLeft  int64        `json:"amount_left,omitempty"`
+	Hash        string       `json:"hash,omitempty"`
+
+	// Phase summarizes the state of the torrent: Pending, Downloading,
+	// Seeding, Completed, Paused or Error
+	// +optional
+	Phase TorrentPhase `json:"phase,omitempty"`
+	// AllowedActions reports the actions of the qbittorrent.io/action
+	// annotation the state of the torrent allows, so that the UIs only
+	// offer the ones qBittorrent can run. Unset until the torrent is found.
+	// +optional
+	AllowedActions *AllowedActions `json:"allowed_actions,omitempty"`
+
+	// LastSyncedTime is when the status was last refreshed from qBittorrent
+	// +optional
+	LastSyncedTime *metav1.Time `json:"last_synced_time,omitempty"`
+
+	// Category and Tags are the ones set on qBittorrent, which may have been
+	// changed from the WebUI or by other tools
+	Category string   `json:"category,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+
+	// ChecksumConfigMap is the name of the ConfigMap holding the SHA256SUMS
+	// of the downloaded content, once published
+	ChecksumConfigMap string `json:"checksum_config_map,omitempty"`
+	// ContentVerifiedTime is when the content of the complete torrent was
+	// found on the volume, with content verification enabled
+	ContentVerifiedTime *metav1.Time `json:"content_verified_time,omitempty"`
+
+	// QueuedPriority is the priority the qBittorrent queue position was set for
+	QueuedPriority TorrentPriority `json:"queued_priority,omitempty"`
+	// Preempted is true while the download of a low priority torrent is
+	// throttled in favor of the high priority ones
+	Preempted bool `json:"preempted,omitempty"`
+
+	// Drift lists the settings of the spec qBittorrent differs from, as
+	// accepted by the drift policy
+	// +listType=map
+	// +listMapKey=field
+	Drift []FieldDrift `json:"drift,omitempty"`
+
+	// CrossSeeds reports the torrents added for spec.cross_seed
+	// +listType=map
+	// +listMapKey=name
+	CrossSeeds []CrossSeedStatus `json:"cross_seeds,omitempty"`
+
+	// Files reports the progress of the files of the torrent, with
+	// spec.status_detail Files. Only the first files are reported, see
+	// truncated_files.
+	// +kubebuilder:validation:MaxItems=100
+	// +optional
+	Files []TorrentFileStatus `json:"files,omitempty"`
+	// TruncatedFiles is the number of files of the torrent not reported in
+	// files
+	// +optional
+	TruncatedFiles int32 `json:"truncated_files,omitempty"`
+
+	// Transfer reports the bytes transferred by the torrent, since it was
+	// added and since qBittorrent started
+	// +optional
+	Transfer *TransferStatus `json:"transfer,omitempty"`
+
+	// TransferBudget reports the bytes transferred by the torrent against
+	// spec.daily_transfer_budget
+	// +optional
+	TransferBudget *TransferBudgetStatus `json:"transfer_budget,omitempty"`
+
+	// Peers summarizes the peers connected for the torrent, with
+	// spec.report_peers
+	// +optional
+	Peers *PeerSummary `json:"peers,omitempty"`
+
+	// WebSeeds lists the web seeds of spec.web_seeds added to the torrent
+	// +optional
+	WebSeeds []string `json:"web_seeds,omitempty"`
+
+	// TrackerRecovery reports the fallback trackers of a TorrentPolicy added
+	// to revive the stalled download
+	// +optional
+	TrackerRecovery *TrackerRecoveryStatus `json:"tracker_recovery,omitempty"`
+
+	// MissingFilesRedownloadTime is when the files found missing were last
+	// downloaded again, with spec.missing_files_policy Redownload
+	// +optional
+	MissingFilesRedownloadTime *metav1.Time `json:"missing_files_redownload_time,omitempty"`
+
+	// Deadline reports the torrent against spec.completion_deadline
+	// +optional
+	Deadline *DeadlineStatus `json:"deadline,omitempty"`
+
+	// Throttle reports the limits the torrent is throttled to while the
+	// network of the node is saturated, see the network pressure of the
+	// operator config
+	// +optional
+	Throttle *ThrottleStatus `json:"throttle,omitempty"`
+
+	// ReconcileStats reports the last reconcile of the Torrent, to spot the
+	// Torrents failing over and over
+	// +optional
+	ReconcileStats *ReconcileStats `json:"reconcile_stats,omitempty"`
+
+	// SpecHash is the hash of the effective spec at the last full reconcile.
+	// The reconciles skip the calls to qBittorrent while neither it nor the
+	// torrent changes, with skipUnchanged in the operator config.
+	// +optional
+	SpecHash string `json:"spec_hash,omitempty"`
 
 	// Conditions represent the latest available observations of a torrent's current state
 	// Standard Kubernetes pattern for representing status
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
+// FieldDrift is a setting of the spec qBittorrent differs from
+type FieldDrift struct {
+	// Field of the v1beta1 spec, e.g. limits.uploadLimit
+	Field string `json:"field"`
+	// Desired value, from the spec
+	Desired string `json:"desired"`
+	// Actual value on qBittorrent
+	Actual string `json:"actual"`
+}
+
+// CrossSeedStatus is the observed state of a cross-seeded torrent
+type CrossSeedStatus struct {
+	Name  string       `json:"name"`
+	Hash  string       `json:"hash,omitempty"`
+	State TorrentState `json:"state,omitempty"`
+}
+
+// TransferStatus is the bytes transferred by a torrent
+type TransferStatus struct {
+	// Downloaded is the number of bytes downloaded since the torrent was added
+	Downloaded int64 `json:"downloaded"`
+	// DownloadedSession is the number of bytes downloaded since qBittorrent started
+	DownloadedSession int64 `json:"downloaded_session"`
+	// Uploaded is the number of bytes uploaded since the torrent was added
+	Uploaded int64 `json:"uploaded"`
+	// UploadedSession is the number of bytes uploaded since qBittorrent started
+	UploadedSession int64 `json:"uploaded_session"`
+	// DownloadSpeed and UploadSpeed are the rates in bytes per second at the
+	// last sync
+	// +optional
+	DownloadSpeed int64 `json:"download_speed,omitempty"`
+	// +optional
+	UploadSpeed int64 `json:"upload_speed,omitempty"`
+	// LastActivityTime is when the torrent last downloaded or uploaded bytes,
+	// as observed by the operator
+	// +optional
+	LastActivityTime *metav1.Time `json:"last_activity_time,omitempty"`
+}
+
+// TransferBudgetStatus is the bytes a torrent transferred in the current day
+type TransferBudgetStatus struct {
+	// WindowStart is the start of the current day, in UTC
+	WindowStart metav1.Time `json:"window_start"`
+	// Consumed is the number of bytes downloaded and uploaded since
+	// WindowStart, summed from the session transfer of each sync
+	Consumed int64 `json:"consumed"`
+	// ExhaustedTime is when the operator stopped the torrent, the budget
+	// being consumed. Unset once the torrent is started again.
+	// +optional
+	ExhaustedTime *metav1.Time `json:"exhausted_time,omitempty"`
+}
+
+// TrackerRecoveryStatus reports the fallback trackers added to a stalled torrent
+type TrackerRecoveryStatus struct {
+	// StalledSince is when the download was seen stalled, unset once it resumes
+	// +optional
+	StalledSince *metav1.Time `json:"stalled_since,omitempty"`
+	// AddedTrackers are the fallback trackers added to the torrent, in order
+	// +optional
+	AddedTrackers []string `json:"added_trackers,omitempty"`
+	// LastAddedTime is when the last fallback tracker was added
+	// +optional
+	LastAddedTime *metav1.Time `json:"last_added_time,omitempty"`
+	// RevivedBy is the fallback tracker added last before the download
+	// resumed
+	// +optional
+	RevivedBy string `json:"revived_by,omitempty"`
+}
+
+// DeadlineStatus reports a torrent against its completion deadline
+type DeadlineStatus struct {
+	// DeadlineTime is when the torrent has to be complete
+	DeadlineTime metav1.Time `json:"deadline_time"`
+	// EscalatedTime is when the torrent was escalated by
+	// spec.deadline_escalation
+	// +optional
+	EscalatedTime *metav1.Time `json:"escalated_time,omitempty"`
+	// CompletionTime is when the torrent was first seen complete
+	// +optional
+	CompletionTime *metav1.Time `json:"completion_time,omitempty"`
+}
+
+// ThrottleStatus reports a torrent throttled by the network pressure
+type ThrottleStatus struct {
+	// DownloadLimit is the download limit, in bytes per second, the torrent
+	// is throttled to
+	DownloadLimit int64 `json:"download_limit"`
+	// UploadLimit is the upload limit, in bytes per second, the torrent is
+	// throttled to
+	UploadLimit int64 `json:"upload_limit"`
+	// Since is when the torrent was throttled
+	Since metav1.Time `json:"since"`
+}
+
+// AllowedActions are the actions allowed in the current state of a torrent
+type AllowedActions struct {
+	// CanPause is true while the torrent is started
+	CanPause bool `json:"can_pause"`
+	// CanResume is true while the torrent is stopped, including on error
+	CanResume bool `json:"can_resume"`
+	// CanRecheck is true once the metadata of the torrent is known, unless
+	// it is being checked or moved
+	CanRecheck bool `json:"can_recheck"`
+}
+
+// ReconcileStats reports the last reconcile of a Torrent
+type ReconcileStats struct {
+	// LastReconcileTime is when the Torrent was last reconciled
+	// +optional
+	LastReconcileTime *metav1.Time `json:"last_reconcile_time,omitempty"`
+	// ConsecutiveFailures is the number of reconciles failed in a row, 0
+	// after a successful one
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutive_failures,omitempty"`
+	// LastError is the error of the last failed reconcile, cleared by a
+	// successful one
+	// +kubebuilder:validation:MaxLength=1024
+	// +optional
+	LastError string `json:"last_error,omitempty"`
+}
+
+// PeerSummary is a summary of the peers connected for a torrent
+type PeerSummary struct {
+	// Connected is the number of connected peers
+	Connected int32 `json:"connected"`
+	// Encrypted is the number of connected peers using an encrypted connection
+	Encrypted int32 `json:"encrypted"`
+	// Clients counts the peers by client, the most common first
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	Clients []PeerCount `json:"clients,omitempty"`
+	// Countries counts the peers by country code, the most common first
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	Countries []PeerCount `json:"countries,omitempty"`
+}
+
+// PeerCount is the number of peers sharing a client or a country
+type PeerCount struct {
+	Name  string `json:"name"`
+	Count int32  `json:"count"`
+}
+
+// TorrentFileStatus is the progress of a file of the torrent
+type TorrentFileStatus struct {
+	// Name is the path of the file relative to the save path, truncated when
+	// longer than 256 characters
+	Name string `json:"name"`
+	// Size in bytes of the file
+	Size int64 `json:"size"`
+	// Progress is the downloaded percentage of the file, from 0 to 100
+	Progress int32 `json:"progress"`
+	// Priority of the file in qBittorrent, 0 means it is not downloaded
+	Priority int32 `json:"priority"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:deprecatedversion:warning="torrent.qbittorrent.io/v1alpha1 Torrent is deprecated, use torrent.qbittorrent.io/v1beta1"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",priority=1
 // +kubebuilder:printcolumn:name="Name",type="string",JSONPath=".status.name"
 // +kubebuilder:printcolumn:name="Size",type="string",JSONPath=".status.total_size"
 // +kubebuilder:printcolumn:name="Progress",type="string",JSONPath=".status.amount_left"
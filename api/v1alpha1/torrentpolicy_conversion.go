@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+// ConvertTo converts this TorrentPolicy (v1alpha1) to the Hub version (v1beta1).
+func (src *TorrentPolicy) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*torrentv1beta1.TorrentPolicy)
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.Defaults.Category = src.Spec.Defaults.Category
+	dst.Spec.Defaults.SavePath = src.Spec.Defaults.SavePath
+	dst.Spec.Defaults.DeletionPolicy = torrentv1beta1.DeletionPolicy(src.Spec.Defaults.DeletionPolicy)
+	dst.Spec.Defaults.Limits = convertLimitsTo(src.Spec.Defaults.Limits)
+	if src.Spec.StalledRecovery != nil {
+		dst.Spec.StalledRecovery = &torrentv1beta1.StalledRecovery{
+			FallbackTrackers: src.Spec.StalledRecovery.FallbackTrackers,
+			StalledFor:       src.Spec.StalledRecovery.StalledFor,
+		}
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the Hub version (v1beta1) to this version (v1alpha1).
+func (dst *TorrentPolicy) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*torrentv1beta1.TorrentPolicy)
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.Defaults.Category = src.Spec.Defaults.Category
+	dst.Spec.Defaults.SavePath = src.Spec.Defaults.SavePath
+	dst.Spec.Defaults.DeletionPolicy = DeletionPolicy(src.Spec.Defaults.DeletionPolicy)
+	dst.Spec.Defaults.Limits = convertLimitsFrom(src.Spec.Defaults.Limits)
+	if src.Spec.StalledRecovery != nil {
+		dst.Spec.StalledRecovery = &StalledRecovery{
+			FallbackTrackers: src.Spec.StalledRecovery.FallbackTrackers,
+			StalledFor:       src.Spec.StalledRecovery.StalledFor,
+		}
+	}
+
+	return nil
+}
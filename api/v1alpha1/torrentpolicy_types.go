@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TorrentPolicySpec defines the desired state of TorrentPolicy.
+type TorrentPolicySpec struct {
+	// Defaults are filled into the spec of the Torrents created in the
+	// namespace, for the fields left unset
+	// +optional
+	Defaults TorrentDefaults `json:"defaults,omitempty"`
+
+	// StalledRecovery revives the stalled downloads of the Torrents of the
+	// namespace with fallback trackers. Unlike the defaults, it applies to
+	// the existing Torrents too.
+	// +optional
+	StalledRecovery *StalledRecovery `json:"stalled_recovery,omitempty"`
+}
+
+// StalledRecovery adds fallback trackers to the downloads stalled for too long
+type StalledRecovery struct {
+	// FallbackTrackers are announce URLs added to the torrent one at a time,
+	// in order, each time its download stays stalled for stalled_for
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:Pattern=`^(https?|udp)://`
+	FallbackTrackers []string `json:"fallback_trackers"`
+
+	// StalledFor before the next fallback tracker is added. Defaults to 10m.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1m')",message="stalled_for must be at least 1m"
+	// +optional
+	StalledFor *metav1.Duration `json:"stalled_for,omitempty"`
+}
+
+// TorrentDefaults are the Torrent spec fields a TorrentPolicy can default
+type TorrentDefaults struct {
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:XValidation:rule="!self.contains('\\\\')",message="category must not contain backslashes"
+	// +kubebuilder:validation:XValidation:rule="!self.startsWith('/') && !self.endsWith('/') && !self.contains('//')",message="category must not start or end with a slash or contain empty subcategories"
+	// +optional
+	Category string `json:"category,omitempty"`
+
+	// +optional
+	SavePath string `json:"save_path,omitempty"`
+
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletion_policy,omitempty"`
+
+	// Limits are defaulted one by one, a Torrent setting only some of them
+	// gets the others from the policy
+	// +optional
+	Limits *TorrentLimits `json:"limits,omitempty"`
+}
+
+// TorrentPolicyStatus defines the observed state of TorrentPolicy.
+type TorrentPolicyStatus struct {
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:deprecatedversion:warning="torrent.qbittorrent.io/v1alpha1 TorrentPolicy is deprecated, use torrent.qbittorrent.io/v1beta1"
+
+// TorrentPolicy is the Schema for the torrentpolicies API.
+// The policies of a namespace are applied in name order when a Torrent is
+// created, the first policy setting a field wins.
+type TorrentPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TorrentPolicySpec   `json:"spec,omitempty"`
+	Status TorrentPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TorrentPolicyList contains a list of TorrentPolicy.
+type TorrentPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TorrentPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TorrentPolicy{}, &TorrentPolicyList{})
+}
@@ -25,12 +25,287 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllowedActions) DeepCopyInto(out *AllowedActions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedActions.
+func (in *AllowedActions) DeepCopy() *AllowedActions {
+	if in == nil {
+		return nil
+	}
+	out := new(AllowedActions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChecksumSpec) DeepCopyInto(out *ChecksumSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChecksumSpec.
+func (in *ChecksumSpec) DeepCopy() *ChecksumSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ChecksumSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContentVerificationSpec) DeepCopyInto(out *ContentVerificationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentVerificationSpec.
+func (in *ContentVerificationSpec) DeepCopy() *ContentVerificationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ContentVerificationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContentVolume) DeepCopyInto(out *ContentVolume) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentVolume.
+func (in *ContentVolume) DeepCopy() *ContentVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(ContentVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrossSeedSource) DeepCopyInto(out *CrossSeedSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossSeedSource.
+func (in *CrossSeedSource) DeepCopy() *CrossSeedSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CrossSeedSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrossSeedStatus) DeepCopyInto(out *CrossSeedStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossSeedStatus.
+func (in *CrossSeedStatus) DeepCopy() *CrossSeedStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CrossSeedStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeadlineEscalation) DeepCopyInto(out *DeadlineEscalation) {
+	*out = *in
+	out.Before = in.Before
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeadlineEscalation.
+func (in *DeadlineEscalation) DeepCopy() *DeadlineEscalation {
+	if in == nil {
+		return nil
+	}
+	out := new(DeadlineEscalation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeadlineStatus) DeepCopyInto(out *DeadlineStatus) {
+	*out = *in
+	in.DeadlineTime.DeepCopyInto(&out.DeadlineTime)
+	if in.EscalatedTime != nil {
+		in, out := &in.EscalatedTime, &out.EscalatedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeadlineStatus.
+func (in *DeadlineStatus) DeepCopy() *DeadlineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeadlineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftPolicy) DeepCopyInto(out *DriftPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftPolicy.
+func (in *DriftPolicy) DeepCopy() *DriftPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldDrift) DeepCopyInto(out *FieldDrift) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldDrift.
+func (in *FieldDrift) DeepCopy() *FieldDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HardlinkCheckSpec) DeepCopyInto(out *HardlinkCheckSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardlinkCheckSpec.
+func (in *HardlinkCheckSpec) DeepCopy() *HardlinkCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HardlinkCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeerCount) DeepCopyInto(out *PeerCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeerCount.
+func (in *PeerCount) DeepCopy() *PeerCount {
+	if in == nil {
+		return nil
+	}
+	out := new(PeerCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeerSummary) DeepCopyInto(out *PeerSummary) {
+	*out = *in
+	if in.Clients != nil {
+		in, out := &in.Clients, &out.Clients
+		*out = make([]PeerCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.Countries != nil {
+		in, out := &in.Countries, &out.Countries
+		*out = make([]PeerCount, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeerSummary.
+func (in *PeerSummary) DeepCopy() *PeerSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(PeerSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileStats) DeepCopyInto(out *ReconcileStats) {
+	*out = *in
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileStats.
+func (in *ReconcileStats) DeepCopy() *ReconcileStats {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StalledRecovery) DeepCopyInto(out *StalledRecovery) {
+	*out = *in
+	if in.FallbackTrackers != nil {
+		in, out := &in.FallbackTrackers, &out.FallbackTrackers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StalledFor != nil {
+		in, out := &in.StalledFor, &out.StalledFor
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StalledRecovery.
+func (in *StalledRecovery) DeepCopy() *StalledRecovery {
+	if in == nil {
+		return nil
+	}
+	out := new(StalledRecovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThrottleStatus) DeepCopyInto(out *ThrottleStatus) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThrottleStatus.
+func (in *ThrottleStatus) DeepCopy() *ThrottleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ThrottleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Torrent) DeepCopyInto(out *Torrent) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -52,6 +327,71 @@ func (in *Torrent) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TorrentDefaults) DeepCopyInto(out *TorrentDefaults) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(TorrentLimits)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TorrentDefaults.
+func (in *TorrentDefaults) DeepCopy() *TorrentDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(TorrentDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TorrentFileStatus) DeepCopyInto(out *TorrentFileStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TorrentFileStatus.
+func (in *TorrentFileStatus) DeepCopy() *TorrentFileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TorrentFileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TorrentLimits) DeepCopyInto(out *TorrentLimits) {
+	*out = *in
+	if in.DownloadLimit != nil {
+		in, out := &in.DownloadLimit, &out.DownloadLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UploadLimit != nil {
+		in, out := &in.UploadLimit, &out.UploadLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SeedingTimeLimit != nil {
+		in, out := &in.SeedingTimeLimit, &out.SeedingTimeLimit
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TorrentLimits.
+func (in *TorrentLimits) DeepCopy() *TorrentLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(TorrentLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TorrentList) DeepCopyInto(out *TorrentList) {
 	*out = *in
@@ -84,9 +424,169 @@ func (in *TorrentList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TorrentPolicy) DeepCopyInto(out *TorrentPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TorrentPolicy.
+func (in *TorrentPolicy) DeepCopy() *TorrentPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TorrentPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TorrentPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TorrentPolicyList) DeepCopyInto(out *TorrentPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TorrentPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TorrentPolicyList.
+func (in *TorrentPolicyList) DeepCopy() *TorrentPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(TorrentPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TorrentPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TorrentPolicySpec) DeepCopyInto(out *TorrentPolicySpec) {
+	*out = *in
+	in.Defaults.DeepCopyInto(&out.Defaults)
+	if in.StalledRecovery != nil {
+		in, out := &in.StalledRecovery, &out.StalledRecovery
+		*out = new(StalledRecovery)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TorrentPolicySpec.
+func (in *TorrentPolicySpec) DeepCopy() *TorrentPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TorrentPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TorrentPolicyStatus) DeepCopyInto(out *TorrentPolicyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TorrentPolicyStatus.
+func (in *TorrentPolicyStatus) DeepCopy() *TorrentPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TorrentPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TorrentSpec) DeepCopyInto(out *TorrentSpec) {
 	*out = *in
+	if in.WebSeeds != nil {
+		in, out := &in.WebSeeds, &out.WebSeeds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(TorrentLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DailyTransferBudget != nil {
+		in, out := &in.DailyTransferBudget, &out.DailyTransferBudget
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.DriftPolicy != nil {
+		in, out := &in.DriftPolicy, &out.DriftPolicy
+		*out = new(DriftPolicy)
+		**out = **in
+	}
+	if in.ContentVolume != nil {
+		in, out := &in.ContentVolume, &out.ContentVolume
+		*out = new(ContentVolume)
+		**out = **in
+	}
+	if in.Checksums != nil {
+		in, out := &in.Checksums, &out.Checksums
+		*out = new(ChecksumSpec)
+		**out = **in
+	}
+	if in.ContentVerification != nil {
+		in, out := &in.ContentVerification, &out.ContentVerification
+		*out = new(ContentVerificationSpec)
+		**out = **in
+	}
+	if in.HardlinkCheck != nil {
+		in, out := &in.HardlinkCheck, &out.HardlinkCheck
+		*out = new(HardlinkCheckSpec)
+		**out = **in
+	}
+	if in.CrossSeed != nil {
+		in, out := &in.CrossSeed, &out.CrossSeed
+		*out = make([]CrossSeedSource, len(*in))
+		copy(*out, *in)
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeFiles != nil {
+		in, out := &in.ExcludeFiles, &out.ExcludeFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletionDeadline != nil {
+		in, out := &in.CompletionDeadline, &out.CompletionDeadline
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DeadlineEscalation != nil {
+		in, out := &in.DeadlineEscalation, &out.DeadlineEscalation
+		*out = new(DeadlineEscalation)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TorrentSpec.
@@ -102,6 +602,83 @@ func (in *TorrentSpec) DeepCopy() *TorrentSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TorrentStatus) DeepCopyInto(out *TorrentStatus) {
 	*out = *in
+	if in.AllowedActions != nil {
+		in, out := &in.AllowedActions, &out.AllowedActions
+		*out = new(AllowedActions)
+		**out = **in
+	}
+	if in.LastSyncedTime != nil {
+		in, out := &in.LastSyncedTime, &out.LastSyncedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContentVerifiedTime != nil {
+		in, out := &in.ContentVerifiedTime, &out.ContentVerifiedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]FieldDrift, len(*in))
+		copy(*out, *in)
+	}
+	if in.CrossSeeds != nil {
+		in, out := &in.CrossSeeds, &out.CrossSeeds
+		*out = make([]CrossSeedStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]TorrentFileStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Transfer != nil {
+		in, out := &in.Transfer, &out.Transfer
+		*out = new(TransferStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TransferBudget != nil {
+		in, out := &in.TransferBudget, &out.TransferBudget
+		*out = new(TransferBudgetStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = new(PeerSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WebSeeds != nil {
+		in, out := &in.WebSeeds, &out.WebSeeds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TrackerRecovery != nil {
+		in, out := &in.TrackerRecovery, &out.TrackerRecovery
+		*out = new(TrackerRecoveryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MissingFilesRedownloadTime != nil {
+		in, out := &in.MissingFilesRedownloadTime, &out.MissingFilesRedownloadTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Deadline != nil {
+		in, out := &in.Deadline, &out.Deadline
+		*out = new(DeadlineStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Throttle != nil {
+		in, out := &in.Throttle, &out.Throttle
+		*out = new(ThrottleStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReconcileStats != nil {
+		in, out := &in.ReconcileStats, &out.ReconcileStats
+		*out = new(ReconcileStats)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -120,3 +697,70 @@ func (in *TorrentStatus) DeepCopy() *TorrentStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrackerRecoveryStatus) DeepCopyInto(out *TrackerRecoveryStatus) {
+	*out = *in
+	if in.StalledSince != nil {
+		in, out := &in.StalledSince, &out.StalledSince
+		*out = (*in).DeepCopy()
+	}
+	if in.AddedTrackers != nil {
+		in, out := &in.AddedTrackers, &out.AddedTrackers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastAddedTime != nil {
+		in, out := &in.LastAddedTime, &out.LastAddedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrackerRecoveryStatus.
+func (in *TrackerRecoveryStatus) DeepCopy() *TrackerRecoveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TrackerRecoveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransferBudgetStatus) DeepCopyInto(out *TransferBudgetStatus) {
+	*out = *in
+	in.WindowStart.DeepCopyInto(&out.WindowStart)
+	if in.ExhaustedTime != nil {
+		in, out := &in.ExhaustedTime, &out.ExhaustedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransferBudgetStatus.
+func (in *TransferBudgetStatus) DeepCopy() *TransferBudgetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TransferBudgetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransferStatus) DeepCopyInto(out *TransferStatus) {
+	*out = *in
+	if in.LastActivityTime != nil {
+		in, out := &in.LastActivityTime, &out.LastActivityTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransferStatus.
+func (in *TransferStatus) DeepCopy() *TransferStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TransferStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// QBittorrentOperatorConfigSpec defines the runtime settings of the operator.
+// They are applied without restarting it, the settings left unset keep the
+// value of the flags of the operator.
+type QBittorrentOperatorConfigSpec struct {
+	// RefreshInterval between the refreshes of each Torrent and of the
+	// QBittorrentServer from qBittorrent. Defaults to 30s.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('5s')",message="refreshInterval must be at least 5s"
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// RetryInterval before a Torrent or the QBittorrentServer is reconciled
+	// again after an error.
+	// Defaults to 10s.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1s')",message="retryInterval must be at least 1s"
+	// +optional
+	RetryInterval *metav1.Duration `json:"retryInterval,omitempty"`
+
+	// StatusStaleThreshold replaces --status-stale-threshold
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="statusStaleThreshold must be a positive duration"
+	// +optional
+	StatusStaleThreshold *metav1.Duration `json:"statusStaleThreshold,omitempty"`
+
+	// DeletionRetryTimeout replaces --deletion-retry-timeout, 0s retries forever
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s')",message="deletionRetryTimeout must be a non-negative duration"
+	// +optional
+	DeletionRetryTimeout *metav1.Duration `json:"deletionRetryTimeout,omitempty"`
+
+	// DefaultDeletionPolicy applies to the Torrents without a deletionPolicy,
+	// from their spec or a TorrentPolicy. Defaults to Delete, set it to Orphan
+	// to leave qBittorrent untouched when Torrents are deleted.
+	// +optional
+	DefaultDeletionPolicy DeletionPolicy `json:"defaultDeletionPolicy,omitempty"`
+
+	// DiskReserve is the free disk space of qBittorrent kept out of the
+	// downloads. Torrents whose content does not fit in the rest are held
+	// Pending until enough space is freed, instead of failing mid-download.
+	// Disk space is not checked if unset.
+	// +optional
+	DiskReserve *resource.Quantity `json:"diskReserve,omitempty"`
+
+	// NetworkPressure throttles the torrents while the network of the node
+	// running qBittorrent is saturated, protecting the latency-sensitive
+	// workloads sharing it. Torrents are not throttled if unset.
+	// +optional
+	NetworkPressure *NetworkPressure `json:"networkPressure,omitempty"`
+
+	// RateLimit bounds the calls made to qBittorrent. Unlimited if unset.
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// SkipUnchanged skips the calls to qBittorrent beyond the torrent info
+	// while neither the effective spec of a Torrent nor its torrent changed
+	// since its last full reconcile, cutting the calls made for stable
+	// fleets. Every reconcile is a full one if unset.
+	// +optional
+	SkipUnchanged *SkipUnchanged `json:"skipUnchanged,omitempty"`
+
+	// Metrics configures the metrics exported by the operator
+	// +optional
+	Metrics *MetricsConfig `json:"metrics,omitempty"`
+}
+
+// NetworkPressure is when the network of the node running qBittorrent is
+// saturated, and how far the torrents are throttled meanwhile
+type NetworkPressure struct {
+	// Ceiling is the traffic, in bytes per second received and sent, above
+	// which the network is saturated, e.g. "100Mi". The torrents are
+	// restored once the traffic drops below 80% of it.
+	Ceiling resource.Quantity `json:"ceiling"`
+
+	// NodeMetricsURL is the Prometheus node exporter endpoint of the node
+	// running qBittorrent, e.g. "http://10.0.0.12:9100/metrics". The traffic
+	// of the node is read from node_network_receive_bytes_total and
+	// node_network_transmit_bytes_total. Only the traffic of qBittorrent is
+	// compared to the ceiling if unset.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	NodeMetricsURL string `json:"nodeMetricsURL,omitempty"`
+
+	// Device is the network interface of the node read from the node
+	// exporter. Defaults to eth0.
+	// +optional
+	Device string `json:"device,omitempty"`
+
+	// MinimumLimit is the lowest download and upload limit, in bytes per
+	// second, a torrent is throttled to. Defaults to 16Ki.
+	// +optional
+	MinimumLimit *resource.Quantity `json:"minimumLimit,omitempty"`
+}
+
+// RateLimit bounds the rate of the calls to qBittorrent
+type RateLimit struct {
+	// RequestsPerSecond made to qBittorrent on average
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerSecond int32 `json:"requestsPerSecond"`
+
+	// Burst of requests allowed above the average. Defaults to requestsPerSecond.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+}
+
+// SkipUnchanged is how long the reconciles of the unchanged Torrents are skipped
+type SkipUnchanged struct {
+	// FullResyncInterval bounds how long the reconciles of a Torrent are
+	// skipped, so that the steps driven by time, e.g. the completion
+	// deadline, still run. Defaults to 10m.
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="fullResyncInterval must be a positive duration"
+	// +optional
+	FullResyncInterval *metav1.Duration `json:"fullResyncInterval,omitempty"`
+}
+
+// MetricsConfig configures the metrics exported by the operator
+type MetricsConfig struct {
+	// TorrentConditions exports the qbittorrent_operator_torrent_condition
+	// series, one per Torrent and condition. Disable it on large fleets to
+	// bound the number of series. Defaults to true.
+	// +optional
+	TorrentConditions *bool `json:"torrentConditions,omitempty"`
+}
+
+// QBittorrentOperatorConfigStatus defines the observed state of QBittorrentOperatorConfig.
+type QBittorrentOperatorConfigStatus struct {
+	// ObservedGeneration is the generation of the spec applied by the operator
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the config
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Applied",type="string",JSONPath=".status.conditions[?(@.type==\"Applied\")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// QBittorrentOperatorConfig is the Schema for the qbittorrentoperatorconfigs API.
+// The operator applies the one named by its --config-name flag.
+type QBittorrentOperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   QBittorrentOperatorConfigSpec   `json:"spec,omitempty"`
+	Status QBittorrentOperatorConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// QBittorrentOperatorConfigList contains a list of QBittorrentOperatorConfig.
+type QBittorrentOperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []QBittorrentOperatorConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&QBittorrentOperatorConfig{}, &QBittorrentOperatorConfigList{})
+}
@@ -0,0 +1,557 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// QBittorrentServerSpec defines the desired state of QBittorrentServer.
+// The connection to qBittorrent is configured on the operator, the
+// QBittorrentServer is created by the operator to report on it. The spec
+// only completes the connection settings that may change at runtime.
+type QBittorrentServerSpec struct {
+	// URL of the qBittorrent WebUI, e.g. http://qbittorrent.media:8080. The
+	// operator keeps a client per QBittorrentServer with a URL, the Torrents
+	// naming the server in their serverName are downloaded on it. It is
+	// ignored for the server of the --server-name of the operator, reached at
+	// --qbittorrent-url.
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:XValidation:rule="self.startsWith('http://') || self.startsWith('https://')",message="url must be an http or https URL"
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// CredentialsSecret references a kubernetes.io/basic-auth Secret whose
+	// username and password keys are the credentials of the WebUI of URL. No
+	// login is made when unset, e.g. when qBittorrent bypasses the
+	// authentication of the operator. Ignored without URL.
+	// +optional
+	CredentialsSecret *SecretReference `json:"credentialsSecret,omitempty"`
+
+	// TLS configures the HTTPS connection to the qBittorrent WebUI
+	// +optional
+	TLS *ServerTLS `json:"tls,omitempty"`
+
+	// ProxyURL of the HTTP, HTTPS or SOCKS5 proxy used to reach the WebUI,
+	// e.g. socks5://proxy.vpn:1080. It replaces the --qbittorrent-proxy-url of
+	// the operator, the proxy environment variables are used if both are empty.
+	// The credentials of the proxy are read from ProxyCredentialsSecret.
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:XValidation:rule="self.startsWith('http://') || self.startsWith('https://') || self.startsWith('socks5://')",message="proxyURL must be an http, https or socks5 URL"
+	// +kubebuilder:validation:XValidation:rule="!self.matches('^[a-z0-9]+://[^/]*@')",message="proxyURL must not hold credentials, set proxyCredentialsSecret instead"
+	// +optional
+	ProxyURL string `json:"proxyURL,omitempty"`
+
+	// ProxyCredentialsSecret references a kubernetes.io/basic-auth Secret
+	// whose username and password keys are the credentials of the proxy of
+	// ProxyURL. The Secret is read again on every refresh.
+	// +optional
+	ProxyCredentialsSecret *SecretReference `json:"proxyCredentialsSecret,omitempty"`
+
+	// HeadersSecret references a Secret whose keys and values are headers
+	// added to every request to the WebUI, e.g. Authorization for the basic
+	// auth or forward-auth of a reverse proxy. They are added to the headers of
+	// --qbittorrent-headers-file of the operator, replacing the ones with the same name.
+	// +optional
+	HeadersSecret *SecretReference `json:"headersSecret,omitempty"`
+
+	// BannedPeers references a ConfigMap key listing peers banned on
+	// qBittorrent, one IP address or host:port per line. The peers are banned
+	// when the list changes, removing a peer from the list does not unban it.
+	// +optional
+	BannedPeers *ConfigMapKeyReference `json:"bannedPeers,omitempty"`
+
+	// Preferences of qBittorrent managed by the operator. Only the preferences
+	// set are changed, the others are left as configured in the WebUI.
+	// +optional
+	Preferences *ServerPreferences `json:"preferences,omitempty"`
+
+	// Janitor removes the categories and tags of the managed torrents from
+	// qBittorrent once no torrent uses them anymore, keeping long-lived
+	// shared instances tidy. Nothing is removed when unset.
+	// +optional
+	Janitor *Janitor `json:"janitor,omitempty"`
+
+	// MaxManagedTorrents bounds the torrents on qBittorrent, protecting
+	// low-memory instances from the bulk creation of Torrents. Beyond it the
+	// new Torrents are not added, they stay Pending with the CapacityExceeded
+	// condition until torrents are removed. Unbounded when unset.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxManagedTorrents *int32 `json:"maxManagedTorrents,omitempty"`
+}
+
+// ServerPreferences are the qBittorrent preferences managed by the operator
+type ServerPreferences struct {
+	// IPFilter filters the peers, and optionally the trackers, by a blocklist
+	// +optional
+	IPFilter *IPFilter `json:"ipFilter,omitempty"`
+
+	// ListenPort pins or rotates the port qBittorrent listens on for
+	// incoming BitTorrent connections
+	// +optional
+	ListenPort *ListenPort `json:"listenPort,omitempty"`
+
+	// Queueing limits the torrents qBittorrent runs at once, queueing the
+	// others. The queue positions set by the Torrent priorities only take
+	// effect with queueing enabled.
+	// +optional
+	Queueing *Queueing `json:"queueing,omitempty"`
+
+	// Privacy pins the encryption, the anonymous mode and the peer discovery
+	// of qBittorrent
+	// +optional
+	Privacy *Privacy `json:"privacy,omitempty"`
+}
+
+// Privacy configures the privacy settings of qBittorrent. The settings left
+// unset keep their value of the WebUI.
+type Privacy struct {
+	// Encryption of the peer connections: Prefer encrypted connections,
+	// Require them or Disable the encryption
+	// +optional
+	Encryption EncryptionMode `json:"encryption,omitempty"`
+
+	// AnonymousMode hides the client identity from peers and trackers
+	// +optional
+	AnonymousMode *bool `json:"anonymousMode,omitempty"`
+
+	// DHT finds peers through the distributed hash table
+	// +optional
+	DHT *bool `json:"dht,omitempty"`
+
+	// PeX finds peers through the peer exchange
+	// +optional
+	PeX *bool `json:"pex,omitempty"`
+
+	// LSD finds peers through the local service discovery
+	// +optional
+	LSD *bool `json:"lsd,omitempty"`
+
+	// DriftPolicy is what the operator does when the settings are changed in
+	// the WebUI: Enforce reverts them, Accept keeps them and lists them in
+	// status.drift. Defaults to Enforce.
+	// +kubebuilder:default=Enforce
+	// +optional
+	DriftPolicy DriftAction `json:"driftPolicy,omitempty"`
+}
+
+// EncryptionMode is the encryption of the peer connections
+// +kubebuilder:validation:Enum=Prefer;Require;Disable
+type EncryptionMode string
+
+const (
+	// EncryptionModePrefer prefers encrypted connections, allowing plain ones
+	EncryptionModePrefer EncryptionMode = "Prefer"
+	// EncryptionModeRequire only allows encrypted connections
+	EncryptionModeRequire EncryptionMode = "Require"
+	// EncryptionModeDisable only allows plain connections
+	EncryptionModeDisable EncryptionMode = "Disable"
+)
+
+// Queueing configures the torrent queueing of qBittorrent. The limits left
+// unset keep their value of the WebUI.
+type Queueing struct {
+	// Enabled turns the torrent queueing on or off
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MaxActiveDownloads is the number of torrents downloading at once, -1
+	// for no limit
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	MaxActiveDownloads *int32 `json:"maxActiveDownloads,omitempty"`
+
+	// MaxActiveUploads is the number of torrents seeding at once, -1 for no
+	// limit
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	MaxActiveUploads *int32 `json:"maxActiveUploads,omitempty"`
+
+	// MaxActiveTorrents is the number of torrents downloading or seeding at
+	// once, -1 for no limit
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	MaxActiveTorrents *int32 `json:"maxActiveTorrents,omitempty"`
+
+	// IgnoreSlowTorrents does not count the slow torrents in the limits
+	// +optional
+	IgnoreSlowTorrents *bool `json:"ignoreSlowTorrents,omitempty"`
+}
+
+// ListenPort configures the port qBittorrent listens on. Port pins the port,
+// rotationInterval picks a random port on schedule instead, and forwardedPort
+// follows the port forwarded by a VPN.
+// +kubebuilder:validation:XValidation:rule="[has(self.port), has(self.rotationInterval), has(self.forwardedPort)].filter(x, x).size() <= 1",message="only one of port, rotationInterval and forwardedPort may be set"
+type ListenPort struct {
+	// Port qBittorrent listens on. It is set again when changed in the WebUI.
+	// +kubebuilder:validation:Minimum=1024
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// RotationInterval between the picks of a random port, from the dynamic
+	// range 49152-65535
+	// +optional
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
+
+	// ForwardedPort references the key a VPN sidecar writes its forwarded
+	// port to, e.g. the forwarded_port file of gluetun synced into a Secret.
+	// The listen port follows the key whenever it changes.
+	// +optional
+	ForwardedPort *ForwardedPortSource `json:"forwardedPort,omitempty"`
+}
+
+// ForwardedPortSource is a key of a Secret or ConfigMap holding a port.
+// Exactly one of secretName and configMapName must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.secretName) != has(self.configMapName)",message="exactly one of secretName and configMapName must be set"
+type ForwardedPortSource struct {
+	// Namespace of the Secret or ConfigMap. A Secret must be in the
+	// namespace of the operator.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// SecretName is the name of the Secret holding the port
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// ConfigMapName is the name of the ConfigMap holding the port
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Key holding the port
+	// +kubebuilder:default="forwarded_port"
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// IPFilter configures the IP filter of qBittorrent from a blocklist. The
+// blocklist is read by qBittorrent from its own filesystem, e.g. from
+// configMap mounted into the qBittorrent container at path.
+// +kubebuilder:validation:XValidation:rule="!has(self.url) || has(self.configMap)",message="url requires configMap to store the blocklist in"
+type IPFilter struct {
+	// Path of the blocklist in the qBittorrent container, a .dat, .p2p or
+	// .p2b file
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+
+	// ConfigMap holding the blocklist, mounted into the qBittorrent
+	// container at path. The filter is reloaded once the blocklist changed.
+	// +optional
+	ConfigMap *BlocklistConfigMap `json:"configMap,omitempty"`
+
+	// URL of a blocklist the operator downloads into configMap on every
+	// refresh. The blocklist must fit in a ConfigMap, 1MB.
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:XValidation:rule="self.startsWith('http://') || self.startsWith('https://')",message="url must be an http or https URL"
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// RefreshInterval between the reloads of the filter, and the downloads
+	// of url. Defaults to 24h.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// FilterTrackers also applies the filter to the trackers
+	// +optional
+	FilterTrackers bool `json:"filterTrackers,omitempty"`
+}
+
+// BlocklistConfigMap references the key of a ConfigMap holding a blocklist
+type BlocklistConfigMap struct {
+	// Namespace of the ConfigMap
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Name of the ConfigMap
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Key holding the blocklist
+	// +kubebuilder:default="blocklist.p2p"
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// ServerTLS configures the HTTPS connection to the qBittorrent WebUI
+type ServerTLS struct {
+	// CABundle holds the PEM encoded CA certificates trusted for the WebUI,
+	// in addition to the system ones and the --qbittorrent-ca-file of the operator
+	// +optional
+	CABundle *CABundleSource `json:"caBundle,omitempty"`
+
+	// InsecureSkipTLSVerify disables the verification of the WebUI certificate.
+	// The connection is then open to man-in-the-middle attacks, prefer caBundle.
+	// +optional
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// ClientCertificate is a kubernetes.io/tls Secret holding the certificate
+	// and key presented to the WebUI, e.g. to a reverse proxy requiring client
+	// certificates. It replaces the client certificate of the operator flags.
+	// +optional
+	ClientCertificate *SecretReference `json:"clientCertificate,omitempty"`
+}
+
+// SecretReference references a Secret by namespace and name
+type SecretReference struct {
+	// Namespace of the Secret, which must be the namespace of the operator
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Name of the Secret
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// ConfigMapKeyReference references a key of a ConfigMap
+type ConfigMapKeyReference struct {
+	// Namespace of the ConfigMap
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Name of the ConfigMap
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Key holding the data
+	// +kubebuilder:default="peers"
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// CABundleSource is a key of a Secret or ConfigMap holding PEM encoded certificates.
+// Exactly one of secret and configMap must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.secret) != has(self.configMap)",message="exactly one of secret and configMap must be set"
+type CABundleSource struct {
+	// Namespace of the Secret or ConfigMap. A Secret must be in the
+	// namespace of the operator.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Secret holding the CA bundle
+	// +optional
+	Secret *KeySelector `json:"secret,omitempty"`
+
+	// ConfigMap holding the CA bundle
+	// +optional
+	ConfigMap *KeySelector `json:"configMap,omitempty"`
+}
+
+// KeySelector selects a key of a Secret or ConfigMap
+type KeySelector struct {
+	// Name of the Secret or ConfigMap
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Key holding the data
+	// +kubebuilder:default="ca.crt"
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// Janitor removes the categories and tags left unused on qBittorrent. Only
+// the ones the managed torrents had are removed, the categories and tags
+// only used by the other torrents of qBittorrent are never touched.
+type Janitor struct {
+	// Categories removes the categories no torrent is in anymore
+	// +optional
+	Categories bool `json:"categories,omitempty"`
+
+	// Tags removes the tags no torrent has anymore
+	// +optional
+	Tags bool `json:"tags,omitempty"`
+
+	// Interval between the cleanups. Defaults to 1h.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// QBittorrentServerStatus defines the observed state of QBittorrentServer.
+type QBittorrentServerStatus struct {
+	// URL of the qBittorrent WebUI the operator is connected to
+	URL string `json:"url,omitempty"`
+	// Version of qBittorrent, e.g. v5.0.4
+	Version string `json:"version,omitempty"`
+	// WebAPIVersion is the version of the qBittorrent WebUI API, e.g. 2.11.2
+	WebAPIVersion string `json:"webAPIVersion,omitempty"`
+
+	// ConnectionStatus of qBittorrent to the BitTorrent network: connected,
+	// firewalled or disconnected
+	ConnectionStatus string `json:"connectionStatus,omitempty"`
+	// DHTNodes is the number of DHT nodes qBittorrent is connected to
+	DHTNodes int64 `json:"dhtNodes,omitempty"`
+
+	// DownloadSpeed and UploadSpeed are the global rates in bytes per second
+	DownloadSpeed int64 `json:"downloadSpeed,omitempty"`
+	UploadSpeed   int64 `json:"uploadSpeed,omitempty"`
+	// SessionDownloaded and SessionUploaded are the bytes transferred since
+	// qBittorrent started
+	SessionDownloaded int64 `json:"sessionDownloaded,omitempty"`
+	SessionUploaded   int64 `json:"sessionUploaded,omitempty"`
+
+	// FreeSpaceOnDisk is the free space in the default save path
+	FreeSpaceOnDisk *resource.Quantity `json:"freeSpaceOnDisk,omitempty"`
+
+	// BannedPeers is the number of peers of spec.bannedPeers banned on qBittorrent
+	BannedPeers int32 `json:"bannedPeers,omitempty"`
+	// ListenPort is the port qBittorrent listens on for incoming BitTorrent
+	// connections
+	ListenPort int32 `json:"listenPort,omitempty"`
+	// ListenPortRotationTime is when a random listen port was last picked,
+	// with spec.preferences.listenPort.rotationInterval
+	ListenPortRotationTime *metav1.Time `json:"listenPortRotationTime,omitempty"`
+
+	// IPFilterReloadTime is when the IP filter was last reloaded
+	IPFilterReloadTime *metav1.Time `json:"ipFilterReloadTime,omitempty"`
+
+	// Drift lists the privacy settings changed in the WebUI and accepted
+	// with spec.preferences.privacy.driftPolicy Accept
+	// +listType=map
+	// +listMapKey=field
+	Drift []FieldDrift `json:"drift,omitempty"`
+	// DriftRevertTime is when privacy settings changed in the WebUI were last
+	// reverted with spec.preferences.privacy.driftPolicy Enforce
+	DriftRevertTime *metav1.Time `json:"driftRevertTime,omitempty"`
+
+	// Maintenance records the torrents paused by the qbittorrent.io/maintenance
+	// annotation, resumed once it is removed
+	// +optional
+	Maintenance *MaintenanceStatus `json:"maintenance,omitempty"`
+
+	// Janitor records the categories and tags of the managed torrents,
+	// removed by spec.janitor once unused
+	// +optional
+	Janitor *JanitorStatus `json:"janitor,omitempty"`
+
+	// Inventory summarizes the managed Torrents of all the namespaces, for
+	// capacity planning without Prometheus
+	// +optional
+	Inventory *Inventory `json:"inventory,omitempty"`
+
+	// LastUpdated is when the status was last refreshed from qBittorrent
+	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
+
+	// Conditions represent the latest available observations of the server state
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// MaintenanceStatus records a maintenance of qBittorrent
+type MaintenanceStatus struct {
+	// StartTime is when the maintenance started
+	StartTime metav1.Time `json:"startTime"`
+
+	// PausedTorrents are the hashes of the managed torrents which were active
+	// and have been paused for the maintenance. Only these are resumed.
+	// +listType=set
+	// +optional
+	PausedTorrents []string `json:"pausedTorrents,omitempty"`
+}
+
+// JanitorStatus records the categories and tags seen on the managed torrents
+type JanitorStatus struct {
+	// Categories of the managed torrents, removed once empty
+	// +listType=set
+	// +optional
+	Categories []string `json:"categories,omitempty"`
+
+	// Tags of the managed torrents, removed once unused
+	// +listType=set
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// LastCleanupTime is when the unused categories and tags were last removed
+	// +optional
+	LastCleanupTime *metav1.Time `json:"lastCleanupTime,omitempty"`
+}
+
+// Inventory summarizes the managed Torrents, from their status
+type Inventory struct {
+	// Torrents is the number of managed Torrents
+	Torrents int32 `json:"torrents"`
+	// BytesOnDisk is the size of the content downloaded by the managed Torrents
+	BytesOnDisk resource.Quantity `json:"bytesOnDisk"`
+
+	// States counts the Torrents by the state of their torrent on
+	// qBittorrent, the Torrents not added yet are left out
+	// +optional
+	States map[string]int32 `json:"states,omitempty"`
+	// Categories counts the Torrents by category, the uncategorized ones are
+	// left out
+	// +optional
+	Categories map[string]int32 `json:"categories,omitempty"`
+
+	// Namespaces summarizes the Torrents of each namespace
+	// +listType=map
+	// +listMapKey=namespace
+	// +optional
+	Namespaces []NamespaceInventory `json:"namespaces,omitempty"`
+
+	// TopConsumers are the Torrents with the most content on disk, the
+	// largest first
+	// +optional
+	TopConsumers []TorrentUsage `json:"topConsumers,omitempty"`
+}
+
+// NamespaceInventory summarizes the managed Torrents of a namespace
+type NamespaceInventory struct {
+	Namespace string `json:"namespace"`
+	// Torrents is the number of Torrents of the namespace
+	Torrents int32 `json:"torrents"`
+	// BytesOnDisk is the size of the content downloaded by the Torrents of the namespace
+	BytesOnDisk resource.Quantity `json:"bytesOnDisk"`
+}
+
+// TorrentUsage is the disk space used by the content of a Torrent
+type TorrentUsage struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// BytesOnDisk is the size of the content downloaded
+	BytesOnDisk resource.Quantity `json:"bytesOnDisk"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version"
+// +kubebuilder:printcolumn:name="Connection",type="string",JSONPath=".status.connectionStatus"
+// +kubebuilder:printcolumn:name="Free",type="string",JSONPath=".status.freeSpaceOnDisk"
+// +kubebuilder:printcolumn:name="Port",type="integer",JSONPath=".status.listenPort",priority=1
+// +kubebuilder:printcolumn:name="Updated",type="date",JSONPath=".status.lastUpdated"
+
+// QBittorrentServer is the Schema for the qbittorrentservers API.
+// It reports the health of the qBittorrent instance managed by the operator.
+type QBittorrentServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   QBittorrentServerSpec   `json:"spec,omitempty"`
+	Status QBittorrentServerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// QBittorrentServerList contains a list of QBittorrentServer.
+type QBittorrentServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []QBittorrentServer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&QBittorrentServer{}, &QBittorrentServerList{})
+}
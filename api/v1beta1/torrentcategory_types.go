@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TorrentCategorySpec defines the defaults of the Torrents of a category.
+type TorrentCategorySpec struct {
+	// Category is the qBittorrent category the defaults apply to, the name of
+	// the TorrentCategory when empty. Subcategories such as movies/hd are set
+	// here, names cannot contain slashes.
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:XValidation:rule="!self.contains('\\\\')",message="category must not contain backslashes"
+	// +kubebuilder:validation:XValidation:rule="!self.startsWith('/') && !self.endsWith('/') && !self.contains('//')",message="category must not start or end with a slash or contain empty subcategories"
+	// +optional
+	Category string `json:"category,omitempty"`
+
+	// SeedGoal is when the Torrents of the category stop seeding. It is
+	// defaulted before the ratioLimit and seedingTimeLimit of limits.
+	// +optional
+	SeedGoal *SeedGoal `json:"seedGoal,omitempty"`
+
+	// Limits are defaulted one by one, a Torrent setting only some of them
+	// gets the others from the category
+	// +optional
+	Limits *TorrentLimits `json:"limits,omitempty"`
+
+	// OnComplete is what is done with the content of the Torrents of the
+	// category once complete
+	// +optional
+	OnComplete *OnComplete `json:"onComplete,omitempty"`
+}
+
+// SeedGoal is the share ratio and seeding time after which a torrent stops
+// seeding, whichever is reached first
+type SeedGoal struct {
+	// Ratio is the share ratio to reach, e.g. "2.0". It defaults the
+	// ratioLimit of the Torrents.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	Ratio string `json:"ratio,omitempty"`
+
+	// SeedingTime is how long to seed for, e.g. "72h". It defaults the
+	// seedingTimeLimit of the Torrents.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s')",message="seedingTime must be a non-negative duration such as 72h"
+	// +optional
+	SeedingTime *metav1.Duration `json:"seedingTime,omitempty"`
+}
+
+// OnComplete are the Jobs run on the content of a torrent once complete
+type OnComplete struct {
+	// ContentVolume is the volume holding the content, mounted by the Jobs.
+	// It defaults the contentVolume of the Torrents.
+	// +optional
+	ContentVolume *ContentVolume `json:"contentVolume,omitempty"`
+
+	// Checksums defaults the checksums of the Torrents
+	// +optional
+	Checksums *ChecksumSpec `json:"checksums,omitempty"`
+
+	// ContentVerification defaults the contentVerification of the Torrents
+	// +optional
+	ContentVerification *ContentVerificationSpec `json:"contentVerification,omitempty"`
+}
+
+// TorrentCategoryStatus defines the observed state of TorrentCategory.
+type TorrentCategoryStatus struct {
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// TorrentCategory is the Schema for the torrentcategories API.
+// Its defaults are filled into the spec of the Torrents of its category
+// created in the namespace. They win over the ones of the TorrentPolicies.
+type TorrentCategory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TorrentCategorySpec   `json:"spec,omitempty"`
+	Status TorrentCategoryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TorrentCategoryList contains a list of TorrentCategory.
+type TorrentCategoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TorrentCategory `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TorrentCategory{}, &TorrentCategoryList{})
+}
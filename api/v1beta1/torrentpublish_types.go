@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TorrentPublishSpec defines the content published as a new torrent.
+// The spec is immutable, delete and recreate the TorrentPublish to publish
+// other content.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type TorrentPublishSpec struct {
+	// ContentVolume is the volume holding the content, mounted by qBittorrent.
+	// The Job building the .torrent mounts it read-only at the same path.
+	ContentVolume ContentVolume `json:"contentVolume"`
+
+	// Path of the file or folder to publish, relative to the mount path of
+	// contentVolume. qBittorrent seeds it in place, from the mount path of
+	// contentVolume in the qBittorrent container.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=4096
+	// +kubebuilder:validation:XValidation:rule="!self.startsWith('/') && !self.split('/').exists(s, s == '..')",message="path must be relative to the contentVolume, without .."
+	Path string `json:"path"`
+
+	// Category assigned to the torrent on qBittorrent
+	// +optional
+	Category string `json:"category,omitempty"`
+
+	// Trackers announced in the .torrent file. A torrent without trackers is
+	// only found through DHT and the web seeds.
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:MaxLength=2048
+	// +kubebuilder:validation:XValidation:rule="self.all(url, url.startsWith('http://') || url.startsWith('https://') || url.startsWith('udp://'))",message="trackers must be http, https or udp URLs"
+	// +optional
+	Trackers []string `json:"trackers,omitempty"`
+
+	// WebSeeds are HTTP(S) URLs serving the content, written into the
+	// .torrent file
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MaxLength=2048
+	// +kubebuilder:validation:XValidation:rule="self.all(url, url.startsWith('http://') || url.startsWith('https://'))",message="webSeeds must be http or https URLs"
+	// +optional
+	WebSeeds []string `json:"webSeeds,omitempty"`
+
+	// Private marks the torrent private, so that peers are only found
+	// through its trackers
+	// +optional
+	Private bool `json:"private,omitempty"`
+
+	// Comment written into the .torrent file
+	// +kubebuilder:validation:MaxLength=1024
+	// +optional
+	Comment string `json:"comment,omitempty"`
+
+	// PieceLength is the log2 of the piece size, from 15 (32KiB) to 28
+	// (256MiB). It is picked from the size of the content when unset.
+	// +kubebuilder:validation:Minimum=15
+	// +kubebuilder:validation:Maximum=28
+	// +optional
+	PieceLength int32 `json:"pieceLength,omitempty"`
+
+	// Image of the Job building the .torrent file with mktorrent. mktorrent
+	// is installed with apk when missing from the image, which requires
+	// access to the Alpine package repositories.
+	// +kubebuilder:default="alpine:3.20"
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// TorrentPublishPhase is the progress of the publication
+// +kubebuilder:validation:Enum=Building;Seeding;Failed
+type TorrentPublishPhase string
+
+const (
+	// TorrentPublishPhaseBuilding torrents have their .torrent file built
+	TorrentPublishPhaseBuilding TorrentPublishPhase = "Building"
+	// TorrentPublishPhaseSeeding torrents are seeded by qBittorrent
+	TorrentPublishPhaseSeeding TorrentPublishPhase = "Seeding"
+	// TorrentPublishPhaseFailed torrents could not be built or seeded
+	TorrentPublishPhaseFailed TorrentPublishPhase = "Failed"
+)
+
+// TorrentPublishStatus defines the observed state of TorrentPublish.
+type TorrentPublishStatus struct {
+	// Phase is the progress of the publication
+	Phase TorrentPublishPhase `json:"phase,omitempty"`
+
+	// Name of the published torrent, the base name of path
+	Name string `json:"name,omitempty"`
+	// InfoHash of the published torrent
+	InfoHash string `json:"infoHash,omitempty"`
+	// MagnetURI of the published torrent, to add it to other clients or as
+	// the source of a Torrent
+	MagnetURI string `json:"magnetURI,omitempty"`
+	// TorrentConfigMap is the ConfigMap holding the .torrent file, under the
+	// key torrent
+	TorrentConfigMap string `json:"torrentConfigMap,omitempty"`
+
+	// State of the torrent on qBittorrent
+	State TorrentState `json:"state,omitempty"`
+
+	// Conditions represent the latest available observations of the publication
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Name",type="string",JSONPath=".status.name"
+// +kubebuilder:printcolumn:name="Hash",type="string",JSONPath=".status.infoHash",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TorrentPublish is the Schema for the torrentpublishes API.
+// It builds a .torrent file from content on a PersistentVolumeClaim and
+// seeds it from qBittorrent.
+type TorrentPublish struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TorrentPublishSpec   `json:"spec"`
+	Status TorrentPublishStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TorrentPublishList contains a list of TorrentPublish.
+type TorrentPublishList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TorrentPublish `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TorrentPublish{}, &TorrentPublishList{})
+}
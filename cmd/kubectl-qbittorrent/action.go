@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/controller"
+)
+
+// actionTimeout bounds the wait for the operator to run an action
+const actionTimeout = 2 * time.Minute
+
+func actionFlags(fs *flag.FlagSet, p *plugin) {
+	fs.BoolVar(&p.wait, "wait", false, "Wait for the operator to run the action")
+}
+
+// allowedActions returns the actions the state of the torrent allows, as
+// reported by the operator, all of them until it does
+func allowedActions(torrent *torrentv1beta1.Torrent) []string {
+	allowed := torrent.Status.AllowedActions
+	if allowed == nil {
+		return []string{controller.ActionPause, controller.ActionResume, controller.ActionRecheck}
+	}
+	var actions []string
+	if allowed.CanPause {
+		actions = append(actions, controller.ActionPause)
+	}
+	if allowed.CanResume {
+		actions = append(actions, controller.ActionResume)
+	}
+	if allowed.CanRecheck {
+		actions = append(actions, controller.ActionRecheck)
+	}
+	return actions
+}
+
+// runAction returns the command asking the operator for the action on a
+// Torrent, through the action annotation
+func runAction(action string) func(ctx context.Context, p *plugin, name string) error {
+	return func(ctx context.Context, p *plugin, name string) error {
+		key := client.ObjectKey{Namespace: p.namespace, Name: name}
+		torrent := &torrentv1beta1.Torrent{}
+		if err := p.client.Get(ctx, key, torrent); err != nil {
+			return fmt.Errorf("failed to get Torrent %s: %w", name, err)
+		}
+		if torrent.Annotations[controller.AnnotationReconcile] == controller.ReconcileDisabled {
+			fmt.Fprintf(os.Stderr, "warning: reconcile of Torrent %s is disabled, the %s waits for it to be enabled\n",
+				name, action)
+		}
+		if pending, ok := torrent.Annotations[controller.AnnotationAction]; ok && pending != action {
+			return fmt.Errorf("the %[2]s action of Torrent %[1]s is pending", name, pending)
+		}
+		if !slices.Contains(allowedActions(torrent), action) {
+			return fmt.Errorf("cannot %s Torrent %s in state %s", action, name, torrent.Status.State)
+		}
+
+		patch, err := json.Marshal(map[string]any{
+			"metadata": map[string]any{
+				"annotations": map[string]string{controller.AnnotationAction: action},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if err := p.client.Patch(ctx, torrent, client.RawPatch(types.MergePatchType, patch)); err != nil {
+			return fmt.Errorf("failed to annotate Torrent %s: %w", name, err)
+		}
+		if !p.wait {
+			fmt.Fprintf(p.out, "torrent.qbittorrent.io/%s %s requested\n", name, action)
+			return nil
+		}
+
+		// The operator removes the annotation once the action is done
+		err = wait.PollUntilContextTimeout(ctx, time.Second, actionTimeout, true,
+			func(ctx context.Context) (bool, error) {
+				if err := p.client.Get(ctx, key, torrent); err != nil {
+					return false, err
+				}
+				_, pending := torrent.Annotations[controller.AnnotationAction]
+				return !pending, nil
+			})
+		if err != nil {
+			return fmt.Errorf("%s of Torrent %s not done: %w", action, name, err)
+		}
+		fmt.Fprintf(p.out, "torrent.qbittorrent.io/%s %s done\n", name, action)
+		return nil
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+func activityFlags(fs *flag.FlagSet, p *plugin) {
+	statusFlags(fs, p)
+	fs.IntVar(&p.limit, "limit", 10, "Number of most active Torrents shown, 0 for all")
+	fs.DurationVar(&p.watch, "watch", 0, "Refresh the view at this interval, e.g. 10s")
+}
+
+// namespaceActivity sums the transfers of the Torrents of a namespace
+type namespaceActivity struct {
+	namespace     string
+	torrents      int
+	active        int
+	downloadSpeed int64
+	uploadSpeed   int64
+}
+
+// runActivity shows the speeds of the Torrents at their last sync, summed
+// by namespace, and the most active Torrents
+func runActivity(ctx context.Context, p *plugin, _ string) error {
+	if p.watch <= 0 {
+		return p.printActivity(ctx)
+	}
+
+	ticker := time.NewTicker(p.watch)
+	defer ticker.Stop()
+	for {
+		fmt.Fprintf(p.out, "%s\n", time.Now().Format(time.TimeOnly))
+		if err := p.printActivity(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			fmt.Fprintln(p.out)
+		}
+	}
+}
+
+func (p *plugin) printActivity(ctx context.Context) error {
+	torrents := &torrentv1beta1.TorrentList{}
+	if err := p.client.List(ctx, torrents, client.InNamespace(p.listNamespace())); err != nil {
+		return fmt.Errorf("failed to list Torrents: %w", err)
+	}
+	namespaces, active := activity(torrents.Items)
+	if p.limit > 0 && len(active) > p.limit {
+		active = active[:p.limit]
+	}
+	if err := writeNamespaceActivity(p.out, namespaces); err != nil {
+		return err
+	}
+	fmt.Fprintln(p.out)
+	if len(active) == 0 {
+		fmt.Fprintln(p.out, "No active Torrents")
+		return nil
+	}
+	return writeActiveTorrents(p.out, active)
+}
+
+// activity sums the speeds of the Torrents by namespace, and returns the
+// Torrents transferring bytes, the fastest first
+func activity(torrents []torrentv1beta1.Torrent) ([]namespaceActivity, []*torrentv1beta1.Torrent) {
+	byNamespace := map[string]*namespaceActivity{}
+	var active []*torrentv1beta1.Torrent
+	for i := range torrents {
+		torrent := &torrents[i]
+		sum, ok := byNamespace[torrent.Namespace]
+		if !ok {
+			sum = &namespaceActivity{namespace: torrent.Namespace}
+			byNamespace[torrent.Namespace] = sum
+		}
+		sum.torrents++
+
+		down, up := speeds(torrent)
+		if down == 0 && up == 0 {
+			continue
+		}
+		sum.active++
+		sum.downloadSpeed += down
+		sum.uploadSpeed += up
+		active = append(active, torrent)
+	}
+
+	namespaces := make([]namespaceActivity, 0, len(byNamespace))
+	for _, sum := range byNamespace {
+		namespaces = append(namespaces, *sum)
+	}
+	sort.Slice(namespaces, func(i, j int) bool {
+		si := namespaces[i].downloadSpeed + namespaces[i].uploadSpeed
+		sj := namespaces[j].downloadSpeed + namespaces[j].uploadSpeed
+		if si != sj {
+			return si > sj
+		}
+		return namespaces[i].namespace < namespaces[j].namespace
+	})
+	sort.SliceStable(active, func(i, j int) bool {
+		di, ui := speeds(active[i])
+		dj, uj := speeds(active[j])
+		return di+ui > dj+uj
+	})
+	return namespaces, active
+}
+
+func speeds(torrent *torrentv1beta1.Torrent) (down, up int64) {
+	if transfer := torrent.Status.Transfer; transfer != nil {
+		return transfer.DownloadSpeed, transfer.UploadSpeed
+	}
+	return 0, 0
+}
+
+func writeNamespaceActivity(out io.Writer, namespaces []namespaceActivity) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tTORRENTS\tACTIVE\tDOWN\tUP")
+	var total namespaceActivity
+	for _, sum := range namespaces {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\n", sum.namespace, sum.torrents, sum.active,
+			formatSpeed(sum.downloadSpeed), formatSpeed(sum.uploadSpeed))
+		total.torrents += sum.torrents
+		total.active += sum.active
+		total.downloadSpeed += sum.downloadSpeed
+		total.uploadSpeed += sum.uploadSpeed
+	}
+	if len(namespaces) > 1 {
+		fmt.Fprintf(w, "TOTAL\t%d\t%d\t%s\t%s\n", total.torrents, total.active,
+			formatSpeed(total.downloadSpeed), formatSpeed(total.uploadSpeed))
+	}
+	return w.Flush()
+}
+
+func writeActiveTorrents(out io.Writer, torrents []*torrentv1beta1.Torrent) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tDOWN\tUP\tPHASE\tPROGRESS\tSYNCED")
+	for _, torrent := range torrents {
+		down, up := speeds(torrent)
+		synced := "<unknown>"
+		if torrent.Status.LastSyncedTime != nil {
+			synced = age(*torrent.Status.LastSyncedTime) + " ago"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", torrent.Namespace, torrent.Name,
+			formatSpeed(down), formatSpeed(up), orNone(string(torrent.Status.Phase)), progress(torrent), synced)
+	}
+	return w.Flush()
+}
+
+func formatSpeed(speed int64) string {
+	return formatBytes(speed) + "/s"
+}
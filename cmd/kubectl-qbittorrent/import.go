@@ -0,0 +1,235 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// importOptions are the flags of the import command
+type importOptions struct {
+	url      string
+	username string
+	password string
+	category string
+	tag      string
+	apply    bool
+}
+
+func importFlags(fs *flag.FlagSet, p *plugin) {
+	fs.StringVar(&p.importOptions.url, "qbittorrent-url", os.Getenv("QBITTORRENT_URL"),
+		"The URL of the qBittorrent server, defaults to $QBITTORRENT_URL")
+	fs.StringVar(&p.importOptions.username, "qbittorrent-username", os.Getenv("QBITTORRENT_USERNAME"),
+		"The username for logging into the qBittorrent server, defaults to $QBITTORRENT_USERNAME")
+	fs.StringVar(&p.importOptions.password, "qbittorrent-password", os.Getenv("QBITTORRENT_PASSWORD"),
+		"The password for logging into the qBittorrent server, defaults to $QBITTORRENT_PASSWORD")
+	fs.StringVar(&p.importOptions.category, "category", "", "Only import the torrents of the category")
+	fs.StringVar(&p.importOptions.tag, "tag", "", "Only import the torrents with the tag")
+	fs.BoolVar(&p.importOptions.apply, "apply", false,
+		"Create the Torrents in the cluster instead of printing them")
+}
+
+// importNeedsCluster is set when the imported Torrents are created, they
+// are only printed otherwise
+func importNeedsCluster(p *plugin) bool {
+	return p.importOptions.apply
+}
+
+// runImport creates a Torrent for each torrent of a qBittorrent instance,
+// with the Orphan deletion policy so that deleting them leaves qBittorrent
+// untouched. The Torrents are printed as YAML, or created with --apply.
+func runImport(ctx context.Context, p *plugin, _ string) error {
+	opts := p.importOptions
+	if opts.url == "" {
+		return fmt.Errorf("--qbittorrent-url or $QBITTORRENT_URL is required")
+	}
+	qbClient := qbittorrent.NewClient(opts.url)
+	if err := qbClient.Login(ctx, opts.username, opts.password); err != nil {
+		return err
+	}
+
+	var infos []qbittorrent.TorrentInfo
+	var err error
+	if opts.tag != "" {
+		infos, err = qbClient.GetTorrentsInfoByTag(ctx, opts.tag)
+	} else {
+		infos, err = qbClient.GetTorrentsInfo(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list the torrents of qBittorrent: %w", err)
+	}
+
+	torrents := importedTorrents(infos, p.namespace, opts.category)
+	if !opts.apply {
+		for i := range torrents {
+			out, err := torrentYAML(&torrents[i])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(p.out, "---\n%s", out)
+		}
+		return nil
+	}
+
+	// The torrents already reconciled by a Torrent of the namespace are skipped
+	existing := &torrentv1beta1.TorrentList{}
+	if err := p.client.List(ctx, existing, client.InNamespace(p.namespace)); err != nil {
+		return fmt.Errorf("failed to list Torrents: %w", err)
+	}
+	managed := map[string]string{}
+	for _, torrent := range existing.Items {
+		if torrent.Status.Hash != "" {
+			managed[torrent.Status.Hash] = torrent.Name
+		}
+	}
+
+	created := 0
+	for i := range torrents {
+		torrent := &torrents[i]
+		hash := torrent.Annotations[annotationImportedHash]
+		if name, ok := managed[hash]; ok {
+			fmt.Fprintf(p.out, "torrent.qbittorrent.io/%s skipped, %s is managed by Torrent %s\n",
+				torrent.Name, hash, name)
+			continue
+		}
+		if err := p.client.Create(ctx, torrent); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				fmt.Fprintf(p.out, "torrent.qbittorrent.io/%s skipped, already exists\n", torrent.Name)
+				continue
+			}
+			return fmt.Errorf("failed to create Torrent %s: %w", torrent.Name, err)
+		}
+		fmt.Fprintf(p.out, "torrent.qbittorrent.io/%s created\n", torrent.Name)
+		created++
+	}
+	fmt.Fprintf(p.out, "%d of %d torrents imported\n", created, len(torrents))
+	return nil
+}
+
+// annotationImportedHash records the hash of the torrent a Torrent was
+// imported from
+const annotationImportedHash = "qbittorrent.io/imported-hash"
+
+// importedTorrents returns the Torrents of the torrents of the category, all
+// of them if empty, sorted by name
+func importedTorrents(infos []qbittorrent.TorrentInfo, namespace, category string) []torrentv1beta1.Torrent {
+	var torrents []torrentv1beta1.Torrent
+	for _, info := range infos {
+		if category != "" && info.Category != category {
+			continue
+		}
+		torrents = append(torrents, torrentv1beta1.Torrent{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: torrentv1beta1.GroupVersion.String(),
+				Kind:       "Torrent",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        importedName(info),
+				Namespace:   namespace,
+				Annotations: map[string]string{annotationImportedHash: info.Hash},
+			},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source:         torrentv1beta1.TorrentSource{MagnetURI: importedMagnet(info)},
+				Category:       info.Category,
+				SavePath:       info.SavePath,
+				DeletionPolicy: torrentv1beta1.DeletionPolicyOrphan,
+			},
+		})
+	}
+	sort.Slice(torrents, func(i, j int) bool { return torrents[i].Name < torrents[j].Name })
+	return torrents
+}
+
+// importedMagnet returns the magnet link reported by qBittorrent, with its
+// trackers, or one with the info hashes only when it would not be accepted
+// by the Torrent validation
+func importedMagnet(info qbittorrent.TorrentInfo) string {
+	if _, err := qbittorrent.GetInfoHashes(info.MagnetURI); err == nil {
+		return info.MagnetURI
+	}
+
+	v1, v2 := info.InfohashV1, info.InfohashV2
+	if v1 == "" && v2 == "" {
+		v1 = info.Hash
+	}
+	var xts []string
+	if v1 != "" {
+		xts = append(xts, "xt=urn:btih:"+v1)
+	}
+	if v2 != "" {
+		xts = append(xts, "xt=urn:btmh:1220"+v2)
+	}
+	return "magnet:?" + strings.Join(xts, "&")
+}
+
+// importedName derives a Torrent name from the name of the torrent, suffixed
+// with the start of its hash to tell the torrents of the same name apart
+func importedName(info qbittorrent.TorrentInfo) string {
+	const maxLength = 63
+	hash := info.Hash
+	if len(hash) > 8 {
+		hash = hash[:8]
+	}
+
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(info.Name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	name := b.String()
+	if len(name) > maxLength-len(hash)-1 {
+		name = name[:maxLength-len(hash)-1]
+	}
+	name = strings.TrimSuffix(name, "-")
+	if name == "" {
+		return "torrent-" + hash
+	}
+	return name + "-" + hash
+}
+
+// torrentYAML marshals a Torrent without its empty status and creation
+// timestamp, as applied with kubectl
+func torrentYAML(torrent *torrentv1beta1.Torrent) ([]byte, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(torrent)
+	if err != nil {
+		return nil, err
+	}
+	unstructured.RemoveNestedField(obj, "status")
+	unstructured.RemoveNestedField(obj, "metadata", "creationTimestamp")
+	return yaml.Marshal(obj)
+}
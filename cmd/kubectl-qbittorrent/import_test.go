@@ -0,0 +1,198 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var importTestTorrents = []qbittorrent.TorrentInfo{
+	{
+		Hash:      "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c",
+		Name:      "Big Buck Bunny",
+		Category:  "movies",
+		SavePath:  "/downloads/movies",
+		MagnetURI: "magnet:?xt=urn:btih:dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c&dn=Big+Buck+Bunny&tr=udp%3A%2F%2Fexplodie.org%3A6969",
+	},
+	{
+		Hash:       "08ada5a7a6183aae1e09d831df6748d566095a10",
+		InfohashV1: "08ada5a7a6183aae1e09d831df6748d566095a10",
+		Name:       "Sintel",
+		Category:   "movies",
+		// Longer than the Torrent validation accepts
+		MagnetURI: "magnet:?xt=urn:btih:08ada5a7a6183aae1e09d831df6748d566095a10&tr=" + strings.Repeat("x", qbittorrent.MaxMagnetURILength),
+	},
+	{
+		Hash:      "c9e15763f722f23e98a29decdfae341b98d53056",
+		Name:      "ubuntu-24.04-desktop-amd64.iso",
+		Category:  "linux",
+		MagnetURI: "magnet:?xt=urn:btih:c9e15763f722f23e98a29decdfae341b98d53056",
+	},
+}
+
+// newImportTestServer serves the torrents of importTestTorrents, with the
+// tag filter matching the linux category
+func newImportTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/auth/login":
+			http.SetCookie(w, &http.Cookie{Name: "SID", Value: "session"})
+			_, _ = w.Write([]byte("Ok."))
+		case "/api/v2/torrents/info":
+			torrents := importTestTorrents
+			if tag := r.URL.Query().Get("tag"); tag != "" {
+				torrents = nil
+				for _, torrent := range importTestTorrents {
+					if torrent.Category == tag {
+						torrents = append(torrents, torrent)
+					}
+				}
+			}
+			_ = json.NewEncoder(w).Encode(torrents)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestImport_PrintsTorrents(t *testing.T) {
+	p, out := newTestPlugin(t)
+	p.importOptions = importOptions{url: newImportTestServer(t).URL, category: "movies"}
+
+	if err := runImport(context.Background(), p, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var torrents []torrentv1beta1.Torrent
+	for _, doc := range strings.Split(out.String(), "---\n")[1:] {
+		torrent := torrentv1beta1.Torrent{}
+		if err := yaml.UnmarshalStrict([]byte(doc), &torrent); err != nil {
+			t.Fatalf("Invalid YAML %q: %v", doc, err)
+		}
+		torrents = append(torrents, torrent)
+	}
+	if len(torrents) != 2 {
+		t.Fatalf("Expected the 2 movies, got %d Torrents", len(torrents))
+	}
+	if strings.Contains(out.String(), "creationTimestamp") || strings.Contains(out.String(), "status") {
+		t.Errorf("Expected no creationTimestamp nor status, got %q", out.String())
+	}
+
+	bunny := torrents[0]
+	if bunny.Name != "big-buck-bunny-dd8255ec" || bunny.Namespace != "media" {
+		t.Errorf("Unexpected Torrent %s/%s", bunny.Namespace, bunny.Name)
+	}
+	if bunny.Kind != "Torrent" || bunny.APIVersion != torrentv1beta1.GroupVersion.String() {
+		t.Errorf("Unexpected type %s %s", bunny.APIVersion, bunny.Kind)
+	}
+	if bunny.Spec.DeletionPolicy != torrentv1beta1.DeletionPolicyOrphan {
+		t.Errorf("Expected the Orphan deletion policy, got %q", bunny.Spec.DeletionPolicy)
+	}
+	if bunny.Spec.Source.MagnetURI != importTestTorrents[0].MagnetURI {
+		t.Errorf("Expected the magnet link of qBittorrent, got %q", bunny.Spec.Source.MagnetURI)
+	}
+	if bunny.Spec.Category != "movies" || bunny.Spec.SavePath != "/downloads/movies" {
+		t.Errorf("Unexpected category %q and save path %q", bunny.Spec.Category, bunny.Spec.SavePath)
+	}
+
+	sintel := torrents[1]
+	if want := "magnet:?xt=urn:btih:08ada5a7a6183aae1e09d831df6748d566095a10"; sintel.Spec.Source.MagnetURI != want {
+		t.Errorf("Expected the magnet link %q, got %q", want, sintel.Spec.Source.MagnetURI)
+	}
+}
+
+func TestImport_Apply(t *testing.T) {
+	managed := newTestTorrent("bunny", 0, 0)
+	p, out := newTestPlugin(t, managed)
+	p.importOptions = importOptions{url: newImportTestServer(t).URL, apply: true}
+	ctx := context.Background()
+
+	if err := runImport(ctx, p, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	torrents := &torrentv1beta1.TorrentList{}
+	if err := p.client.List(ctx, torrents, client.InNamespace("media")); err != nil {
+		t.Fatal(err)
+	}
+	// The hash of newTestTorrent is the one of Big Buck Bunny
+	if len(torrents.Items) != 3 {
+		t.Errorf("Expected Sintel and Ubuntu imported next to the managed Torrent, got %d Torrents", len(torrents.Items))
+	}
+	if !strings.Contains(out.String(), "managed by Torrent bunny") || !strings.Contains(out.String(), "2 of 3 torrents imported") {
+		t.Errorf("Unexpected output %q", out.String())
+	}
+
+	// Importing again creates nothing
+	out.Reset()
+	if err := runImport(ctx, p, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "0 of 3 torrents imported") {
+		t.Errorf("Unexpected output %q", out.String())
+	}
+}
+
+func TestImport_Tag(t *testing.T) {
+	p, out := newTestPlugin(t)
+	p.importOptions = importOptions{url: newImportTestServer(t).URL, tag: "linux"}
+
+	if err := runImport(context.Background(), p, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := strings.Count(out.String(), "kind: Torrent"); got != 1 {
+		t.Errorf("Expected the torrent with the tag only, got %d", got)
+	}
+	if !strings.Contains(out.String(), "name: ubuntu-24-04-desktop-amd64-iso-c9e15763") {
+		t.Errorf("Unexpected output %q", out.String())
+	}
+}
+
+func TestImportedName(t *testing.T) {
+	tests := []struct {
+		name string
+		hash string
+		want string
+	}{
+		{name: "Big Buck Bunny", hash: "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c", want: "big-buck-bunny-dd8255ec"},
+		{name: "[Group] Show - 01 (1080p).mkv", hash: "0123456789", want: "group-show-01-1080p-mkv-01234567"},
+		{name: "日本語", hash: "abcdef0123", want: "torrent-abcdef01"},
+		{name: strings.Repeat("a", 60) + "-b", hash: "abcdef0123", want: strings.Repeat("a", 54) + "-abcdef01"},
+	}
+	for _, tt := range tests {
+		got := importedName(qbittorrent.TorrentInfo{Name: tt.name, Hash: tt.hash})
+		if got != tt.want {
+			t.Errorf("importedName(%q) = %q, expected %q", tt.name, got, tt.want)
+		}
+		if len(got) > 63 {
+			t.Errorf("importedName(%q) is longer than 63 characters", tt.name)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"regexp"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// operatorSelector selects the pods of the operator, as deployed by config/manager
+	operatorSelector = "control-plane=controller-manager"
+	// operatorContainer is the container of the operator in its pods
+	operatorContainer = "manager"
+)
+
+func logsFlags(fs *flag.FlagSet, p *plugin) {
+	fs.StringVar(&p.operatorNamespace, "operator-namespace", "qbittorrent-operator",
+		"The namespace the operator is deployed in")
+	fs.Int64Var(&p.tail, "tail", -1, "Number of lines of the operator logs searched, -1 for all of them")
+	fs.BoolVar(&p.follow, "follow", false, "Follow the operator logs")
+	fs.BoolVar(&p.follow, "f", false, "Shorthand for --follow")
+}
+
+// runLogs prints the lines of the operator logs about a Torrent, those of
+// its reconciles carrying its name and namespace
+func runLogs(ctx context.Context, p *plugin, name string) error {
+	pods, err := p.clientset.CoreV1().Pods(p.operatorNamespace).List(ctx,
+		metav1.ListOptions{LabelSelector: operatorSelector})
+	if err != nil {
+		return fmt.Errorf("failed to list the operator pods: %w", err)
+	}
+	if len(pods.Items) == 0 {
+		return fmt.Errorf("no operator pod found in namespace %s, see --operator-namespace", p.operatorNamespace)
+	}
+
+	matches := torrentLogMatcher(p.namespace, name)
+	options := &corev1.PodLogOptions{Container: operatorContainer, Follow: p.follow}
+	if p.tail >= 0 {
+		options.TailLines = &p.tail
+	}
+
+	// Only the leader reconciles, but all the pods are searched as the
+	// leader may have changed
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make([]error, len(pods.Items))
+	for i, pod := range pods.Items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stream, err := p.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, options).Stream(ctx)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to get the logs of pod %s: %w", pod.Name, err)
+				return
+			}
+			defer stream.Close()
+			errs[i] = filterLines(stream, matches, func(line string) {
+				mu.Lock()
+				defer mu.Unlock()
+				if len(pods.Items) > 1 {
+					fmt.Fprintf(p.out, "[%s] ", pod.Name)
+				}
+				fmt.Fprintln(p.out, line)
+			})
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// torrentLogMatcher matches the log lines of the reconciles of a Torrent,
+// which carry its name and namespace in the JSON or console encoding
+func torrentLogMatcher(namespace, name string) func(string) bool {
+	nameRe := regexp.MustCompile(`"name":\s*"` + regexp.QuoteMeta(name) + `"`)
+	namespaceRe := regexp.MustCompile(`"namespace":\s*"` + regexp.QuoteMeta(namespace) + `"`)
+	return func(line string) bool {
+		return nameRe.MatchString(line) && namespaceRe.MatchString(line)
+	}
+}
+
+func filterLines(r io.Reader, matches func(string) bool, print func(string)) error {
+	scanner := bufio.NewScanner(r)
+	// Log lines with a large reconcile error may exceed the default buffer
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Text(); matches(line) {
+			print(line)
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kubectl-qbittorrent is a kubectl plugin for the day-2 tasks on Torrents,
+// without access to the qBittorrent WebUI. Installed in the PATH, it is run
+// as kubectl qbittorrent.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/controller"
+)
+
+const usage = `kubectl qbittorrent manages the Torrents of the qBittorrent operator.
+
+Usage:
+  kubectl qbittorrent status [NAME]        Show the Torrents, or the details of one
+  kubectl qbittorrent top                  Show the Torrents sorted by bytes transferred
+  kubectl qbittorrent activity             Show the speeds by namespace and the most active Torrents
+  kubectl qbittorrent pause NAME           Pause the torrent on qBittorrent
+  kubectl qbittorrent resume NAME          Resume the paused torrent
+  kubectl qbittorrent recheck NAME         Recheck the downloaded content of the torrent
+  kubectl qbittorrent logs NAME            Show the operator logs about the Torrent
+  kubectl qbittorrent import               Create Torrents for the torrents of a qBittorrent instance
+
+Run kubectl qbittorrent COMMAND -h for the flags of a command.
+`
+
+// command is a subcommand of the plugin
+type command struct {
+	// name is the Torrent the command operates on, if it takes one
+	name string
+	// optionalName is set if the Torrent name may be omitted
+	optionalName bool
+	run          func(ctx context.Context, p *plugin, name string) error
+	flags        func(fs *flag.FlagSet, p *plugin)
+	// needsCluster tells whether the command reaches the cluster, always if nil
+	needsCluster func(p *plugin) bool
+}
+
+var commands = map[string]command{
+	"status":   {name: "NAME", optionalName: true, run: runStatus, flags: statusFlags},
+	"top":      {run: runTop, flags: topFlags},
+	"activity": {run: runActivity, flags: activityFlags},
+	"pause":    {name: "NAME", run: runAction(controller.ActionPause), flags: actionFlags},
+	"resume":   {name: "NAME", run: runAction(controller.ActionResume), flags: actionFlags},
+	"recheck":  {name: "NAME", run: runAction(controller.ActionRecheck), flags: actionFlags},
+	"logs":     {name: "NAME", run: runLogs, flags: logsFlags},
+	"import":   {run: runImport, flags: importFlags, needsCluster: importNeedsCluster},
+}
+
+// plugin holds the flags and the clients of a run
+type plugin struct {
+	out io.Writer
+
+	kubeconfig    string
+	kubeContext   string
+	namespace     string
+	allNamespaces bool
+
+	// status, top and activity
+	limit  int
+	sortBy string
+	watch  time.Duration
+	// pause, resume and recheck
+	wait bool
+	// logs
+	operatorNamespace string
+	tail              int64
+	follow            bool
+	// import
+	importOptions importOptions
+
+	client    client.Client
+	clientset kubernetes.Interface
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprint(out, usage)
+		return nil
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q, run kubectl qbittorrent --help", args[0])
+	}
+
+	p := &plugin{out: out}
+	fs := flag.NewFlagSet("kubectl qbittorrent "+args[0], flag.ContinueOnError)
+	fs.SetOutput(out)
+	fs.StringVar(&p.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file")
+	fs.StringVar(&p.kubeContext, "context", "", "The kubeconfig context to use")
+	fs.StringVar(&p.namespace, "namespace", "", "The namespace of the Torrents, of the context if empty")
+	fs.StringVar(&p.namespace, "n", "", "Shorthand for --namespace")
+	if cmd.flags != nil {
+		cmd.flags(fs, p)
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	name := ""
+	switch {
+	case cmd.name == "" && fs.NArg() > 0:
+		return fmt.Errorf("%s takes no argument", args[0])
+	case cmd.name != "" && fs.NArg() > 1:
+		return fmt.Errorf("%s takes a single %s", args[0], cmd.name)
+	case cmd.name != "" && fs.NArg() == 0 && !cmd.optionalName:
+		return fmt.Errorf("%s requires a %s", args[0], cmd.name)
+	case fs.NArg() == 1:
+		name = fs.Arg(0)
+	}
+
+	config := p.clientConfig()
+	needsCluster := cmd.needsCluster == nil || cmd.needsCluster(p)
+	if p.namespace == "" {
+		namespace, _, err := config.Namespace()
+		switch {
+		case err == nil:
+			p.namespace = namespace
+		case needsCluster:
+			return fmt.Errorf("failed to get the namespace of the kubeconfig: %w", err)
+		default:
+			// Commands without the cluster run without a kubeconfig too
+			p.namespace = "default"
+		}
+	}
+	if needsCluster {
+		restConfig, err := config.ClientConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load the kubeconfig: %w", err)
+		}
+		if err := p.connectTo(restConfig); err != nil {
+			return err
+		}
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return cmd.run(ctx, p, name)
+}
+
+// clientConfig returns the client config of the kubeconfig and context of
+// the flags
+func (p *plugin) clientConfig() clientcmd.ClientConfig {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = p.kubeconfig
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules,
+		&clientcmd.ConfigOverrides{CurrentContext: p.kubeContext})
+}
+
+func (p *plugin) connectTo(restConfig *rest.Config) error {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(torrentv1beta1.AddToScheme(scheme))
+
+	var err error
+	if p.client, err = client.New(restConfig, client.Options{Scheme: scheme}); err != nil {
+		return fmt.Errorf("failed to create the client: %w", err)
+	}
+	if p.clientset, err = kubernetes.NewForConfig(restConfig); err != nil {
+		return fmt.Errorf("failed to create the clientset: %w", err)
+	}
+	return nil
+}
+
+// listNamespace returns the namespace of the Torrents listed, empty for all
+// of them
+func (p *plugin) listNamespace() string {
+	if p.allNamespaces {
+		return ""
+	}
+	return p.namespace
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/controller"
+)
+
+func newTestPlugin(t *testing.T, torrents ...*torrentv1beta1.Torrent) (*plugin, *bytes.Buffer) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := torrentv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, torrent := range torrents {
+		builder = builder.WithObjects(torrent)
+	}
+	out := &bytes.Buffer{}
+	return &plugin{out: out, namespace: "media", limit: 10, sortBy: "uploaded", client: builder.Build()}, out
+}
+
+func newTestTorrent(name string, uploaded, downloaded int64) *torrentv1beta1.Torrent {
+	return &torrentv1beta1.Torrent{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "media"},
+		Status: torrentv1beta1.TorrentStatus{
+			Hash:       "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c",
+			Phase:      torrentv1beta1.TorrentPhaseDownloading,
+			State:      "downloading",
+			TotalSize:  4 << 30,
+			AmountLeft: 1 << 30,
+			Transfer:   &torrentv1beta1.TransferStatus{Uploaded: uploaded, Downloaded: downloaded},
+		},
+	}
+}
+
+func TestStatus(t *testing.T) {
+	p, out := newTestPlugin(t, newTestTorrent("ubuntu", 0, 3<<30))
+
+	if err := runStatus(context.Background(), p, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected a header and a Torrent, got %q", out.String())
+	}
+	for _, field := range []string{"ubuntu", "Downloading", "downloading", "75.0%", "4.0 GiB", "Unknown"} {
+		if !strings.Contains(lines[1], field) {
+			t.Errorf("Expected %q in %q", field, lines[1])
+		}
+	}
+
+	out.Reset()
+	if err := runStatus(context.Background(), p, "ubuntu"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c") {
+		t.Errorf("Expected the hash in the details, got %q", out.String())
+	}
+
+	if err := runStatus(context.Background(), p, "missing"); err == nil {
+		t.Error("Expected an error for a missing Torrent")
+	}
+}
+
+func TestTop(t *testing.T) {
+	p, out := newTestPlugin(t,
+		newTestTorrent("low", 1<<20, 1<<30),
+		newTestTorrent("high", 8<<30, 4<<30),
+		newTestTorrent("ratio", 2<<30, 1<<29))
+
+	tests := []struct {
+		sortBy string
+		limit  int
+		want   []string
+	}{
+		{sortBy: "uploaded", want: []string{"high", "ratio", "low"}},
+		{sortBy: "downloaded", want: []string{"high", "low", "ratio"}},
+		{sortBy: "ratio", want: []string{"ratio", "high", "low"}},
+		{sortBy: "uploaded", limit: 1, want: []string{"high"}},
+	}
+	for _, tt := range tests {
+		out.Reset()
+		p.sortBy, p.limit = tt.sortBy, tt.limit
+		if err := runTop(context.Background(), p, ""); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")[1:]
+		var got []string
+		for _, line := range lines {
+			got = append(got, strings.Fields(line)[0])
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("Sorted by %s, limit %d: expected %v, got %v", tt.sortBy, tt.limit, tt.want, got)
+		}
+	}
+
+	p.sortBy = "peers"
+	if err := runTop(context.Background(), p, ""); err == nil {
+		t.Error("Expected an error for an invalid sort")
+	}
+}
+
+func TestAction(t *testing.T) {
+	p, out := newTestPlugin(t, newTestTorrent("ubuntu", 0, 0))
+	ctx := context.Background()
+
+	if err := runAction(controller.ActionPause)(ctx, p, "ubuntu"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	torrent := &torrentv1beta1.Torrent{}
+	if err := p.client.Get(ctx, client.ObjectKey{Namespace: "media", Name: "ubuntu"}, torrent); err != nil {
+		t.Fatal(err)
+	}
+	if got := torrent.Annotations[controller.AnnotationAction]; got != controller.ActionPause {
+		t.Errorf("Expected the pause action annotation, got %q", got)
+	}
+	if !strings.Contains(out.String(), "pause requested") {
+		t.Errorf("Unexpected output %q", out.String())
+	}
+
+	// Another action is refused until the pending one is done
+	if err := runAction(controller.ActionRecheck)(ctx, p, "ubuntu"); err == nil {
+		t.Error("Expected an error with an action pending")
+	}
+
+	// The actions the state does not allow are refused
+	stopped := newTestTorrent("stopped", 0, 0)
+	stopped.Status.State = "stoppedDL"
+	stopped.Status.AllowedActions = &torrentv1beta1.AllowedActions{CanResume: true, CanRecheck: true}
+	p, out = newTestPlugin(t, stopped)
+	if err := runAction(controller.ActionPause)(ctx, p, "stopped"); err == nil ||
+		!strings.Contains(err.Error(), "in state stoppedDL") {
+		t.Errorf("Expected the pause of a stopped torrent refused, got %v", err)
+	}
+	if err := runStatus(ctx, p, "stopped"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "resume, recheck") {
+		t.Errorf("Expected the allowed actions in the details, got %q", out.String())
+	}
+}
+
+func TestActivity(t *testing.T) {
+	torrent := func(namespace, name string, down, up int64) *torrentv1beta1.Torrent {
+		torrent := newTestTorrent(name, 0, 0)
+		torrent.Namespace = namespace
+		torrent.Status.Transfer.DownloadSpeed = down
+		torrent.Status.Transfer.UploadSpeed = up
+		return torrent
+	}
+	p, out := newTestPlugin(t,
+		torrent("media", "idle", 0, 0),
+		torrent("media", "seeding", 0, 2<<20),
+		torrent("datasets", "downloading", 8<<20, 1<<20),
+		torrent("datasets", "slow", 1024, 0))
+	p.allNamespaces = true
+	p.limit = 2
+
+	if err := runActivity(context.Background(), p, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sections := strings.Split(strings.TrimSpace(out.String()), "\n\n")
+	if len(sections) != 2 {
+		t.Fatalf("Expected the namespaces and the Torrents, got %q", out.String())
+	}
+
+	namespaces := strings.Split(sections[0], "\n")[1:]
+	want := []string{
+		"datasets 2 2 8.0 MiB/s 1.0 MiB/s",
+		"media 2 1 0 B/s 2.0 MiB/s",
+		"TOTAL 4 3 8.0 MiB/s 3.0 MiB/s",
+	}
+	if len(namespaces) != len(want) {
+		t.Fatalf("Expected %d namespace lines, got %q", len(want), sections[0])
+	}
+	for i, line := range namespaces {
+		// Fields drops the padding of the columns
+		if got := strings.Join(strings.Fields(line), " "); got != want[i] {
+			t.Errorf("Expected %q, got %q", want[i], got)
+		}
+	}
+
+	active := strings.Split(sections[1], "\n")[1:]
+	if len(active) != 2 || strings.Fields(active[0])[1] != "downloading" || strings.Fields(active[1])[1] != "seeding" {
+		t.Errorf("Expected the 2 most active Torrents, got %q", sections[1])
+	}
+}
+
+func TestTorrentLogMatcher(t *testing.T) {
+	matches := torrentLogMatcher("media", "ubuntu")
+
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{`{"level":"info","msg":"Reconciling","Torrent":{"name":"ubuntu","namespace":"media"},"namespace":"media","name":"ubuntu"}`, true},
+		{`2025-01-01T00:00:00Z	INFO	Reconciling	{"namespace": "media", "name": "ubuntu"}`, true},
+		{`{"msg":"Reconciling","namespace":"other","name":"ubuntu"}`, false},
+		{`{"msg":"Reconciling","namespace":"media","name":"ubuntu-2"}`, false},
+		{`{"msg":"Starting workers"}`, false},
+	}
+	for _, tt := range tests {
+		if got := matches(tt.line); got != tt.want {
+			t.Errorf("matches(%q) = %v, expected %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1024:            "1.0 KiB",
+		3 << 29:         "1.5 GiB",
+		5 << 40:         "5.0 TiB",
+		1<<62 + 1<<61:   "6.0 EiB",
+		1536 * 1024:     "1.5 MiB",
+		1024*1024 - 100: "1023.9 KiB",
+	}
+	for size, want := range tests {
+		if got := formatBytes(size); got != want {
+			t.Errorf("formatBytes(%d) = %q, expected %q", size, got, want)
+		}
+	}
+}
+
+func TestRun_Arguments(t *testing.T) {
+	tests := [][]string{
+		{"unknown"},
+		{"pause"},
+		{"top", "extra"},
+		{"logs", "a", "b"},
+	}
+	for _, args := range tests {
+		if err := run(args, &bytes.Buffer{}); err == nil {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+}
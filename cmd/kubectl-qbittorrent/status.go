@@ -0,0 +1,276 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/controller"
+)
+
+func statusFlags(fs *flag.FlagSet, p *plugin) {
+	fs.BoolVar(&p.allNamespaces, "all-namespaces", false, "List the Torrents of all the namespaces")
+	fs.BoolVar(&p.allNamespaces, "A", false, "Shorthand for --all-namespaces")
+}
+
+func topFlags(fs *flag.FlagSet, p *plugin) {
+	statusFlags(fs, p)
+	fs.StringVar(&p.sortBy, "sort", "uploaded", "Sort the Torrents by uploaded, downloaded or ratio")
+	fs.IntVar(&p.limit, "limit", 10, "Number of Torrents shown, 0 for all")
+}
+
+// runStatus lists the Torrents, or describes the one named
+func runStatus(ctx context.Context, p *plugin, name string) error {
+	if name != "" {
+		torrent := &torrentv1beta1.Torrent{}
+		if err := p.client.Get(ctx, client.ObjectKey{Namespace: p.namespace, Name: name}, torrent); err != nil {
+			return fmt.Errorf("failed to get Torrent %s: %w", name, err)
+		}
+		describeTorrent(p.out, torrent)
+		return nil
+	}
+
+	torrents := &torrentv1beta1.TorrentList{}
+	if err := p.client.List(ctx, torrents, client.InNamespace(p.listNamespace())); err != nil {
+		return fmt.Errorf("failed to list Torrents: %w", err)
+	}
+	if len(torrents.Items) == 0 {
+		fmt.Fprintln(p.out, "No Torrents found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(p.out, 0, 0, 3, ' ', 0)
+	if p.allNamespaces {
+		fmt.Fprint(w, "NAMESPACE\t")
+	}
+	fmt.Fprintln(w, "NAME\tPHASE\tSTATE\tPROGRESS\tSIZE\tAVAILABLE\tFAILURES\tAGE")
+	for i := range torrents.Items {
+		torrent := &torrents.Items[i]
+		if p.allNamespaces {
+			fmt.Fprintf(w, "%s\t", torrent.Namespace)
+		}
+		failures := int32(0)
+		if torrent.Status.ReconcileStats != nil {
+			failures = torrent.Status.ReconcileStats.ConsecutiveFailures
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n", torrent.Name,
+			orNone(string(torrent.Status.Phase)), orNone(string(torrent.Status.State)),
+			progress(torrent), formatBytes(torrent.Status.TotalSize), available(torrent),
+			failures, age(torrent.CreationTimestamp))
+	}
+	return w.Flush()
+}
+
+// runTop lists the Torrents which transferred the most bytes
+func runTop(ctx context.Context, p *plugin, _ string) error {
+	metric, ok := topMetrics[p.sortBy]
+	if !ok {
+		return fmt.Errorf("invalid --sort %q, expected uploaded, downloaded or ratio", p.sortBy)
+	}
+
+	torrents := &torrentv1beta1.TorrentList{}
+	if err := p.client.List(ctx, torrents, client.InNamespace(p.listNamespace())); err != nil {
+		return fmt.Errorf("failed to list Torrents: %w", err)
+	}
+	items := torrents.Items
+	sort.SliceStable(items, func(i, j int) bool {
+		return metric(&items[i]) > metric(&items[j])
+	})
+	if p.limit > 0 && len(items) > p.limit {
+		items = items[:p.limit]
+	}
+
+	w := tabwriter.NewWriter(p.out, 0, 0, 3, ' ', 0)
+	if p.allNamespaces {
+		fmt.Fprint(w, "NAMESPACE\t")
+	}
+	fmt.Fprintln(w, "NAME\tUPLOADED\tDOWNLOADED\tRATIO\tSESSION UP\tSESSION DOWN\tLAST ACTIVE")
+	for i := range items {
+		torrent := &items[i]
+		if p.allNamespaces {
+			fmt.Fprintf(w, "%s\t", torrent.Namespace)
+		}
+		transfer := torrent.Status.Transfer
+		if transfer == nil {
+			fmt.Fprintf(w, "%s\t-\t-\t-\t-\t-\t-\n", torrent.Name)
+			continue
+		}
+		lastActive := "<none>"
+		if transfer.LastActivityTime != nil {
+			lastActive = age(*transfer.LastActivityTime)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%.2f\t%s\t%s\t%s\n", torrent.Name,
+			formatBytes(transfer.Uploaded), formatBytes(transfer.Downloaded), ratio(torrent),
+			formatBytes(transfer.UploadedSession), formatBytes(transfer.DownloadedSession), lastActive)
+	}
+	return w.Flush()
+}
+
+// topMetrics are the values top sorts the Torrents by
+var topMetrics = map[string]func(*torrentv1beta1.Torrent) float64{
+	"uploaded": func(t *torrentv1beta1.Torrent) float64 {
+		if t.Status.Transfer == nil {
+			return -1
+		}
+		return float64(t.Status.Transfer.Uploaded)
+	},
+	"downloaded": func(t *torrentv1beta1.Torrent) float64 {
+		if t.Status.Transfer == nil {
+			return -1
+		}
+		return float64(t.Status.Transfer.Downloaded)
+	},
+	"ratio": func(t *torrentv1beta1.Torrent) float64 {
+		if t.Status.Transfer == nil {
+			return -1
+		}
+		return ratio(t)
+	},
+}
+
+// describeTorrent prints the details of a Torrent
+func describeTorrent(out io.Writer, torrent *torrentv1beta1.Torrent) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	status := torrent.Status
+	fmt.Fprintf(w, "Name:\t%s\n", torrent.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", torrent.Namespace)
+	fmt.Fprintf(w, "Hash:\t%s\n", orNone(status.Hash))
+	fmt.Fprintf(w, "Torrent Name:\t%s\n", orNone(status.Name))
+	fmt.Fprintf(w, "Phase:\t%s\n", orNone(string(status.Phase)))
+	fmt.Fprintf(w, "State:\t%s\n", orNone(string(status.State)))
+	fmt.Fprintf(w, "Progress:\t%s of %s\n", progress(torrent), formatBytes(status.TotalSize))
+	fmt.Fprintf(w, "Content Path:\t%s\n", orNone(status.ContentPath))
+	if status.LastSyncedTime != nil {
+		fmt.Fprintf(w, "Last Synced:\t%s ago\n", age(*status.LastSyncedTime))
+	}
+	if torrent.Annotations[controller.AnnotationReconcile] == controller.ReconcileDisabled {
+		fmt.Fprintf(w, "Reconcile:\tdisabled\n")
+	}
+	if action, ok := torrent.Annotations[controller.AnnotationAction]; ok {
+		fmt.Fprintf(w, "Pending Action:\t%s\n", action)
+	}
+	if status.AllowedActions != nil {
+		fmt.Fprintf(w, "Allowed Actions:\t%s\n", orNone(strings.Join(allowedActions(torrent), ", ")))
+	}
+
+	if transfer := status.Transfer; transfer != nil {
+		fmt.Fprintf(w, "Transfer:\n")
+		fmt.Fprintf(w, "  Uploaded:\t%s (%s this session)\n",
+			formatBytes(transfer.Uploaded), formatBytes(transfer.UploadedSession))
+		fmt.Fprintf(w, "  Downloaded:\t%s (%s this session)\n",
+			formatBytes(transfer.Downloaded), formatBytes(transfer.DownloadedSession))
+		fmt.Fprintf(w, "  Ratio:\t%.2f\n", ratio(torrent))
+		fmt.Fprintf(w, "  Speed:\t%s down, %s up\n",
+			formatSpeed(transfer.DownloadSpeed), formatSpeed(transfer.UploadSpeed))
+	}
+
+	if stats := status.ReconcileStats; stats != nil {
+		fmt.Fprintf(w, "Reconcile Stats:\n")
+		if stats.LastReconcileTime != nil {
+			fmt.Fprintf(w, "  Last Reconcile:\t%s ago\n", age(*stats.LastReconcileTime))
+		}
+		fmt.Fprintf(w, "  Consecutive Failures:\t%d\n", stats.ConsecutiveFailures)
+		if stats.LastError != "" {
+			fmt.Fprintf(w, "  Last Error:\t%s\n", stats.LastError)
+		}
+	}
+
+	fmt.Fprintf(w, "Conditions:\n")
+	if len(status.Conditions) == 0 {
+		fmt.Fprintf(w, "  <none>\n")
+	}
+	for _, condition := range status.Conditions {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", condition.Type, condition.Status, condition.Reason, condition.Message)
+	}
+}
+
+// progress is the downloaded percentage of the selected files
+func progress(torrent *torrentv1beta1.Torrent) string {
+	if torrent.Status.TotalSize <= 0 {
+		return "-"
+	}
+	done := torrent.Status.TotalSize - torrent.Status.AmountLeft
+	return fmt.Sprintf("%.1f%%", float64(done)*100/float64(torrent.Status.TotalSize))
+}
+
+// ratio is the share ratio of the torrent, over the size of its content
+// when nothing was downloaded, as for the torrents seeded from existing files
+func ratio(torrent *torrentv1beta1.Torrent) float64 {
+	transfer := torrent.Status.Transfer
+	if transfer == nil {
+		return 0
+	}
+	base := transfer.Downloaded
+	if base <= 0 {
+		base = torrent.Status.TotalSize
+	}
+	if base <= 0 {
+		return 0
+	}
+	return float64(transfer.Uploaded) / float64(base)
+}
+
+func available(torrent *torrentv1beta1.Torrent) string {
+	condition := meta.FindStatusCondition(torrent.Status.Conditions, controller.TypeAvailableTorrent)
+	if condition == nil {
+		return "Unknown"
+	}
+	return string(condition.Status)
+}
+
+// formatBytes formats a size in binary units, as the qBittorrent WebUI
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+func age(t metav1.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(time.Since(t.Time))
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
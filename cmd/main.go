@@ -20,9 +20,13 @@ import (
 	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -30,18 +34,26 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	torrentv1alpha1 "github.com/guidonguido/qbittorrent-operator/api/v1alpha1"
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/clientpool"
 	"github.com/guidonguido/qbittorrent-operator/internal/controller"
+	"github.com/guidonguido/qbittorrent-operator/internal/logging"
 	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+	"github.com/guidonguido/qbittorrent-operator/internal/torrentapi"
+	webhooktorrentv1beta1 "github.com/guidonguido/qbittorrent-operator/internal/webhook/v1beta1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -58,75 +70,222 @@ func init() {
 	// Must is used to ensure that the scheme is added to the scheme
 	// and panic if the scheme registration fails
 	utilruntime.Must(torrentv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(torrentv1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
-// nolint:gocyclo
-func main() {
-	var metricsAddr string
-	var metricsCertPath, metricsCertName, metricsCertKey string
-	var webhookCertPath, webhookCertName, webhookCertKey string
-	var enableLeaderElection bool
-	var probeAddr string
-	var secureMetrics bool
-	var enableHTTP2 bool
-	var tlsOpts []func(*tls.Config)
-	var qbittorrentURL, qbittorrentUsername, qbittorrentPassword string
-	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
+// options are the flags of the operator
+type options struct {
+	metricsAddr                                              string
+	metricsCertPath, metricsCertName, metricsCertKey         string
+	webhookCertPath, webhookCertName, webhookCertKey         string
+	enableLeaderElection                                     bool
+	probeAddr                                                string
+	secureMetrics                                            bool
+	enableHTTP2                                              bool
+	qbittorrentURL, qbittorrentUsername, qbittorrentPassword string
+	labelTagKeys, labelTagPrefix                             string
+	serverName                                               string
+	operatorNamespace                                        string
+	configName                                               string
+	auditLogPath                                             string
+	dryRun                                                   bool
+	debugHTTP                                                bool
+	qbittorrentCAFile                                        string
+	qbittorrentInsecureSkipTLSVerify                         bool
+	qbittorrentClientCertFile, qbittorrentClientKeyFile      string
+	qbittorrentProxyURL                                      string
+	qbittorrentHeadersFile                                   string
+	watchNamespaces                                          string
+	isolateNamespaces                                        bool
+	savePathRoot                                             string
+	lowPriorityDownloadLimit                                 int64
+	shardIndex, shardCount                                   int
+	maxConcurrentReconciles                                  int
+	restartGracePeriod                                       time.Duration
+	keepAliveInterval                                        time.Duration
+	requestTimeout                                           time.Duration
+	pageSize                                                 int
+	pool                                                     qbittorrent.PoolOptions
+	deletionRetryTimeout                                     time.Duration
+	deletionBatchWindow                                      time.Duration
+	statusStaleThreshold                                     time.Duration
+	eventThrottleWindow                                      time.Duration
+	torrentsAPIAddr, torrentsAPICertPath                     string
+
+	// labelTagKeyList are the keys of labelTagKeys
+	labelTagKeyList []string
+}
+
+// bindFlags binds the flags of the operator to o
+func bindFlags(o *options) {
+	flag.StringVar(&o.metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
-	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
-	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
+	flag.StringVar(&o.probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.BoolVar(&o.enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
-	flag.BoolVar(&secureMetrics, "metrics-secure", true,
+	flag.BoolVar(&o.secureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
-	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
-	flag.StringVar(&webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
-	flag.StringVar(&webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
-	flag.StringVar(&metricsCertPath, "metrics-cert-path", "",
+	flag.StringVar(&o.webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
+	flag.StringVar(&o.webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
+	flag.StringVar(&o.webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
+	flag.StringVar(&o.metricsCertPath, "metrics-cert-path", "",
 		"The directory that contains the metrics server certificate.")
-	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
-	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
-	flag.BoolVar(&enableHTTP2, "enable-http2", false,
+	flag.StringVar(&o.metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
+	flag.StringVar(&o.metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
+	flag.BoolVar(&o.enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
-	flag.StringVar(&qbittorrentURL, "qbittorrent-url", "", "The URL of the qBittorrent server.")
-	flag.StringVar(&qbittorrentUsername, "qbittorrent-username", "",
-		"The username for logging into the qBittorrent server.")
-	flag.StringVar(&qbittorrentPassword, "qbittorrent-password", "",
+	flag.StringVar(&o.qbittorrentURL, "qbittorrent-url", "", "The URL of the qBittorrent server.")
+	flag.StringVar(&o.qbittorrentUsername, "qbittorrent-username", "",
+		"The username for logging into the qBittorrent server. Empty if qBittorrent bypasses the authentication of the operator.")
+	flag.StringVar(&o.qbittorrentPassword, "qbittorrent-password", "",
 		"The password for logging into the qBittorrent server.")
-	opts := zap.Options{
-		Development: true,
-	}
-	opts.BindFlags(flag.CommandLine)
-	flag.Parse()
+	flag.StringVar(&o.qbittorrentCAFile, "qbittorrent-ca-file", "",
+		"The file of the PEM encoded CA certificates trusted for the qBittorrent server, in addition to the system ones.")
+	flag.BoolVar(&o.qbittorrentInsecureSkipTLSVerify, "qbittorrent-insecure-skip-tls-verify", false,
+		"Skip the verification of the qBittorrent server certificate. Insecure, prefer --qbittorrent-ca-file.")
+	flag.StringVar(&o.qbittorrentClientCertFile, "qbittorrent-client-cert-file", "",
+		"The file of the PEM encoded client certificate presented to the qBittorrent server or its reverse proxy.")
+	flag.StringVar(&o.qbittorrentClientKeyFile, "qbittorrent-client-key-file", "",
+		"The file of the PEM encoded key of the client certificate.")
+	flag.StringVar(&o.qbittorrentProxyURL, "qbittorrent-proxy-url", "",
+		"The HTTP, HTTPS or SOCKS5 proxy used to reach the qBittorrent server. "+
+			"The HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used if empty.")
+	flag.StringVar(&o.qbittorrentHeadersFile, "qbittorrent-headers-file", "",
+		"The file of the headers added to every request to the qBittorrent server, one \"Name: value\" per line.")
+	flag.StringVar(&o.watchNamespaces, "watch-namespaces", "",
+		"Comma separated list of the namespaces of the Torrents managed by the operator, "+
+			"which then only needs permissions in these namespaces. All the namespaces if empty.")
+	flag.BoolVar(&o.isolateNamespaces, "isolate-namespaces", false,
+		"Prefix the qBittorrent categories and label tags with the namespace of the Torrent, "+
+			"for tenants sharing the qBittorrent server.")
+	flag.StringVar(&o.savePathRoot, "save-path-root", "",
+		"With --isolate-namespaces, confine the save paths to a folder per namespace under this folder. "+
+			"The save paths are not confined if empty.")
+	flag.Int64Var(&o.lowPriorityDownloadLimit, "low-priority-download-limit", 0,
+		"The download limit, in bytes per second, of the low priority Torrents while high priority ones are downloading. "+
+			"Disabled if 0.")
+	flag.IntVar(&o.maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"The number of Torrents reconciled at once, reduced to one while qBittorrent is rate limited or failing.")
+	flag.IntVar(&o.shardCount, "shard-count", 1,
+		"The number of operator replicas the Torrents are sharded across, each replica reconciling a part of them. "+
+			"Sharding is disabled if 1.")
+	flag.IntVar(&o.shardIndex, "shard-index", 0,
+		"The index of the replica, from 0 to --shard-count minus 1, when the Torrents are sharded.")
+	flag.DurationVar(&o.restartGracePeriod, "restart-grace-period", qbittorrent.DefaultRestartGracePeriod,
+		"How long after a restart of qBittorrent the Torrents missing from it are not added again, "+
+			"while it loads them.")
+	flag.DurationVar(&o.requestTimeout, "qbittorrent-request-timeout", qbittorrent.DefaultRequestTimeout,
+		"The timeout of each call to qBittorrent, including the read of the response. "+
+			"Raise it for slow or remote seedboxes.")
+	flag.IntVar(&o.pool.MaxIdleConns, "qbittorrent-max-idle-conns", qbittorrent.DefaultMaxIdleConns,
+		"The number of idle connections kept open to qBittorrent and reused by the calls.")
+	flag.DurationVar(&o.pool.IdleConnTimeout, "qbittorrent-idle-conn-timeout", qbittorrent.DefaultIdleConnTimeout,
+		"How long an idle connection to qBittorrent is kept open. Kept until closed by qBittorrent if 0.")
+	flag.BoolVar(&o.pool.DisableKeepAlives, "qbittorrent-disable-keep-alives", false,
+		"Open a new connection to qBittorrent for every call, e.g. behind a proxy closing idle connections.")
+	flag.IntVar(&o.pageSize, "qbittorrent-page-size", 0,
+		"The number of torrents listed per call to qBittorrent, bounding the size of the responses "+
+			"of very large instances. All the torrents are listed at once if 0.")
+	flag.DurationVar(&o.keepAliveInterval, "qbittorrent-keep-alive-interval", qbittorrent.DefaultKeepAliveInterval,
+		"How often the qBittorrent sessions are used while the operator is idle, so that it does not expire. "+
+			"Shorter than the WebUI session timeout of qBittorrent. Disabled if 0.")
+	flag.DurationVar(&o.deletionRetryTimeout, "deletion-retry-timeout", controller.DefaultDeletionRetryTimeout,
+		"How long the deletion of a deleted Torrent from qBittorrent is retried before its finalizer is removed "+
+			"anyway, leaving the torrent on qBittorrent. Retried forever if 0.")
+	flag.DurationVar(&o.deletionBatchWindow, "deletion-batch-window", controller.DefaultDeletionBatchWindow,
+		"How long the deletions of deleted Torrents from qBittorrent are gathered before being made in a single "+
+			"call. Each Torrent is deleted on its own if 0.")
+	flag.DurationVar(&o.statusStaleThreshold, "status-stale-threshold", controller.DefaultStatusStaleThreshold,
+		"How long the status of a Torrent may go without being refreshed from qBittorrent before its StatusStale "+
+			"condition is set.")
+	flag.DurationVar(&o.eventThrottleWindow, "event-throttle-window", controller.DefaultEventThrottleWindow,
+		"How long the repeats of an Event of a Torrent are counted instead of recorded. Disabled if 0.")
+	flag.StringVar(&o.labelTagKeys, "label-tag-keys", "",
+		"Comma separated list of Torrent label keys mirrored into qBittorrent tags. Disabled if empty.")
+	flag.StringVar(&o.labelTagPrefix, "label-tag-prefix", controller.DefaultLabelTagPrefix,
+		"The prefix of the qBittorrent tags mirrored from Torrent labels, must not be empty with label-tag-keys.")
+	flag.StringVar(&o.serverName, "server-name", controller.DefaultServerName,
+		"The name of the QBittorrentServer reached at --qbittorrent-url, on which the Torrents "+
+			"without spec.serverName are downloaded.")
+	flag.StringVar(&o.operatorNamespace, "operator-namespace", os.Getenv("POD_NAMESPACE"),
+		"The namespace of the operator, the only one the Secrets referenced by the QBittorrentServer are read from. "+
+			"Defaults to the POD_NAMESPACE environment variable.")
+	flag.StringVar(&o.configName, "config-name", controller.DefaultConfigName,
+		"The name of the QBittorrentOperatorConfig applied by the operator.")
+	flag.StringVar(&o.auditLogPath, "audit-log", "",
+		"Record the calls changing qBittorrent as JSON lines, to this file or to stdout. Disabled if empty.")
+	flag.BoolVar(&o.dryRun, "dry-run", false,
+		"Observe qBittorrent and update the status without changing qBittorrent. The changes are only logged.")
+	flag.StringVar(&o.torrentsAPIAddr, "torrents-api-bind-address", "0",
+		"The address the read-only torrent API binds to, authenticating with Kubernetes tokens. Use 0 to disable it.")
+	flag.StringVar(&o.torrentsAPICertPath, "torrents-api-cert-path", "",
+		"The directory that contains the tls.crt and tls.key of the torrent API, served over plain HTTP if empty.")
+	flag.BoolVar(&o.debugHTTP, "debug-http", false,
+		"Keep the last qBittorrent requests and responses, redacted and truncated, and serve them on "+
+			"/debug/qbittorrent/http of the metrics endpoint.")
+}
 
+// setupOptions applies the environment variable overrides, and validates the flags
+func setupOptions(o *options) error {
 	// Allow environment variable overrides
 	if url := os.Getenv("QBITTORRENT_URL"); url != "" {
-		qbittorrentURL = url
+		o.qbittorrentURL = url
 	}
 	if username := os.Getenv("QBITTORRENT_USERNAME"); username != "" {
-		qbittorrentUsername = username
+		o.qbittorrentUsername = username
 	}
 	if password := os.Getenv("QBITTORRENT_PASSWORD"); password != "" {
-		qbittorrentPassword = password
+		o.qbittorrentPassword = password
+	}
+
+	// Parse the label keys mirrored into qBittorrent tags
+	for _, key := range strings.Split(o.labelTagKeys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			o.labelTagKeyList = append(o.labelTagKeyList, key)
+		}
 	}
 
 	// Validate the required flags
-	if qbittorrentURL == "" {
-		setupLog.Error(nil, "qbittorrent-url is required")
-		os.Exit(1)
+	switch {
+	case o.shardCount < 1 || o.shardIndex < 0 || o.shardIndex >= o.shardCount:
+		return fmt.Errorf("shard-index must be between 0 and shard-count minus 1, got %d of %d",
+			o.shardIndex, o.shardCount)
+	case o.savePathRoot != "" && (!o.isolateNamespaces || !path.IsAbs(o.savePathRoot)):
+		return fmt.Errorf("save-path-root must be an absolute path, used with isolate-namespaces")
+	case o.qbittorrentURL == "":
+		return fmt.Errorf("qbittorrent-url is required")
+	case len(o.labelTagKeyList) > 0 && o.labelTagPrefix == "":
+		// The tags with the prefix are managed, an empty one would remove them all
+		return fmt.Errorf("label-tag-prefix must not be empty with label-tag-keys")
+	// Both credentials can be omitted when qbittorrent bypasses the
+	// authentication of the operator
+	case o.qbittorrentUsername == "" && o.qbittorrentPassword != "":
+		return fmt.Errorf("qbittorrent-username is required with qbittorrent-password")
+	case o.qbittorrentPassword == "" && o.qbittorrentUsername != "":
+		return fmt.Errorf("qbittorrent-password is required with qbittorrent-username")
 	}
-	if qbittorrentUsername == "" {
-		setupLog.Error(nil, "qbittorrent-username is required")
-		os.Exit(1)
+	return nil
+}
+
+func main() {
+	var o options
+	bindFlags(&o)
+	opts := zap.Options{
+		Development: true,
 	}
-	if qbittorrentPassword == "" {
-		setupLog.Error(nil, "qbittorrent-password is required")
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := setupOptions(&o); err != nil {
+		setupLog.Error(err, "invalid flags")
 		os.Exit(1)
 	}
 
 	// Set the logger for the controller runtime
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	// Mask the tracker passkeys, session IDs and credentials in all log output
+	ctrl.SetLogger(logging.NewRedactingLogger(zap.New(zap.UseFlagOptions(&opts))))
 
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
@@ -134,14 +293,87 @@ func main() {
 	// Rapid Reset CVEs. For more information see:
 	// - https://github.com/advisories/GHSA-qppj-fm5r-hxr3
 	// - https://github.com/advisories/GHSA-4374-p667-p6c8
-	disableHTTP2 := func(c *tls.Config) {
-		setupLog.Info("disabling http/2")
-		c.NextProtos = []string{"http/1.1"}
+	var tlsOpts []func(*tls.Config)
+	if !o.enableHTTP2 {
+		tlsOpts = append(tlsOpts, func(c *tls.Config) {
+			setupLog.Info("disabling http/2")
+			c.NextProtos = []string{"http/1.1"}
+		})
+	}
+
+	// Serve the captured qBittorrent calls with the metrics, behind the same
+	// authentication and authorization
+	var httpCapture *qbittorrent.HTTPCapture
+	if o.debugHTTP {
+		httpCapture = qbittorrent.NewHTTPCapture(qbittorrent.DefaultHTTPCaptureSize)
+	}
+
+	mgr, namespaced, shard, err := setupManager(&o, tlsOpts, httpCapture)
+	if err != nil {
+		setupLog.Error(err, "unable to start manager")
+		os.Exit(1)
+	}
+
+	newClient, err := setupQBittorrentClients(&o, httpCapture)
+	if err != nil {
+		setupLog.Error(err, "unable to create the qBittorrent client")
+		os.Exit(1)
+	}
+
+	// The settings of the QBittorrentOperatorConfig, applied without restarts
+	operatorConfig := &controller.OperatorConfig{}
+
+	clients, serverReconciler, err := setupClientPool(&o, mgr, newClient, operatorConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to configure the connection to qBittorrent")
+		os.Exit(1)
+	}
+
+	if err := setupReconcilers(&o, mgr, clients, serverReconciler, operatorConfig, shard, namespaced); err != nil {
+		setupLog.Error(err, "unable to create controller")
+		os.Exit(1)
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err := setupWebhooks(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook")
+			os.Exit(1)
+		}
+	}
+	// +kubebuilder:scaffold:builder
+
+	if err := setupTorrentsAPI(&o, mgr, clients, tlsOpts, namespaced); err != nil {
+		setupLog.Error(err, "unable to set up the torrent API")
+		os.Exit(1)
+	}
+
+	if err := setupHealthChecks(&o, mgr, clients); err != nil {
+		setupLog.Error(err, "unable to set up health checks")
+		os.Exit(1)
 	}
 
-	if !enableHTTP2 {
-		tlsOpts = append(tlsOpts, disableHTTP2)
+	setupLog.Info("starting manager")
+	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+		setupLog.Error(err, "problem running manager")
+		os.Exit(1)
 	}
+}
+
+// setupCertWatcher creates a watcher of the certificate of a server, added to
+// the manager once it is created
+func setupCertWatcher(certPath, certName, certKey string) (*certwatcher.CertWatcher, error) {
+	return certwatcher.New(
+		filepath.Join(certPath, certName),
+		filepath.Join(certPath, certKey),
+	)
+}
+
+// setupManager creates the controller runtime manager with its webhook and
+// metrics servers. It also returns whether the operator is restricted to
+// namespaces, and the shard of the replica.
+func setupManager(o *options, tlsOpts []func(*tls.Config),
+	httpCapture *qbittorrent.HTTPCapture) (manager.Manager, bool, controller.Shard, error) {
+	shard := controller.Shard{Index: o.shardIndex, Count: o.shardCount}
 
 	// Create watchers for metrics and webhooks certificates
 	var metricsCertWatcher, webhookCertWatcher *certwatcher.CertWatcher
@@ -149,18 +381,15 @@ func main() {
 	// Initial webhook TLS options
 	webhookTLSOpts := tlsOpts
 
-	if len(webhookCertPath) > 0 {
+	if len(o.webhookCertPath) > 0 {
 		setupLog.Info("Initializing webhook certificate watcher using provided certificates",
-			"webhook-cert-path", webhookCertPath, "webhook-cert-name", webhookCertName, "webhook-cert-key", webhookCertKey)
+			"webhook-cert-path", o.webhookCertPath, "webhook-cert-name", o.webhookCertName,
+			"webhook-cert-key", o.webhookCertKey)
 
 		var err error
-		webhookCertWatcher, err = certwatcher.New(
-			filepath.Join(webhookCertPath, webhookCertName),
-			filepath.Join(webhookCertPath, webhookCertKey),
-		)
+		webhookCertWatcher, err = setupCertWatcher(o.webhookCertPath, o.webhookCertName, o.webhookCertKey)
 		if err != nil {
-			setupLog.Error(err, "Failed to initialize webhook certificate watcher")
-			os.Exit(1)
+			return nil, false, shard, fmt.Errorf("failed to initialize webhook certificate watcher: %w", err)
 		}
 
 		webhookTLSOpts = append(webhookTLSOpts, func(config *tls.Config) {
@@ -177,12 +406,16 @@ func main() {
 	// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.21.0/pkg/metrics/server
 	// - https://book.kubebuilder.io/reference/metrics.html
 	metricsServerOptions := metricsserver.Options{
-		BindAddress:   metricsAddr,
-		SecureServing: secureMetrics,
+		BindAddress:   o.metricsAddr,
+		SecureServing: o.secureMetrics,
 		TLSOpts:       tlsOpts,
 	}
 
-	if secureMetrics {
+	if httpCapture != nil {
+		metricsServerOptions.ExtraHandlers = map[string]http.Handler{"/debug/qbittorrent/http": httpCapture}
+	}
+
+	if o.secureMetrics {
 		// FilterProvider is used to protect the metrics endpoint with authn/authz.
 		// These configurations ensure that only authorized users and service accounts
 		// can access the metrics endpoint. The RBAC are configured in 'config/rbac/kustomization.yaml'. More info:
@@ -198,18 +431,15 @@ func main() {
 	// - [METRICS-WITH-CERTS] at config/default/kustomization.yaml to generate and use certificates
 	// managed by cert-manager for the metrics server.
 	// - [PROMETHEUS-WITH-CERTS] at config/prometheus/kustomization.yaml for TLS certification.
-	if len(metricsCertPath) > 0 {
+	if len(o.metricsCertPath) > 0 {
 		setupLog.Info("Initializing metrics certificate watcher using provided certificates",
-			"metrics-cert-path", metricsCertPath, "metrics-cert-name", metricsCertName, "metrics-cert-key", metricsCertKey)
+			"metrics-cert-path", o.metricsCertPath, "metrics-cert-name", o.metricsCertName,
+			"metrics-cert-key", o.metricsCertKey)
 
 		var err error
-		metricsCertWatcher, err = certwatcher.New(
-			filepath.Join(metricsCertPath, metricsCertName),
-			filepath.Join(metricsCertPath, metricsCertKey),
-		)
+		metricsCertWatcher, err = setupCertWatcher(o.metricsCertPath, o.metricsCertName, o.metricsCertKey)
 		if err != nil {
-			setupLog.Error(err, "to initialize metrics certificate watcher", "error", err)
-			os.Exit(1)
+			return nil, false, shard, fmt.Errorf("failed to initialize metrics certificate watcher: %w", err)
 		}
 
 		metricsServerOptions.TLSOpts = append(metricsServerOptions.TLSOpts, func(config *tls.Config) {
@@ -217,14 +447,37 @@ func main() {
 		})
 	}
 
-	// Create the controller runtime manager
+	// Restrict the cache, and so the permissions needed, to the watched namespaces
+	var cacheOptions cache.Options
+	for _, namespace := range strings.Split(o.watchNamespaces, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			if cacheOptions.DefaultNamespaces == nil {
+				cacheOptions.DefaultNamespaces = map[string]cache.Config{}
+			}
+			cacheOptions.DefaultNamespaces[namespace] = cache.Config{}
+		}
+	}
+	namespaced := len(cacheOptions.DefaultNamespaces) > 0
+	if namespaced {
+		setupLog.Info("Watching the Torrents of namespaces", "namespaces", o.watchNamespaces)
+	}
+
+	// Each shard elects its own leader, so that two replicas never reconcile
+	// the same Torrents, e.g. during a rolling update
+	leaderElectionID := "e3228fca.qbittorrent.io"
+	if shard.Enabled() {
+		leaderElectionID = fmt.Sprintf("e3228fca-shard-%d.qbittorrent.io", shard.Index)
+		setupLog.Info("Sharding Torrents", "shardIndex", shard.Index, "shardCount", shard.Count)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  cacheOptions,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "e3228fca.qbittorrent.io",
+		HealthProbeBindAddress: o.probeAddr,
+		LeaderElection:         o.enableLeaderElection,
+		LeaderElectionID:       leaderElectionID,
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -238,70 +491,320 @@ func main() {
 		// LeaderElectionReleaseOnCancel: true,
 	})
 	if err != nil {
-		setupLog.Error(err, "unable to start manager")
-		os.Exit(1)
+		return nil, false, shard, err
 	}
 
-	// Initialize qBittorrent client without logger
-	qbClient := qbittorrent.NewClient(qbittorrentURL)
+	if metricsCertWatcher != nil {
+		setupLog.Info("Adding metrics certificate watcher to manager")
+		if err := mgr.Add(metricsCertWatcher); err != nil {
+			return nil, false, shard, fmt.Errorf("unable to add metrics certificate watcher to manager: %w", err)
+		}
+	}
+
+	if webhookCertWatcher != nil {
+		setupLog.Info("Adding webhook certificate watcher to manager")
+		if err := mgr.Add(webhookCertWatcher); err != nil {
+			return nil, false, shard, fmt.Errorf("unable to add webhook certificate watcher to manager: %w", err)
+		}
+	}
+
+	return mgr, namespaced, shard, nil
+}
+
+// setupQBittorrentClients returns the function creating the qBittorrent
+// clients with the options of the flags, for the URL of the flags and the
+// ones of the QBittorrentServers
+func setupQBittorrentClients(o *options, httpCapture *qbittorrent.HTTPCapture) (func(string) *qbittorrent.Client, error) {
+	if httpCapture != nil {
+		setupLog.Info("Capturing the qBittorrent calls", "path", "/debug/qbittorrent/http")
+	}
+	if o.dryRun {
+		setupLog.Info("Dry run, qBittorrent will not be changed")
+	}
+
+	// Audit the calls changing qBittorrent
+	var audit *qbittorrent.AuditLog
+	if o.auditLogPath != "" {
+		auditLog := os.Stdout
+		if o.auditLogPath != "stdout" {
+			var err error
+			auditLog, err = os.OpenFile(o.auditLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+			if err != nil {
+				return nil, fmt.Errorf("unable to open the audit log %s: %w", o.auditLogPath, err)
+			}
+		}
+		audit = qbittorrent.NewAuditLog(auditLog)
+		setupLog.Info("Auditing qBittorrent calls", "path", o.auditLogPath)
+	}
+
+	return func(url string) *qbittorrent.Client {
+		// Initialize qBittorrent client without logger
+		qbClient := qbittorrent.NewClient(url)
+		qbClient.SetRestartGracePeriod(o.restartGracePeriod)
+		qbClient.SetRequestTimeout(o.requestTimeout)
+		qbClient.SetPoolOptions(o.pool)
+		qbClient.SetPageSize(o.pageSize)
+		if httpCapture != nil {
+			qbClient.SetHTTPCapture(httpCapture)
+		}
+		qbClient.SetDryRun(o.dryRun)
+		if audit != nil {
+			qbClient.SetAuditLog(audit)
+		}
+		return qbClient
+	}, nil
+}
+
+// setupTLS reads the TLS options of the qBittorrent client from the files of the flags
+func setupTLS(o *options) (qbittorrent.TLSOptions, error) {
+	qbTLSOptions := qbittorrent.TLSOptions{InsecureSkipVerify: o.qbittorrentInsecureSkipTLSVerify}
+	if o.qbittorrentCAFile != "" {
+		caBundle, err := os.ReadFile(o.qbittorrentCAFile)
+		if err != nil {
+			return qbTLSOptions, fmt.Errorf("unable to read the qBittorrent CA file: %w", err)
+		}
+		qbTLSOptions.CABundles = append(qbTLSOptions.CABundles, caBundle)
+	}
+	if o.qbittorrentClientCertFile != "" || o.qbittorrentClientKeyFile != "" {
+		var err error
+		if qbTLSOptions.ClientCertificate, err = os.ReadFile(o.qbittorrentClientCertFile); err != nil {
+			return qbTLSOptions, fmt.Errorf("unable to read the qBittorrent client certificate: %w", err)
+		}
+		if qbTLSOptions.ClientKey, err = os.ReadFile(o.qbittorrentClientKeyFile); err != nil {
+			return qbTLSOptions, fmt.Errorf("unable to read the qBittorrent client key: %w", err)
+		}
+	}
+	return qbTLSOptions, nil
+}
+
+// setupHeaders reads the headers added to every request to qBittorrent from
+// the file of the flags
+func setupHeaders(o *options) (http.Header, error) {
+	if o.qbittorrentHeadersFile == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(o.qbittorrentHeadersFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the qBittorrent headers file: %w", err)
+	}
+	headers, err := qbittorrent.ParseHeaders(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the qBittorrent headers file %s: %w", o.qbittorrentHeadersFile, err)
+	}
+	return headers, nil
+}
+
+// setupClientPool creates the pool of the qBittorrent clients and the
+// reconciler of their QBittorrentServer, configuring the connection of the
+// client of the flags and adding the clients of the QBittorrentServers
+// before logging in
+func setupClientPool(o *options, mgr manager.Manager, newClient func(string) *qbittorrent.Client,
+	operatorConfig *controller.OperatorConfig) (*clientpool.Pool, *controller.QBittorrentServerReconciler, error) {
+	qbTLSOptions, err := setupTLS(o)
+	if err != nil {
+		return nil, nil, err
+	}
+	qbHeaders, err := setupHeaders(o)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The clients of the QBittorrentServers, logged in on first use
+	clients := clientpool.New()
+	clients.Add(o.serverName, newClient(o.qbittorrentURL), &clientpool.Credentials{
+		Username: o.qbittorrentUsername,
+		Password: o.qbittorrentPassword,
+	})
+
+	serverReconciler := &controller.QBittorrentServerReconciler{
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Clients:    clients,
+		NewClient:  newClient,
+		ServerName: o.serverName,
+		Namespace:  o.operatorNamespace,
+		TLS:        qbTLSOptions,
+		ProxyURL:   o.qbittorrentProxyURL,
+		Headers:    qbHeaders,
+		APIReader:  mgr.GetAPIReader(),
+		Config:     operatorConfig,
+	}
 
 	// Create a context for the login call
 	ctx := context.Background()
-	if err := qbClient.Login(ctx, qbittorrentUsername, qbittorrentPassword); err != nil {
-		setupLog.Error(err, "unable to login to qBittorrent")
-		os.Exit(1)
+	if err := serverReconciler.ConfigureConnection(ctx); err != nil {
+		return nil, nil, err
+	}
+	// A qBittorrent unreachable at startup is logged in to once it is back
+	if _, err := clients.Client(ctx, o.serverName); err != nil {
+		setupLog.Error(err, "unable to login to qBittorrent, logging in again on first use")
+	} else {
+		setupLog.Info("Successfully logged into qBittorrent")
+	}
+
+	return clients, serverReconciler, nil
+}
+
+// setupReconcilers creates the controllers of the operator
+func setupReconcilers(o *options, mgr manager.Manager, clients *clientpool.Pool,
+	serverReconciler *controller.QBittorrentServerReconciler, operatorConfig *controller.OperatorConfig,
+	shard controller.Shard, namespaced bool) error {
+	recorder := controller.NewThrottledRecorder(mgr.GetEventRecorderFor("torrent-controller"), o.eventThrottleWindow)
+
+	// Every replica adds the clients of the QBittorrentServers its Torrents
+	// are downloaded on. The cluster-scoped servers cannot be read when the
+	// operator is restricted to namespaces.
+	var connect func(context.Context, string) error
+	if !namespaced {
+		connect = serverReconciler.Connect
 	}
-	setupLog.Info("Successfully logged into qBittorrent")
 
 	// Create controller without logger parameter
 	if err := (&controller.TorrentReconciler{
-		Client:    mgr.GetClient(),
-		Scheme:    mgr.GetScheme(),
-		QBTClient: qbClient,
+		Client:                   mgr.GetClient(),
+		Scheme:                   mgr.GetScheme(),
+		Clients:                  clients,
+		ServerName:               o.serverName,
+		Connect:                  connect,
+		Clientset:                kubernetes.NewForConfigOrDie(mgr.GetConfig()),
+		Recorder:                 recorder,
+		LabelTagKeys:             o.labelTagKeyList,
+		LabelTagPrefix:           o.labelTagPrefix,
+		IsolateNamespaces:        o.isolateNamespaces,
+		SavePathRoot:             o.savePathRoot,
+		LowPriorityDownloadLimit: o.lowPriorityDownloadLimit,
+		Shard:                    shard,
+		MaxConcurrentReconciles:  o.maxConcurrentReconciles,
+		DeletionRetryTimeout:     o.deletionRetryTimeout,
+		DeletionBatchWindow:      o.deletionBatchWindow,
+		StatusStaleThreshold:     o.statusStaleThreshold,
+		BatchNamespaceDeletion:   !namespaced,
+		ServerConditions:         !namespaced,
+		Config:                   operatorConfig,
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Torrent")
-		os.Exit(1)
+		return fmt.Errorf("unable to create the Torrent controller: %w", err)
 	}
-	// +kubebuilder:scaffold:builder
+	if err := (&controller.TorrentPublishReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		Clients:           clients,
+		ServerName:        o.serverName,
+		Clientset:         kubernetes.NewForConfigOrDie(mgr.GetConfig()),
+		IsolateNamespaces: o.isolateNamespaces,
+		SavePathRoot:      o.savePathRoot,
+		Shard:             shard,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create the TorrentPublish controller: %w", err)
+	}
+	// The QBittorrentServer is cluster-scoped, it is not reported on when
+	// the operator is restricted to namespaces, and only by the first shard
+	if namespaced {
+		setupLog.Info("QBittorrentServer not reported on when watching namespaces")
+	} else if shard.Index != 0 {
+		setupLog.Info("QBittorrentServer reported on by the replica of shard 0")
+	} else if err := serverReconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create the QBittorrentServer controller: %w", err)
+	}
+	// The QBittorrentOperatorConfig is cluster-scoped too, the flags apply
+	// when the operator is restricted to namespaces
+	if namespaced {
+		setupLog.Info("QBittorrentOperatorConfig not applied when watching namespaces")
+	} else if err := (&controller.QBittorrentOperatorConfigReconciler{
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Clients:    clients,
+		ConfigName: o.configName,
+		Config:     operatorConfig,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create the QBittorrentOperatorConfig controller: %w", err)
+	}
+	return nil
+}
 
-	if metricsCertWatcher != nil {
-		setupLog.Info("Adding metrics certificate watcher to manager")
-		if err := mgr.Add(metricsCertWatcher); err != nil {
-			setupLog.Error(err, "unable to add metrics certificate watcher to manager")
-			os.Exit(1)
-		}
+// setupWebhooks registers the admission and conversion webhooks
+func setupWebhooks(mgr manager.Manager) error {
+	if err := webhooktorrentv1beta1.SetupTorrentWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create the Torrent webhook: %w", err)
+	}
+	if err := webhooktorrentv1beta1.SetupTorrentPolicyWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create the TorrentPolicy webhook: %w", err)
 	}
+	return nil
+}
 
-	if webhookCertWatcher != nil {
-		setupLog.Info("Adding webhook certificate watcher to manager")
-		if err := mgr.Add(webhookCertWatcher); err != nil {
-			setupLog.Error(err, "unable to add webhook certificate watcher to manager")
-			os.Exit(1)
+// setupTorrentsAPI serves the read-only torrent API, whose callers are reviewed
+// by the cluster-scoped TokenReview and SubjectAccessReview
+func setupTorrentsAPI(o *options, mgr manager.Manager, clients *clientpool.Pool,
+	tlsOpts []func(*tls.Config), namespaced bool) error {
+	if o.torrentsAPIAddr == "" || o.torrentsAPIAddr == "0" {
+		return nil
+	}
+	if namespaced {
+		setupLog.Info("Torrent API not served when watching namespaces")
+		return nil
+	}
+
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return fmt.Errorf("unable to create the clientset of the torrent API: %w", err)
+	}
+	torrentsAPI := &torrentapi.Server{
+		Addr:       o.torrentsAPIAddr,
+		Reader:     mgr.GetClient(),
+		Clientset:  clientset,
+		Clients:    clients,
+		ServerName: o.serverName,
+		Informers:  mgr.GetCache(),
+	}
+	if len(o.torrentsAPICertPath) > 0 {
+		certWatcher, err := setupCertWatcher(o.torrentsAPICertPath, "tls.crt", "tls.key")
+		if err != nil {
+			return fmt.Errorf("failed to initialize torrent API certificate watcher: %w", err)
+		}
+		if err := mgr.Add(certWatcher); err != nil {
+			return fmt.Errorf("unable to add torrent API certificate watcher to manager: %w", err)
+		}
+		torrentsAPI.TLSConfig = &tls.Config{GetCertificate: certWatcher.GetCertificate}
+		for _, opt := range tlsOpts {
+			opt(torrentsAPI.TLSConfig)
 		}
+	} else {
+		setupLog.Info("Serving the torrent API over plain HTTP, set --torrents-api-cert-path to serve HTTPS")
 	}
+	return mgr.Add(torrentsAPI)
+}
 
+// setupHealthChecks adds the health and ready checks, and keeps the
+// qBittorrent sessions alive while idle
+func setupHealthChecks(o *options, mgr manager.Manager, clients *clientpool.Pool) error {
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up health check")
-		os.Exit(1)
+		return fmt.Errorf("unable to set up health check: %w", err)
 	}
 	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up ready check")
-		os.Exit(1)
+		return fmt.Errorf("unable to set up ready check: %w", err)
+	}
+
+	// Keep the qBittorrent sessions alive while idle
+	if o.keepAliveInterval > 0 {
+		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			clients.KeepAlive(ctx, o.keepAliveInterval)
+			return nil
+		})); err != nil {
+			return fmt.Errorf("unable to set up qBittorrent keep-alive: %w", err)
+		}
 	}
 
 	// Add qBittorrent connectivity check
 	if err := mgr.AddReadyzCheck("qbittorrent", func(req *http.Request) error {
 		ctx := context.Background()
-		_, err := qbClient.GetTorrentsInfo(ctx)
+		qbt, err := clients.Client(ctx, o.serverName)
+		if err != nil {
+			return err
+		}
+		_, err = qbt.GetTorrentsInfo(ctx)
 		return err
 	}); err != nil {
-		setupLog.Error(err, "unable to set up qBittorrent ready check")
-		os.Exit(1)
-	}
-
-	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
-		setupLog.Error(err, "problem running manager")
-		os.Exit(1)
+		return fmt.Errorf("unable to set up qBittorrent ready check: %w", err)
 	}
+	return nil
 }
@@ -0,0 +1,267 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clientpool keeps the qBittorrent clients of the operator, one per
+// QBittorrentServer, logged in on first use and scored by the outcome of
+// their calls.
+package clientpool
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// healthWeight is the weight of the outcome of the last call in the health
+// score, the previous ones fading away
+const healthWeight = 0.2
+
+var (
+	healthScore = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "qbittorrent_operator_backend_health_score",
+		Help: "Health of the qBittorrent of a QBittorrentServer, from 0 when its calls fail to 1 when they succeed",
+	}, []string{"server"})
+	callFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "qbittorrent_operator_backend_call_failures_total",
+		Help: "Calls failing to reach the qBittorrent of a QBittorrentServer",
+	}, []string{"server"})
+	logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "qbittorrent_operator_backend_logins_total",
+		Help: "Logins of the operator to the qBittorrent of a QBittorrentServer, by result",
+	}, []string{"server", "result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(healthScore, callFailures, logins)
+}
+
+// Credentials are the credentials the client of a server logs in with
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Health is the health of a server, scored from the outcome of its calls
+type Health struct {
+	// Score goes from 0, when the calls fail, to 1, when they succeed. The
+	// recent calls weigh the most.
+	Score float64
+	// ConsecutiveFailures is the number of calls failed since the last success
+	ConsecutiveFailures int
+	// LastFailure is when a call last failed, zero if none did
+	LastFailure time.Time
+}
+
+// Healthy reports whether the last call succeeded
+func (h Health) Healthy() bool {
+	return h.ConsecutiveFailures == 0
+}
+
+// Pool keeps one client per QBittorrentServer, by name. A nil Pool has no
+// servers.
+type Pool struct {
+	mu      sync.RWMutex
+	servers map[string]*server
+}
+
+type server struct {
+	name   string
+	client *qbittorrent.Client
+	// credentials are nil when the server needs no login, e.g. when
+	// qBittorrent bypasses the authentication of the operator
+	credentials *Credentials
+
+	// loginMu serializes the logins
+	loginMu sync.Mutex
+
+	mu     sync.Mutex
+	health Health
+	// loginErr is the error of the last login, nil once it succeeded
+	loginErr error
+}
+
+// New creates an empty pool
+func New() *Pool {
+	return &Pool{servers: map[string]*server{}}
+}
+
+// Add adds the client of a server, replacing the previous one. It logs in
+// with the credentials on first use, none are needed if nil.
+func (p *Pool) Add(name string, client *qbittorrent.Client, credentials *Credentials) {
+	s := &server{name: name, client: client, credentials: credentials, health: Health{Score: 1}}
+	client.OnCall(s.observe)
+	healthScore.WithLabelValues(name).Set(1)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if previous, ok := p.servers[name]; ok && previous.client != client {
+		previous.client.OnCall(nil)
+	}
+	p.servers[name] = s
+}
+
+// Remove removes the client of a deleted server, with its metrics
+func (p *Pool) Remove(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s, ok := p.servers[name]
+	if !ok {
+		return
+	}
+	s.client.OnCall(nil)
+	delete(p.servers, name)
+	healthScore.DeleteLabelValues(name)
+	callFailures.DeleteLabelValues(name)
+	logins.DeletePartialMatch(prometheus.Labels{"server": name})
+}
+
+func (p *Pool) server(name string) *server {
+	if p == nil {
+		return nil
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.servers[name]
+}
+
+// Get returns the client of the server, without logging in, or nil if the
+// server is not in the pool
+func (p *Pool) Get(name string) *qbittorrent.Client {
+	if s := p.server(name); s != nil {
+		return s.client
+	}
+	return nil
+}
+
+// Client returns the client of the server, logged in. A client whose login
+// failed logs in again, so that a server unreachable when the operator
+// started is used once it is back.
+func (p *Pool) Client(ctx context.Context, name string) (*qbittorrent.Client, error) {
+	s := p.server(name)
+	if s == nil {
+		return nil, fmt.Errorf("no qBittorrent client for the QBittorrentServer %s", name)
+	}
+	if err := s.login(ctx); err != nil {
+		return nil, err
+	}
+	return s.client, nil
+}
+
+// Health returns the health of the server, false if it is not in the pool
+func (p *Pool) Health(name string) (Health, bool) {
+	s := p.server(name)
+	if s == nil {
+		return Health{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.health, true
+}
+
+// LoginError returns the error of the last login to the server, nil if it
+// succeeded or none was needed
+func (p *Pool) LoginError(name string) error {
+	s := p.server(name)
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loginErr
+}
+
+// Names returns the names of the servers, sorted
+func (p *Pool) Names() []string {
+	if p == nil {
+		return nil
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	names := make([]string, 0, len(p.servers))
+	for name := range p.servers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// KeepAlive pings the qBittorrent of every server each interval until the
+// context is done, so that their sessions do not expire while the operator
+// is idle
+func (p *Pool) KeepAlive(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, name := range p.Names() {
+			if client := p.Get(name); client != nil {
+				client.Ping(ctx)
+			}
+		}
+	}
+}
+
+// login logs the client in unless it did already. The client logs in again
+// by itself when its session expires.
+func (s *server) login(ctx context.Context) error {
+	if s.credentials == nil || s.client.LoggedIn() {
+		return nil
+	}
+	s.loginMu.Lock()
+	defer s.loginMu.Unlock()
+	if s.client.LoggedIn() {
+		return nil
+	}
+
+	err := s.client.Login(ctx, s.credentials.Username, s.credentials.Password)
+	s.mu.Lock()
+	s.loginErr = err
+	s.mu.Unlock()
+	if err != nil {
+		logins.WithLabelValues(s.name, "failure").Inc()
+		return err
+	}
+	logins.WithLabelValues(s.name, "success").Inc()
+	return nil
+}
+
+// observe scores the health of the server with the outcome of a call
+func (s *server) observe(failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	outcome := 1.0
+	if failed {
+		outcome = 0
+		s.health.ConsecutiveFailures++
+		s.health.LastFailure = time.Now()
+		callFailures.WithLabelValues(s.name).Inc()
+	} else {
+		s.health.ConsecutiveFailures = 0
+	}
+	s.health.Score = (1-healthWeight)*s.health.Score + healthWeight*outcome
+	healthScore.WithLabelValues(s.name).Set(s.health.Score)
+}
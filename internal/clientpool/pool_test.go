@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientpool
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+func TestPool_LogsInAgainAfterAFailedLogin(t *testing.T) {
+	var down atomic.Bool
+	down.Store(true)
+	var loginCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if req.URL.Path == "/api/v2/auth/login" {
+			loginCalls.Add(1)
+			http.SetCookie(w, &http.Cookie{Name: "SID", Value: "session"})
+		}
+	}))
+	defer server.Close()
+
+	pool := New()
+	pool.Add("default", qbittorrent.NewClient(server.URL), &Credentials{Username: "admin", Password: "adminadmin"})
+
+	if _, err := pool.Client(context.Background(), "default"); err == nil {
+		t.Fatalf("Expected the login to fail while qBittorrent is down")
+	}
+	if pool.LoginError("default") == nil {
+		t.Errorf("Expected the error of the failed login")
+	}
+
+	down.Store(false)
+	for range 2 {
+		client, err := pool.Client(context.Background(), "default")
+		if err != nil {
+			t.Fatalf("Expected a logged in client, got %v", err)
+		}
+		if !client.LoggedIn() {
+			t.Errorf("Expected the client to be logged in")
+		}
+	}
+	if err := pool.LoginError("default"); err != nil {
+		t.Errorf("Expected no login error once logged in, got %v", err)
+	}
+	if calls := loginCalls.Load(); calls != 1 {
+		t.Errorf("Expected a single login once qBittorrent is back, got %d", calls)
+	}
+}
+
+func TestPool_ScoresTheHealthOfTheServers(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	pool := New()
+	client := qbittorrent.NewClient(server.URL)
+	pool.Add("default", client, nil)
+
+	status.Store(http.StatusBadGateway)
+	for range 3 {
+		_, _ = client.GetVersion(context.Background())
+	}
+	health, ok := pool.Health("default")
+	if !ok {
+		t.Fatalf("Expected the health of the server")
+	}
+	if health.Healthy() || health.ConsecutiveFailures != 3 || health.Score >= 0.6 {
+		t.Errorf("Expected an unhealthy server, got %+v", health)
+	}
+
+	status.Store(http.StatusOK)
+	_, _ = client.GetVersion(context.Background())
+	recovered, _ := pool.Health("default")
+	if !recovered.Healthy() || recovered.Score <= health.Score {
+		t.Errorf("Expected the server to recover, got %+v", recovered)
+	}
+
+	if _, ok := pool.Health("other"); ok {
+		t.Errorf("Expected no health for an unknown server")
+	}
+	if pool.Get("other") != nil {
+		t.Errorf("Expected no client for an unknown server")
+	}
+}
+
+func TestPool_RemovesTheClientOfADeletedServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	pool := New()
+	client := qbittorrent.NewClient(server.URL)
+	pool.Add("default", qbittorrent.NewClient(server.URL), nil)
+	pool.Add("seedbox", client, nil)
+	pool.Remove("seedbox")
+	pool.Remove("unknown")
+
+	if pool.Get("seedbox") != nil {
+		t.Errorf("Expected no client for the removed server")
+	}
+	if names := pool.Names(); len(names) != 1 || names[0] != "default" {
+		t.Errorf("Expected only the default server to be left, got %v", names)
+	}
+
+	// The calls of the removed client are not scored anymore
+	_, _ = client.GetVersion(context.Background())
+	if _, ok := pool.Health("seedbox"); ok {
+		t.Errorf("Expected no health for the removed server")
+	}
+}
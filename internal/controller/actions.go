@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// AnnotationAction asks for a one-off action on the torrent in qBittorrent.
+// The annotation is removed once the action is done.
+const AnnotationAction = "qbittorrent.io/action"
+
+// The actions of the action annotation
+const (
+	// ActionPause stops the torrent
+	ActionPause = "pause"
+	// ActionResume starts the stopped torrent
+	ActionResume = "resume"
+	// ActionRecheck checks the downloaded content against its pieces
+	ActionRecheck = "recheck"
+)
+
+// torrentAction is an action of the action annotation, with the Event
+// recorded once it is done
+type torrentAction struct {
+	call    func(r *TorrentReconciler, ctx context.Context, torrent *torrentv1beta1.Torrent, hash string) error
+	reason  string
+	message string
+}
+
+// The pause and resume go through the backend, the recheck is specific to qBittorrent
+var torrentActions = map[string]torrentAction{
+	ActionPause: {func(r *TorrentReconciler, ctx context.Context, torrent *torrentv1beta1.Torrent, hash string) error {
+		return r.backend(torrent).Pause(ctx, hash)
+	}, "Paused", "Torrent paused on qBittorrent"},
+	ActionResume: {func(r *TorrentReconciler, ctx context.Context, torrent *torrentv1beta1.Torrent, hash string) error {
+		return r.backend(torrent).Resume(ctx, hash)
+	}, "Resumed", "Torrent resumed on qBittorrent"},
+	ActionRecheck: {func(r *TorrentReconciler, ctx context.Context, torrent *torrentv1beta1.Torrent, hash string) error {
+		return r.qbt(torrent).RecheckTorrent(ctx, hash)
+	}, "RecheckStarted", "Recheck of the torrent started on qBittorrent"},
+}
+
+// allowedActions returns the actions the state of the torrent allows.
+// qBittorrent starts again a torrent on error, and rechecks neither the
+// torrents checked or moved nor the magnets without metadata.
+func allowedActions(state torrentv1beta1.TorrentState) *torrentv1beta1.AllowedActions {
+	allowed := &torrentv1beta1.AllowedActions{CanRecheck: true}
+	switch state {
+	case torrentv1beta1.TorrentStatePausedDL, torrentv1beta1.TorrentStatePausedUP,
+		torrentv1beta1.TorrentStateStoppedDL, torrentv1beta1.TorrentStateStoppedUP,
+		torrentv1beta1.TorrentStateError, torrentv1beta1.TorrentStateMissingFiles:
+		allowed.CanResume = true
+	default:
+		allowed.CanPause = true
+	}
+	switch state {
+	case torrentv1beta1.TorrentStateCheckingDL, torrentv1beta1.TorrentStateCheckingUP,
+		torrentv1beta1.TorrentStateCheckingResumeData, torrentv1beta1.TorrentStateMoving,
+		torrentv1beta1.TorrentStateMetaDL, torrentv1beta1.TorrentStateForcedMetaDL:
+		allowed.CanRecheck = false
+	}
+	return allowed
+}
+
+// runAnnotatedAction runs the action of the action annotation of the torrent,
+// recording it in an Event. It returns whether the annotation was removed
+// from the torrent, which then needs to be updated.
+func (r *TorrentReconciler) runAnnotatedAction(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) (bool, error) {
+	name, ok := torrent.Annotations[AnnotationAction]
+	if !ok {
+		return false, nil
+	}
+
+	action, ok := torrentActions[name]
+	if !ok {
+		return false, fmt.Errorf("invalid %s annotation %q, expected %s, %s or %s",
+			AnnotationAction, name, ActionPause, ActionResume, ActionRecheck)
+	}
+	log.FromContext(ctx).Info("Running the annotated action", "Name", torrent.Name, "Action", name)
+	if err := action.call(r, ctx, torrent, qbTorrent.Hash); err != nil {
+		return false, fmt.Errorf("failed to %s torrent: %w", name, err)
+	}
+	r.recordEvent(torrent, corev1.EventTypeNormal, action.reason, action.message)
+
+	delete(torrent.Annotations, AnnotationAction)
+	return true, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Torrent actions", func() {
+	const hash = "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "actions", Namespace: "default"}
+
+	var qb *fakeQBittorrent
+	var recorder *record.FakeRecorder
+	var controllerReconciler *TorrentReconciler
+
+	reconcileTorrent := func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getTorrent := func() *torrentv1beta1.Torrent {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent
+	}
+
+	annotate := func(action string) {
+		torrent := getTorrent()
+		torrent.Annotations = map[string]string{AnnotationAction: action}
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		recorder = record.NewFakeRecorder(20)
+		controllerReconciler = &TorrentReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Clients:  poolOf(qb.client()),
+			Recorder: recorder,
+		}
+
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source: torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + hash},
+			},
+		})).To(Succeed())
+		reconcileTorrent()
+		reconcileTorrent()
+		reconcileTorrent()
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		torrent := getTorrent()
+		controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+	})
+
+	It("should pause, recheck and resume the torrent once", func() {
+		// The magnet is fetching its metadata, there is nothing to recheck yet
+		Expect(getTorrent().Status.AllowedActions).To(Equal(&torrentv1beta1.AllowedActions{CanPause: true}))
+		for _, step := range []struct {
+			action, state, event string
+			allowed              torrentv1beta1.AllowedActions
+		}{
+			{ActionPause, "stoppedDL", "Normal Paused Torrent paused on qBittorrent",
+				torrentv1beta1.AllowedActions{CanResume: true, CanRecheck: true}},
+			{ActionRecheck, "checkingDL", "Normal RecheckStarted Recheck of the torrent started on qBittorrent",
+				torrentv1beta1.AllowedActions{CanPause: true}},
+			{ActionResume, "downloading", "Normal Resumed Torrent resumed on qBittorrent",
+				torrentv1beta1.AllowedActions{CanPause: true, CanRecheck: true}},
+		} {
+			By("running the " + step.action + " action")
+			annotate(step.action)
+			reconcileTorrent()
+			Expect(getTorrent().Annotations).NotTo(HaveKey(AnnotationAction))
+			Expect(recorder.Events).To(Receive(Equal(step.event)))
+
+			reconcileTorrent()
+			Expect(getTorrent().Status.State).To(Equal(torrentv1beta1.TorrentState(step.state)))
+			Expect(getTorrent().Status.AllowedActions).To(Equal(&step.allowed))
+		}
+		Expect(qb.callCount("/api/v2/torrents/stop")).To(Equal(1))
+	})
+
+	It("should report an unknown action", func() {
+		annotate("rewind")
+		reconcileTorrent()
+
+		torrent := getTorrent()
+		Expect(torrent.Annotations).To(HaveKeyWithValue(AnnotationAction, "rewind"))
+		degraded := meta.FindStatusCondition(torrent.Status.Conditions, TypeDegradedTorrent)
+		Expect(degraded).NotTo(BeNil())
+		Expect(degraded.Reason).To(Equal("FailedToRunAction"))
+	})
+})
@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// AnnotationApproved set to "true" approves a Torrent with approvalRequired,
+// which is then started on qBittorrent
+const AnnotationApproved = "qbittorrent.io/approved"
+
+// reasonAwaitingApproval is the reason of the conditions of the torrents
+// held back until approved
+const reasonAwaitingApproval = "AwaitingApproval"
+
+// awaitingApproval reports whether the torrent requires an approval it did
+// not get yet
+func awaitingApproval(torrent *torrentv1beta1.Torrent) bool {
+	return torrent.Spec.ApprovalRequired && torrent.Annotations[AnnotationApproved] != "true"
+}
+
+// heldForApproval reports whether the torrent was held back by reconcileApproval
+func heldForApproval(torrent *torrentv1beta1.Torrent) bool {
+	available := meta.FindStatusCondition(torrent.Status.Conditions, TypeAvailableTorrent)
+	return available != nil && available.Reason == reasonAwaitingApproval
+}
+
+// reconcileApproval keeps the torrent awaiting approval stopped, reported
+// Pending, and starts it once approved. The torrent is added stopped, it is
+// only stopped here when started by hand or when approvalRequired is set
+// later. It returns whether the torrent is held back.
+func (r *TorrentReconciler) reconcileApproval(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) (bool, error) {
+	logger := log.FromContext(ctx)
+	held := heldForApproval(torrent)
+
+	if !awaitingApproval(torrent) {
+		if held {
+			logger.Info("Torrent approved, starting it", "Name", torrent.Name)
+			if err := r.qbt(torrent).StartTorrent(ctx, qbTorrent.Hash); err != nil {
+				return false, fmt.Errorf("failed to start torrent: %w", err)
+			}
+			r.recordEvent(torrent, corev1.EventTypeNormal, "Approved", "Torrent approved, started on qBittorrent")
+		}
+		return false, nil
+	}
+
+	if phase := torrentPhase(qbTorrent); phase != torrentv1beta1.TorrentPhasePaused &&
+		phase != torrentv1beta1.TorrentPhaseCompleted {
+		logger.Info("Torrent awaiting approval, stopping it", "Name", torrent.Name)
+		if err := r.qbt(torrent).StopTorrent(ctx, qbTorrent.Hash); err != nil {
+			return false, fmt.Errorf("failed to stop torrent: %w", err)
+		}
+	}
+
+	// Waiting is not a failure, the torrent is not degraded
+	message := fmt.Sprintf("Waiting for the %s annotation to be set to \"true\"", AnnotationApproved)
+	if !held {
+		r.recordEvent(torrent, corev1.EventTypeNormal, reasonAwaitingApproval, message)
+	}
+	setCondition(&torrent.Status.Conditions, metav1.Condition{
+		Type:    TypeAvailableTorrent,
+		Status:  metav1.ConditionFalse,
+		Reason:  reasonAwaitingApproval,
+		Message: message,
+	})
+	setCondition(&torrent.Status.Conditions, metav1.Condition{
+		Type:    TypeDegradedTorrent,
+		Status:  metav1.ConditionFalse,
+		Reason:  reasonAwaitingApproval,
+		Message: message,
+	})
+	return true, nil
+}
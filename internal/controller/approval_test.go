@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Torrent approval", func() {
+	const magnetHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var controllerReconciler *TorrentReconciler
+	var recorder *record.FakeRecorder
+	var key types.NamespacedName
+
+	reconcileTorrent := func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getTorrent := func() *torrentv1beta1.Torrent {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		key = types.NamespacedName{Name: "approval", Namespace: "default"}
+		recorder = record.NewFakeRecorder(20)
+		controllerReconciler = &TorrentReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Clients:  poolOf(qb.client()),
+			Recorder: recorder,
+			Config:   &OperatorConfig{},
+		}
+
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source:           torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + magnetHash},
+				ApprovalRequired: true,
+			},
+		})).To(Succeed())
+		reconcileTorrent()
+		reconcileTorrent()
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		torrent := getTorrent()
+		controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+	})
+
+	It("should add the torrent stopped and start it once approved", func() {
+		Expect(qb.torrentState(magnetHash)).To(Equal("stoppedDL"))
+		reconcileTorrent()
+		torrent := getTorrent()
+		Expect(torrent.Status.Phase).To(Equal(torrentv1beta1.TorrentPhasePending))
+		condition := meta.FindStatusCondition(torrent.Status.Conditions, TypeAvailableTorrent)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("AwaitingApproval"))
+		Expect(meta.IsStatusConditionFalse(torrent.Status.Conditions, TypeDegradedTorrent)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal AwaitingApproval")))
+
+		By("stopping it again when started by hand")
+		qb.setState(magnetHash, "downloading")
+		reconcileTorrent()
+		Expect(qb.torrentState(magnetHash)).To(Equal("stoppedDL"))
+		Expect(recorder.Events).NotTo(Receive())
+
+		By("starting it once approved")
+		torrent = getTorrent()
+		torrent.Annotations = map[string]string{AnnotationApproved: "true"}
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		reconcileTorrent()
+		Expect(qb.torrentState(magnetHash)).To(Equal("downloading"))
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal Approved")))
+		reconcileTorrent()
+		Expect(meta.IsStatusConditionTrue(getTorrent().Status.Conditions, TypeAvailableTorrent)).To(BeTrue())
+	})
+
+	It("should start the held torrent once approval is no longer required", func() {
+		reconcileTorrent()
+		Expect(qb.torrentState(magnetHash)).To(Equal("stoppedDL"))
+
+		torrent := getTorrent()
+		torrent.Spec.ApprovalRequired = false
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		reconcileTorrent()
+		Expect(qb.torrentState(magnetHash)).To(Equal("downloading"))
+	})
+})
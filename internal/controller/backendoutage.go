@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+// TypeBackendUnavailableTorrent reports whether the QBittorrentServer of the
+// Torrent is not Connected. The outage is kept out of the Available and
+// Degraded conditions, left as they were, so that alerts tell it apart from
+// the problems of the torrent.
+const TypeBackendUnavailableTorrent = "BackendUnavailable"
+
+// setBackendCondition mirrors the Connected condition of the QBittorrentServer
+// of the Torrent, and reports whether it is unavailable. The condition is
+// left out while the server reports no connection.
+func (r *TorrentReconciler) setBackendCondition(ctx context.Context, torrent *torrentv1beta1.Torrent) bool {
+	if !r.ServerConditions {
+		return false
+	}
+
+	name := r.serverName(torrent)
+	server := &torrentv1beta1.QBittorrentServer{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, server); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			logf.FromContext(ctx).Error(err, "Failed to get the QBittorrentServer", "Server", name)
+		}
+		meta.RemoveStatusCondition(&torrent.Status.Conditions, TypeBackendUnavailableTorrent)
+		return false
+	}
+
+	connected := meta.FindStatusCondition(server.Status.Conditions, TypeConnectedServer)
+	switch {
+	case connected == nil || connected.Status == metav1.ConditionUnknown:
+		meta.RemoveStatusCondition(&torrent.Status.Conditions, TypeBackendUnavailableTorrent)
+		return false
+	case connected.Status == metav1.ConditionTrue:
+		setCondition(&torrent.Status.Conditions, metav1.Condition{
+			Type:    TypeBackendUnavailableTorrent,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ServerConnected",
+			Message: fmt.Sprintf("The QBittorrentServer %s is Connected", name),
+		})
+		return false
+	}
+	setCondition(&torrent.Status.Conditions, metav1.Condition{
+		Type:    TypeBackendUnavailableTorrent,
+		Status:  metav1.ConditionTrue,
+		Reason:  connected.Reason,
+		Message: fmt.Sprintf("The QBittorrentServer %s is not Connected: %s", name, connected.Message),
+	})
+	return true
+}
+
+// serverTorrents maps the QBittorrentServer of the Torrents to the ones of
+// the shard, so that their BackendUnavailable condition follows it
+func (r *TorrentReconciler) serverTorrents(ctx context.Context, obj client.Object) []reconcile.Request {
+	torrents := &torrentv1beta1.TorrentList{}
+	if err := r.List(ctx, torrents); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list the Torrents of the QBittorrentServer", "Server", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, torrent := range torrents.Items {
+		if torrent.DeletionTimestamp.IsZero() && r.Shard.Owns(&torrent) && r.serverName(&torrent) == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&torrent)})
+		}
+	}
+	return requests
+}
+
+// serverConnectedChanged passes the updates of the QBittorrentServer
+// changing its Connected condition
+var serverConnectedChanged = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldServer, okOld := e.ObjectOld.(*torrentv1beta1.QBittorrentServer)
+		newServer, okNew := e.ObjectNew.(*torrentv1beta1.QBittorrentServer)
+		if !okOld || !okNew {
+			return false
+		}
+		return connectedStatus(oldServer) != connectedStatus(newServer)
+	},
+}
+
+func connectedStatus(server *torrentv1beta1.QBittorrentServer) metav1.ConditionStatus {
+	if connected := meta.FindStatusCondition(server.Status.Conditions, TypeConnectedServer); connected != nil {
+		return connected.Status
+	}
+	return metav1.ConditionUnknown
+}
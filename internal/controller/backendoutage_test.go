@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Backend outages", func() {
+	const magnetHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "outage", Namespace: "default"}
+
+	var qb *fakeQBittorrent
+	var fakeClient client.Client
+	var controllerReconciler *TorrentReconciler
+
+	reconcileTorrent := func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getTorrent := func() *torrentv1beta1.Torrent {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(fakeClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent
+	}
+
+	setConnected := func(err error) {
+		server := &torrentv1beta1.QBittorrentServer{}
+		Expect(fakeClient.Get(ctx, types.NamespacedName{Name: DefaultServerName}, server)).To(Succeed())
+		setConnectionConditions(&server.Status.Conditions, err)
+		Expect(fakeClient.Status().Update(ctx, server)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+
+		scheme := runtime.NewScheme()
+		Expect(torrentv1beta1.AddToScheme(scheme)).To(Succeed())
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&torrentv1beta1.Torrent{}, &torrentv1beta1.QBittorrentServer{}).
+			WithObjects(
+				&torrentv1beta1.QBittorrentServer{ObjectMeta: metav1.ObjectMeta{Name: DefaultServerName}},
+				&torrentv1beta1.Torrent{
+					ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+					Spec: torrentv1beta1.TorrentSpec{
+						Source: torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + magnetHash},
+					},
+				},
+			).
+			Build()
+		controllerReconciler = &TorrentReconciler{
+			Client:           fakeClient,
+			Scheme:           scheme,
+			Clients:          poolOf(qb.client()),
+			Recorder:         record.NewFakeRecorder(20),
+			Config:           &OperatorConfig{},
+			ServerConditions: true,
+		}
+	})
+
+	AfterEach(func() {
+		qb.Close()
+	})
+
+	It("should report the outage of the QBittorrentServer apart from the health of the Torrent", func() {
+		By("leaving the condition out while the server reports no connection")
+		reconcileTorrent()
+		reconcileTorrent()
+		reconcileTorrent()
+		torrent := getTorrent()
+		Expect(torrent.Status.Hash).To(Equal(magnetHash))
+		Expect(meta.FindStatusCondition(torrent.Status.Conditions, TypeBackendUnavailableTorrent)).To(BeNil())
+		available := meta.FindStatusCondition(torrent.Status.Conditions, TypeAvailableTorrent)
+		Expect(available.Status).To(Equal(metav1.ConditionTrue))
+
+		By("setting the condition while the server is not Connected, leaving the others")
+		setConnected(context.DeadlineExceeded)
+		calls := qb.callCount("/api/v2/torrents/info")
+		reconcileTorrent()
+		torrent = getTorrent()
+		unavailable := meta.FindStatusCondition(torrent.Status.Conditions, TypeBackendUnavailableTorrent)
+		Expect(unavailable.Status).To(Equal(metav1.ConditionTrue))
+		Expect(unavailable.Reason).To(Equal("ServerUnreachable"))
+		Expect(meta.FindStatusCondition(torrent.Status.Conditions, TypeAvailableTorrent)).To(Equal(available))
+		Expect(meta.IsStatusConditionFalse(torrent.Status.Conditions, TypeDegradedTorrent)).To(BeTrue())
+		Expect(qb.callCount("/api/v2/torrents/info")).To(Equal(calls))
+
+		By("clearing the condition once the server is Connected again")
+		setConnected(nil)
+		reconcileTorrent()
+		Expect(meta.IsStatusConditionFalse(getTorrent().Status.Conditions, TypeBackendUnavailableTorrent)).To(BeTrue())
+		Expect(qb.callCount("/api/v2/torrents/info")).To(BeNumerically(">", calls))
+	})
+
+	It("should enqueue the Torrents when the Connected condition of their server changes", func() {
+		server := &torrentv1beta1.QBittorrentServer{ObjectMeta: metav1.ObjectMeta{Name: DefaultServerName}}
+		changed := server.DeepCopy()
+		setConnectionConditions(&changed.Status.Conditions, nil)
+		Expect(serverConnectedChanged.Update(event.UpdateEvent{ObjectOld: server, ObjectNew: changed})).To(BeTrue())
+		Expect(serverConnectedChanged.Update(event.UpdateEvent{ObjectOld: changed, ObjectNew: changed})).To(BeFalse())
+
+		Expect(controllerReconciler.serverTorrents(ctx, server)).To(ConsistOf(reconcile.Request{NamespacedName: key}))
+		other := &torrentv1beta1.QBittorrentServer{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+		Expect(controllerReconciler.serverTorrents(ctx, other)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+const (
+	// MaxBackpressureDelay bounds the delay of the reconciles while
+	// qBittorrent is saturated or failing
+	MaxBackpressureDelay = 5 * time.Minute
+	// minRateLimitBackpressure is the wait for the rate limit from which the
+	// calls are saturated, the shorter waits being part of the normal pace
+	minRateLimitBackpressure = time.Second
+	// backpressureFailures is the number of consecutive failed calls from
+	// which the failures back the reconciles off, a single one being retried
+	// as usual
+	backpressureFailures = 2
+)
+
+// backpressure lets a single Torrent be reconciled at a time while
+// qBittorrent is saturated or failing, the others being requeued instead of
+// waiting for the rate limit or failing at once
+type backpressure struct {
+	inFlight atomic.Int32
+}
+
+// admit reports whether a reconcile may start under the delay of
+// backpressureDelay, done must be called once it ends
+func (b *backpressure) admit(delay time.Duration) bool {
+	if b.inFlight.Add(1) > 1 && delay > 0 {
+		b.inFlight.Add(-1)
+		return false
+	}
+	return true
+}
+
+// done ends an admitted reconcile
+func (b *backpressure) done() {
+	b.inFlight.Add(-1)
+}
+
+// backpressureDelay returns how long the reconciles of the Torrents of the
+// server should be delayed: the wait for the rate limit of the calls when
+// saturated, and the retry interval doubled on every consecutive failed
+// call. Zero when its qBittorrent keeps up.
+func (r *TorrentReconciler) backpressureDelay(name string) time.Duration {
+	var delay time.Duration
+	if qbt := r.Clients.Get(name); qbt != nil {
+		if wait := qbt.RateLimitDelay(); wait >= minRateLimitBackpressure {
+			delay = wait
+		}
+	}
+	if health, ok := r.Clients.Health(name); ok && health.ConsecutiveFailures >= backpressureFailures {
+		doublings := min(health.ConsecutiveFailures-backpressureFailures, 8)
+		delay = max(delay, r.retryInterval()<<doublings)
+	}
+	return min(delay, MaxBackpressureDelay)
+}
+
+// requestServerName returns the name of the QBittorrentServer of the Torrent
+// of the request, the one of the operator when it cannot be read
+func (r *TorrentReconciler) requestServerName(ctx context.Context, req ctrl.Request) string {
+	torrent := &torrentv1beta1.Torrent{}
+	if err := r.Get(ctx, req.NamespacedName, torrent); err != nil {
+		return serverNameOr(r.ServerName)
+	}
+	return r.serverName(torrent)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Backpressure", func() {
+	const magnetHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "backpressure", Namespace: "default"}
+
+	var qb *fakeQBittorrent
+	var controllerReconciler *TorrentReconciler
+
+	reconcileTorrent := func() ctrl.Result {
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+
+		scheme := runtime.NewScheme()
+		Expect(torrentv1beta1.AddToScheme(scheme)).To(Succeed())
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&torrentv1beta1.Torrent{}).
+			WithObjects(&torrentv1beta1.Torrent{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				Spec: torrentv1beta1.TorrentSpec{
+					Source: torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + magnetHash},
+				},
+			}).
+			Build()
+		controllerReconciler = &TorrentReconciler{
+			Client:   fakeClient,
+			Scheme:   scheme,
+			Clients:  poolOf(qb.client()),
+			Recorder: record.NewFakeRecorder(20),
+			Config:   &OperatorConfig{},
+		}
+	})
+
+	AfterEach(func() {
+		qb.Close()
+	})
+
+	It("should back the Torrents off while qBittorrent keeps failing", func() {
+		reconcileTorrent()
+		reconcileTorrent()
+		reconcileTorrent()
+		Expect(controllerReconciler.backpressureDelay(DefaultServerName)).To(BeZero())
+
+		By("doubling the retry interval on every consecutive failed call")
+		qb.setDown(true)
+		reconcileTorrent()
+		reconcileTorrent()
+		health, _ := controllerReconciler.Clients.Health(DefaultServerName)
+		Expect(health.ConsecutiveFailures).To(BeNumerically(">=", backpressureFailures))
+		delay := DefaultRetryInterval << (health.ConsecutiveFailures - backpressureFailures)
+		Expect(controllerReconciler.backpressureDelay(DefaultServerName)).To(Equal(min(delay, MaxBackpressureDelay)))
+		Expect(reconcileTorrent().RequeueAfter).To(BeNumerically(">=", time.Duration(0.8*float64(DefaultRetryInterval))))
+
+		By("requeuing the Torrents reconciled meanwhile without calling qBittorrent")
+		Expect(controllerReconciler.backpressure.admit(0)).To(BeTrue())
+		calls := qb.callCount("/api/v2/torrents/info")
+		Expect(reconcileTorrent().RequeueAfter).To(BeNumerically(">", 0))
+		Expect(qb.callCount("/api/v2/torrents/info")).To(Equal(calls))
+		controllerReconciler.backpressure.done()
+
+		By("reconciling as usual once qBittorrent answers again")
+		qb.setDown(false)
+		reconcileTorrent()
+		Expect(controllerReconciler.backpressureDelay(DefaultServerName)).To(BeZero())
+		Expect(controllerReconciler.backpressure.admit(0)).To(BeTrue())
+		Expect(controllerReconciler.backpressure.admit(0)).To(BeTrue())
+	})
+})
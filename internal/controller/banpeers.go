@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// AnnotationBanPeers lists peers, IP addresses or host:port separated by
+// commas, to ban on qBittorrent. The annotation is removed once they are banned.
+const AnnotationBanPeers = "qbittorrent.io/ban-peers"
+
+// parsePeers returns the normalized peers of a list separated by commas,
+// whitespaces or new lines. Lines starting with # are comments.
+func parsePeers(list string) ([]string, error) {
+	var peers []string
+	var errs []error
+	for _, line := range strings.Split(list, "\n") {
+		if line = strings.TrimSpace(line); strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, peer := range strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		}) {
+			normalized, err := qbittorrent.NormalizePeer(peer)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			peers = append(peers, normalized)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	slices.Sort(peers)
+	return slices.Compact(peers), nil
+}
+
+// banAnnotatedPeers bans the peers of the ban-peers annotation of the
+// torrent, recording them in an Event. It returns whether the annotation was
+// removed from the torrent, which then needs to be updated.
+func (r *TorrentReconciler) banAnnotatedPeers(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, error) {
+	list, ok := torrent.Annotations[AnnotationBanPeers]
+	if !ok {
+		return false, nil
+	}
+
+	peers, err := parsePeers(list)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s annotation: %w", AnnotationBanPeers, err)
+	}
+	if len(peers) > 0 {
+		log.FromContext(ctx).Info("Banning peers", "Name", torrent.Name, "Peers", peers)
+		if err := r.qbt(torrent).BanPeers(ctx, peers); err != nil {
+			return false, fmt.Errorf("failed to ban peers: %w", err)
+		}
+		r.Recorder.Event(torrent, corev1.EventTypeNormal, "PeersBanned",
+			fmt.Sprintf("Banned %d peers on qBittorrent: %s", len(peers), strings.Join(peers, ", ")))
+	}
+
+	delete(torrent.Annotations, AnnotationBanPeers)
+	return true, nil
+}
+
+// banListedPeers bans the peers listed in the bannedPeers ConfigMap of the
+// server when the list changed, and returns the number of peers banned
+func (r *QBittorrentServerReconciler) banListedPeers(ctx context.Context, conn *connection,
+	server *torrentv1beta1.QBittorrentServer) (int32, error) {
+	if server.Spec.BannedPeers == nil {
+		conn.appliedBannedPeers = nil
+		return 0, nil
+	}
+
+	ref := server.Spec.BannedPeers
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+	if err := r.reader().Get(ctx, key, configMap); err != nil {
+		return 0, fmt.Errorf("failed to get banned peers ConfigMap: %w", err)
+	}
+	list, ok := configMap.Data[ref.Key]
+	if !ok {
+		return 0, fmt.Errorf("key %s not found in banned peers ConfigMap %s", ref.Key, key)
+	}
+	peers, err := parsePeers(list)
+	if err != nil {
+		return 0, fmt.Errorf("invalid banned peers ConfigMap %s: %w", key, err)
+	}
+
+	if !slices.Equal(peers, conn.appliedBannedPeers) && len(peers) > 0 {
+		log.FromContext(ctx).Info("Banning the listed peers", "ConfigMap", key, "Peers", len(peers))
+		if err := r.qbt(server).BanPeers(ctx, peers); err != nil {
+			return 0, fmt.Errorf("failed to ban peers: %w", err)
+		}
+	}
+	conn.appliedBannedPeers = peers
+	return int32(len(peers)), nil
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Banned peers", func() {
+	It("should parse peers separated by commas, spaces and lines", func() {
+		peers, err := parsePeers("203.0.113.2, 203.0.113.1:51413\n# abusive leecher\n2001:db8::1\n\n203.0.113.2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(peers).To(Equal([]string{"203.0.113.1:51413", "203.0.113.2:0", "[2001:db8::1]:0"}))
+	})
+
+	It("should reject invalid peers", func() {
+		_, err := parsePeers("203.0.113.1, tracker.example.com:6881")
+		Expect(err).To(MatchError(ContainSubstring("tracker.example.com")))
+	})
+
+	It("should accept an empty list", func() {
+		peers, err := parsePeers("# nobody yet\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(peers).To(BeEmpty())
+	})
+})
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+// TypeCapacityExceededTorrent is True while the Torrent is not added because
+// qBittorrent has the maxManagedTorrents of its QBittorrentServer
+const TypeCapacityExceededTorrent = "CapacityExceeded"
+
+// reasonCapacityExceeded is the reason of the conditions of the torrents
+// held back until qBittorrent has room for them
+const reasonCapacityExceeded = "CapacityExceeded"
+
+// maxManagedTorrents returns the maxManagedTorrents of the QBittorrentServer
+// of the Torrent, 0 if unbounded. The cluster-scoped QBittorrentServer is
+// not read when restricted to namespaces.
+func (r *TorrentReconciler) maxManagedTorrents(ctx context.Context, torrent *torrentv1beta1.Torrent) (int, error) {
+	if !r.ServerConditions {
+		return 0, nil
+	}
+	server := &torrentv1beta1.QBittorrentServer{}
+	if err := r.Get(ctx, types.NamespacedName{Name: r.serverName(torrent)}, server); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+	if server.Spec.MaxManagedTorrents == nil {
+		return 0, nil
+	}
+	return int(*server.Spec.MaxManagedTorrents), nil
+}
+
+// capacityExceeded reports whether the qBittorrent of the Torrent has no room
+// for another torrent, with the number of its torrents and the bound
+func (r *TorrentReconciler) capacityExceeded(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, int, int, error) {
+	limit, err := r.maxManagedTorrents(ctx, torrent)
+	if err != nil || limit == 0 {
+		return false, 0, limit, err
+	}
+	torrents, err := r.qbt(torrent).GetTorrentsInfo(ctx)
+	if err != nil {
+		return false, 0, limit, fmt.Errorf("failed to count the torrents: %w", err)
+	}
+	return len(torrents) >= limit, len(torrents), limit, nil
+}
+
+// holdForCapacity reports the torrent Pending until qBittorrent has room for
+// it. Waiting is not a failure, the torrent is not degraded.
+func (r *TorrentReconciler) holdForCapacity(torrent *torrentv1beta1.Torrent, count, limit int) {
+	message := fmt.Sprintf("qBittorrent has %d torrents, the maxManagedTorrents of the QBittorrentServer is %d",
+		count, limit)
+	if !meta.IsStatusConditionTrue(torrent.Status.Conditions, TypeCapacityExceededTorrent) {
+		r.recordEvent(torrent, corev1.EventTypeWarning, reasonCapacityExceeded, message)
+	}
+
+	torrent.Status.Phase = torrentv1beta1.TorrentPhasePending
+	for _, conditionType := range []string{TypeAvailableTorrent, TypeDegradedTorrent} {
+		setCondition(&torrent.Status.Conditions, metav1.Condition{
+			Type:    conditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  reasonCapacityExceeded,
+			Message: message,
+		})
+	}
+	setCondition(&torrent.Status.Conditions, metav1.Condition{
+		Type:    TypeCapacityExceededTorrent,
+		Status:  metav1.ConditionTrue,
+		Reason:  reasonCapacityExceeded,
+		Message: message,
+	})
+}
+
+// releaseCapacity clears the CapacityExceeded condition of a torrent held
+// back before, once qBittorrent has room for it
+func releaseCapacity(torrent *torrentv1beta1.Torrent) {
+	if meta.FindStatusCondition(torrent.Status.Conditions, TypeCapacityExceededTorrent) == nil {
+		return
+	}
+	setCondition(&torrent.Status.Conditions, metav1.Condition{
+		Type:    TypeCapacityExceededTorrent,
+		Status:  metav1.ConditionFalse,
+		Reason:  "CapacityAvailable",
+		Message: "qBittorrent has room for the torrent",
+	})
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Torrent capacity", func() {
+	const firstHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+	const secondHash = "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"
+
+	ctx := context.Background()
+	first := types.NamespacedName{Name: "first", Namespace: "default"}
+	second := types.NamespacedName{Name: "second", Namespace: "default"}
+
+	var qb *fakeQBittorrent
+	var fakeClient client.Client
+	var controllerReconciler *TorrentReconciler
+
+	reconcileTorrent := func(key types.NamespacedName) {
+		for range 3 {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+	}
+
+	getTorrent := func(key types.NamespacedName) *torrentv1beta1.Torrent {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(fakeClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent
+	}
+
+	newTorrent := func(key types.NamespacedName, hash string) *torrentv1beta1.Torrent {
+		return &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source: torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + hash},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+
+		scheme := runtime.NewScheme()
+		Expect(torrentv1beta1.AddToScheme(scheme)).To(Succeed())
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&torrentv1beta1.Torrent{}, &torrentv1beta1.QBittorrentServer{}).
+			WithObjects(
+				&torrentv1beta1.QBittorrentServer{
+					ObjectMeta: metav1.ObjectMeta{Name: DefaultServerName},
+					Spec:       torrentv1beta1.QBittorrentServerSpec{MaxManagedTorrents: ptr.To[int32](1)},
+				},
+				newTorrent(first, firstHash),
+				newTorrent(second, secondHash),
+			).
+			Build()
+		controllerReconciler = &TorrentReconciler{
+			Client:           fakeClient,
+			Scheme:           scheme,
+			Clients:          poolOf(qb.client()),
+			Recorder:         record.NewFakeRecorder(20),
+			Config:           &OperatorConfig{},
+			ServerConditions: true,
+		}
+	})
+
+	AfterEach(func() {
+		qb.Close()
+	})
+
+	It("should hold the new Torrents back while qBittorrent has the maxManagedTorrents", func() {
+		reconcileTorrent(first)
+		Expect(qb.hashes()).To(ConsistOf(firstHash))
+		Expect(meta.FindStatusCondition(getTorrent(first).Status.Conditions, TypeCapacityExceededTorrent)).To(BeNil())
+
+		By("leaving the Torrent Pending without adding it")
+		reconcileTorrent(second)
+		Expect(qb.hashes()).To(ConsistOf(firstHash))
+		torrent := getTorrent(second)
+		Expect(torrent.Status.Phase).To(Equal(torrentv1beta1.TorrentPhasePending))
+		Expect(meta.IsStatusConditionTrue(torrent.Status.Conditions, TypeCapacityExceededTorrent)).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(torrent.Status.Conditions, TypeDegradedTorrent)).To(BeTrue())
+		available := meta.FindStatusCondition(torrent.Status.Conditions, TypeAvailableTorrent)
+		Expect(available.Reason).To(Equal(reasonCapacityExceeded))
+
+		By("adding it once qBittorrent has room for it")
+		server := &torrentv1beta1.QBittorrentServer{}
+		Expect(fakeClient.Get(ctx, types.NamespacedName{Name: DefaultServerName}, server)).To(Succeed())
+		server.Spec.MaxManagedTorrents = ptr.To[int32](2)
+		Expect(fakeClient.Update(ctx, server)).To(Succeed())
+		reconcileTorrent(second)
+		Expect(qb.hashes()).To(ConsistOf(firstHash, secondHash))
+		torrent = getTorrent(second)
+		Expect(meta.IsStatusConditionFalse(torrent.Status.Conditions, TypeCapacityExceededTorrent)).To(BeTrue())
+		Expect(torrent.Status.Hash).To(Equal(secondHash))
+	})
+})
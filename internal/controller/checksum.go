@@ -0,0 +1,259 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+const (
+	// Key of the ConfigMap entry holding the checksums, in sha256sum -c format
+	ChecksumConfigMapKey = "SHA256SUMS"
+
+	// ConfigMaps are capped at 1MiB, keep some room for metadata
+	maxChecksumDataSize = 1000 * 1024
+
+	defaultChecksumImage = "busybox:1.36"
+	defaultMountPath     = "/downloads"
+)
+
+// Hash every file below CONTENT_PATH, printing paths relative to it so the
+// output can be verified with `sha256sum -c` from the content directory. A
+// file failing to hash fails the pipeline, rather than being left out.
+const checksumScript = `set -e -o pipefail
+if [ -d "$CONTENT_PATH" ]; then
+  cd "$CONTENT_PATH"
+  find . -type f -exec sha256sum {} + | sort -k 2
+else
+  cd "$(dirname "$CONTENT_PATH")"
+  sha256sum "$(basename "$CONTENT_PATH")"
+fi
+`
+
+// checksumJobName returns the name of the checksum Job for a torrent
+func checksumJobName(torrent *torrentv1beta1.Torrent) string {
+	return truncateName(torrent.Name, "-checksums")
+}
+
+// reconcileChecksums makes sure the checksums of a completed torrent are
+// published. It returns true once the ConfigMap exists and status references it.
+// A failed Job is deleted, so that the checksums are computed again at the
+// next reconcile.
+func (r *TorrentReconciler) reconcileChecksums(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	if torrent.Spec.ContentVolume == nil {
+		return false, fmt.Errorf("checksums require spec.contentVolume to be set")
+	}
+
+	// Step 1: Get or create the checksum Job
+	job := &batchv1.Job{}
+	jobKey := types.NamespacedName{Name: checksumJobName(torrent), Namespace: torrent.Namespace}
+	if err := r.Get(ctx, jobKey, job); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get checksum job: %w", err)
+		}
+
+		job = r.checksumJob(torrent)
+		if err := controllerutil.SetControllerReference(torrent, job, r.Scheme); err != nil {
+			return false, fmt.Errorf("failed to set owner reference on checksum job: %w", err)
+		}
+
+		logger.Info("Creating checksum Job", "Job", job.Name)
+		if err := r.Create(ctx, job); err != nil {
+			return false, fmt.Errorf("failed to create checksum job: %w", err)
+		}
+		return false, nil
+	}
+
+	// Step 2: Wait for the Job to finish
+	if job.Status.Succeeded == 0 && jobFailed(job) {
+		reason := r.jobFailureMessage(ctx, job)
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+			!apierrors.IsNotFound(err) {
+			logger.Error(err, "Failed to delete checksum Job", "Job", job.Name)
+		}
+		return false, fmt.Errorf("checksum job %s failed: %s", job.Name, reason)
+	}
+	if job.Status.Succeeded == 0 {
+		logger.V(1).Info("Checksum Job still running", "Job", job.Name)
+		return false, nil
+	}
+
+	// Step 3: Collect the checksums from the Job logs
+	checksums, err := jobOutput(ctx, r.Client, r.Clientset, job)
+	if err != nil {
+		return false, err
+	}
+	if len(checksums) > maxChecksumDataSize {
+		return false, fmt.Errorf("checksums output is too large for a ConfigMap (%d bytes)", len(checksums))
+	}
+
+	// Step 4: Publish the checksums
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      truncateName(torrent.Name, "-checksums"),
+			Namespace: torrent.Namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Labels = managedLabels(torrent)
+		configMap.Data = map[string]string{ChecksumConfigMapKey: checksums}
+		return controllerutil.SetControllerReference(torrent, configMap, r.Scheme)
+	}); err != nil {
+		return false, fmt.Errorf("failed to publish checksums: %w", err)
+	}
+	logger.Info("Published checksums", "ConfigMap", configMap.Name)
+
+	torrent.Status.ChecksumConfigMap = configMap.Name
+
+	// The Job is not needed anymore
+	if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+		!apierrors.IsNotFound(err) {
+		logger.Error(err, "Failed to delete checksum Job", "Job", job.Name)
+	}
+
+	return true, nil
+}
+
+// checksumJob builds the Job computing the checksums of the torrent content
+func (r *TorrentReconciler) checksumJob(torrent *torrentv1beta1.Torrent) *batchv1.Job {
+	image := torrent.Spec.Checksums.Image
+	if image == "" {
+		image = defaultChecksumImage
+	}
+
+	mountPath := torrent.Spec.ContentVolume.MountPath
+	if mountPath == "" {
+		mountPath = defaultMountPath
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      checksumJobName(torrent),
+			Namespace: torrent.Namespace,
+			Labels:    managedLabels(torrent),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To[int32](2),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: managedLabels(torrent),
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:    "checksums",
+						Image:   image,
+						Command: []string{"sh", "-c", checksumScript},
+						Env: []corev1.EnvVar{{
+							Name:  "CONTENT_PATH",
+							Value: torrent.Status.ContentPath,
+						}},
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      "content",
+							MountPath: mountPath,
+							ReadOnly:  true,
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "content",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: torrent.Spec.ContentVolume.ClaimName,
+								ReadOnly:  true,
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// jobFailed reports whether the Job failed for good. Its pod is retried up
+// to the backoff limit with none active in between, so a failed pod alone
+// does not fail the Job.
+func jobFailed(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	// The default backoff limit of the Jobs
+	backoffLimit := int32(6)
+	if job.Spec.BackoffLimit != nil {
+		backoffLimit = *job.Spec.BackoffLimit
+	}
+	return job.Status.Failed > backoffLimit
+}
+
+// jobOutput reads the logs of the succeeded pod of a Job
+func jobOutput(ctx context.Context, c client.Reader, clientset kubernetes.Interface, job *batchv1.Job) (string, error) {
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods,
+		client.InNamespace(job.Namespace),
+		client.MatchingLabels{batchv1.JobNameLabel: job.Name},
+	); err != nil {
+		return "", fmt.Errorf("failed to list pods of job %s: %w", job.Name, err)
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+
+		logs, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{}).DoRaw(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get logs of job %s: %w", job.Name, err)
+		}
+		return string(logs), nil
+	}
+
+	return "", fmt.Errorf("no succeeded pod found for job %s", job.Name)
+}
+
+// managedLabels returns the labels set on objects created for a torrent
+func managedLabels(torrent *torrentv1beta1.Torrent) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/managed-by": "qbittorrent-operator",
+		"torrent.qbittorrent.io/name":  truncateName(torrent.Name, ""),
+	}
+}
+
+// truncateName appends suffix to name, keeping the result a valid label value
+func truncateName(name, suffix string) string {
+	const maxLength = 63
+	if len(name)+len(suffix) > maxLength {
+		name = name[:maxLength-len(suffix)]
+	}
+	return name + suffix
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Checksum Job", func() {
+	ctx := context.Background()
+
+	var controllerReconciler *TorrentReconciler
+	var torrent *torrentv1beta1.Torrent
+	var jobKey types.NamespacedName
+
+	BeforeEach(func() {
+		controllerReconciler = &TorrentReconciler{
+			Client:    k8sClient,
+			Scheme:    k8sClient.Scheme(),
+			Clientset: kubefake.NewClientset(),
+		}
+
+		torrent = &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: "checksum-job", Namespace: "default"},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source: torrentv1beta1.TorrentSource{
+					MagnetURI: "magnet:?xt=urn:btih:c9e15763f722f23e98a29decdfae341b98d53056",
+				},
+				ContentVolume: &torrentv1beta1.ContentVolume{ClaimName: "media-pvc", MountPath: "/media"},
+				Checksums:     &torrentv1beta1.ChecksumSpec{Enabled: true},
+			},
+		}
+		Expect(k8sClient.Create(ctx, torrent)).To(Succeed())
+		torrent.Status.ContentPath = "/media/ubuntu"
+		jobKey = types.NamespacedName{Name: "checksum-job-checksums", Namespace: torrent.Namespace}
+	})
+
+	AfterEach(func() {
+		for _, object := range []client.Object{
+			&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: jobKey.Name, Namespace: jobKey.Namespace}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: jobKey.Name + "-pod", Namespace: jobKey.Namespace}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: jobKey.Name, Namespace: jobKey.Namespace}},
+			torrent,
+		} {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, object))).To(Succeed())
+		}
+	})
+
+	It("should hash the content path on the content volume", func() {
+		published, err := controllerReconciler.reconcileChecksums(ctx, torrent)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(published).To(BeFalse())
+
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, jobKey, job)).To(Succeed())
+		Expect(metav1.IsControlledBy(job, torrent)).To(BeTrue())
+		pod := job.Spec.Template.Spec
+		Expect(pod.Containers).To(HaveLen(1))
+		Expect(pod.Containers[0].Image).To(Equal(defaultChecksumImage))
+		Expect(pod.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "CONTENT_PATH", Value: "/media/ubuntu"}))
+		Expect(pod.Containers[0].VolumeMounts).To(ConsistOf(corev1.VolumeMount{
+			Name: "content", MountPath: "/media", ReadOnly: true,
+		}))
+		Expect(pod.Volumes).To(HaveLen(1))
+		Expect(pod.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("media-pvc"))
+		Expect(pod.Volumes[0].PersistentVolumeClaim.ReadOnly).To(BeTrue())
+	})
+
+	It("should wait for the Job to succeed", func() {
+		_, err := controllerReconciler.reconcileChecksums(ctx, torrent)
+		Expect(err).NotTo(HaveOccurred())
+
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, jobKey, job)).To(Succeed())
+		job.Status.Active = 1
+		Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+
+		published, err := controllerReconciler.reconcileChecksums(ctx, torrent)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(published).To(BeFalse())
+		Expect(torrent.Status.ChecksumConfigMap).To(BeEmpty())
+	})
+
+	It("should publish the checksums logged by the Job and delete it", func() {
+		_, err := controllerReconciler.reconcileChecksums(ctx, torrent)
+		Expect(err).NotTo(HaveOccurred())
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      jobKey.Name + "-pod",
+				Namespace: jobKey.Namespace,
+				Labels:    map[string]string{batchv1.JobNameLabel: jobKey.Name},
+			},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "checksums", Image: defaultChecksumImage}}},
+		}
+		Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+		pod.Status.Phase = corev1.PodSucceeded
+		Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, jobKey, job)).To(Succeed())
+		job.Status.Succeeded = 1
+		Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+
+		published, err := controllerReconciler.reconcileChecksums(ctx, torrent)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(published).To(BeTrue())
+		Expect(torrent.Status.ChecksumConfigMap).To(Equal(jobKey.Name))
+
+		configMap := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, jobKey, configMap)).To(Succeed())
+		// The fake clientset logs "fake logs" for every pod
+		Expect(configMap.Data).To(HaveKeyWithValue(ChecksumConfigMapKey, "fake logs"))
+		Expect(metav1.IsControlledBy(configMap, torrent)).To(BeTrue())
+
+		err = k8sClient.Get(ctx, jobKey, &batchv1.Job{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should require a content volume", func() {
+		torrent.Spec.ContentVolume = nil
+		_, err := controllerReconciler.reconcileChecksums(ctx, torrent)
+		Expect(err).To(MatchError(ContainSubstring("contentVolume")))
+	})
+})
+
+var _ = Describe("Failed checksum Jobs", func() {
+	const magnetHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var controllerReconciler *TorrentReconciler
+	var key, jobKey types.NamespacedName
+
+	reconcileTorrent := func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getTorrent := func() *torrentv1beta1.Torrent {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent
+	}
+
+	// setJobStatus sets the pods of the checksum Job run so far
+	setJobStatus := func(active, succeeded, failed int32) {
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, jobKey, job)).To(Succeed())
+		job.Status.Active, job.Status.Succeeded, job.Status.Failed = active, succeeded, failed
+		Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+	}
+
+	expectNoJob := func() {
+		err := k8sClient.Get(ctx, jobKey, &batchv1.Job{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		key = types.NamespacedName{Name: "checksums", Namespace: "default"}
+		jobKey = types.NamespacedName{Name: "checksums-checksums", Namespace: key.Namespace}
+		controllerReconciler = &TorrentReconciler{
+			Client:    k8sClient,
+			Scheme:    k8sClient.Scheme(),
+			Clients:   poolOf(qb.client()),
+			Clientset: kubefake.NewClientset(),
+			Recorder:  record.NewFakeRecorder(20),
+			Config:    &OperatorConfig{},
+		}
+
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source:        torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + magnetHash},
+				ContentVolume: &torrentv1beta1.ContentVolume{ClaimName: "media-pvc", MountPath: "/downloads"},
+				Checksums:     &torrentv1beta1.ChecksumSpec{Enabled: true},
+			},
+		})).To(Succeed())
+		reconcileTorrent()
+		reconcileTorrent()
+		expectNoJob()
+
+		By("computing the checksums of the complete torrent in a Job")
+		qb.setCompleted(magnetHash, 1<<20)
+		reconcileTorrent()
+		Expect(k8sClient.Get(ctx, jobKey, &batchv1.Job{})).To(Succeed())
+		Expect(getTorrent().Status.ChecksumConfigMap).To(BeEmpty())
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: jobKey.Name, Namespace: jobKey.Namespace},
+		}))).To(Succeed())
+		torrent := getTorrent()
+		controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+	})
+
+	It("should wait for the retries of a failed pod", func() {
+		setJobStatus(0, 0, 1)
+
+		reconcileTorrent()
+		torrent := getTorrent()
+		Expect(meta.IsStatusConditionTrue(torrent.Status.Conditions, TypeDegradedTorrent)).To(BeFalse())
+		Expect(torrent.Status.ChecksumConfigMap).To(BeEmpty())
+		Expect(k8sClient.Get(ctx, jobKey, &batchv1.Job{})).To(Succeed())
+	})
+
+	It("should compute the checksums again once the Job failed", func() {
+		setJobStatus(0, 0, 3)
+
+		reconcileTorrent()
+		condition := meta.FindStatusCondition(getTorrent().Status.Conditions, TypeDegradedTorrent)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("FailedToPublishChecksums"))
+		expectNoJob()
+
+		By("creating the Job again at the next reconcile")
+		reconcileTorrent()
+		Expect(k8sClient.Get(ctx, jobKey, &batchv1.Job{})).To(Succeed())
+	})
+})
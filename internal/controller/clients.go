@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/clientpool"
+	"github.com/guidonguido/qbittorrent-operator/internal/downloader"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// serverNameOr returns the name of the QBittorrentServer, the default one if empty
+func serverNameOr(name string) string {
+	if name == "" {
+		return DefaultServerName
+	}
+	return name
+}
+
+// serverName returns the name of the QBittorrentServer the Torrent is
+// downloaded on, the one of the operator when spec.serverName is empty
+func (r *TorrentReconciler) serverName(torrent *torrentv1beta1.Torrent) string {
+	if torrent.Spec.ServerName != "" {
+		return torrent.Spec.ServerName
+	}
+	return serverNameOr(r.ServerName)
+}
+
+// qbt returns the qBittorrent client of the server of the Torrent. It is nil
+// when the pool has no client for the server, which login reports before
+// any call is made.
+func (r *TorrentReconciler) qbt(torrent *torrentv1beta1.Torrent) *qbittorrent.Client {
+	return r.Clients.Get(r.serverName(torrent))
+}
+
+// backend returns the server of the Torrent as a downloader.Backend, for the
+// calls any download client supports
+func (r *TorrentReconciler) backend(torrent *torrentv1beta1.Torrent) downloader.Backend {
+	return qbittorrent.NewBackend(r.qbt(torrent))
+}
+
+// login logs the client of the server of the Torrent in, adding it to the
+// pool first when missing
+func (r *TorrentReconciler) login(ctx context.Context, torrent *torrentv1beta1.Torrent) error {
+	name := r.serverName(torrent)
+	if r.Clients.Get(name) == nil && r.Connect != nil {
+		if err := r.Connect(ctx, name); err != nil {
+			return err
+		}
+	}
+	return login(ctx, r.Clients, name)
+}
+
+// qbt returns the qBittorrent client of the server the torrents are published on
+func (r *TorrentPublishReconciler) qbt() *qbittorrent.Client {
+	return r.Clients.Get(serverNameOr(r.ServerName))
+}
+
+// qbt returns the qBittorrent client of the server
+func (r *QBittorrentServerReconciler) qbt(server *torrentv1beta1.QBittorrentServer) *qbittorrent.Client {
+	return r.Clients.Get(server.Name)
+}
+
+// login logs the client of the server in, again if its previous login
+// failed. A failed login is only logged, the calls made next fail and are
+// retried as when qBittorrent cannot be reached. A server without a client
+// in the pool is an error, as none of its calls can be made.
+func login(ctx context.Context, clients *clientpool.Pool, name string) error {
+	if clients.Get(name) == nil {
+		return fmt.Errorf("no qBittorrent client for the QBittorrentServer %s", name)
+	}
+	if _, err := clients.Client(ctx, name); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to log in to qBittorrent", "Server", name)
+	}
+	return nil
+}
+
+// setRateLimit sets the rate limit of the calls to every qBittorrent
+func (r *QBittorrentOperatorConfigReconciler) setRateLimit(rateLimit, burst int) {
+	for _, name := range r.Clients.Names() {
+		r.Clients.Get(name).SetRateLimit(rateLimit, burst)
+	}
+}
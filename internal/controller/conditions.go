@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// setCondition sets a condition in the conditions. Its last transition time
+// is only moved when its status or reason change, so that reconciling an
+// unchanged resource does not rewrite its conditions, which GitOps tools
+// would report as drift.
+func setCondition(conditions *[]metav1.Condition, condition metav1.Condition) {
+	existing := meta.FindStatusCondition(*conditions, condition.Type)
+	if existing == nil {
+		if condition.LastTransitionTime.IsZero() {
+			condition.LastTransitionTime = metav1.Now()
+		}
+		*conditions = append(*conditions, condition)
+		return
+	}
+
+	if existing.Status == condition.Status && existing.Reason == condition.Reason {
+		condition.LastTransitionTime = existing.LastTransitionTime
+	} else if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = metav1.Now()
+	}
+	*existing = condition
+}
+
+// setHealthConditions sets both the Available and Degraded conditions, one
+// True and the other False, so that both types are always present
+func setHealthConditions(conditions *[]metav1.Condition, availableType, degradedType string,
+	available bool, reason, message string) {
+	availableStatus, degradedStatus := metav1.ConditionTrue, metav1.ConditionFalse
+	if !available {
+		availableStatus, degradedStatus = metav1.ConditionFalse, metav1.ConditionTrue
+	}
+	setCondition(conditions, metav1.Condition{
+		Type:    availableType,
+		Status:  availableStatus,
+		Reason:  reason,
+		Message: message,
+	})
+	setCondition(conditions, metav1.Condition{
+		Type:    degradedType,
+		Status:  degradedStatus,
+		Reason:  reason,
+		Message: message,
+	})
+}
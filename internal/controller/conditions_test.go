@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Conditions", func() {
+	var conditions []metav1.Condition
+	past := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+
+	BeforeEach(func() {
+		conditions = []metav1.Condition{{
+			Type:               TypeAvailableTorrent,
+			Status:             metav1.ConditionTrue,
+			Reason:             "TorrentActive",
+			Message:            "Torrent is active on qBittorrent",
+			LastTransitionTime: past,
+		}}
+	})
+
+	It("should keep the transition time while the status and reason are unchanged", func() {
+		setCondition(&conditions, metav1.Condition{
+			Type:    TypeAvailableTorrent,
+			Status:  metav1.ConditionTrue,
+			Reason:  "TorrentActive",
+			Message: "Torrent is still active on qBittorrent",
+		})
+		Expect(conditions).To(HaveLen(1))
+		Expect(conditions[0].LastTransitionTime).To(Equal(past))
+		Expect(conditions[0].Message).To(Equal("Torrent is still active on qBittorrent"))
+	})
+
+	It("should move the transition time when the reason changes", func() {
+		setCondition(&conditions, metav1.Condition{
+			Type:   TypeAvailableTorrent,
+			Status: metav1.ConditionTrue,
+			Reason: "TorrentAdded",
+		})
+		Expect(conditions[0].LastTransitionTime.After(past.Time)).To(BeTrue())
+	})
+
+	It("should keep both health conditions present", func() {
+		setHealthConditions(&conditions, TypeAvailableTorrent, TypeDegradedTorrent,
+			false, "FailedToGetTorrentInfo", "qBittorrent cannot be reached")
+		Expect(conditions).To(HaveLen(2))
+		Expect(meta.IsStatusConditionFalse(conditions, TypeAvailableTorrent)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(conditions, TypeDegradedTorrent)).To(BeTrue())
+
+		setHealthConditions(&conditions, TypeAvailableTorrent, TypeDegradedTorrent,
+			true, "TorrentActive", "Torrent is active on qBittorrent")
+		Expect(conditions).To(HaveLen(2))
+		Expect(meta.IsStatusConditionTrue(conditions, TypeAvailableTorrent)).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(conditions, TypeDegradedTorrent)).To(BeTrue())
+	})
+})
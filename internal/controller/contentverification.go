@@ -0,0 +1,202 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// Fail unless CONTENT_PATH exists and its files hold at least EXPECTED_SIZE
+// bytes. Files left out of the download may be partially written, so the
+// content may be larger than the size of the selected files. The reason is
+// the termination message of the pod.
+const verifyContentScript = `if [ ! -e "$CONTENT_PATH" ]; then
+  echo "$CONTENT_PATH is missing" | tee /dev/termination-log
+  exit 1
+fi
+size=$(find "$CONTENT_PATH" -type f -exec stat -c %s {} + | awk '{s+=$1} END {print s+0}')
+if [ "$size" -lt "$EXPECTED_SIZE" ]; then
+  echo "$CONTENT_PATH holds $size bytes, expected $EXPECTED_SIZE" | tee /dev/termination-log
+  exit 1
+fi
+`
+
+// contentVerificationJobName returns the name of the content verification Job for a torrent
+func contentVerificationJobName(torrent *torrentv1beta1.Torrent) string {
+	return truncateName(torrent.Name, "-verify")
+}
+
+// reconcileContentVerification verifies the content of a complete torrent is
+// on the content volume, in a Job. It returns true once verified, or while
+// the torrent is incomplete, as there is nothing to verify yet. A failed Job
+// is deleted, so that the content is verified again at the next reconcile.
+func (r *TorrentReconciler) reconcileContentVerification(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	if !isTorrentComplete(qbTorrent) {
+		// The content is verified again once downloaded, e.g. after a recheck
+		torrent.Status.ContentVerifiedTime = nil
+		return true, nil
+	}
+	if torrent.Status.ContentVerifiedTime != nil {
+		return true, nil
+	}
+	if torrent.Spec.ContentVolume == nil {
+		return false, fmt.Errorf("content verification requires spec.contentVolume to be set")
+	}
+
+	// Step 1: Get or create the verification Job
+	job := &batchv1.Job{}
+	jobKey := types.NamespacedName{Name: contentVerificationJobName(torrent), Namespace: torrent.Namespace}
+	if err := r.Get(ctx, jobKey, job); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get content verification job: %w", err)
+		}
+
+		job = r.contentVerificationJob(torrent, qbTorrent)
+		if err := controllerutil.SetControllerReference(torrent, job, r.Scheme); err != nil {
+			return false, fmt.Errorf("failed to set owner reference on content verification job: %w", err)
+		}
+
+		logger.Info("Creating content verification Job", "Job", job.Name)
+		if err := r.Create(ctx, job); err != nil {
+			return false, fmt.Errorf("failed to create content verification job: %w", err)
+		}
+		return false, nil
+	}
+
+	// Step 2: Wait for the Job to finish
+	if job.Status.Failed > 0 && job.Status.Succeeded == 0 && job.Status.Active == 0 {
+		reason := r.jobFailureMessage(ctx, job)
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+			!apierrors.IsNotFound(err) {
+			logger.Error(err, "Failed to delete content verification Job", "Job", job.Name)
+		}
+		return false, fmt.Errorf("content of the torrent not found on %s: %s",
+			torrent.Spec.ContentVolume.ClaimName, reason)
+	}
+	if job.Status.Succeeded == 0 {
+		logger.V(1).Info("Content verification Job still running", "Job", job.Name)
+		return false, nil
+	}
+
+	// Step 3: Record the verification, the Job is not needed anymore
+	logger.Info("Verified the content of the torrent", "Job", job.Name)
+	now := metav1.Now()
+	torrent.Status.ContentVerifiedTime = &now
+	if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+		!apierrors.IsNotFound(err) {
+		logger.Error(err, "Failed to delete content verification Job", "Job", job.Name)
+	}
+	return true, nil
+}
+
+// jobFailureMessage returns the termination message of the failed pod of a
+// Job, or a generic reason when there is none
+func (r *TorrentReconciler) jobFailureMessage(ctx context.Context, job *batchv1.Job) string {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods,
+		client.InNamespace(job.Namespace),
+		client.MatchingLabels{batchv1.JobNameLabel: job.Name},
+	); err == nil {
+		for _, pod := range pods.Items {
+			for _, status := range pod.Status.ContainerStatuses {
+				if terminated := status.State.Terminated; terminated != nil && terminated.Message != "" {
+					return strings.TrimSpace(terminated.Message)
+				}
+			}
+		}
+	}
+	return fmt.Sprintf("job %s failed", job.Name)
+}
+
+// contentVerificationJob builds the Job verifying the torrent content
+func (r *TorrentReconciler) contentVerificationJob(torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) *batchv1.Job {
+	image := torrent.Spec.ContentVerification.Image
+	if image == "" {
+		image = defaultChecksumImage
+	}
+
+	mountPath := torrent.Spec.ContentVolume.MountPath
+	if mountPath == "" {
+		mountPath = defaultMountPath
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      contentVerificationJobName(torrent),
+			Namespace: torrent.Namespace,
+			Labels:    managedLabels(torrent),
+		},
+		Spec: batchv1.JobSpec{
+			// The content is there or not, retrying would not tell otherwise
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: managedLabels(torrent),
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:    "verify",
+						Image:   image,
+						Command: []string{"sh", "-c", verifyContentScript},
+						Env: []corev1.EnvVar{{
+							Name:  "CONTENT_PATH",
+							Value: qbTorrent.ContentPath,
+						}, {
+							Name:  "EXPECTED_SIZE",
+							Value: strconv.FormatInt(qbTorrent.TotalSize, 10),
+						}},
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      "content",
+							MountPath: mountPath,
+							ReadOnly:  true,
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "content",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: torrent.Spec.ContentVolume.ClaimName,
+								ReadOnly:  true,
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Torrent content verification", func() {
+	const magnetHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var controllerReconciler *TorrentReconciler
+	var key, jobKey types.NamespacedName
+
+	reconcileTorrent := func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getTorrent := func() *torrentv1beta1.Torrent {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent
+	}
+
+	// finishJob sets the outcome of the verification Job
+	finishJob := func(succeeded bool) {
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, jobKey, job)).To(Succeed())
+		if succeeded {
+			job.Status.Succeeded = 1
+		} else {
+			job.Status.Failed = 1
+		}
+		Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+	}
+
+	expectNoJob := func() {
+		err := k8sClient.Get(ctx, jobKey, &batchv1.Job{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		key = types.NamespacedName{Name: "content-verification", Namespace: "default"}
+		jobKey = types.NamespacedName{Name: "content-verification-verify", Namespace: key.Namespace}
+		controllerReconciler = &TorrentReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Clients:  poolOf(qb.client()),
+			Recorder: record.NewFakeRecorder(20),
+			Config:   &OperatorConfig{},
+		}
+
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source:              torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + magnetHash},
+				ContentVolume:       &torrentv1beta1.ContentVolume{ClaimName: "media-pvc", MountPath: "/downloads"},
+				ContentVerification: &torrentv1beta1.ContentVerificationSpec{Enabled: true},
+			},
+		})).To(Succeed())
+		reconcileTorrent()
+		reconcileTorrent()
+		qb.setState(magnetHash, "downloading")
+		reconcileTorrent()
+		Expect(meta.IsStatusConditionTrue(getTorrent().Status.Conditions, TypeAvailableTorrent)).To(BeTrue())
+		expectNoJob()
+
+		By("holding the complete torrent back while its content is verified")
+		qb.setCompleted(magnetHash, 1<<20)
+		reconcileTorrent()
+		torrent := getTorrent()
+		condition := meta.FindStatusCondition(torrent.Status.Conditions, TypeAvailableTorrent)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("VerifyingContent"))
+		Expect(meta.IsStatusConditionFalse(torrent.Status.Conditions, TypeDegradedTorrent)).To(BeTrue())
+		Expect(torrent.Status.Phase).To(Equal(torrentv1beta1.TorrentPhaseDownloading))
+
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, jobKey, job)).To(Succeed())
+		Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+			HaveField("Value", "1048576")))
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: jobKey.Name, Namespace: jobKey.Namespace},
+		}))).To(Succeed())
+		torrent := getTorrent()
+		controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+	})
+
+	It("should make the torrent Available once its content is verified", func() {
+		finishJob(true)
+		reconcileTorrent()
+		torrent := getTorrent()
+		Expect(torrent.Status.ContentVerifiedTime).NotTo(BeNil())
+		Expect(meta.IsStatusConditionTrue(torrent.Status.Conditions, TypeAvailableTorrent)).To(BeTrue())
+		Expect(torrent.Status.Phase).To(Equal(torrentv1beta1.TorrentPhaseSeeding))
+		expectNoJob()
+
+		By("verifying the content again once downloaded again")
+		qb.setState(magnetHash, "checkingUP")
+		reconcileTorrent()
+		Expect(getTorrent().Status.ContentVerifiedTime).To(BeNil())
+	})
+
+	It("should degrade the torrent whose content is not on the volume", func() {
+		finishJob(false)
+		reconcileTorrent()
+		torrent := getTorrent()
+		Expect(torrent.Status.ContentVerifiedTime).To(BeNil())
+		condition := meta.FindStatusCondition(torrent.Status.Conditions, TypeDegradedTorrent)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("ContentVerificationFailed"))
+		Expect(torrent.Status.Phase).To(Equal(torrentv1beta1.TorrentPhaseError))
+
+		By("verifying it again at the next reconcile")
+		expectNoJob()
+		reconcileTorrent()
+		Expect(k8sClient.Get(ctx, jobKey, &batchv1.Job{})).To(Succeed())
+	})
+})
@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// crossSeedTag returns the tag identifying a cross-seeded torrent in qBittorrent.
+// Sources added from a .torrent URL have no hash known upfront, so every
+// cross-seed is looked up by tag instead.
+func crossSeedTag(torrent *torrentv1beta1.Torrent, name string) string {
+	return fmt.Sprintf("cross-seed/%s/%s/%s", torrent.Namespace, torrent.Name, name)
+}
+
+// reconcileCrossSeeds adds the cross-seed sources of a completed torrent to
+// qBittorrent, removes the ones dropped from spec and refreshes their status.
+func (r *TorrentReconciler) reconcileCrossSeeds(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) error {
+	logger := log.FromContext(ctx)
+
+	// Step 1: Remove the cross-seeds no longer in spec.
+	// The content is shared with the main torrent so files are kept.
+	wanted := map[string]bool{}
+	for _, source := range torrent.Spec.CrossSeed {
+		wanted[source.Name] = true
+	}
+	for _, crossSeed := range torrent.Status.CrossSeeds {
+		if wanted[crossSeed.Name] {
+			continue
+		}
+		logger.Info("Removing cross-seed from qBittorrent", "CrossSeed", crossSeed.Name)
+		if err := r.removeCrossSeed(ctx, torrent, crossSeed); err != nil {
+			return err
+		}
+	}
+
+	// Step 2: Add the missing cross-seeds and collect their state
+	statuses := make([]torrentv1beta1.CrossSeedStatus, 0, len(torrent.Spec.CrossSeed))
+	for _, source := range torrent.Spec.CrossSeed {
+		status := torrentv1beta1.CrossSeedStatus{Name: source.Name}
+		tag := crossSeedTag(torrent, source.Name)
+
+		found, err := r.qbt(torrent).GetTorrentsInfoByTag(ctx, tag)
+		if err != nil {
+			return fmt.Errorf("failed to get cross-seed %s: %w", source.Name, err)
+		}
+
+		if len(found) == 0 && r.qbt(torrent).InRestartGracePeriod() {
+			// qBittorrent may still be loading the cross-seed after a restart
+			logger.Info("Cross-seed not found in qBittorrent after a restart, waiting for it to be loaded", "CrossSeed", source.Name)
+		} else if len(found) == 0 {
+			uri := source.MagnetURI
+			if uri == "" {
+				uri = source.TorrentURL
+			}
+
+			logger.Info("Adding cross-seed to qBittorrent", "CrossSeed", source.Name, "SavePath", qbTorrent.SavePath)
+			if err := r.qbt(torrent).AddTorrentWithOptions(ctx, uri, qbittorrent.AddTorrentOptions{
+				SavePath:     qbTorrent.SavePath,
+				Tags:         []string{tag},
+				SkipChecking: true,
+			}); err != nil {
+				return fmt.Errorf("failed to add cross-seed %s: %w", source.Name, err)
+			}
+		} else {
+			status.Hash = found[0].Hash
+			status.State = torrentv1beta1.TorrentState(found[0].State)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	torrent.Status.CrossSeeds = statuses
+	return nil
+}
+
+// deleteCrossSeeds removes every cross-seed of the torrent from qBittorrent, keeping the files
+func (r *TorrentReconciler) deleteCrossSeeds(ctx context.Context, torrent *torrentv1beta1.Torrent) error {
+	for _, crossSeed := range torrent.Status.CrossSeeds {
+		if err := r.removeCrossSeed(ctx, torrent, crossSeed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeCrossSeed removes the cross-seed from qBittorrent, keeping the files.
+// A cross-seed added but whose hash is not recorded in status yet is found by
+// its tag, so that it is not left behind.
+func (r *TorrentReconciler) removeCrossSeed(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	crossSeed torrentv1beta1.CrossSeedStatus) error {
+	hashes := []string{crossSeed.Hash}
+	if crossSeed.Hash == "" {
+		found, err := r.qbt(torrent).GetTorrentsInfoByTag(ctx, crossSeedTag(torrent, crossSeed.Name))
+		if err != nil {
+			return fmt.Errorf("failed to get cross-seed %s: %w", crossSeed.Name, err)
+		}
+		hashes = hashes[:0]
+		for _, info := range found {
+			hashes = append(hashes, info.Hash)
+		}
+	}
+	for _, hash := range hashes {
+		if err := r.backend(torrent).Remove(ctx, hash, false); err != nil {
+			return fmt.Errorf("failed to remove cross-seed %s: %w", crossSeed.Name, err)
+		}
+	}
+	return nil
+}
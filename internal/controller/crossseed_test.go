@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// crossSeedWebUI answers the calls made for cross-seeds: torrents are listed
+// by tag, and the adds and deletes are recorded
+type crossSeedWebUI struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	tagged  map[string][]qbittorrent.TorrentInfo
+	added   []map[string]string
+	deleted []string
+}
+
+func newCrossSeedWebUI() *crossSeedWebUI {
+	webUI := &crossSeedWebUI{tagged: map[string][]qbittorrent.TorrentInfo{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/torrents/info", func(w http.ResponseWriter, req *http.Request) {
+		webUI.mu.Lock()
+		defer webUI.mu.Unlock()
+		torrents := webUI.tagged[req.URL.Query().Get("tag")]
+		if torrents == nil {
+			torrents = []qbittorrent.TorrentInfo{}
+		}
+		_ = json.NewEncoder(w).Encode(torrents)
+	})
+	mux.HandleFunc("/api/v2/torrents/add", func(w http.ResponseWriter, req *http.Request) {
+		webUI.mu.Lock()
+		defer webUI.mu.Unlock()
+		if err := req.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fields := map[string]string{}
+		for name, values := range req.MultipartForm.Value {
+			fields[name] = values[0]
+		}
+		webUI.added = append(webUI.added, fields)
+	})
+	mux.HandleFunc("/api/v2/torrents/delete", func(_ http.ResponseWriter, req *http.Request) {
+		webUI.mu.Lock()
+		defer webUI.mu.Unlock()
+		webUI.deleted = append(webUI.deleted, req.FormValue("hashes")+" deleteFiles="+req.FormValue("deleteFiles"))
+	})
+	webUI.Server = httptest.NewServer(mux)
+	return webUI
+}
+
+var _ = Describe("Cross-seeds", func() {
+	const crossSeedHash = "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"
+
+	ctx := context.Background()
+
+	var webUI *crossSeedWebUI
+	var controllerReconciler *TorrentReconciler
+	var torrent *torrentv1beta1.Torrent
+	qbTorrent := &qbittorrent.TorrentInfo{SavePath: "/downloads/linux"}
+
+	BeforeEach(func() {
+		webUI = newCrossSeedWebUI()
+		controllerReconciler = &TorrentReconciler{Clients: poolOf(qbittorrent.NewClient(webUI.URL))}
+		torrent = &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: "ubuntu", Namespace: "default"},
+			Spec: torrentv1beta1.TorrentSpec{
+				CrossSeed: []torrentv1beta1.CrossSeedSource{{
+					Name: "other-tracker", MagnetURI: "magnet:?xt=urn:btih:" + crossSeedHash,
+				}},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		webUI.Close()
+	})
+
+	It("should add the cross-seeds against the save path of the torrent", func() {
+		Expect(controllerReconciler.reconcileCrossSeeds(ctx, torrent, qbTorrent)).To(Succeed())
+		Expect(webUI.added).To(ConsistOf(map[string]string{
+			"urls":          "magnet:?xt=urn:btih:" + crossSeedHash,
+			"savepath":      "/downloads/linux",
+			"tags":          "cross-seed/default/ubuntu/other-tracker",
+			"skip_checking": "true",
+		}))
+		Expect(torrent.Status.CrossSeeds).To(ConsistOf(torrentv1beta1.CrossSeedStatus{Name: "other-tracker"}))
+	})
+
+	It("should record the hash and state of the cross-seeds found by tag", func() {
+		webUI.tagged["cross-seed/default/ubuntu/other-tracker"] = []qbittorrent.TorrentInfo{
+			{Hash: crossSeedHash, State: "stalledUP"},
+		}
+		Expect(controllerReconciler.reconcileCrossSeeds(ctx, torrent, qbTorrent)).To(Succeed())
+		Expect(webUI.added).To(BeEmpty())
+		Expect(torrent.Status.CrossSeeds).To(ConsistOf(torrentv1beta1.CrossSeedStatus{
+			Name: "other-tracker", Hash: crossSeedHash, State: "stalledUP",
+		}))
+	})
+
+	It("should remove the cross-seeds dropped from spec, keeping the files", func() {
+		torrent.Spec.CrossSeed = nil
+		torrent.Status.CrossSeeds = []torrentv1beta1.CrossSeedStatus{{Name: "other-tracker", Hash: crossSeedHash}}
+		Expect(controllerReconciler.reconcileCrossSeeds(ctx, torrent, qbTorrent)).To(Succeed())
+		Expect(webUI.deleted).To(ConsistOf(crossSeedHash + " deleteFiles=false"))
+		Expect(torrent.Status.CrossSeeds).To(BeEmpty())
+	})
+
+	It("should remove the cross-seeds dropped before their hash is recorded", func() {
+		webUI.tagged["cross-seed/default/ubuntu/other-tracker"] = []qbittorrent.TorrentInfo{{Hash: crossSeedHash}}
+		torrent.Spec.CrossSeed = nil
+		torrent.Status.CrossSeeds = []torrentv1beta1.CrossSeedStatus{{Name: "other-tracker"}}
+		Expect(controllerReconciler.reconcileCrossSeeds(ctx, torrent, qbTorrent)).To(Succeed())
+		Expect(webUI.deleted).To(ConsistOf(crossSeedHash + " deleteFiles=false"))
+		Expect(torrent.Status.CrossSeeds).To(BeEmpty())
+	})
+
+	It("should remove every cross-seed with the torrent, keeping the files", func() {
+		torrent.Status.CrossSeeds = []torrentv1beta1.CrossSeedStatus{{Name: "other-tracker", Hash: crossSeedHash}}
+		Expect(controllerReconciler.deleteCrossSeeds(ctx, torrent)).To(Succeed())
+		Expect(webUI.deleted).To(ConsistOf(crossSeedHash + " deleteFiles=false"))
+	})
+
+	It("should remove the cross-seeds without a recorded hash with the torrent", func() {
+		webUI.tagged["cross-seed/default/ubuntu/other-tracker"] = []qbittorrent.TorrentInfo{{Hash: crossSeedHash}}
+		torrent.Status.CrossSeeds = []torrentv1beta1.CrossSeedStatus{{Name: "other-tracker"}}
+		Expect(controllerReconciler.deleteCrossSeeds(ctx, torrent)).To(Succeed())
+		Expect(webUI.deleted).To(ConsistOf(crossSeedHash + " deleteFiles=false"))
+	})
+})
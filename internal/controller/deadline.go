@@ -0,0 +1,183 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// TypeDeadlineExceededTorrent is set to True once the torrent is incomplete
+// past its spec.completionDeadline, and stays True if it completes late
+const TypeDeadlineExceededTorrent = "DeadlineExceeded"
+
+// deadlineEscalated reports whether the torrent is escalated by
+// spec.deadlineEscalation, from the escalation until it is complete
+func deadlineEscalated(torrent *torrentv1beta1.Torrent) bool {
+	deadline := torrent.Status.Deadline
+	return torrent.Spec.DeadlineEscalation != nil && deadline != nil &&
+		deadline.EscalatedTime != nil && deadline.CompletionTime == nil
+}
+
+// limitsLifted reports whether the transfer limits of the spec are lifted
+// by the deadline escalation
+func limitsLifted(torrent *torrentv1beta1.Torrent) bool {
+	return deadlineEscalated(torrent) && torrent.Spec.DeadlineEscalation.RemoveLimits
+}
+
+// reconcileDeadline reports the torrent against its completion deadline,
+// escalating it shortly before the deadline as set by spec.deadlineEscalation
+// and restoring the spec once it is complete. It returns whether the status
+// changed.
+func (r *TorrentReconciler) reconcileDeadline(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) (bool, error) {
+	logger := log.FromContext(ctx)
+	previous := torrent.Status.Deadline
+	escalated := previous != nil && previous.EscalatedTime != nil && previous.CompletionTime == nil
+
+	if torrent.Spec.CompletionDeadline == nil {
+		if previous == nil {
+			return false, nil
+		}
+		torrent.Status.Deadline = nil
+		if escalated {
+			if err := r.restoreLimits(ctx, torrent, qbTorrent); err != nil {
+				torrent.Status.Deadline = previous
+				return false, err
+			}
+		}
+		meta.RemoveStatusCondition(&torrent.Status.Conditions, TypeDeadlineExceededTorrent)
+		return true, nil
+	}
+
+	deadline := previous.DeepCopy()
+	if deadline == nil {
+		deadline = &torrentv1beta1.DeadlineStatus{}
+	}
+	deadline.DeadlineTime = metav1.NewTime(torrent.CreationTimestamp.Add(torrent.Spec.CompletionDeadline.Duration))
+	escalation := torrent.Spec.DeadlineEscalation
+	now := metav1.Now()
+
+	switch {
+	case deadline.CompletionTime != nil:
+		// Reported once, a recheck does not make the torrent late
+	case isTorrentComplete(qbTorrent):
+		deadline.CompletionTime = &now
+		if escalated {
+			logger.Info("The escalated torrent is complete, restoring its priority and limits")
+		}
+	case deadline.EscalatedTime != nil && escalation == nil:
+		// The escalation was removed from the spec
+		deadline.EscalatedTime = nil
+	case deadline.EscalatedTime == nil && escalation != nil &&
+		!now.Time.Before(deadline.DeadlineTime.Add(-escalation.Before.Duration)):
+		var actions []string
+		if escalation.BoostPriority {
+			actions = append(actions, "raising its priority")
+		}
+		if escalation.RemoveLimits {
+			actions = append(actions, "lifting its limits")
+		}
+		// The limits of a torrent throttled by the network pressure are lifted once restored
+		if escalation.RemoveLimits && torrent.Status.Throttle == nil {
+			if err := r.qbt(torrent).SetDownloadLimit(ctx, qbTorrent.Hash, 0); err != nil {
+				return false, fmt.Errorf("failed to lift the download limit: %w", err)
+			}
+			if err := r.qbt(torrent).SetUploadLimit(ctx, qbTorrent.Hash, 0); err != nil {
+				return false, fmt.Errorf("failed to lift the upload limit: %w", err)
+			}
+		}
+		message := "Torrent is incomplete close to its completion deadline of " +
+			deadline.DeadlineTime.UTC().Format(time.RFC3339)
+		if len(actions) > 0 {
+			message += ", " + strings.Join(actions, " and ")
+		}
+		logger.Info("Escalating the torrent before its completion deadline", "Deadline", deadline.DeadlineTime)
+		r.recordEvent(torrent, corev1.EventTypeWarning, "DeadlineEscalated", message)
+		deadline.EscalatedTime = &now
+	}
+
+	torrent.Status.Deadline = deadline
+	if escalated && !deadlineEscalated(torrent) {
+		if err := r.restoreLimits(ctx, torrent, qbTorrent); err != nil {
+			torrent.Status.Deadline = previous
+			return false, err
+		}
+	}
+
+	condition := deadlineCondition(deadline, now)
+	if condition.Status == metav1.ConditionTrue &&
+		!meta.IsStatusConditionTrue(torrent.Status.Conditions, TypeDeadlineExceededTorrent) {
+		r.recordEvent(torrent, corev1.EventTypeWarning, TypeDeadlineExceededTorrent, condition.Message)
+	}
+	setCondition(&torrent.Status.Conditions, condition)
+	return !equality.Semantic.DeepEqual(previous, deadline), nil
+}
+
+// deadlineCondition returns the DeadlineExceeded condition of the torrent
+func deadlineCondition(deadline *torrentv1beta1.DeadlineStatus, now metav1.Time) metav1.Condition {
+	formatted := deadline.DeadlineTime.UTC().Format(time.RFC3339)
+	condition := metav1.Condition{Type: TypeDeadlineExceededTorrent}
+	switch {
+	case deadline.CompletionTime != nil && deadline.CompletionTime.After(deadline.DeadlineTime.Time):
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "CompletedAfterDeadline"
+		condition.Message = "Torrent completed after its completion deadline of " + formatted
+	case deadline.CompletionTime != nil:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "CompletedWithinDeadline"
+		condition.Message = "Torrent completed before its completion deadline of " + formatted
+	case now.After(deadline.DeadlineTime.Time):
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "DeadlineExceeded"
+		condition.Message = "Torrent is incomplete past its completion deadline of " + formatted
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "WithinDeadline"
+		condition.Message = "Torrent has to be complete by " + formatted
+	}
+	return condition
+}
+
+// restoreLimits sets the limits the torrent runs with back on qBittorrent,
+// see transferLimits. The limits of a torrent throttled by the network
+// pressure are restored with it.
+func (r *TorrentReconciler) restoreLimits(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) error {
+	if torrent.Status.Throttle != nil {
+		return nil
+	}
+	download, upload := r.transferLimits(torrent)
+	if err := r.qbt(torrent).SetDownloadLimit(ctx, qbTorrent.Hash, download); err != nil {
+		return fmt.Errorf("failed to restore the download limit: %w", err)
+	}
+	if err := r.qbt(torrent).SetUploadLimit(ctx, qbTorrent.Hash, upload); err != nil {
+		return fmt.Errorf("failed to restore the upload limit: %w", err)
+	}
+	return nil
+}
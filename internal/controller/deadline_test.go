@@ -0,0 +1,176 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Torrent completion deadline", func() {
+	const magnetHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var controllerReconciler *TorrentReconciler
+	var recorder *record.FakeRecorder
+	var key types.NamespacedName
+
+	// createTorrent creates a torrent created the given time ago, and adds it
+	createTorrent := func(age time.Duration, escalation *torrentv1beta1.DeadlineEscalation) {
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              key.Name,
+				Namespace:         key.Namespace,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+			},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source:             torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + magnetHash},
+				Limits:             &torrentv1beta1.TorrentLimits{DownloadLimit: ptr.To[int64](1024), UploadLimit: ptr.To[int64](512)},
+				DriftPolicy:        &torrentv1beta1.DriftPolicy{Limits: torrentv1beta1.DriftActionEnforce},
+				CompletionDeadline: &metav1.Duration{Duration: 6 * time.Hour},
+				DeadlineEscalation: escalation,
+			},
+		})).To(Succeed())
+	}
+
+	reconcileTorrent := func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getTorrent := func() *torrentv1beta1.Torrent {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent
+	}
+
+	deadlineCondition := func() *metav1.Condition {
+		return meta.FindStatusCondition(getTorrent().Status.Conditions, TypeDeadlineExceededTorrent)
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		key = types.NamespacedName{Name: "completion-deadline", Namespace: "default"}
+		recorder = record.NewFakeRecorder(20)
+		controllerReconciler = &TorrentReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Clients:  poolOf(qb.client()),
+			Recorder: recorder,
+			Config:   &OperatorConfig{},
+		}
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		torrent := getTorrent()
+		controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+	})
+
+	It("should report a torrent completing within its deadline", func() {
+		createTorrent(time.Hour, nil)
+		reconcileTorrent()
+		reconcileTorrent()
+		qb.setState(magnetHash, "downloading")
+		reconcileTorrent()
+
+		torrent := getTorrent()
+		Expect(torrent.Status.Deadline).NotTo(BeNil())
+		Expect(torrent.Status.Deadline.DeadlineTime.Time).To(BeTemporally("~", torrent.CreationTimestamp.Add(6*time.Hour), time.Second))
+		Expect(deadlineCondition().Status).To(Equal(metav1.ConditionFalse))
+		Expect(deadlineCondition().Reason).To(Equal("WithinDeadline"))
+
+		qb.setCompleted(magnetHash, 1<<20)
+		reconcileTorrent()
+		Expect(getTorrent().Status.Deadline.CompletionTime).NotTo(BeNil())
+		Expect(deadlineCondition().Status).To(Equal(metav1.ConditionFalse))
+		Expect(deadlineCondition().Reason).To(Equal("CompletedWithinDeadline"))
+	})
+
+	It("should escalate the torrent close to its deadline and restore the spec once complete", func() {
+		createTorrent(5*time.Hour+30*time.Minute, &torrentv1beta1.DeadlineEscalation{
+			Before:        metav1.Duration{Duration: time.Hour},
+			BoostPriority: true,
+			RemoveLimits:  true,
+		})
+		reconcileTorrent()
+		reconcileTorrent()
+		Expect(qb.torrentInfo(magnetHash).DLLimit).To(Equal(int64(1024)))
+
+		By("raising the priority and lifting the limits")
+		qb.setState(magnetHash, "downloading")
+		reconcileTorrent()
+		reconcileTorrent()
+		torrent := getTorrent()
+		Expect(torrent.Status.Deadline.EscalatedTime).NotTo(BeNil())
+		Expect(torrent.Status.QueuedPriority).To(Equal(torrentv1beta1.TorrentPriorityHigh))
+		Expect(qb.callCount("/api/v2/torrents/topPrio")).To(Equal(1))
+		Expect(qb.torrentInfo(magnetHash).DLLimit).To(BeZero())
+		Expect(qb.torrentInfo(magnetHash).UPLimit).To(BeZero())
+		Expect(torrent.Status.Drift).To(BeEmpty())
+		Expect(deadlineCondition().Status).To(Equal(metav1.ConditionFalse))
+
+		By("restoring the priority and the limits once complete")
+		qb.setCompleted(magnetHash, 1<<20)
+		reconcileTorrent()
+		torrent = getTorrent()
+		Expect(torrent.Status.Deadline.CompletionTime).NotTo(BeNil())
+		Expect(torrent.Status.QueuedPriority).To(Equal(torrentv1beta1.TorrentPriorityNormal))
+		Expect(qb.torrentInfo(magnetHash).DLLimit).To(Equal(int64(1024)))
+		Expect(qb.torrentInfo(magnetHash).UPLimit).To(Equal(int64(512)))
+	})
+
+	It("should report an incomplete torrent past its deadline", func() {
+		createTorrent(7*time.Hour, nil)
+		reconcileTorrent()
+		reconcileTorrent()
+		qb.setState(magnetHash, "downloading")
+		reconcileTorrent()
+
+		condition := deadlineCondition()
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("DeadlineExceeded"))
+		Expect(recorder.Events).To(Receive(Equal("Warning DeadlineExceeded " + condition.Message)))
+		reconcileTorrent()
+		Expect(recorder.Events).NotTo(Receive())
+
+		By("keeping the condition once it completes late")
+		qb.setCompleted(magnetHash, 1<<20)
+		reconcileTorrent()
+		condition = deadlineCondition()
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("CompletedAfterDeadline"))
+	})
+})
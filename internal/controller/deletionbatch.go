@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/guidonguido/qbittorrent-operator/internal/clientpool"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// DefaultDeletionBatchWindow is how long the deletions from qBittorrent are
+// gathered by default before being made together
+const DefaultDeletionBatchWindow = time.Second
+
+// maxDeletionBatch bounds the hashes deleted in a single call to qBittorrent
+const maxDeletionBatch = 100
+
+// deletionResultTTL is how long the outcome of a deletion is kept for the
+// reconcile of its Torrent, which may have been force-released meanwhile
+const deletionResultTTL = 10 * time.Minute
+
+// deletionQueue gathers the deletions from qBittorrent of the deleted
+// Torrents, made together by flush, in a call per server and kind of
+// deletion instead of a call per Torrent each deleting its files on its own
+type deletionQueue struct {
+	mu sync.Mutex
+	// pending maps the hashes waiting for the next flush to deleteFiles
+	pending map[deletionKey]bool
+	// results holds the outcome of the flushed hashes until read
+	results map[deletionKey]deletionResult
+}
+
+// deletionKey is a hash deleted from the qBittorrent of a QBittorrentServer
+type deletionKey struct {
+	server string
+	hash   string
+}
+
+type deletionResult struct {
+	err  error
+	time time.Time
+}
+
+// delete queues the deletion of the hash from the server, if not queued yet.
+// It returns true with the outcome once the deletion was made, which is then
+// forgotten.
+func (q *deletionQueue) delete(server, hash string, deleteFiles bool) (bool, error) {
+	key := deletionKey{server: server, hash: hash}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if result, ok := q.results[key]; ok {
+		delete(q.results, key)
+		return true, result.err
+	}
+	if q.pending == nil {
+		q.pending = map[deletionKey]bool{}
+	}
+	// Deleting the files wins over keeping them, as in qBittorrent
+	q.pending[key] = q.pending[key] || deleteFiles
+	return false, nil
+}
+
+// flush deletes the queued hashes from the qBittorrent of their server, at
+// most maxDeletionBatch of them per call
+func (q *deletionQueue) flush(ctx context.Context, clients *clientpool.Pool) {
+	q.mu.Lock()
+	pending := q.pending
+	q.pending = nil
+	q.mu.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	batches := map[string]map[bool][]string{}
+	for key, deleteFiles := range pending {
+		if batches[key.server] == nil {
+			batches[key.server] = map[bool][]string{}
+		}
+		batches[key.server][deleteFiles] = append(batches[key.server][deleteFiles], key.hash)
+	}
+
+	results := map[deletionKey]deletionResult{}
+	for server, serverBatches := range batches {
+		qbt := clients.Get(server)
+		// The torrents keeping their files first, they may share them with the others
+		for _, deleteFiles := range []bool{false, true} {
+			hashes := serverBatches[deleteFiles]
+			for start := 0; start < len(hashes); start += maxDeletionBatch {
+				batch := hashes[start:min(start+maxDeletionBatch, len(hashes))]
+				logf.FromContext(ctx).Info("Deleting torrents from qBittorrent together",
+					"Server", server, "Torrents", len(batch), "DeleteFiles", deleteFiles)
+				err := fmt.Errorf("no qBittorrent client for the QBittorrentServer %s", server)
+				if qbt != nil {
+					err = qbt.DeleteTorrent(ctx, strings.Join(batch, "|"), deleteFiles)
+				}
+				for _, hash := range batch {
+					results[deletionKey{server: server, hash: hash}] = deletionResult{err: err, time: time.Now()}
+				}
+			}
+		}
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.results == nil {
+		q.results = map[deletionKey]deletionResult{}
+	}
+	for key, result := range q.results {
+		if time.Since(result.time) > deletionResultTTL {
+			delete(q.results, key)
+		}
+	}
+	for key, result := range results {
+		q.results[key] = result
+	}
+}
+
+// run flushes the queue every window until the context is done
+func (q *deletionQueue) run(ctx context.Context, clients *clientpool.Pool, window time.Duration) error {
+	ctx = qbittorrent.WithAuditObject(ctx, "Torrent deletion batch")
+	ticker := time.NewTicker(window)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			q.flush(ctx, clients)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Torrent deletion batching", func() {
+	const (
+		moviesHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+		showsHash  = "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"
+		deletePath = "/api/v2/torrents/delete"
+	)
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var controllerReconciler *TorrentReconciler
+	var keys []types.NamespacedName
+
+	reconcileTorrent := func(key types.NamespacedName) reconcile.Result {
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		// The queue is only flushed by the specs
+		controllerReconciler = &TorrentReconciler{
+			Client:              k8sClient,
+			Scheme:              k8sClient.Scheme(),
+			Clients:             poolOf(qb.client()),
+			Recorder:            record.NewFakeRecorder(20),
+			DeletionBatchWindow: time.Minute,
+			Config:              &OperatorConfig{},
+		}
+
+		keys = []types.NamespacedName{
+			{Name: "batch-movies", Namespace: "default"},
+			{Name: "batch-shows", Namespace: "default"},
+		}
+		for i, hash := range []string{moviesHash, showsHash} {
+			Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+				ObjectMeta: metav1.ObjectMeta{Name: keys[i].Name, Namespace: keys[i].Namespace},
+				Spec: torrentv1beta1.TorrentSpec{
+					Source: torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + hash},
+				},
+			})).To(Succeed())
+			reconcileTorrent(keys[i])
+			reconcileTorrent(keys[i])
+			reconcileTorrent(keys[i])
+		}
+		Expect(qb.hashes()).To(ConsistOf(moviesHash, showsHash))
+
+		for _, key := range keys {
+			Expect(k8sClient.Delete(ctx, &torrentv1beta1.Torrent{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			})).To(Succeed())
+			Expect(reconcileTorrent(key).RequeueAfter).To(BeNumerically(">", 0))
+		}
+		Expect(qb.hashes()).To(ConsistOf(moviesHash, showsHash))
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		for _, key := range keys {
+			torrent := &torrentv1beta1.Torrent{}
+			if err := k8sClient.Get(ctx, key, torrent); err == nil {
+				controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+				Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+			}
+		}
+	})
+
+	It("should delete the queued torrents in a single call", func() {
+		calls := qb.callCount(deletePath)
+		controllerReconciler.deletions.flush(ctx, controllerReconciler.Clients)
+		Expect(qb.callCount(deletePath)).To(Equal(calls + 1))
+		Expect(qb.hashes()).To(BeEmpty())
+
+		for _, key := range keys {
+			reconcileTorrent(key)
+			err := k8sClient.Get(ctx, key, &torrentv1beta1.Torrent{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		}
+	})
+
+	It("should queue the torrents again when the batched deletion failed", func() {
+		qb.setFailDelete(true)
+		controllerReconciler.deletions.flush(ctx, controllerReconciler.Clients)
+
+		for _, key := range keys {
+			reconcileTorrent(key)
+			torrent := &torrentv1beta1.Torrent{}
+			Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+			condition := meta.FindStatusCondition(torrent.Status.Conditions, TypeDegradedTorrent)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("FailedToDeleteTorrent"))
+		}
+
+		By("deleting them at the next flush")
+		qb.setFailDelete(false)
+		for _, key := range keys {
+			reconcileTorrent(key)
+		}
+		controllerReconciler.deletions.flush(ctx, controllerReconciler.Clients)
+		Expect(qb.hashes()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+// errDependencyCycle is returned when the dependencies of a torrent lead back
+// to it, which would keep them all waiting forever
+var errDependencyCycle = errors.New("dependency cycle")
+
+// isDependencyComplete reports whether a dependency downloaded all its
+// content, whether it is still seeding or not
+func isDependencyComplete(torrent *torrentv1beta1.Torrent) bool {
+	return torrent.Status.Phase == torrentv1beta1.TorrentPhaseSeeding ||
+		torrent.Status.Phase == torrentv1beta1.TorrentPhaseCompleted
+}
+
+// pendingDependencies returns the dependencies of the torrent which are not
+// complete yet, missing ones included
+func (r *TorrentReconciler) pendingDependencies(ctx context.Context, torrent *torrentv1beta1.Torrent) ([]string, error) {
+	var pending []string
+	for _, name := range torrent.Spec.DependsOn {
+		dependency := &torrentv1beta1.Torrent{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: torrent.Namespace, Name: name}, dependency)
+		if apierrors.IsNotFound(err) {
+			pending = append(pending, name)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dependency %s: %w", name, err)
+		}
+		if !isDependencyComplete(dependency) {
+			pending = append(pending, name)
+		}
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	if err := r.checkDependencyCycle(ctx, torrent); err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+// checkDependencyCycle walks the dependencies of the torrent, returning
+// errDependencyCycle if one of them depends back on it. Complete and missing
+// dependencies end the walk.
+func (r *TorrentReconciler) checkDependencyCycle(ctx context.Context, torrent *torrentv1beta1.Torrent) error {
+	visited := map[string]bool{}
+	var walk func(names []string, path []string) error
+	walk = func(names []string, path []string) error {
+		for _, name := range names {
+			if name == torrent.Name {
+				return fmt.Errorf("%w: %s", errDependencyCycle, strings.Join(append(path, name), " -> "))
+			}
+			if visited[name] {
+				continue
+			}
+			visited[name] = true
+
+			dependency := &torrentv1beta1.Torrent{}
+			err := r.Get(ctx, types.NamespacedName{Namespace: torrent.Namespace, Name: name}, dependency)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get dependency %s: %w", name, err)
+			}
+			if isDependencyComplete(dependency) {
+				continue
+			}
+			if err := walk(dependency.Spec.DependsOn, append(path, name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(torrent.Spec.DependsOn, []string{torrent.Name})
+}
+
+// dependentTorrents maps a Torrent to the Torrents of its namespace
+// depending on it, to add them as soon as it completes instead of at their
+// next refresh
+func (r *TorrentReconciler) dependentTorrents(ctx context.Context, obj client.Object) []reconcile.Request {
+	torrents := &torrentv1beta1.TorrentList{}
+	if err := r.List(ctx, torrents, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list the Torrents depending on a Torrent", "Name", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, torrent := range torrents.Items {
+		if slices.Contains(torrent.Spec.DependsOn, obj.GetName()) && r.Shard.Owns(&torrent) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&torrent)})
+		}
+	}
+	return requests
+}
+
+// dependencyCompleted passes the updates of the Torrents which just
+// completed, the only ones their dependents wait for
+var dependencyCompleted = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldTorrent, ok := e.ObjectOld.(*torrentv1beta1.Torrent)
+		if !ok {
+			return false
+		}
+		newTorrent, ok := e.ObjectNew.(*torrentv1beta1.Torrent)
+		if !ok {
+			return false
+		}
+		return !isDependencyComplete(oldTorrent) && isDependencyComplete(newTorrent)
+	},
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Torrent dependencies", func() {
+	const (
+		part1Hash = "c9e15763f722f23e98a29decdfae341b98d53056"
+		part2Hash = "08ada5a7a6183aae1e09d831df6748d566095a10"
+	)
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var controllerReconciler *TorrentReconciler
+	var created []types.NamespacedName
+
+	createTorrent := func(name, hash string, dependsOn ...string) types.NamespacedName {
+		key := types.NamespacedName{Name: name, Namespace: "default"}
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source:    torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + hash},
+				DependsOn: dependsOn,
+			},
+		})).To(Succeed())
+		created = append(created, key)
+		return key
+	}
+
+	reconcileTorrent := func(key types.NamespacedName) {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getTorrent := func(key types.NamespacedName) *torrentv1beta1.Torrent {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		created = nil
+		controllerReconciler = &TorrentReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Clients:  poolOf(qb.client()),
+			Recorder: record.NewFakeRecorder(20),
+		}
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		for _, key := range created {
+			torrent := getTorrent(key)
+			controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+			Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+		}
+	})
+
+	It("should add the torrent once its dependencies are complete", func() {
+		part2 := createTorrent("part-2", part2Hash, "part-1")
+
+		By("waiting for a missing dependency")
+		reconcileTorrent(part2)
+		reconcileTorrent(part2)
+		Expect(qb.hashes()).To(BeEmpty())
+		torrent := getTorrent(part2)
+		Expect(torrent.Status.Phase).To(Equal(torrentv1beta1.TorrentPhasePending))
+		available := meta.FindStatusCondition(torrent.Status.Conditions, TypeAvailableTorrent)
+		Expect(available).NotTo(BeNil())
+		Expect(available.Status).To(Equal(metav1.ConditionFalse))
+		Expect(available.Reason).To(Equal("WaitingForDependencies"))
+		Expect(available.Message).To(Equal("Waiting for the Torrents part-1 to complete"))
+		Expect(meta.IsStatusConditionTrue(torrent.Status.Conditions, TypeDegradedTorrent)).To(BeFalse())
+
+		By("waiting for the dependency to download")
+		part1 := createTorrent("part-1", part1Hash)
+		reconcileTorrent(part1)
+		reconcileTorrent(part1)
+		reconcileTorrent(part1)
+		reconcileTorrent(part2)
+		Expect(qb.hashes()).To(Equal([]string{part1Hash}))
+
+		By("adding the torrent once the dependency is seeding")
+		before := getTorrent(part1)
+		qb.setState(part1Hash, "uploading")
+		reconcileTorrent(part1)
+		after := getTorrent(part1)
+		Expect(after.Status.Phase).To(Equal(torrentv1beta1.TorrentPhaseSeeding))
+
+		Expect(dependencyCompleted.Update(event.UpdateEvent{ObjectOld: before, ObjectNew: after})).To(BeTrue())
+		Expect(dependencyCompleted.Update(event.UpdateEvent{ObjectOld: after, ObjectNew: after})).To(BeFalse())
+		Expect(controllerReconciler.dependentTorrents(ctx, after)).To(ConsistOf(reconcile.Request{NamespacedName: part2}))
+
+		reconcileTorrent(part2)
+		Expect(qb.hashes()).To(ConsistOf(part1Hash, part2Hash))
+		reconcileTorrent(part2)
+		Expect(meta.IsStatusConditionTrue(getTorrent(part2).Status.Conditions, TypeAvailableTorrent)).To(BeTrue())
+	})
+
+	It("should report a dependency cycle", func() {
+		partA := createTorrent("part-a", part1Hash, "part-b")
+		createTorrent("part-b", part2Hash, "part-a")
+
+		reconcileTorrent(partA)
+		reconcileTorrent(partA)
+		Expect(qb.hashes()).To(BeEmpty())
+		degraded := meta.FindStatusCondition(getTorrent(partA).Status.Conditions, TypeDegradedTorrent)
+		Expect(degraded).NotTo(BeNil())
+		Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+		Expect(degraded.Reason).To(Equal("DependencyCycle"))
+		Expect(degraded.Message).To(ContainSubstring("part-a -> part-b -> part-a"))
+	})
+})
@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// reasonInsufficientDiskSpace is the reason of the conditions of the torrents
+// held back until their content fits on disk
+const reasonInsufficientDiskSpace = "InsufficientDiskSpace"
+
+// diskReserve returns the free disk space kept out of the downloads, and
+// whether the disk space is checked at all
+func (r *TorrentReconciler) diskReserve() (int64, bool) {
+	reserve := r.Config.Spec().DiskReserve
+	if reserve == nil {
+		return 0, false
+	}
+	return reserve.Value(), true
+}
+
+// diskSpaceShortfall returns how many bytes are missing for content of the
+// given size to fit in the free disk space of the qBittorrent of the Torrent
+// minus the reserve, 0 if it fits
+func (r *TorrentReconciler) diskSpaceShortfall(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	size int64) (int64, error) {
+	reserve, _ := r.diskReserve()
+	state, err := r.qbt(torrent).GetServerState(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the free disk space: %w", err)
+	}
+	if budget := state.FreeSpaceOnDisk - reserve; size > budget {
+		return size - budget, nil
+	}
+	return 0, nil
+}
+
+// holdForDiskSpace reports the torrent Pending until its content fits on
+// disk. Waiting is not a failure, the torrent is not degraded.
+func (r *TorrentReconciler) holdForDiskSpace(torrent *torrentv1beta1.Torrent, size, shortfall int64) {
+	message := fmt.Sprintf("The content needs %s, %s more than the free disk space minus the reserve",
+		formatQuantity(size), formatQuantity(shortfall))
+	if !heldForDiskSpace(torrent) {
+		r.recordEvent(torrent, corev1.EventTypeWarning, reasonInsufficientDiskSpace, message)
+	}
+
+	torrent.Status.Phase = torrentv1beta1.TorrentPhasePending
+	setCondition(&torrent.Status.Conditions, metav1.Condition{
+		Type:    TypeAvailableTorrent,
+		Status:  metav1.ConditionFalse,
+		Reason:  reasonInsufficientDiskSpace,
+		Message: message,
+	})
+	setCondition(&torrent.Status.Conditions, metav1.Condition{
+		Type:    TypeDegradedTorrent,
+		Status:  metav1.ConditionFalse,
+		Reason:  reasonInsufficientDiskSpace,
+		Message: message,
+	})
+}
+
+// heldForDiskSpace reports whether the torrent was held back by holdForDiskSpace
+func heldForDiskSpace(torrent *torrentv1beta1.Torrent) bool {
+	available := meta.FindStatusCondition(torrent.Status.Conditions, TypeAvailableTorrent)
+	return available != nil && available.Reason == reasonInsufficientDiskSpace
+}
+
+// reconcileDiskSpace checks the size of the torrents qBittorrent learned
+// only once added, such as magnets after metaDL. A torrent whose content does
+// not fit is stopped before it downloads anything, and started again once
+// it fits. It returns whether the torrent is held back.
+func (r *TorrentReconciler) reconcileDiskSpace(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) (bool, error) {
+	logger := log.FromContext(ctx)
+	held := heldForDiskSpace(torrent)
+	stopped := torrentPhase(qbTorrent) == torrentv1beta1.TorrentPhasePaused
+
+	_, enabled := r.diskReserve()
+	switch {
+	case !enabled && !held, qbTorrent.TotalSize <= 0:
+		return false, nil
+	case !held && (stopped || qbTorrent.AmountLeft < qbTorrent.TotalSize):
+		// Stopped by the user, or already downloading
+		return false, nil
+	}
+
+	var shortfall int64
+	if enabled {
+		var err error
+		if shortfall, err = r.diskSpaceShortfall(ctx, torrent, qbTorrent.AmountLeft); err != nil {
+			return false, err
+		}
+	}
+
+	if shortfall == 0 {
+		// The torrent awaiting approval is started once approved, the one
+		// with metadataOnly once it is unset
+		if held && !awaitingApproval(torrent) && !torrent.Spec.MetadataOnly {
+			logger.Info("The content of the Torrent fits on disk, starting it", "Name", torrent.Name)
+			if err := r.qbt(torrent).StartTorrent(ctx, qbTorrent.Hash); err != nil {
+				return false, fmt.Errorf("failed to start torrent: %w", err)
+			}
+		}
+		return false, nil
+	}
+
+	if !stopped {
+		logger.Info("The content of the Torrent does not fit on disk, stopping it", "Name", torrent.Name,
+			"Size", qbTorrent.AmountLeft, "Shortfall", shortfall)
+		if err := r.qbt(torrent).StopTorrent(ctx, qbTorrent.Hash); err != nil {
+			return false, fmt.Errorf("failed to stop torrent: %w", err)
+		}
+	}
+	r.holdForDiskSpace(torrent, qbTorrent.AmountLeft, shortfall)
+	return true, nil
+}
+
+// formatQuantity formats a size in bytes in binary units
+func formatQuantity(size int64) string {
+	return resource.NewQuantity(size, resource.BinarySI).String()
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("Torrent disk space", func() {
+	const magnetHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var controllerReconciler *TorrentReconciler
+	var key types.NamespacedName
+
+	createTorrent := func(source torrentv1beta1.TorrentSource) {
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec:       torrentv1beta1.TorrentSpec{Source: source},
+		})).To(Succeed())
+	}
+
+	reconcileTorrent := func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getTorrent := func() *torrentv1beta1.Torrent {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent
+	}
+
+	expectHeld := func() {
+		torrent := getTorrent()
+		Expect(torrent.Status.Phase).To(Equal(torrentv1beta1.TorrentPhasePending))
+		available := meta.FindStatusCondition(torrent.Status.Conditions, TypeAvailableTorrent)
+		Expect(available).NotTo(BeNil())
+		Expect(available.Status).To(Equal(metav1.ConditionFalse))
+		Expect(available.Reason).To(Equal(reasonInsufficientDiskSpace))
+		Expect(available.Message).To(Equal("The content needs 4Gi, 3584Mi more than the free disk space minus the reserve"))
+		Expect(meta.IsStatusConditionTrue(torrent.Status.Conditions, TypeDegradedTorrent)).To(BeFalse())
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		qb.setFreeSpace(1 << 30)
+		key = types.NamespacedName{Name: "disk-space", Namespace: "default"}
+
+		config := &OperatorConfig{}
+		reserve := resource.MustParse("512Mi")
+		config.set(&torrentv1beta1.QBittorrentOperatorConfigSpec{DiskReserve: &reserve})
+		controllerReconciler = &TorrentReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Clients:  poolOf(qb.client()),
+			Recorder: record.NewFakeRecorder(20),
+			Config:   config,
+		}
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		torrent := getTorrent()
+		controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+	})
+
+	It("should not add a .torrent source until its content fits on disk", func() {
+		data := fmt.Appendf(nil, "d4:infod6:lengthi%de4:name4:test12:piece lengthi16384e6:pieces20:aaaaaaaaaaaaaaaaaaaaee", int64(4<<30))
+		file, err := qbittorrent.ParseTorrentFile(data)
+		Expect(err).NotTo(HaveOccurred())
+		createTorrent(torrentv1beta1.TorrentSource{TorrentData: data})
+
+		By("holding the torrent back")
+		reconcileTorrent()
+		reconcileTorrent()
+		Expect(qb.hashes()).To(BeEmpty())
+		expectHeld()
+
+		By("adding the torrent once space is freed")
+		qb.setFreeSpace(8 << 30)
+		reconcileTorrent()
+		Expect(qb.hashes()).To(Equal([]string{file.InfoHashes.V1}))
+	})
+
+	It("should stop a magnet until its content fits on disk", func() {
+		createTorrent(torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + magnetHash})
+		reconcileTorrent()
+		reconcileTorrent()
+		Expect(qb.hashes()).To(Equal([]string{magnetHash}))
+
+		By("downloading the metadata while the size is unknown")
+		reconcileTorrent()
+		Expect(qb.torrentState(magnetHash)).To(Equal("metaDL"))
+
+		By("stopping the torrent once its size is known")
+		qb.setState(magnetHash, "downloading")
+		qb.setSize(magnetHash, 4<<30)
+		reconcileTorrent()
+		Expect(qb.torrentState(magnetHash)).To(Equal("stoppedDL"))
+		expectHeld()
+		reconcileTorrent()
+		expectHeld()
+
+		By("starting the torrent once space is freed")
+		qb.setFreeSpace(8 << 30)
+		reconcileTorrent()
+		Expect(qb.torrentState(magnetHash)).To(Equal("downloading"))
+		Expect(meta.IsStatusConditionTrue(getTorrent().Status.Conditions, TypeAvailableTorrent)).To(BeTrue())
+	})
+})
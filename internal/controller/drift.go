@@ -0,0 +1,217 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// qBittorrent share limit values meaning the global limit is used, or no limit
+const (
+	shareLimitGlobal    = -2
+	shareLimitUnlimited = -1
+)
+
+// reconcileDrift reverts the settings qBittorrent differs from the spec on
+// when the drift policy enforces them, and returns the accepted drift
+func (r *TorrentReconciler) reconcileDrift(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) ([]torrentv1beta1.FieldDrift, error) {
+	logger := log.FromContext(ctx)
+
+	policy := torrentv1beta1.DriftPolicy{}
+	if torrent.Spec.DriftPolicy != nil {
+		policy = *torrent.Spec.DriftPolicy
+	}
+
+	var accepted []torrentv1beta1.FieldDrift
+
+	if drift := r.categoryDrift(torrent, qbTorrent); drift != nil {
+		if policy.Category == torrentv1beta1.DriftActionEnforce {
+			logger.Info("Reverting category drift", "Desired", drift.Desired, "Actual", drift.Actual)
+			if err := r.qbt(torrent).SetCategory(ctx, qbTorrent.Hash, drift.Desired); err != nil {
+				return nil, fmt.Errorf("failed to revert category: %w", err)
+			}
+		} else {
+			accepted = append(accepted, *drift)
+		}
+	}
+
+	drift, err := limitsDrift(torrent, qbTorrent)
+	if err != nil {
+		return nil, err
+	}
+	if len(drift) > 0 {
+		if policy.Limits == torrentv1beta1.DriftActionEnforce {
+			logger.Info("Reverting limits drift", "Drift", drift)
+			if err := r.enforceLimits(ctx, torrent, qbTorrent); err != nil {
+				return nil, fmt.Errorf("failed to revert limits: %w", err)
+			}
+		} else {
+			accepted = append(accepted, drift...)
+		}
+	}
+
+	return accepted, nil
+}
+
+// categoryDrift returns the drift of the category, if set in the spec
+func (r *TorrentReconciler) categoryDrift(torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) *torrentv1beta1.FieldDrift {
+	if torrent.Spec.Category == "" {
+		return nil
+	}
+	desired := r.backendCategory(torrent)
+	if desired == qbTorrent.Category {
+		return nil
+	}
+	return &torrentv1beta1.FieldDrift{
+		Field:   "category",
+		Desired: desired,
+		Actual:  qbTorrent.Category,
+	}
+}
+
+// limitsDrift returns the drift of the limits set in the spec
+func limitsDrift(torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) ([]torrentv1beta1.FieldDrift, error) {
+	limits := torrent.Spec.Limits
+	if limits == nil {
+		return nil, nil
+	}
+
+	var drift []torrentv1beta1.FieldDrift
+	// The download limit of a preempted torrent is set by its priority, the
+	// transfer limits of an escalated one are lifted until it is complete and
+	// the ones of a throttled one are set by the network pressure
+	overridden := limitsLifted(torrent) || torrent.Status.Throttle != nil
+	if limits.DownloadLimit != nil && !torrent.Status.Preempted && !overridden &&
+		*limits.DownloadLimit != transferLimit(qbTorrent.DLLimit) {
+		drift = append(drift, torrentv1beta1.FieldDrift{
+			Field:   "limits.downloadLimit",
+			Desired: strconv.FormatInt(*limits.DownloadLimit, 10),
+			Actual:  strconv.FormatInt(transferLimit(qbTorrent.DLLimit), 10),
+		})
+	}
+	if limits.UploadLimit != nil && !overridden && *limits.UploadLimit != transferLimit(qbTorrent.UPLimit) {
+		drift = append(drift, torrentv1beta1.FieldDrift{
+			Field:   "limits.uploadLimit",
+			Desired: strconv.FormatInt(*limits.UploadLimit, 10),
+			Actual:  strconv.FormatInt(transferLimit(qbTorrent.UPLimit), 10),
+		})
+	}
+	if limits.RatioLimit != "" {
+		ratio, err := strconv.ParseFloat(limits.RatioLimit, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ratio limit %q: %w", limits.RatioLimit, err)
+		}
+		// qBittorrent rounds the ratio limit to two decimals
+		if math.Abs(ratio-qbTorrent.RatioLimit) >= 0.005 {
+			drift = append(drift, torrentv1beta1.FieldDrift{
+				Field:   "limits.ratioLimit",
+				Desired: limits.RatioLimit,
+				Actual:  ratioLimitString(qbTorrent.RatioLimit),
+			})
+		}
+	}
+	if limits.SeedingTimeLimit != nil &&
+		int64(limits.SeedingTimeLimit.Minutes()) != qbTorrent.SeedingTimeLimit {
+		drift = append(drift, torrentv1beta1.FieldDrift{
+			Field:   "limits.seedingTimeLimit",
+			Desired: limits.SeedingTimeLimit.Duration.String(),
+			Actual:  seedingTimeLimitString(qbTorrent.SeedingTimeLimit),
+		})
+	}
+
+	return drift, nil
+}
+
+// enforceLimits sets the limits of the spec on qBittorrent, the share limits
+// missing from the spec are kept
+func (r *TorrentReconciler) enforceLimits(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) error {
+	opts, err := r.addTorrentOptions(torrent)
+	if err != nil {
+		return err
+	}
+
+	limits := torrent.Spec.Limits
+	overridden := limitsLifted(torrent) || torrent.Status.Throttle != nil
+	if limits.DownloadLimit != nil && !torrent.Status.Preempted && !overridden {
+		if err := r.qbt(torrent).SetDownloadLimit(ctx, qbTorrent.Hash, opts.DownloadLimit); err != nil {
+			return err
+		}
+	}
+	if limits.UploadLimit != nil && !overridden {
+		if err := r.qbt(torrent).SetUploadLimit(ctx, qbTorrent.Hash, opts.UploadLimit); err != nil {
+			return err
+		}
+	}
+
+	if opts.RatioLimit == nil && opts.SeedingTimeLimit == nil {
+		return nil
+	}
+	ratioLimit := qbTorrent.RatioLimit
+	if opts.RatioLimit != nil {
+		ratioLimit = *opts.RatioLimit
+	}
+	seedingTimeLimit := qbTorrent.SeedingTimeLimit
+	if opts.SeedingTimeLimit != nil {
+		seedingTimeLimit = int64(opts.SeedingTimeLimit.Minutes())
+	}
+	// Not reported by qBittorrent before v4.6
+	inactiveSeedingTimeLimit := int64(shareLimitGlobal)
+	if qbTorrent.InactiveSeedingTimeLimit != nil {
+		inactiveSeedingTimeLimit = *qbTorrent.InactiveSeedingTimeLimit
+	}
+	return r.qbt(torrent).SetShareLimits(ctx, qbTorrent.Hash, ratioLimit, seedingTimeLimit, inactiveSeedingTimeLimit)
+}
+
+// transferLimit returns the transfer limit reported by qBittorrent as in the
+// spec, where 0 means unlimited. Depending on the version qBittorrent reports
+// no limit as 0 or -1.
+func transferLimit(limit int64) int64 {
+	return max(limit, 0)
+}
+
+func ratioLimitString(limit float64) string {
+	switch limit {
+	case shareLimitGlobal:
+		return "global"
+	case shareLimitUnlimited:
+		return "unlimited"
+	}
+	return strconv.FormatFloat(limit, 'f', -1, 64)
+}
+
+func seedingTimeLimitString(minutes int64) string {
+	switch minutes {
+	case shareLimitGlobal:
+		return "global"
+	case shareLimitUnlimited:
+		return "unlimited"
+	}
+	return (time.Duration(minutes) * time.Minute).String()
+}
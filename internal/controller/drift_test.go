@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("Drift detection", func() {
+	var torrent *torrentv1beta1.Torrent
+	r := &TorrentReconciler{}
+
+	BeforeEach(func() {
+		torrent = &torrentv1beta1.Torrent{
+			Spec: torrentv1beta1.TorrentSpec{
+				Category: "movies",
+				Limits: &torrentv1beta1.TorrentLimits{
+					DownloadLimit:    ptr.To[int64](0),
+					UploadLimit:      ptr.To[int64](1024),
+					RatioLimit:       "2.0",
+					SeedingTimeLimit: &metav1.Duration{Duration: 72 * time.Hour},
+				},
+			},
+		}
+	})
+
+	It("should not report drift when qBittorrent matches the spec", func() {
+		qbTorrent := &qbittorrent.TorrentInfo{
+			Category:         "movies",
+			DLLimit:          -1,
+			UPLimit:          1024,
+			RatioLimit:       2,
+			SeedingTimeLimit: 72 * 60,
+		}
+		Expect(r.categoryDrift(torrent, qbTorrent)).To(BeNil())
+		Expect(limitsDrift(torrent, qbTorrent)).To(BeEmpty())
+	})
+
+	It("should report the settings changed on qBittorrent", func() {
+		qbTorrent := &qbittorrent.TorrentInfo{
+			Category:         "tv",
+			DLLimit:          2048,
+			UPLimit:          1024,
+			RatioLimit:       -2,
+			SeedingTimeLimit: -1,
+		}
+		Expect(r.categoryDrift(torrent, qbTorrent)).To(Equal(&torrentv1beta1.FieldDrift{
+			Field: "category", Desired: "movies", Actual: "tv",
+		}))
+		Expect(limitsDrift(torrent, qbTorrent)).To(Equal([]torrentv1beta1.FieldDrift{
+			{Field: "limits.downloadLimit", Desired: "0", Actual: "2048"},
+			{Field: "limits.ratioLimit", Desired: "2.0", Actual: "global"},
+			{Field: "limits.seedingTimeLimit", Desired: "72h0m0s", Actual: "unlimited"},
+		}))
+	})
+
+	It("should ignore the download limit of a preempted torrent", func() {
+		torrent.Status.Preempted = true
+		qbTorrent := &qbittorrent.TorrentInfo{
+			Category:         "movies",
+			DLLimit:          2048,
+			UPLimit:          1024,
+			RatioLimit:       2,
+			SeedingTimeLimit: 72 * 60,
+		}
+		Expect(limitsDrift(torrent, qbTorrent)).To(BeEmpty())
+	})
+
+	It("should ignore the settings missing from the spec", func() {
+		torrent.Spec = torrentv1beta1.TorrentSpec{}
+		qbTorrent := &qbittorrent.TorrentInfo{Category: "tv", UPLimit: 2048}
+		Expect(r.categoryDrift(torrent, qbTorrent)).To(BeNil())
+		Expect(limitsDrift(torrent, qbTorrent)).To(BeEmpty())
+	})
+})
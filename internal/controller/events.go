@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+// DefaultEventThrottleWindow is how long the repeats of an Event of an
+// object are counted instead of recorded
+const DefaultEventThrottleWindow = 10 * time.Minute
+
+// throttledRecorder records the first Event of each object, type and reason
+// in a window, and counts the following ones. The count is recorded with the
+// first Event of the next window, so that a flapping Torrent produces one
+// Event per window instead of one per transition.
+type throttledRecorder struct {
+	record.EventRecorder
+	window time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	windows map[eventKey]*eventWindow
+}
+
+type eventKey struct {
+	uid       types.UID
+	eventtype string
+	reason    string
+}
+
+type eventWindow struct {
+	start time.Time
+	// object and suppressed are the object of the Events and the number of
+	// Events not recorded since start
+	object     runtime.Object
+	suppressed int
+}
+
+// NewThrottledRecorder returns a recorder throttling the Events of the
+// recorder to one per object, type and reason in each window. The recorder is
+// returned as is if the window is 0.
+func NewThrottledRecorder(recorder record.EventRecorder, window time.Duration) record.EventRecorder {
+	if window <= 0 {
+		return recorder
+	}
+	return &throttledRecorder{
+		EventRecorder: recorder,
+		window:        window,
+		now:           time.Now,
+		windows:       map[eventKey]*eventWindow{},
+	}
+}
+
+func (r *throttledRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if message, ok := r.admit(object, eventtype, reason, message); ok {
+		r.EventRecorder.Event(object, eventtype, reason, message)
+	}
+}
+
+func (r *throttledRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *throttledRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string,
+	eventtype, reason, messageFmt string, args ...interface{}) {
+	if message, ok := r.admit(object, eventtype, reason, fmt.Sprintf(messageFmt, args...)); ok {
+		r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+	}
+}
+
+// admit reports whether the Event is recorded, with the count of the Events
+// suppressed in the previous window added to its message
+func (r *throttledRecorder) admit(object runtime.Object, eventtype, reason, message string) (string, bool) {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return message, true
+	}
+	key := eventKey{uid: accessor.GetUID(), eventtype: eventtype, reason: reason}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	r.flush(now, key)
+
+	window, ok := r.windows[key]
+	if ok && now.Sub(window.start) < r.window {
+		window.object = object
+		window.suppressed++
+		return "", false
+	}
+	if ok && window.suppressed > 0 {
+		message = fmt.Sprintf("%s (%d similar Events suppressed in the previous %s)", message, window.suppressed, r.window)
+	}
+	r.windows[key] = &eventWindow{start: now, object: object}
+	return message, true
+}
+
+// flush forgets the windows ended, other than the one of skip, recording the
+// count of their suppressed Events. It must be called with mu held.
+func (r *throttledRecorder) flush(now time.Time, skip eventKey) {
+	for key, window := range r.windows {
+		if key == skip || now.Sub(window.start) < r.window {
+			continue
+		}
+		if window.suppressed > 0 {
+			r.EventRecorder.Eventf(window.object, key.eventtype, key.reason,
+				"%d similar Events suppressed in the last %s", window.suppressed, r.window)
+		}
+		delete(r.windows, key)
+	}
+}
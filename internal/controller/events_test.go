@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Event throttling", func() {
+	var fakeRecorder *record.FakeRecorder
+	var recorder *throttledRecorder
+	var now time.Time
+	torrent := &torrentv1beta1.Torrent{ObjectMeta: metav1.ObjectMeta{Name: "flapping", UID: "flapping-uid"}}
+	other := &torrentv1beta1.Torrent{ObjectMeta: metav1.ObjectMeta{Name: "other", UID: "other-uid"}}
+
+	BeforeEach(func() {
+		fakeRecorder = record.NewFakeRecorder(10)
+		recorder = NewThrottledRecorder(fakeRecorder, 10*time.Minute).(*throttledRecorder)
+		now = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		recorder.now = func() time.Time { return now }
+	})
+
+	It("should count the repeats of an Event in a window", func() {
+		By("recording the first Event")
+		recorder.Event(torrent, corev1.EventTypeWarning, "Degraded", "FailedToGetTorrent: connection refused")
+		Expect(fakeRecorder.Events).To(Receive(Equal("Warning Degraded FailedToGetTorrent: connection refused")))
+
+		By("counting the repeats instead of recording them")
+		for range 5 {
+			now = now.Add(time.Minute)
+			recorder.Eventf(torrent, corev1.EventTypeWarning, "Degraded", "FailedToGetTorrent: %s", "timeout")
+		}
+		Expect(fakeRecorder.Events).NotTo(Receive())
+
+		By("recording the other objects and reasons")
+		recorder.Event(other, corev1.EventTypeWarning, "Degraded", "FailedToGetTorrent: connection refused")
+		recorder.Event(torrent, corev1.EventTypeWarning, "Stalled", "The download is stalled")
+		Expect(fakeRecorder.Events).To(HaveLen(2))
+		<-fakeRecorder.Events
+		<-fakeRecorder.Events
+
+		By("recording the count with the first Event of the next window")
+		now = now.Add(10 * time.Minute)
+		recorder.Event(torrent, corev1.EventTypeWarning, "Degraded", "FailedToGetTorrent: connection refused")
+		Expect(fakeRecorder.Events).To(Receive(Equal(
+			"Warning Degraded FailedToGetTorrent: connection refused (5 similar Events suppressed in the previous 10m0s)")))
+	})
+
+	It("should record the count of a window ended without new Events", func() {
+		recorder.Event(torrent, corev1.EventTypeWarning, "Degraded", "FailedToGetTorrent: connection refused")
+		recorder.Event(torrent, corev1.EventTypeWarning, "Degraded", "FailedToGetTorrent: connection refused")
+		<-fakeRecorder.Events
+
+		now = now.Add(11 * time.Minute)
+		recorder.Event(other, corev1.EventTypeNormal, "PeersBanned", "Banned 1 peers on qBittorrent")
+		Expect(fakeRecorder.Events).To(Receive(Equal("Warning Degraded 1 similar Events suppressed in the last 10m0s")))
+		Expect(fakeRecorder.Events).To(Receive(Equal("Normal PeersBanned Banned 1 peers on qBittorrent")))
+		Expect(recorder.windows).To(HaveLen(1))
+	})
+
+	It("should not throttle without a window", func() {
+		Expect(NewThrottledRecorder(fakeRecorder, 0)).To(BeIdenticalTo(fakeRecorder))
+	})
+})
@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// excludedFile reports whether the path of a file in the torrent matches one
+// of the excludeFiles patterns, the ones without a slash matching its name
+func excludedFile(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		target := name
+		if !strings.Contains(pattern, "/") {
+			target = path.Base(name)
+		}
+		if matched, _ := path.Match(pattern, target); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileExcludedFiles stops the download of the files matching
+// spec.excludeFiles, with priority 0, once qBittorrent knows the files of
+// the torrent. The files no longer matching are left as they are.
+func (r *TorrentReconciler) reconcileExcludedFiles(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) error {
+	if len(torrent.Spec.ExcludeFiles) == 0 {
+		return nil
+	}
+
+	// A magnet has no files until its metadata is received
+	files, err := r.qbt(torrent).GetTorrentFiles(ctx, qbTorrent.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to get torrent files: %w", err)
+	}
+	var indexes []int
+	for _, file := range files {
+		if file.Priority != 0 && excludedFile(torrent.Spec.ExcludeFiles, file.Name) {
+			indexes = append(indexes, file.Index)
+		}
+	}
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Excluding the files matching excludeFiles", "Name", torrent.Name, "Files", len(indexes))
+	if err := r.qbt(torrent).SetFilePriority(ctx, qbTorrent.Hash, indexes, 0); err != nil {
+		return fmt.Errorf("failed to exclude the files: %w", err)
+	}
+	r.recordEvent(torrent, corev1.EventTypeNormal, "FilesExcluded",
+		fmt.Sprintf("%d files matching excludeFiles are not downloaded", len(indexes)))
+	return nil
+}
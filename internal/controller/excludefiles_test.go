@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("Excluded files", func() {
+	const magnetHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+
+	ctx := context.Background()
+
+	It("should match the names of the files, or their paths with a slash", func() {
+		patterns := []string{"*.nfo", "*.sample.*", "Extras/*"}
+		Expect(excludedFile(patterns, "movie/movie.nfo")).To(BeTrue())
+		Expect(excludedFile(patterns, "movie/movie.sample.mkv")).To(BeTrue())
+		Expect(excludedFile(patterns, "Extras/interview.mkv")).To(BeTrue())
+		Expect(excludedFile(patterns, "movie/Extras/interview.mkv")).To(BeFalse())
+		Expect(excludedFile(patterns, "movie/movie.mkv")).To(BeFalse())
+		Expect(excludedFile(nil, "movie/movie.nfo")).To(BeFalse())
+	})
+
+	It("should not download the matching files once the metadata is received", func() {
+		qb := newFakeQBittorrent()
+		defer qb.Close()
+		recorder := record.NewFakeRecorder(20)
+		controllerReconciler := &TorrentReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Clients:  poolOf(qb.client()),
+			Recorder: recorder,
+			Config:   &OperatorConfig{},
+		}
+		key := types.NamespacedName{Name: "exclude-files", Namespace: "default"}
+		reconcileTorrent := func() {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source:       torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + magnetHash},
+				MetadataOnly: true,
+				ExcludeFiles: []string{"*.nfo", "*.sample.*"},
+			},
+		})).To(Succeed())
+		DeferCleanup(func() {
+			torrent := &torrentv1beta1.Torrent{}
+			Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+			controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+			Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+		})
+		reconcileTorrent()
+		reconcileTorrent()
+
+		qb.receiveMetadata(magnetHash, 4<<30)
+		qb.setFiles(magnetHash,
+			qbittorrent.FileInfo{Name: "movie/movie.mkv", Size: 4 << 30, Priority: 1},
+			qbittorrent.FileInfo{Name: "movie/movie.nfo", Size: 1 << 10, Priority: 1},
+			qbittorrent.FileInfo{Name: "movie/movie.sample.mkv", Size: 1 << 20, Priority: 1},
+		)
+		reconcileTorrent()
+
+		files := qb.torrentFiles(magnetHash)
+		Expect([]int{files[0].Priority, files[1].Priority, files[2].Priority}).To(Equal([]int{1, 0, 0}))
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal MetadataReceived")))
+		Expect(recorder.Events).To(Receive(Equal("Normal FilesExcluded 2 files matching excludeFiles are not downloaded")))
+
+		By("previewing the excluded files in status")
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		Expect(torrent.Status.Files).To(HaveLen(3))
+		Expect(torrent.Status.Files[1].Priority).To(BeZero())
+
+		By("leaving the files alone once excluded")
+		reconcileTorrent()
+		Expect(recorder.Events).NotTo(Receive())
+	})
+})
@@ -0,0 +1,704 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/guidonguido/qbittorrent-operator/internal/clientpool"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// fakeQBittorrent is a qBittorrent WebUI keeping the torrents added and
+// deleted through it, so that the Torrent reconciler can be run against it
+// without a qBittorrent container.
+type fakeQBittorrent struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	torrents map[string]qbittorrent.TorrentInfo
+	// calls counts the calls by API path
+	calls map[string]int
+	// down makes every call fail as if qBittorrent was unavailable
+	down bool
+	// failDelete makes the deletions fail
+	failDelete bool
+	// freeSpace is the free disk space reported in the server state
+	freeSpace int64
+	// downloadSpeed and uploadSpeed are the transfer speeds reported in the
+	// server state
+	downloadSpeed, uploadSpeed int64
+	// trackers are the trackers added to the torrents, by hash
+	trackers map[string][]string
+	// files are the files of the torrents, by hash, none until set
+	files map[string][]qbittorrent.FileInfo
+	// stopConditions are the stop conditions the torrents were added with, by hash
+	stopConditions map[string]string
+	// transferred are the bytes downloaded and uploaded by the torrents in the
+	// session, by hash
+	transferred map[string][2]int64
+	// deletedFiles records whether the files of the deleted torrents were deleted, by hash
+	deletedFiles map[string]bool
+	// categories and tags are the ones created, kept once unused as in qBittorrent
+	categories, tags map[string]bool
+	// added counts the torrents added, standing for their addition time
+	added int64
+
+	faults fakeFaults
+	random *rand.Rand
+	// injected counts the calls failed by the faults
+	injected int
+
+	// sessionID is the session the calls must have, they need none while empty
+	sessionID string
+	logins    int
+}
+
+// fakeFaults are the faults injected by the fake qBittorrent WebUI. The
+// random ones are drawn from a seeded source, so that a spec sees the same
+// faults on every run.
+type fakeFaults struct {
+	// Seed seeds the draws of ErrorRate
+	Seed uint64
+	// ErrorRate is the share of the calls answered 502 Bad Gateway, as by a
+	// reverse proxy in front of a restarting qBittorrent
+	ErrorRate float64
+	// Delay delays every answer, or until the call is canceled
+	Delay time.Duration
+	// TruncateJSON cuts the torrent lists in half
+	TruncateJSON bool
+}
+
+// newFakeQBittorrent starts a fake qBittorrent WebUI without torrents
+func newFakeQBittorrent() *fakeQBittorrent {
+	fake := &fakeQBittorrent{
+		torrents:       map[string]qbittorrent.TorrentInfo{},
+		calls:          map[string]int{},
+		trackers:       map[string][]string{},
+		files:          map[string][]qbittorrent.FileInfo{},
+		stopConditions: map[string]string{},
+		deletedFiles:   map[string]bool{},
+		transferred:    map[string][2]int64{},
+		categories:     map[string]bool{},
+		tags:           map[string]bool{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/auth/login", fake.login)
+	mux.HandleFunc("/api/v2/torrents/info", fake.info)
+	mux.HandleFunc("/api/v2/torrents/add", fake.add)
+	mux.HandleFunc("/api/v2/torrents/delete", fake.delete)
+	mux.HandleFunc("/api/v2/torrents/stop", fake.setStateOf("stoppedDL", "stoppedUP"))
+	mux.HandleFunc("/api/v2/torrents/start", fake.setStateOf("downloading", "uploading"))
+	mux.HandleFunc("/api/v2/torrents/recheck", fake.setStateOf("checkingDL", "checkingUP"))
+	mux.HandleFunc("/api/v2/torrents/addTrackers", fake.addTrackers)
+	mux.HandleFunc("/api/v2/torrents/addTags", fake.addTags)
+	mux.HandleFunc("/api/v2/torrents/setDownloadLimit", fake.setLimit(false))
+	mux.HandleFunc("/api/v2/torrents/setUploadLimit", fake.setLimit(true))
+	mux.HandleFunc("/api/v2/torrents/topPrio", func(http.ResponseWriter, *http.Request) {})
+	mux.HandleFunc("/api/v2/torrents/bottomPrio", func(http.ResponseWriter, *http.Request) {})
+	mux.HandleFunc("/api/v2/torrents/reannounce", func(http.ResponseWriter, *http.Request) {})
+	mux.HandleFunc("/api/v2/torrents/files", fake.listFiles)
+	mux.HandleFunc("/api/v2/torrents/filePrio", fake.setFilePriority)
+	mux.HandleFunc("/api/v2/torrents/categories", fake.listCategories)
+	mux.HandleFunc("/api/v2/torrents/removeCategories", fake.removeCategories)
+	mux.HandleFunc("/api/v2/torrents/tags", fake.listTags)
+	mux.HandleFunc("/api/v2/torrents/deleteTags", fake.deleteTags)
+	mux.HandleFunc("/api/v2/sync/maindata", fake.mainData)
+	mux.HandleFunc("/api/v2/torrents/properties", fake.properties)
+	fake.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fake.mu.Lock()
+		fake.calls[req.URL.Path]++
+		down := fake.down
+		delay := fake.faults.Delay
+		injected := fake.random != nil && fake.random.Float64() < fake.faults.ErrorRate
+		if injected {
+			fake.injected++
+		}
+		expired := fake.sessionID != "" && req.URL.Path != "/api/v2/auth/login" && !fake.hasSession(req)
+		fake.mu.Unlock()
+
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-req.Context().Done():
+				return
+			}
+		}
+		switch {
+		case down:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case injected:
+			w.WriteHeader(http.StatusBadGateway)
+		case expired:
+			// qBittorrent answers 403 Forbidden to the sessions it does not know
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			mux.ServeHTTP(w, req)
+		}
+	}))
+	return fake
+}
+
+// poolOf returns a pool with the client as the one of the default server,
+// needing no login
+func poolOf(qbt *qbittorrent.Client) *clientpool.Pool {
+	pool := clientpool.New()
+	pool.Add(DefaultServerName, qbt, nil)
+	return pool
+}
+
+// client returns a qBittorrent client of the fake WebUI
+func (f *fakeQBittorrent) client() *qbittorrent.Client {
+	return qbittorrent.NewClient(f.URL)
+}
+
+// setDown makes qBittorrent unavailable, or available again
+func (f *fakeQBittorrent) setDown(down bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.down = down
+}
+
+// setFailDelete makes the deletions fail, or succeed again
+func (f *fakeQBittorrent) setFailDelete(failDelete bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failDelete = failDelete
+}
+
+// setFaults sets the faults injected in the following calls, none if empty
+func (f *fakeQBittorrent) setFaults(faults fakeFaults) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults = faults
+	f.random = nil
+	if faults.ErrorRate > 0 {
+		f.random = rand.New(rand.NewPCG(faults.Seed, faults.Seed))
+	}
+}
+
+// injectedFaults returns the number of calls failed by the faults
+func (f *fakeQBittorrent) injectedFaults() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.injected
+}
+
+// expireSession forgets the session of the last login, as qBittorrent does
+// when it restarts, so that the calls are rejected until the next login
+func (f *fakeQBittorrent) expireSession() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sessionID = "expired"
+}
+
+// loginCount returns the number of logins
+func (f *fakeQBittorrent) loginCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.logins
+}
+
+// hasSession reports whether the request has the current session. It must be
+// called with mu held.
+func (f *fakeQBittorrent) hasSession(req *http.Request) bool {
+	cookie, err := req.Cookie("SID")
+	return err == nil && cookie.Value == f.sessionID
+}
+
+// setState sets the state of a torrent, as qBittorrent does while downloading
+func (f *fakeQBittorrent) setState(hash, state string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if torrent, ok := f.torrents[hash]; ok {
+		torrent.State = state
+		f.torrents[hash] = torrent
+	}
+}
+
+// setSize sets the size of a torrent with nothing downloaded yet, as
+// qBittorrent does once it has the metadata of a magnet
+func (f *fakeQBittorrent) setSize(hash string, size int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if torrent, ok := f.torrents[hash]; ok {
+		torrent.TotalSize = size
+		torrent.AmountLeft = size
+		f.torrents[hash] = torrent
+	}
+}
+
+// receiveMetadata sets the size of a magnet fetching its metadata, stopping
+// it as qBittorrent does when added with the MetadataReceived stop condition
+func (f *fakeQBittorrent) receiveMetadata(hash string, size int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if torrent, ok := f.torrents[hash]; ok {
+		torrent.TotalSize = size
+		torrent.AmountLeft = size
+		torrent.State = "downloading"
+		if f.stopConditions[hash] == string(qbittorrent.StopConditionMetadataReceived) {
+			torrent.State = "stoppedDL"
+		}
+		f.torrents[hash] = torrent
+	}
+}
+
+// setCompleted marks a torrent of the given size downloaded, as qBittorrent
+// does once it seeds
+func (f *fakeQBittorrent) setCompleted(hash string, size int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if torrent, ok := f.torrents[hash]; ok {
+		torrent.State = "uploading"
+		torrent.TotalSize = size
+		torrent.AmountLeft = 0
+		f.torrents[hash] = torrent
+	}
+}
+
+// setContentPath sets the path of the content of a torrent
+func (f *fakeQBittorrent) setContentPath(hash, contentPath string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if torrent, ok := f.torrents[hash]; ok {
+		torrent.ContentPath = contentPath
+		f.torrents[hash] = torrent
+	}
+}
+
+// filesDeleted returns whether a torrent was deleted, and whether with its files
+func (f *fakeQBittorrent) filesDeleted(hash string) (deleted, withFiles bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	withFiles, deleted = f.deletedFiles[hash]
+	return deleted, withFiles
+}
+
+// setTransferred sets the bytes downloaded and uploaded by a torrent in the
+// session, 0 after a restart of qBittorrent
+func (f *fakeQBittorrent) setTransferred(hash string, downloaded, uploaded int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.transferred[hash] = [2]int64{downloaded, uploaded}
+}
+
+// setFreeSpace sets the free disk space reported by qBittorrent
+func (f *fakeQBittorrent) setFreeSpace(freeSpace int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.freeSpace = freeSpace
+}
+
+// setSpeeds sets the transfer speeds of a torrent
+func (f *fakeQBittorrent) setSpeeds(hash string, download, upload int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if torrent, ok := f.torrents[hash]; ok {
+		torrent.DLSpeed = download
+		torrent.UPSpeed = upload
+		f.torrents[hash] = torrent
+	}
+}
+
+// setServerSpeeds sets the transfer speeds reported by qBittorrent
+func (f *fakeQBittorrent) setServerSpeeds(download, upload int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.downloadSpeed, f.uploadSpeed = download, upload
+}
+
+// torrentState returns the state of a torrent, empty if it is missing
+func (f *fakeQBittorrent) torrentState(hash string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.torrents[hash].State
+}
+
+// hashes returns the hashes of the torrents, sorted
+func (f *fakeQBittorrent) hashes() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	hashes := make([]string, 0, len(f.torrents))
+	for hash := range f.torrents {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+	return hashes
+}
+
+// addedTrackers returns the trackers added to a torrent
+func (f *fakeQBittorrent) addedTrackers(hash string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.trackers[hash])
+}
+
+// setCategoryAndTags sets the category and the tags of a torrent, creating them
+func (f *fakeQBittorrent) setCategoryAndTags(hash, category string, tags ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if torrent, ok := f.torrents[hash]; ok {
+		torrent.Category = category
+		torrent.Tags = strings.Join(tags, ",")
+		f.torrents[hash] = torrent
+	}
+	if category != "" {
+		f.categories[category] = true
+	}
+	for _, tag := range tags {
+		f.tags[tag] = true
+	}
+}
+
+// setFiles sets the files of a torrent, as once its metadata is received
+func (f *fakeQBittorrent) setFiles(hash string, files ...qbittorrent.FileInfo) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range files {
+		files[i].Index = i
+	}
+	f.files[hash] = files
+}
+
+// torrentFiles returns the files of a torrent
+func (f *fakeQBittorrent) torrentFiles(hash string) []qbittorrent.FileInfo {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.files[hash])
+}
+
+// categoryNames returns the categories created, sorted
+func (f *fakeQBittorrent) categoryNames() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Sorted(maps.Keys(f.categories))
+}
+
+// tagNames returns the tags created, sorted
+func (f *fakeQBittorrent) tagNames() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Sorted(maps.Keys(f.tags))
+}
+
+// torrentInfo returns the torrent as reported by qBittorrent
+func (f *fakeQBittorrent) torrentInfo(hash string) qbittorrent.TorrentInfo {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.torrents[hash]
+}
+
+// callCount returns the number of calls made to the API path
+func (f *fakeQBittorrent) callCount(path string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[path]
+}
+
+// login starts a new session, whatever the credentials
+func (f *fakeQBittorrent) login(w http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	f.logins++
+	f.sessionID = fmt.Sprintf("session-%d", f.logins)
+	sessionID := f.sessionID
+	f.mu.Unlock()
+
+	http.SetCookie(w, &http.Cookie{Name: "SID", Value: sessionID})
+	_, _ = io.WriteString(w, "Ok.")
+}
+
+func (f *fakeQBittorrent) info(w http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	torrents := make([]qbittorrent.TorrentInfo, 0, len(f.torrents))
+	for _, torrent := range f.torrents {
+		torrents = append(torrents, torrent)
+	}
+	truncate := f.faults.TruncateJSON
+	f.mu.Unlock()
+
+	sort.Slice(torrents, func(i, j int) bool { return torrents[i].Hash < torrents[j].Hash })
+	body, _ := json.Marshal(torrents)
+	if truncate {
+		body = body[:len(body)/2]
+	}
+	_, _ = w.Write(body)
+}
+
+// mainData answers a full sync with the server state only
+func (f *fakeQBittorrent) mainData(w http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	freeSpace, downloadSpeed, uploadSpeed := f.freeSpace, f.downloadSpeed, f.uploadSpeed
+	f.mu.Unlock()
+
+	_, _ = fmt.Fprintf(w, `{"rid":1,"full_update":true,"server_state":{"free_space_on_disk":%d,`+
+		`"dl_info_speed":%d,"up_info_speed":%d}}`, freeSpace, downloadSpeed, uploadSpeed)
+}
+
+// add adds the magnet URIs and the .torrent files of the request, starting
+// their download
+func (f *fakeQBittorrent) add(w http.ResponseWriter, req *http.Request) {
+	if err := req.ParseMultipartForm(1 << 20); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var added []qbittorrent.TorrentInfo
+	for _, uri := range strings.Split(req.FormValue("urls"), "\n") {
+		if uri == "" {
+			continue
+		}
+		hashes, err := qbittorrent.GetInfoHashes(uri)
+		if err != nil {
+			// qBittorrent answers Fails. to the torrents it cannot add
+			_, _ = io.WriteString(w, "Fails.")
+			return
+		}
+		added = append(added, qbittorrent.TorrentInfo{Hash: hashes.V1, InfohashV1: hashes.V1, MagnetURI: uri})
+	}
+	for _, header := range req.MultipartForm.File["torrents"] {
+		file, err := header.Open()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, err := io.ReadAll(file)
+		_ = file.Close()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		torrentFile, err := qbittorrent.ParseTorrentFile(data)
+		if err != nil {
+			_, _ = io.WriteString(w, "Fails.")
+			return
+		}
+		added = append(added, qbittorrent.TorrentInfo{
+			Hash:       torrentFile.InfoHashes.V1,
+			InfohashV1: torrentFile.InfoHashes.V1,
+			Name:       torrentFile.Name,
+			TotalSize:  torrentFile.TotalSize,
+			AmountLeft: torrentFile.TotalSize,
+		})
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, torrent := range added {
+		if _, ok := f.torrents[torrent.Hash]; ok {
+			continue
+		}
+		torrent.State = "metaDL"
+		if req.FormValue("stopped") == "true" {
+			torrent.State = "stoppedDL"
+		}
+		if stopCondition := req.FormValue("stopCondition"); stopCondition != "" {
+			f.stopConditions[torrent.Hash] = stopCondition
+		}
+		torrent.Category = req.FormValue("category")
+		if torrent.Category != "" {
+			f.categories[torrent.Category] = true
+		}
+		torrent.Tags = req.FormValue("tags")
+		for _, tag := range torrent.TagList() {
+			f.tags[tag] = true
+		}
+		torrent.SavePath = req.FormValue("savepath")
+		f.added++
+		torrent.AddedOn = f.added
+		torrent.DLLimit, _ = strconv.ParseInt(req.FormValue("dlLimit"), 10, 64)
+		torrent.UPLimit, _ = strconv.ParseInt(req.FormValue("upLimit"), 10, 64)
+		f.torrents[torrent.Hash] = torrent
+	}
+	_, _ = io.WriteString(w, "Ok.")
+}
+
+// properties answers the bytes transferred by the torrent, all in the session
+func (f *fakeQBittorrent) properties(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	transferred := f.transferred[req.FormValue("hash")]
+	f.mu.Unlock()
+	_, _ = fmt.Fprintf(w, `{"total_downloaded":%[1]d,"total_uploaded":%[2]d,`+
+		`"total_downloaded_session":%[1]d,"total_uploaded_session":%[2]d,"share_ratio":0}`,
+		transferred[0], transferred[1])
+}
+
+func (f *fakeQBittorrent) listFiles(w http.ResponseWriter, req *http.Request) {
+	files := f.torrentFiles(req.URL.Query().Get("hash"))
+	if files == nil {
+		files = []qbittorrent.FileInfo{}
+	}
+	_ = json.NewEncoder(w).Encode(files)
+}
+
+func (f *fakeQBittorrent) setFilePriority(w http.ResponseWriter, req *http.Request) {
+	priority, err := strconv.Atoi(req.FormValue("priority"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	files := f.files[req.FormValue("hash")]
+	for _, id := range strings.Split(req.FormValue("id"), "|") {
+		index, err := strconv.Atoi(id)
+		if err != nil || index >= len(files) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		files[index].Priority = priority
+	}
+}
+
+func (f *fakeQBittorrent) listCategories(w http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	categories := map[string]map[string]string{}
+	for category := range f.categories {
+		categories[category] = map[string]string{"name": category, "savePath": ""}
+	}
+	_ = json.NewEncoder(w).Encode(categories)
+}
+
+// removeCategories removes the categories, the torrents in them are left
+// without category
+func (f *fakeQBittorrent) removeCategories(_ http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, category := range strings.Split(req.FormValue("categories"), "\n") {
+		delete(f.categories, category)
+		for hash, torrent := range f.torrents {
+			if torrent.Category == category {
+				torrent.Category = ""
+				f.torrents[hash] = torrent
+			}
+		}
+	}
+}
+
+func (f *fakeQBittorrent) listTags(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(f.tagNames())
+}
+
+// deleteTags deletes the tags, removing them from the torrents
+func (f *fakeQBittorrent) deleteTags(_ http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	deleted := strings.Split(req.FormValue("tags"), ",")
+	for _, tag := range deleted {
+		delete(f.tags, tag)
+	}
+	for hash, torrent := range f.torrents {
+		tags := slices.DeleteFunc(torrent.TagList(), func(tag string) bool {
+			return slices.Contains(deleted, tag)
+		})
+		torrent.Tags = strings.Join(tags, ",")
+		f.torrents[hash] = torrent
+	}
+}
+
+// setStateOf returns a handler setting the state of the torrents of the
+// request, the seeding ones to seedingState as qBittorrent keeps the UP
+// states of the stopped and started torrents
+func (f *fakeQBittorrent) setStateOf(state, seedingState string) http.HandlerFunc {
+	return func(_ http.ResponseWriter, req *http.Request) {
+		for _, hash := range strings.Split(req.FormValue("hashes"), "|") {
+			if strings.HasSuffix(f.torrentState(hash), "UP") || f.torrentState(hash) == "uploading" {
+				f.setState(hash, seedingState)
+			} else {
+				f.setState(hash, state)
+			}
+		}
+	}
+}
+
+// setLimit returns a handler setting the download or upload limit of the
+// torrents of the request
+func (f *fakeQBittorrent) setLimit(upload bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		limit, err := strconv.ParseInt(req.FormValue("limit"), 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for _, hash := range strings.Split(req.FormValue("hashes"), "|") {
+			if torrent, ok := f.torrents[hash]; ok {
+				if upload {
+					torrent.UPLimit = limit
+				} else {
+					torrent.DLLimit = limit
+				}
+				f.torrents[hash] = torrent
+			}
+		}
+	}
+}
+
+func (f *fakeQBittorrent) addTrackers(_ http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	hash := req.FormValue("hash")
+	f.trackers[hash] = append(f.trackers[hash], strings.Split(req.FormValue("urls"), "\n")...)
+}
+
+func (f *fakeQBittorrent) addTags(_ http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, hash := range strings.Split(req.FormValue("hashes"), "|") {
+		torrent, ok := f.torrents[hash]
+		if !ok {
+			continue
+		}
+		tags := torrent.TagList()
+		for _, tag := range strings.Split(req.FormValue("tags"), ",") {
+			f.tags[tag] = true
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		torrent.Tags = strings.Join(tags, ",")
+		f.torrents[hash] = torrent
+	}
+}
+
+func (f *fakeQBittorrent) delete(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failDelete {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	for _, hash := range strings.Split(req.FormValue("hashes"), "|") {
+		delete(f.torrents, hash)
+		f.deletedFiles[hash] = req.FormValue("deleteFiles") == "true"
+	}
+}
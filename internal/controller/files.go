@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// maxStatusFiles bounds the files reported in status.files, as its MaxItems,
+// so that torrents with many files stay well below the etcd object size limit
+const maxStatusFiles = 100
+
+// maxStatusFileName bounds the length of the file names reported in status
+const maxStatusFileName = 256
+
+// fileStatuses returns the status of the first files of a torrent, and the
+// number of files left out
+func fileStatuses(files []qbittorrent.FileInfo) ([]torrentv1beta1.TorrentFileStatus, int32) {
+	truncated := 0
+	if len(files) > maxStatusFiles {
+		truncated = len(files) - maxStatusFiles
+		files = files[:maxStatusFiles]
+	}
+
+	statuses := make([]torrentv1beta1.TorrentFileStatus, 0, len(files))
+	for _, file := range files {
+		statuses = append(statuses, torrentv1beta1.TorrentFileStatus{
+			Name:     truncateFileName(file.Name),
+			Size:     file.Size,
+			Progress: int32(math.Floor(file.Progress * 100)),
+			Priority: int32(file.Priority),
+		})
+	}
+	return statuses, int32(truncated)
+}
+
+// truncateFileName shortens the file names longer than maxStatusFileName,
+// keeping their end which holds the file name itself
+func truncateFileName(name string) string {
+	runes := []rune(name)
+	if len(runes) <= maxStatusFileName {
+		return name
+	}
+	return "..." + string(runes[len(runes)-maxStatusFileName+3:])
+}
+
+// reconcileFiles reports the progress of the files of the torrent in status
+// with spec.statusDetail Files or metadataOnly, and clears it otherwise. It
+// returns whether the status changed.
+func (r *TorrentReconciler) reconcileFiles(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) (bool, error) {
+	if torrent.Spec.StatusDetail != torrentv1beta1.StatusDetailFiles && !torrent.Spec.MetadataOnly {
+		updated := torrent.Status.Files != nil || torrent.Status.TruncatedFiles != 0
+		torrent.Status.Files = nil
+		torrent.Status.TruncatedFiles = 0
+		return updated, nil
+	}
+
+	files, err := r.qbt(torrent).GetTorrentFiles(ctx, qbTorrent.Hash)
+	if err != nil {
+		return false, fmt.Errorf("failed to get torrent files: %w", err)
+	}
+
+	statuses, truncated := fileStatuses(files)
+	if slices.Equal(torrent.Status.Files, statuses) && torrent.Status.TruncatedFiles == truncated {
+		return false, nil
+	}
+	torrent.Status.Files = statuses
+	torrent.Status.TruncatedFiles = truncated
+	return true, nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("File status", func() {
+	It("should report the progress of each file", func() {
+		statuses, truncated := fileStatuses([]qbittorrent.FileInfo{
+			{Name: "big_buck_bunny/movie.mp4", Size: 276134947, Progress: 0.4567, Priority: 1},
+			{Name: "big_buck_bunny/poster.jpg", Size: 310380, Progress: 1, Priority: 0},
+		})
+		Expect(truncated).To(BeZero())
+		Expect(statuses).To(Equal([]torrentv1beta1.TorrentFileStatus{
+			{Name: "big_buck_bunny/movie.mp4", Size: 276134947, Progress: 45, Priority: 1},
+			{Name: "big_buck_bunny/poster.jpg", Size: 310380, Progress: 100, Priority: 0},
+		}))
+	})
+
+	It("should cap the number of files", func() {
+		files := make([]qbittorrent.FileInfo, maxStatusFiles+20)
+		for i := range files {
+			files[i].Name = fmt.Sprintf("episode-%d.mkv", i)
+		}
+		statuses, truncated := fileStatuses(files)
+		Expect(statuses).To(HaveLen(maxStatusFiles))
+		Expect(truncated).To(BeEquivalentTo(20))
+	})
+
+	It("should keep the end of the long file names", func() {
+		name := strings.Repeat("é/", 200) + "movie.mp4"
+		truncatedName := truncateFileName(name)
+		Expect(utf8.RuneCountInString(truncatedName)).To(Equal(maxStatusFileName))
+		Expect(truncatedName).To(HavePrefix("..."))
+		Expect(truncatedName).To(HaveSuffix("/movie.mp4"))
+
+		Expect(truncateFileName("movie.mp4")).To(Equal("movie.mp4"))
+	})
+})
@@ -0,0 +1,174 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+// HardlinkCheckTimeout is how long the deletion of a Torrent waits for its
+// hardlink check Job, the files are kept past it
+const HardlinkCheckTimeout = 5 * time.Minute
+
+// Fail when files of CONTENT_PATH have other hardlinks, naming the first one
+// in the termination message of the pod. Missing content has nothing to keep.
+const checkHardlinksScript = `if [ ! -e "$CONTENT_PATH" ]; then
+  exit 0
+fi
+linked=$(find "$CONTENT_PATH" -type f -links +1)
+if [ -n "$linked" ]; then
+  count=$(echo "$linked" | wc -l)
+  echo "$count files have other hardlinks, e.g. $(echo "$linked" | head -n 1)" | tee /dev/termination-log
+  exit 1
+fi
+`
+
+// hardlinkCheckEnabled reports whether the files of the torrent are checked
+// for other hardlinks before they are deleted
+func hardlinkCheckEnabled(torrent *torrentv1beta1.Torrent) bool {
+	return torrent.Spec.HardlinkCheck != nil && torrent.Spec.HardlinkCheck.Enabled
+}
+
+// hardlinkCheckJobName returns the name of the hardlink check Job for a torrent
+func hardlinkCheckJobName(torrent *torrentv1beta1.Torrent) string {
+	return truncateName(torrent.Name, "-hardlinks")
+}
+
+// holdForHardlinks checks the content of the deleted torrent for other
+// hardlinks in a Job, before its files are deleted. It returns whether the
+// deletion waits for the Job, and whether the files are kept: some have
+// other hardlinks, or the check failed or timed out. The Job is left to the
+// garbage collector with the Torrent.
+func (r *TorrentReconciler) holdForHardlinks(ctx context.Context,
+	torrent *torrentv1beta1.Torrent) (held, keepFiles bool, err error) {
+	if !hardlinkCheckEnabled(torrent) || torrent.Status.Hash == "" || torrent.Status.ContentPath == "" ||
+		r.deletionPolicy(torrent) != torrentv1beta1.DeletionPolicyDelete {
+		return false, false, nil
+	}
+	logger := log.FromContext(ctx)
+	keep := func(reason string) (bool, bool, error) {
+		logger.Info("Keeping the files of the Torrent", "Name", torrent.Name, "Reason", reason)
+		r.recordEvent(torrent, corev1.EventTypeWarning, "HardlinkedFilesKept", fmt.Sprintf(
+			"Files of %s kept, the torrent is only removed from qBittorrent: %s", torrent.Status.ContentPath, reason))
+		return false, true, nil
+	}
+	if torrent.Spec.ContentVolume == nil {
+		return keep("the hardlink check requires spec.contentVolume to be set")
+	}
+
+	// Step 1: Get or create the check Job
+	job := &batchv1.Job{}
+	jobKey := types.NamespacedName{Name: hardlinkCheckJobName(torrent), Namespace: torrent.Namespace}
+	if err := r.Get(ctx, jobKey, job); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, false, fmt.Errorf("failed to get hardlink check job: %w", err)
+		}
+
+		job = r.hardlinkCheckJob(torrent)
+		if err := controllerutil.SetControllerReference(torrent, job, r.Scheme); err != nil {
+			return false, false, fmt.Errorf("failed to set owner reference on hardlink check job: %w", err)
+		}
+
+		logger.Info("Creating hardlink check Job", "Job", job.Name)
+		if err := r.Create(ctx, job); err != nil {
+			return false, false, fmt.Errorf("failed to create hardlink check job: %w", err)
+		}
+		return true, false, nil
+	}
+
+	// Step 2: Wait for the Job to finish
+	switch {
+	case job.Status.Succeeded > 0:
+		logger.Info("No file of the Torrent has other hardlinks", "Name", torrent.Name, "Job", job.Name)
+		return false, false, nil
+	case job.Status.Failed > 0 && job.Status.Active == 0:
+		return keep(r.jobFailureMessage(ctx, job))
+	case !job.CreationTimestamp.IsZero() && time.Since(job.CreationTimestamp.Time) > HardlinkCheckTimeout:
+		return keep(fmt.Sprintf("the hardlink check did not finish within %s", HardlinkCheckTimeout))
+	}
+	logger.V(1).Info("Hardlink check Job still running", "Job", job.Name)
+	return true, false, nil
+}
+
+// hardlinkCheckJob builds the Job checking the torrent content for other hardlinks
+func (r *TorrentReconciler) hardlinkCheckJob(torrent *torrentv1beta1.Torrent) *batchv1.Job {
+	image := torrent.Spec.HardlinkCheck.Image
+	if image == "" {
+		image = defaultChecksumImage
+	}
+
+	mountPath := torrent.Spec.ContentVolume.MountPath
+	if mountPath == "" {
+		mountPath = defaultMountPath
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hardlinkCheckJobName(torrent),
+			Namespace: torrent.Namespace,
+			Labels:    managedLabels(torrent),
+		},
+		Spec: batchv1.JobSpec{
+			// A failed check keeps the files, retrying would only delay the deletion
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: managedLabels(torrent),
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:    "check",
+						Image:   image,
+						Command: []string{"sh", "-c", checkHardlinksScript},
+						Env: []corev1.EnvVar{{
+							Name:  "CONTENT_PATH",
+							Value: torrent.Status.ContentPath,
+						}},
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      "content",
+							MountPath: mountPath,
+							ReadOnly:  true,
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "content",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: torrent.Spec.ContentVolume.ClaimName,
+								ReadOnly:  true,
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Torrent hardlink check", func() {
+	const magnetHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var recorder *record.FakeRecorder
+	var controllerReconciler *TorrentReconciler
+	var key, jobKey types.NamespacedName
+
+	reconcileTorrent := func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	// finishJob sets the outcome of the hardlink check Job
+	finishJob := func(succeeded bool) {
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, jobKey, job)).To(Succeed())
+		if succeeded {
+			job.Status.Succeeded = 1
+		} else {
+			job.Status.Failed = 1
+		}
+		Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+	}
+
+	expectTorrentGone := func() {
+		err := k8sClient.Get(ctx, key, &torrentv1beta1.Torrent{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		recorder = record.NewFakeRecorder(20)
+		key = types.NamespacedName{Name: "hardlink-check", Namespace: "default"}
+		jobKey = types.NamespacedName{Name: "hardlink-check-hardlinks", Namespace: key.Namespace}
+		controllerReconciler = &TorrentReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Clients:  poolOf(qb.client()),
+			Recorder: recorder,
+			Config:   &OperatorConfig{},
+		}
+
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source:        torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + magnetHash},
+				ContentVolume: &torrentv1beta1.ContentVolume{ClaimName: "media-pvc", MountPath: "/downloads"},
+				HardlinkCheck: &torrentv1beta1.HardlinkCheckSpec{Enabled: true},
+			},
+		})).To(Succeed())
+		reconcileTorrent()
+		reconcileTorrent()
+		qb.setCompleted(magnetHash, 1<<20)
+		qb.setContentPath(magnetHash, "/downloads/movie")
+		reconcileTorrent()
+
+		By("holding the deletion back while the content is checked for other hardlinks")
+		Expect(k8sClient.Delete(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		})).To(Succeed())
+		reconcileTorrent()
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, jobKey, job)).To(Succeed())
+		Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+			HaveField("Value", "/downloads/movie")))
+		Expect(qb.hashes()).To(ContainElement(magnetHash))
+
+		reconcileTorrent()
+		Expect(qb.hashes()).To(ContainElement(magnetHash))
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: jobKey.Name, Namespace: jobKey.Namespace},
+		}))).To(Succeed())
+		torrent := &torrentv1beta1.Torrent{}
+		if err := k8sClient.Get(ctx, key, torrent); err == nil {
+			controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+			Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		}
+	})
+
+	It("should delete the files without other hardlinks", func() {
+		finishJob(true)
+		reconcileTorrent()
+		deleted, withFiles := qb.filesDeleted(magnetHash)
+		Expect(deleted).To(BeTrue())
+		Expect(withFiles).To(BeTrue())
+		expectTorrentGone()
+	})
+
+	It("should keep the files hardlinked elsewhere", func() {
+		finishJob(false)
+		reconcileTorrent()
+		deleted, withFiles := qb.filesDeleted(magnetHash)
+		Expect(deleted).To(BeTrue())
+		Expect(withFiles).To(BeFalse())
+		Expect(recorder.Events).To(Receive(ContainSubstring("HardlinkedFilesKept")))
+		expectTorrentGone()
+	})
+})
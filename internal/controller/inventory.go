@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+// inventoryTopConsumers is the number of Torrents listed in the top consumers
+// of the inventory
+const inventoryTopConsumers = 10
+
+// bytesOnDisk returns the size of the content of the torrent downloaded
+func bytesOnDisk(torrent *torrentv1beta1.Torrent) int64 {
+	return max(torrent.Status.TotalSize-torrent.Status.AmountLeft, 0)
+}
+
+// inventory summarizes the Torrents of the server in all the namespaces from
+// their status, refreshed with the status of the QBittorrentServer
+func (r *QBittorrentServerReconciler) inventory(ctx context.Context,
+	server *torrentv1beta1.QBittorrentServer) (*torrentv1beta1.Inventory, error) {
+	torrents, err := r.managedTorrents(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := &torrentv1beta1.Inventory{Torrents: int32(len(torrents))}
+	var total int64
+	namespaces := map[string]*torrentv1beta1.NamespaceInventory{}
+	nsBytes := map[string]int64{}
+	for i := range torrents {
+		torrent := &torrents[i]
+		size := bytesOnDisk(torrent)
+		total += size
+
+		if state := string(torrent.Status.State); state != "" {
+			if inventory.States == nil {
+				inventory.States = map[string]int32{}
+			}
+			inventory.States[state]++
+		}
+		if category := torrent.Status.Category; category != "" {
+			if inventory.Categories == nil {
+				inventory.Categories = map[string]int32{}
+			}
+			inventory.Categories[category]++
+		}
+
+		namespace, ok := namespaces[torrent.Namespace]
+		if !ok {
+			namespace = &torrentv1beta1.NamespaceInventory{Namespace: torrent.Namespace}
+			namespaces[torrent.Namespace] = namespace
+		}
+		namespace.Torrents++
+		nsBytes[torrent.Namespace] += size
+	}
+	inventory.BytesOnDisk = *resource.NewQuantity(total, resource.BinarySI)
+
+	for name, namespace := range namespaces {
+		namespace.BytesOnDisk = *resource.NewQuantity(nsBytes[name], resource.BinarySI)
+		inventory.Namespaces = append(inventory.Namespaces, *namespace)
+	}
+	slices.SortFunc(inventory.Namespaces, func(a, b torrentv1beta1.NamespaceInventory) int {
+		return cmp.Compare(a.Namespace, b.Namespace)
+	})
+
+	// The largest first, by name for the same size so that the status only
+	// changes with the sizes
+	largest := slices.Clone(torrents)
+	slices.SortFunc(largest, func(a, b torrentv1beta1.Torrent) int {
+		if c := cmp.Compare(bytesOnDisk(&b), bytesOnDisk(&a)); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	for i := range largest[:min(len(largest), inventoryTopConsumers)] {
+		size := bytesOnDisk(&largest[i])
+		if size == 0 {
+			break
+		}
+		inventory.TopConsumers = append(inventory.TopConsumers, torrentv1beta1.TorrentUsage{
+			Namespace:   largest[i].Namespace,
+			Name:        largest[i].Name,
+			BytesOnDisk: *resource.NewQuantity(size, resource.BinarySI),
+		})
+	}
+	return inventory, nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Inventory", func() {
+	ctx := context.Background()
+
+	var fakeClient client.Client
+	var controllerReconciler *QBittorrentServerReconciler
+
+	manage := func(namespace, name, category string, state torrentv1beta1.TorrentState, totalSize, amountLeft int64) {
+		Expect(fakeClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status: torrentv1beta1.TorrentStatus{
+				Category:   category,
+				State:      state,
+				TotalSize:  totalSize,
+				AmountLeft: amountLeft,
+			},
+		})).To(Succeed())
+	}
+
+	server := &torrentv1beta1.QBittorrentServer{ObjectMeta: metav1.ObjectMeta{Name: DefaultServerName}}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(torrentv1beta1.AddToScheme(scheme)).To(Succeed())
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+		controllerReconciler = &QBittorrentServerReconciler{
+			Client:     fakeClient,
+			Scheme:     scheme,
+			ServerName: DefaultServerName,
+		}
+	})
+
+	It("should summarize the Torrents of all the namespaces", func() {
+		manage("media", "movie", "movies", torrentv1beta1.TorrentStateUploading, 4<<30, 0)
+		manage("media", "show", "tv", torrentv1beta1.TorrentStateDownloading, 2<<30, 1<<30)
+		manage("books", "novel", "books", torrentv1beta1.TorrentStateUploading, 1<<20, 0)
+		manage("books", "pending", "", "", 0, 0)
+
+		inventory, err := controllerReconciler.inventory(ctx, server)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inventory.Torrents).To(Equal(int32(4)))
+		Expect(inventory.BytesOnDisk.Value()).To(Equal(int64(5<<30 + 1<<20)))
+		Expect(inventory.States).To(Equal(map[string]int32{"uploading": 2, "downloading": 1}))
+		Expect(inventory.Categories).To(Equal(map[string]int32{"movies": 1, "tv": 1, "books": 1}))
+
+		Expect(inventory.Namespaces).To(HaveLen(2))
+		Expect(inventory.Namespaces[0].Namespace).To(Equal("books"))
+		Expect(inventory.Namespaces[0].Torrents).To(Equal(int32(2)))
+		Expect(inventory.Namespaces[0].BytesOnDisk.String()).To(Equal("1Mi"))
+		Expect(inventory.Namespaces[1].Namespace).To(Equal("media"))
+		Expect(inventory.Namespaces[1].BytesOnDisk.String()).To(Equal("5Gi"))
+
+		By("listing the largest Torrents first, without the empty ones")
+		Expect(inventory.TopConsumers).To(HaveLen(3))
+		Expect(inventory.TopConsumers[0].Name).To(Equal("movie"))
+		Expect(inventory.TopConsumers[0].BytesOnDisk.String()).To(Equal("4Gi"))
+		Expect(inventory.TopConsumers[1].Name).To(Equal("show"))
+		Expect(inventory.TopConsumers[2].Namespace).To(Equal("books"))
+	})
+
+	It("should only summarize the Torrents of the server", func() {
+		manage("media", "movie", "movies", torrentv1beta1.TorrentStateUploading, 4<<30, 0)
+		Expect(fakeClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: "seeded", Namespace: "media"},
+			Spec:       torrentv1beta1.TorrentSpec{ServerName: "seedbox"},
+			Status:     torrentv1beta1.TorrentStatus{State: torrentv1beta1.TorrentStateUploading, TotalSize: 1 << 30},
+		})).To(Succeed())
+
+		inventory, err := controllerReconciler.inventory(ctx, server)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inventory.Torrents).To(Equal(int32(1)))
+		Expect(inventory.BytesOnDisk.String()).To(Equal("4Gi"))
+
+		seedbox := &torrentv1beta1.QBittorrentServer{ObjectMeta: metav1.ObjectMeta{Name: "seedbox"}}
+		inventory, err = controllerReconciler.inventory(ctx, seedbox)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inventory.Torrents).To(Equal(int32(1)))
+		Expect(inventory.BytesOnDisk.String()).To(Equal("1Gi"))
+	})
+
+	It("should report an empty inventory without Torrents", func() {
+		inventory, err := controllerReconciler.inventory(ctx, server)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inventory.Torrents).To(BeZero())
+		Expect(inventory.BytesOnDisk.IsZero()).To(BeTrue())
+		Expect(inventory.TopConsumers).To(BeEmpty())
+	})
+})
@@ -0,0 +1,214 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+const (
+	// DefaultIPFilterRefreshInterval is the interval between the reloads of
+	// the IP filter when spec.preferences.ipFilter.refreshInterval is unset
+	DefaultIPFilterRefreshInterval = 24 * time.Hour
+
+	// ConfigMaps are capped at 1MiB, keep some room for metadata
+	maxBlocklistSize = 1000 * 1024
+
+	// ipFilterPropagationDelay is the time the kubelet takes to update a
+	// ConfigMap mounted into the qBittorrent container
+	ipFilterPropagationDelay = 2 * time.Minute
+)
+
+// ipFilterState is the IP filter qBittorrent was configured with
+type ipFilterState struct {
+	// path and filterTrackers are the applied settings
+	path           string
+	filterTrackers bool
+	// reloadTime is when the filter was last reloaded, downloadTime when the
+	// blocklist was last downloaded
+	reloadTime   time.Time
+	downloadTime time.Time
+	// blocklistHash is the hash of the blocklist in the ConfigMap, and
+	// reloadAfter when the blocklist changed and reaches qBittorrent
+	blocklistHash string
+	reloadAfter   time.Time
+}
+
+// ipFilterRefreshInterval returns the refresh interval of the IP filter
+func ipFilterRefreshInterval(filter *torrentv1beta1.IPFilter) time.Duration {
+	if filter.RefreshInterval != nil && filter.RefreshInterval.Duration > 0 {
+		return filter.RefreshInterval.Duration
+	}
+	return DefaultIPFilterRefreshInterval
+}
+
+// needsReload reports whether the IP filter is to be reloaded, because its
+// settings or blocklist changed or it was not reloaded for an interval
+func (s *ipFilterState) needsReload(filter *torrentv1beta1.IPFilter, now time.Time) bool {
+	return s.path != filter.Path || s.filterTrackers != filter.FilterTrackers || s.reloadTime.IsZero() ||
+		now.Sub(s.reloadTime) >= ipFilterRefreshInterval(filter) ||
+		(!s.reloadAfter.IsZero() && !now.Before(s.reloadAfter))
+}
+
+// observeBlocklist records the hash of the blocklist. A changed blocklist
+// is reloaded once the kubelet updated the mounted ConfigMap.
+func (s *ipFilterState) observeBlocklist(blocklist []byte, now time.Time) {
+	sum := sha256.Sum256(blocklist)
+	hash := hex.EncodeToString(sum[:])
+	if s.blocklistHash != "" && s.blocklistHash != hash {
+		s.reloadAfter = now.Add(ipFilterPropagationDelay)
+	}
+	s.blocklistHash = hash
+}
+
+// reconcileIPFilter configures the IP filter of qBittorrent, downloading the
+// blocklist into its ConfigMap, and returns when it was last reloaded. The
+// filter is left as configured in the WebUI when the server sets none.
+func (r *QBittorrentServerReconciler) reconcileIPFilter(ctx context.Context, conn *connection,
+	server *torrentv1beta1.QBittorrentServer) (*metav1.Time, error) {
+	if server.Spec.Preferences == nil || server.Spec.Preferences.IPFilter == nil {
+		conn.ipFilter = ipFilterState{}
+		return nil, nil
+	}
+	filter := server.Spec.Preferences.IPFilter
+	logger := log.FromContext(ctx)
+	now := time.Now()
+
+	// Download the blocklist into its ConfigMap
+	if filter.URL != "" && (conn.ipFilter.downloadTime.IsZero() ||
+		now.Sub(conn.ipFilter.downloadTime) >= ipFilterRefreshInterval(filter)) {
+		blocklist, err := r.qbt(server).FetchBlocklist(ctx, filter.URL, maxBlocklistSize)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.writeBlocklist(ctx, filter.ConfigMap, blocklist); err != nil {
+			return nil, err
+		}
+		conn.ipFilter.downloadTime = now
+	}
+
+	if filter.ConfigMap != nil {
+		blocklist, err := r.readBlocklist(ctx, filter.ConfigMap)
+		if err != nil {
+			return nil, err
+		}
+		conn.ipFilter.observeBlocklist(blocklist, now)
+	}
+
+	if conn.ipFilter.needsReload(filter, now) {
+		logger.Info("Reloading the qBittorrent IP filter", "Path", filter.Path, "FilterTrackers", filter.FilterTrackers)
+
+		// qBittorrent only reads the blocklist again when the filter is enabled
+		if err := r.qbt(server).SetPreferences(ctx, map[string]any{"ip_filter_enabled": false}); err != nil {
+			return nil, fmt.Errorf("failed to disable the IP filter: %w", err)
+		}
+		if err := r.qbt(server).SetPreferences(ctx, map[string]any{
+			"ip_filter_enabled":  true,
+			"ip_filter_path":     filter.Path,
+			"ip_filter_trackers": filter.FilterTrackers,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to enable the IP filter: %w", err)
+		}
+		conn.ipFilter.path, conn.ipFilter.filterTrackers = filter.Path, filter.FilterTrackers
+		conn.ipFilter.reloadTime, conn.ipFilter.reloadAfter = now, time.Time{}
+	}
+
+	reloadTime := metav1.NewTime(conn.ipFilter.reloadTime)
+	return &reloadTime, nil
+}
+
+// readBlocklist reads the blocklist from its ConfigMap
+func (r *QBittorrentServerReconciler) readBlocklist(ctx context.Context,
+	ref *torrentv1beta1.BlocklistConfigMap) ([]byte, error) {
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+	if err := r.reader().Get(ctx, key, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get blocklist ConfigMap: %w", err)
+	}
+	blocklist, ok := blocklistData(configMap, ref.Key)
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in blocklist ConfigMap %s", ref.Key, key)
+	}
+	return blocklist, nil
+}
+
+// blocklistData returns the blocklist of a ConfigMap key, from its data or
+// binary data
+func blocklistData(configMap *corev1.ConfigMap, key string) ([]byte, bool) {
+	if data, ok := configMap.Data[key]; ok {
+		return []byte(data), true
+	}
+	data, ok := configMap.BinaryData[key]
+	return data, ok
+}
+
+// writeBlocklist stores the downloaded blocklist in its ConfigMap, as binary
+// data for the .p2b format
+func (r *QBittorrentServerReconciler) writeBlocklist(ctx context.Context,
+	ref *torrentv1beta1.BlocklistConfigMap, blocklist []byte) error {
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+	err := r.reader().Get(ctx, key, configMap)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get blocklist ConfigMap: %w", err)
+	}
+	found := err == nil
+	if current, ok := blocklistData(configMap, ref.Key); ok && bytes.Equal(current, blocklist) {
+		return nil
+	}
+
+	configMap.Namespace, configMap.Name = ref.Namespace, ref.Name
+	delete(configMap.Data, ref.Key)
+	delete(configMap.BinaryData, ref.Key)
+	if utf8.Valid(blocklist) {
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[ref.Key] = string(blocklist)
+	} else {
+		if configMap.BinaryData == nil {
+			configMap.BinaryData = map[string][]byte{}
+		}
+		configMap.BinaryData[ref.Key] = blocklist
+	}
+
+	log.FromContext(ctx).Info("Storing the downloaded blocklist", "ConfigMap", key, "Size", len(blocklist))
+	if !found {
+		if err := r.Create(ctx, configMap); err != nil {
+			return fmt.Errorf("failed to create blocklist ConfigMap: %w", err)
+		}
+		return nil
+	}
+	if err := r.Update(ctx, configMap); err != nil {
+		return fmt.Errorf("failed to update blocklist ConfigMap: %w", err)
+	}
+	return nil
+}
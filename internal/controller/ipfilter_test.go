@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("IP filter", func() {
+	var filter *torrentv1beta1.IPFilter
+	var state ipFilterState
+	now := time.Now()
+
+	BeforeEach(func() {
+		filter = &torrentv1beta1.IPFilter{Path: "/blocklists/blocklist.p2p"}
+		state = ipFilterState{path: filter.Path, reloadTime: now}
+	})
+
+	It("should be reloaded when never loaded or its settings changed", func() {
+		Expect(state.needsReload(filter, now)).To(BeFalse())
+		Expect((&ipFilterState{}).needsReload(filter, now)).To(BeTrue())
+
+		filter.FilterTrackers = true
+		Expect(state.needsReload(filter, now)).To(BeTrue())
+	})
+
+	It("should be reloaded on schedule", func() {
+		Expect(state.needsReload(filter, now.Add(DefaultIPFilterRefreshInterval))).To(BeTrue())
+
+		filter.RefreshInterval = &metav1.Duration{Duration: time.Hour}
+		Expect(state.needsReload(filter, now.Add(30*time.Minute))).To(BeFalse())
+		Expect(state.needsReload(filter, now.Add(time.Hour))).To(BeTrue())
+	})
+
+	It("should be reloaded once a changed blocklist reached qBittorrent", func() {
+		state.observeBlocklist([]byte("Bad peers:203.0.113.0-203.0.113.255\n"), now)
+		Expect(state.reloadAfter.IsZero()).To(BeTrue())
+
+		state.observeBlocklist([]byte("Bad peers:203.0.113.0-203.0.113.255\n"), now)
+		Expect(state.reloadAfter.IsZero()).To(BeTrue())
+
+		state.observeBlocklist([]byte("Bad peers:198.51.100.0-198.51.100.255\n"), now)
+		Expect(state.needsReload(filter, now)).To(BeFalse())
+		Expect(state.needsReload(filter, now.Add(ipFilterPropagationDelay))).To(BeTrue())
+	})
+})
@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+// backendCategory returns the qBittorrent category of the torrent. With
+// namespace isolation, the category is a subcategory of the namespace one.
+func (r *TorrentReconciler) backendCategory(torrent *torrentv1beta1.Torrent) string {
+	if !r.IsolateNamespaces {
+		return torrent.Spec.Category
+	}
+	return namespaceCategory(torrent.Namespace, torrent.Spec.Category)
+}
+
+// namespaceCategory returns the category as a subcategory of the namespace one
+func namespaceCategory(namespace, category string) string {
+	if category == "" {
+		return namespace
+	}
+	return namespace + "/" + category
+}
+
+// backendSavePath returns the qBittorrent save path of the torrent. With
+// namespace isolation and a save path root, the save path is confined to the
+// folder of the namespace under the root: a relative save path is resolved
+// against it, an absolute one must be in it.
+func (r *TorrentReconciler) backendSavePath(torrent *torrentv1beta1.Torrent) (string, error) {
+	if !r.IsolateNamespaces || r.SavePathRoot == "" {
+		return torrent.Spec.SavePath, nil
+	}
+	return confineSavePath(r.SavePathRoot, torrent.Namespace, torrent.Spec.SavePath)
+}
+
+// confineSavePath resolves the save path against the folder of the namespace
+// under root, failing when it is outside of it
+func confineSavePath(root, namespace, savePath string) (string, error) {
+	namespaceRoot := path.Join(root, namespace)
+	confined := savePath
+	if !path.IsAbs(confined) {
+		confined = path.Join(namespaceRoot, confined)
+	}
+	confined = path.Clean(confined)
+	if confined != namespaceRoot && !strings.HasPrefix(confined, namespaceRoot+"/") {
+		return "", fmt.Errorf("save path %q is outside of the namespace folder %q", savePath, namespaceRoot)
+	}
+	return confined, nil
+}
+
+// labelTagPrefix returns the prefix of the tags mirrored from the labels of
+// the torrent. With namespace isolation, the prefix includes the namespace.
+func (r *TorrentReconciler) labelTagPrefix(torrent *torrentv1beta1.Torrent) string {
+	if !r.IsolateNamespaces {
+		return r.LabelTagPrefix
+	}
+	return r.LabelTagPrefix + torrent.Namespace + "/"
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Namespace isolation", func() {
+	var torrent *torrentv1beta1.Torrent
+	var r *TorrentReconciler
+
+	BeforeEach(func() {
+		torrent = &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: "ubuntu", Namespace: "media"},
+			Spec:       torrentv1beta1.TorrentSpec{Category: "linux", SavePath: "isos"},
+		}
+		r = &TorrentReconciler{
+			LabelTagPrefix:    DefaultLabelTagPrefix,
+			IsolateNamespaces: true,
+			SavePathRoot:      "/downloads",
+		}
+	})
+
+	It("should leave the spec untouched when disabled", func() {
+		r.IsolateNamespaces = false
+		Expect(r.backendCategory(torrent)).To(Equal("linux"))
+		Expect(r.backendSavePath(torrent)).To(Equal("isos"))
+		Expect(r.labelTagPrefix(torrent)).To(Equal("k8s:"))
+	})
+
+	It("should prefix the category and the label tags with the namespace", func() {
+		Expect(r.backendCategory(torrent)).To(Equal("media/linux"))
+		Expect(r.labelTagPrefix(torrent)).To(Equal("k8s:media/"))
+
+		torrent.Spec.Category = ""
+		Expect(r.backendCategory(torrent)).To(Equal("media"))
+	})
+
+	It("should confine the save path to the namespace folder", func() {
+		Expect(r.backendSavePath(torrent)).To(Equal("/downloads/media/isos"))
+
+		torrent.Spec.SavePath = ""
+		Expect(r.backendSavePath(torrent)).To(Equal("/downloads/media"))
+
+		torrent.Spec.SavePath = "/downloads/media/isos"
+		Expect(r.backendSavePath(torrent)).To(Equal("/downloads/media/isos"))
+
+		for _, savePath := range []string{"../other", "/downloads/other", "/downloads/mediaextra", "/etc"} {
+			torrent.Spec.SavePath = savePath
+			_, err := r.backendSavePath(torrent)
+			Expect(err).To(HaveOccurred(), savePath)
+		}
+	})
+
+	It("should not confine the save path without root", func() {
+		r.SavePathRoot = ""
+		torrent.Spec.SavePath = "/anywhere"
+		Expect(r.backendSavePath(torrent)).To(Equal("/anywhere"))
+	})
+})
@@ -0,0 +1,163 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+// DefaultJanitorInterval is the interval between the cleanups of the unused
+// categories and tags when spec.janitor.interval is not set
+const DefaultJanitorInterval = time.Hour
+
+func janitorInterval(janitor *torrentv1beta1.Janitor) time.Duration {
+	if janitor.Interval != nil && janitor.Interval.Duration > 0 {
+		return janitor.Interval.Duration
+	}
+	return DefaultJanitorInterval
+}
+
+// reconcileJanitor records the categories and tags of the managed torrents
+// on every reconcile and, every interval, removes from qBittorrent the
+// recorded ones no torrent uses anymore. It returns the status of the janitor.
+func (r *QBittorrentServerReconciler) reconcileJanitor(ctx context.Context,
+	server *torrentv1beta1.QBittorrentServer) (*torrentv1beta1.JanitorStatus, error) {
+	janitor := server.Spec.Janitor
+	if janitor == nil || (!janitor.Categories && !janitor.Tags) {
+		return nil, nil
+	}
+
+	torrents, err := r.managedTorrents(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+	// Only the categories set on qBittorrent are recorded. The ones of the
+	// spec are never removed either, their torrent may be on its way.
+	managedCategories, managedTags := map[string]bool{}, map[string]bool{}
+	usedCategories, usedTags := map[string]bool{}, map[string]bool{}
+	for _, torrent := range torrents {
+		if torrent.Status.Category != "" {
+			managedCategories[torrent.Status.Category] = true
+		}
+		for _, tag := range torrent.Status.Tags {
+			managedTags[tag] = true
+		}
+		for _, category := range []string{torrent.Spec.Category, torrent.Annotations[AnnotationBackendCategory]} {
+			if category != "" {
+				usedCategories[category] = true
+			}
+		}
+	}
+
+	status := &torrentv1beta1.JanitorStatus{}
+	if server.Status.Janitor != nil {
+		status = server.Status.Janitor.DeepCopy()
+	}
+	status.Categories = recordNames(janitor.Categories, status.Categories, managedCategories)
+	status.Tags = recordNames(janitor.Tags, status.Tags, managedTags)
+	if status.LastCleanupTime != nil && time.Since(status.LastCleanupTime.Time) < janitorInterval(janitor) {
+		return status, nil
+	}
+
+	infos, err := r.qbt(server).GetTorrentsInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the torrents: %w", err)
+	}
+	for i := range infos {
+		if infos[i].Category != "" {
+			usedCategories[infos[i].Category] = true
+		}
+		for _, tag := range infos[i].TagList() {
+			usedTags[tag] = true
+		}
+	}
+	maps.Copy(usedCategories, managedCategories)
+	maps.Copy(usedTags, managedTags)
+
+	if janitor.Categories {
+		existing, err := r.qbt(server).GetCategories(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the categories: %w", err)
+		}
+		var unused []string
+		status.Categories, unused = sortUnused(status.Categories, existing, usedCategories)
+		if len(unused) > 0 {
+			log.FromContext(ctx).Info("Removing the unused categories", "Categories", unused)
+			if err := r.qbt(server).RemoveCategories(ctx, unused); err != nil {
+				return nil, fmt.Errorf("failed to remove the unused categories: %w", err)
+			}
+		}
+	}
+	if janitor.Tags {
+		existing, err := r.qbt(server).GetTags(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the tags: %w", err)
+		}
+		var unused []string
+		status.Tags, unused = sortUnused(status.Tags, existing, usedTags)
+		if len(unused) > 0 {
+			log.FromContext(ctx).Info("Removing the unused tags", "Tags", unused)
+			if err := r.qbt(server).DeleteTags(ctx, unused); err != nil {
+				return nil, fmt.Errorf("failed to delete the unused tags: %w", err)
+			}
+		}
+	}
+
+	now := metav1.Now()
+	status.LastCleanupTime = &now
+	return status, nil
+}
+
+// recordNames adds the names to the recorded ones, sorted, or forgets them
+// all when their cleanup is disabled
+func recordNames(enabled bool, recorded []string, names map[string]bool) []string {
+	if !enabled {
+		return nil
+	}
+	for name := range names {
+		if !slices.Contains(recorded, name) {
+			recorded = append(recorded, name)
+		}
+	}
+	slices.Sort(recorded)
+	return recorded
+}
+
+// sortUnused splits the recorded names still on qBittorrent into the used
+// ones, kept recorded, and the unused ones to remove. The names no longer on
+// qBittorrent are forgotten.
+func sortUnused(recorded, existing []string, used map[string]bool) (kept, unused []string) {
+	for _, name := range recorded {
+		switch {
+		case !slices.Contains(existing, name):
+		case used[name]:
+			kept = append(kept, name)
+		default:
+			unused = append(unused, name)
+		}
+	}
+	return kept, unused
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Janitor", func() {
+	const (
+		moviesHash    = "c9e15763f722f23e98a29decdfae341b98d53056"
+		showsHash     = "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"
+		unmanagedHash = "5f5e8848426129ab63cb4db717bb54193c1c1ad7"
+	)
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var fakeClient client.Client
+	var controllerReconciler *QBittorrentServerReconciler
+
+	addTorrent := func(hash, category string, tags ...string) {
+		Expect(qb.client().AddTorrent(ctx, "magnet:?xt=urn:btih:"+hash)).To(Succeed())
+		qb.setCategoryAndTags(hash, category, tags...)
+	}
+
+	manage := func(hash, category string, tags ...string) {
+		Expect(fakeClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: hash[:8], Namespace: "default"},
+			Status:     torrentv1beta1.TorrentStatus{Hash: hash, Category: category, Tags: tags},
+		})).To(Succeed())
+	}
+
+	getServer := func() *torrentv1beta1.QBittorrentServer {
+		server := &torrentv1beta1.QBittorrentServer{}
+		Expect(fakeClient.Get(ctx, types.NamespacedName{Name: DefaultServerName}, server)).To(Succeed())
+		return server
+	}
+
+	// reconcileJanitor runs a cleanup, as if the interval elapsed
+	reconcileJanitor := func() *torrentv1beta1.JanitorStatus {
+		server := getServer()
+		if server.Status.Janitor != nil {
+			server.Status.Janitor.LastCleanupTime = nil
+		}
+		status, err := controllerReconciler.reconcileJanitor(ctx, server)
+		Expect(err).NotTo(HaveOccurred())
+		server.Status.Janitor = status
+		Expect(fakeClient.Status().Update(ctx, server)).To(Succeed())
+		return status
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+
+		scheme := runtime.NewScheme()
+		Expect(torrentv1beta1.AddToScheme(scheme)).To(Succeed())
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&torrentv1beta1.QBittorrentServer{}).
+			WithObjects(&torrentv1beta1.QBittorrentServer{
+				ObjectMeta: metav1.ObjectMeta{Name: DefaultServerName},
+				Spec: torrentv1beta1.QBittorrentServerSpec{
+					Janitor: &torrentv1beta1.Janitor{Categories: true, Tags: true},
+				},
+			}).
+			Build()
+		controllerReconciler = &QBittorrentServerReconciler{
+			Client:     fakeClient,
+			Scheme:     scheme,
+			Clients:    poolOf(qb.client()),
+			ServerName: DefaultServerName,
+		}
+
+		addTorrent(moviesHash, "movies", "k8s:app=radarr")
+		addTorrent(showsHash, "tv", "k8s:app=sonarr", "shared")
+		addTorrent(unmanagedHash, "music", "shared")
+		manage(moviesHash, "movies", "k8s:app=radarr")
+		manage(showsHash, "tv", "k8s:app=sonarr", "shared")
+	})
+
+	AfterEach(func() {
+		qb.Close()
+	})
+
+	It("should remove the categories and tags of the managed torrents once unused", func() {
+		status := reconcileJanitor()
+		Expect(status.Categories).To(Equal([]string{"movies", "tv"}))
+		Expect(status.Tags).To(Equal([]string{"k8s:app=radarr", "k8s:app=sonarr", "shared"}))
+		Expect(status.LastCleanupTime).NotTo(BeNil())
+		Expect(qb.categoryNames()).To(Equal([]string{"movies", "music", "tv"}))
+
+		By("keeping them while the Torrents exist, even without their torrent")
+		Expect(qb.client().DeleteTorrent(ctx, showsHash, false)).To(Succeed())
+		reconcileJanitor()
+		Expect(qb.categoryNames()).To(Equal([]string{"movies", "music", "tv"}))
+		Expect(qb.tagNames()).To(ContainElement("k8s:app=sonarr"))
+
+		By("removing the ones left unused once the Torrent is deleted")
+		Expect(fakeClient.Delete(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: showsHash[:8], Namespace: "default"},
+		})).To(Succeed())
+		status = reconcileJanitor()
+		Expect(qb.categoryNames()).To(Equal([]string{"movies", "music"}))
+		Expect(qb.tagNames()).To(Equal([]string{"k8s:app=radarr", "shared"}))
+		Expect(status.Categories).To(Equal([]string{"movies"}))
+		Expect(status.Tags).To(Equal([]string{"k8s:app=radarr", "shared"}))
+	})
+
+	It("should leave the categories and tags alone until the interval elapsed", func() {
+		reconcileJanitor()
+		Expect(fakeClient.Delete(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: moviesHash[:8], Namespace: "default"},
+		})).To(Succeed())
+		Expect(qb.client().DeleteTorrent(ctx, moviesHash, false)).To(Succeed())
+
+		status, err := controllerReconciler.reconcileJanitor(ctx, getServer())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Categories).To(ContainElement("movies"))
+		Expect(qb.categoryNames()).To(ContainElement("movies"))
+		Expect(qb.tagNames()).To(ContainElement("k8s:app=radarr"))
+	})
+
+	It("should forget the recorded categories and tags once disabled", func() {
+		reconcileJanitor()
+		server := getServer()
+		server.Spec.Janitor = nil
+		status, err := controllerReconciler.reconcileJanitor(ctx, server)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(BeNil())
+	})
+})
@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// Default prefix of the tags mirrored from Torrent labels
+const DefaultLabelTagPrefix = "k8s:"
+
+// desiredLabelTags returns the qBittorrent tags mirroring the selected labels
+// of the torrent, formatted as <prefix><key>=<value>
+func (r *TorrentReconciler) desiredLabelTags(torrent *torrentv1beta1.Torrent) map[string]bool {
+	tags := map[string]bool{}
+	for _, key := range r.LabelTagKeys {
+		if value, ok := torrent.Labels[key]; ok {
+			// Commas separate tags in the qBittorrent API
+			tag := r.labelTagPrefix(torrent) + key + "=" + strings.ReplaceAll(value, ",", "_")
+			tags[tag] = true
+		}
+	}
+	return tags
+}
+
+// syncLabelTags mirrors the selected labels of the torrent into qBittorrent tags.
+// Only tags carrying the label tag prefix are managed, other tags are left untouched.
+// Without a prefix every tag would be managed, so nothing is synced.
+func (r *TorrentReconciler) syncLabelTags(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) error {
+	logger := log.FromContext(ctx)
+
+	if r.LabelTagPrefix == "" {
+		logger.V(1).Info("No label tag prefix, not syncing the label tags")
+		return nil
+	}
+
+	desired := r.desiredLabelTags(torrent)
+
+	var toRemove []string
+	current := map[string]bool{}
+	for _, tag := range qbTorrent.TagList() {
+		if !strings.HasPrefix(tag, r.labelTagPrefix(torrent)) {
+			continue
+		}
+		current[tag] = true
+		if !desired[tag] {
+			toRemove = append(toRemove, tag)
+		}
+	}
+
+	var toAdd []string
+	for tag := range desired {
+		if !current[tag] {
+			toAdd = append(toAdd, tag)
+		}
+	}
+
+	if len(toRemove) > 0 {
+		logger.Info("Removing label tags", "Tags", toRemove)
+		if err := r.qbt(torrent).RemoveTags(ctx, qbTorrent.Hash, toRemove); err != nil {
+			return fmt.Errorf("failed to remove label tags: %w", err)
+		}
+	}
+
+	if len(toAdd) > 0 {
+		logger.Info("Adding label tags", "Tags", toAdd)
+		if err := r.qbt(torrent).AddTags(ctx, qbTorrent.Hash, toAdd); err != nil {
+			return fmt.Errorf("failed to add label tags: %w", err)
+		}
+	}
+
+	return nil
+}
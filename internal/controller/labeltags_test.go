@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("Label tags", func() {
+	const hash = "c9e15763f722f23e98a29decdfae341b98d53056"
+
+	ctx := context.Background()
+
+	var webUI *httptest.Server
+	var added, removed []string
+	var r *TorrentReconciler
+	var torrent *torrentv1beta1.Torrent
+
+	BeforeEach(func() {
+		added, removed = nil, nil
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/v2/torrents/addTags", func(_ http.ResponseWriter, req *http.Request) {
+			added = append(added, req.FormValue("hashes")+":"+req.FormValue("tags"))
+		})
+		mux.HandleFunc("/api/v2/torrents/removeTags", func(_ http.ResponseWriter, req *http.Request) {
+			removed = append(removed, req.FormValue("hashes")+":"+req.FormValue("tags"))
+		})
+		webUI = httptest.NewServer(mux)
+
+		r = &TorrentReconciler{
+			Clients:        poolOf(qbittorrent.NewClient(webUI.URL)),
+			LabelTagKeys:   []string{"team", "tier"},
+			LabelTagPrefix: DefaultLabelTagPrefix,
+		}
+		torrent = &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ubuntu",
+				Namespace: "default",
+				Labels:    map[string]string{"team": "linux,iso", "app": "downloads"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		webUI.Close()
+	})
+
+	It("should mirror the selected labels and leave the other tags untouched", func() {
+		qbTorrent := &qbittorrent.TorrentInfo{Hash: hash, Tags: "k8s:team=old, k8s:tier=gold, manual"}
+		Expect(r.syncLabelTags(ctx, torrent, qbTorrent)).To(Succeed())
+		// Commas separate tags in the qBittorrent API
+		Expect(added).To(ConsistOf(hash + ":k8s:team=linux_iso"))
+		Expect(removed).To(ConsistOf(SatisfyAll(
+			HavePrefix(hash+":"), ContainSubstring("k8s:team=old"), ContainSubstring("k8s:tier=gold"),
+		)))
+	})
+
+	It("should not call qBittorrent when the tags are in sync", func() {
+		qbTorrent := &qbittorrent.TorrentInfo{Hash: hash, Tags: "k8s:team=linux_iso, manual"}
+		Expect(r.syncLabelTags(ctx, torrent, qbTorrent)).To(Succeed())
+		Expect(added).To(BeEmpty())
+		Expect(removed).To(BeEmpty())
+	})
+
+	It("should leave every tag untouched without a prefix", func() {
+		r.LabelTagPrefix = ""
+		qbTorrent := &qbittorrent.TorrentInfo{Hash: hash, Tags: "k8s:team=old, manual"}
+		Expect(r.syncLabelTags(ctx, torrent, qbTorrent)).To(Succeed())
+		Expect(added).To(BeEmpty())
+		Expect(removed).To(BeEmpty())
+	})
+})
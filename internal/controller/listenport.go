@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// The dynamic port range the rotated listen ports are picked from
+const (
+	minRotatedPort = 49152
+	maxRotatedPort = 65535
+)
+
+// randomListenPort picks a random port of the dynamic range, other than the
+// current one
+func randomListenPort(current int32) int32 {
+	for {
+		port := int32(minRotatedPort + rand.IntN(maxRotatedPort-minRotatedPort+1))
+		if port != current {
+			return port
+		}
+	}
+}
+
+// desiredListenPort returns the port qBittorrent should listen on, or 0 to
+// leave it unchanged, and whether it is a rotated port. The rotation is
+// tracked by the rotation time of the status, so that it survives restarts
+// of the operator.
+func desiredListenPort(listenPort *torrentv1beta1.ListenPort, current int32,
+	lastRotation *metav1.Time, now time.Time) (int32, bool) {
+	if listenPort.RotationInterval != nil {
+		if lastRotation == nil || now.Sub(lastRotation.Time) >= listenPort.RotationInterval.Duration {
+			return randomListenPort(current), true
+		}
+		return 0, false
+	}
+	return listenPort.Port, false
+}
+
+// reconcileListenPort reports the port qBittorrent listens on in status, and
+// pins or rotates it as set in the server preferences
+func (r *QBittorrentServerReconciler) reconcileListenPort(ctx context.Context,
+	server *torrentv1beta1.QBittorrentServer, preferences *qbittorrent.Preferences,
+	status *torrentv1beta1.QBittorrentServerStatus) error {
+	status.ListenPort = preferences.ListenPort
+
+	if server.Spec.Preferences == nil || server.Spec.Preferences.ListenPort == nil {
+		return nil
+	}
+	listenPort := server.Spec.Preferences.ListenPort
+	if listenPort.RotationInterval != nil {
+		status.ListenPortRotationTime = server.Status.ListenPortRotationTime
+	}
+
+	now := metav1.Now()
+	port, rotated := desiredListenPort(listenPort, preferences.ListenPort, server.Status.ListenPortRotationTime, now.Time)
+	if listenPort.ForwardedPort != nil {
+		var err error
+		if port, err = r.readForwardedPort(ctx, listenPort.ForwardedPort); err != nil {
+			return err
+		}
+	}
+	if port == 0 || (port == preferences.ListenPort && !preferences.RandomPort) {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Setting the qBittorrent listen port", "Port", port, "Previous", preferences.ListenPort,
+		"Rotated", rotated)
+	// A random port picked by qBittorrent on startup would replace it
+	if err := r.qbt(server).SetPreferences(ctx, map[string]any{"listen_port": port, "random_port": false}); err != nil {
+		return fmt.Errorf("failed to set the listen port: %w", err)
+	}
+	status.ListenPort = port
+	if rotated {
+		status.ListenPortRotationTime = &now
+	}
+	return nil
+}
+
+// readForwardedPort reads the port forwarded by the VPN from its Secret or
+// ConfigMap. It is read on every refresh, following the port when it changes.
+func (r *QBittorrentServerReconciler) readForwardedPort(ctx context.Context,
+	source *torrentv1beta1.ForwardedPortSource) (int32, error) {
+	var data string
+	if source.SecretName != "" {
+		key, err := r.secretName(source.Namespace, source.SecretName)
+		if err != nil {
+			return 0, err
+		}
+		secret := &corev1.Secret{}
+		if err := r.reader().Get(ctx, key, secret); err != nil {
+			return 0, fmt.Errorf("failed to get forwarded port Secret: %w", err)
+		}
+		value, ok := secret.Data[source.Key]
+		if !ok {
+			return 0, fmt.Errorf("key %s not found in forwarded port Secret %s", source.Key, key)
+		}
+		data = string(value)
+	} else {
+		configMap := &corev1.ConfigMap{}
+		key := types.NamespacedName{Namespace: source.Namespace, Name: source.ConfigMapName}
+		if err := r.reader().Get(ctx, key, configMap); err != nil {
+			return 0, fmt.Errorf("failed to get forwarded port ConfigMap: %w", err)
+		}
+		value, ok := configMap.Data[source.Key]
+		if !ok {
+			return 0, fmt.Errorf("key %s not found in forwarded port ConfigMap %s", source.Key, key)
+		}
+		data = value
+	}
+	return parseForwardedPort(data)
+}
+
+// parseForwardedPort parses the port written by a VPN sidecar, ignoring the
+// surrounding whitespace, e.g. the trailing newline of the gluetun file
+func parseForwardedPort(data string) (int32, error) {
+	port, err := strconv.ParseInt(strings.TrimSpace(data), 10, 32)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid forwarded port %q", strings.TrimSpace(data))
+	}
+	return int32(port), nil
+}
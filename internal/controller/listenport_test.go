@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Listen port", func() {
+	now := time.Now()
+
+	It("should pin the port of the spec", func() {
+		port, rotated := desiredListenPort(&torrentv1beta1.ListenPort{Port: 51413}, 6881, nil, now)
+		Expect(port).To(Equal(int32(51413)))
+		Expect(rotated).To(BeFalse())
+	})
+
+	It("should rotate the port on schedule", func() {
+		listenPort := &torrentv1beta1.ListenPort{RotationInterval: &metav1.Duration{Duration: time.Hour}}
+
+		port, rotated := desiredListenPort(listenPort, 6881, nil, now)
+		Expect(rotated).To(BeTrue())
+		Expect(port).To(BeNumerically(">=", minRotatedPort))
+		Expect(port).To(BeNumerically("<=", maxRotatedPort))
+
+		lastRotation := metav1.NewTime(now.Add(-30 * time.Minute))
+		port, rotated = desiredListenPort(listenPort, 6881, &lastRotation, now)
+		Expect(port).To(BeZero())
+		Expect(rotated).To(BeFalse())
+
+		lastRotation = metav1.NewTime(now.Add(-time.Hour))
+		_, rotated = desiredListenPort(listenPort, 6881, &lastRotation, now)
+		Expect(rotated).To(BeTrue())
+	})
+
+	It("should parse the forwarded port", func() {
+		port, err := parseForwardedPort("51820\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(port).To(Equal(int32(51820)))
+
+		for _, data := range []string{"", "port", "0", "65536", "-1"} {
+			_, err := parseForwardedPort(data)
+			Expect(err).To(HaveOccurred(), data)
+		}
+	})
+
+	It("should never pick the current port", func() {
+		for range 100 {
+			Expect(randomListenPort(minRotatedPort)).NotTo(Equal(int32(minRotatedPort)))
+		}
+	})
+})
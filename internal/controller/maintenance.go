@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+// AnnotationMaintenance set to "true" on the QBittorrentServer pauses the
+// active managed torrents, e.g. while the node or the storage of qBittorrent
+// is serviced. Removing it resumes exactly the torrents it paused.
+const AnnotationMaintenance = "qbittorrent.io/maintenance"
+
+// TypeMaintenanceServer reports whether the managed torrents are paused for
+// maintenance
+const TypeMaintenanceServer = "Maintenance"
+
+func inMaintenance(server *torrentv1beta1.QBittorrentServer) bool {
+	return server.Annotations[AnnotationMaintenance] == "true"
+}
+
+// activeManagedTorrents returns the hashes of the torrents of the Torrents of
+// the server which are downloading or seeding on qBittorrent, sorted, leaving
+// out the ones already paused
+func (r *QBittorrentServerReconciler) activeManagedTorrents(ctx context.Context,
+	server *torrentv1beta1.QBittorrentServer, paused []string) ([]string, error) {
+	torrents, err := r.managedTorrents(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := r.qbt(server).GetTorrentsInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the torrents: %w", err)
+	}
+	managed := map[string]bool{}
+	for _, torrent := range torrents {
+		if torrent.Status.Hash != "" {
+			managed[torrent.Status.Hash] = true
+		}
+	}
+
+	var active []string
+	for i := range infos {
+		info := &infos[i]
+		if !managed[info.Hash] || slices.Contains(paused, info.Hash) {
+			continue
+		}
+		if phase := torrentPhase(info); phase == torrentv1beta1.TorrentPhaseDownloading ||
+			phase == torrentv1beta1.TorrentPhaseSeeding {
+			active = append(active, info.Hash)
+		}
+	}
+	slices.Sort(active)
+	return active, nil
+}
+
+// reconcileMaintenance pauses the active managed torrents while the server
+// has the maintenance annotation, the ones added or resumed meanwhile
+// included, and resumes them once it is removed. It returns the condition
+// reporting the maintenance.
+func (r *QBittorrentServerReconciler) reconcileMaintenance(ctx context.Context,
+	server *torrentv1beta1.QBittorrentServer) (metav1.Condition, error) {
+	logger := log.FromContext(ctx)
+	maintenance := server.Status.Maintenance
+
+	if !inMaintenance(server) {
+		if maintenance == nil {
+			return metav1.Condition{
+				Type:    TypeMaintenanceServer,
+				Status:  metav1.ConditionFalse,
+				Reason:  "NoMaintenance",
+				Message: "The managed torrents run normally",
+			}, nil
+		}
+
+		if len(maintenance.PausedTorrents) > 0 {
+			logger.Info("Maintenance ended, resuming the paused torrents", "Count", len(maintenance.PausedTorrents))
+			// The torrents deleted meanwhile are ignored by qBittorrent
+			if err := r.qbt(server).StartTorrent(ctx, strings.Join(maintenance.PausedTorrents, "|")); err != nil {
+				return metav1.Condition{}, fmt.Errorf("failed to resume the paused torrents: %w", err)
+			}
+		}
+		server.Status.Maintenance = nil
+		return metav1.Condition{
+			Type:    TypeMaintenanceServer,
+			Status:  metav1.ConditionFalse,
+			Reason:  "TorrentsResumed",
+			Message: fmt.Sprintf("Maintenance ended, %d torrents resumed", len(maintenance.PausedTorrents)),
+		}, nil
+	}
+
+	if maintenance == nil {
+		logger.Info("Maintenance started, pausing the active torrents")
+		maintenance = &torrentv1beta1.MaintenanceStatus{StartTime: metav1.Now()}
+	}
+	active, err := r.activeManagedTorrents(ctx, server, maintenance.PausedTorrents)
+	if err != nil {
+		return metav1.Condition{}, err
+	}
+	if len(active) > 0 || server.Status.Maintenance == nil {
+		// The torrents are recorded before they are paused, so that they are
+		// resumed even if pausing them fails midway
+		maintenance = maintenance.DeepCopy()
+		maintenance.PausedTorrents = append(maintenance.PausedTorrents, active...)
+		slices.Sort(maintenance.PausedTorrents)
+		server.Status.Maintenance = maintenance
+		if err := r.Status().Update(ctx, server); err != nil {
+			return metav1.Condition{}, fmt.Errorf("failed to record the paused torrents: %w", err)
+		}
+	}
+	if len(active) > 0 {
+		logger.Info("Pausing the active torrents for maintenance", "Count", len(active))
+		if err := r.qbt(server).StopTorrent(ctx, strings.Join(active, "|")); err != nil {
+			return metav1.Condition{}, fmt.Errorf("failed to pause the active torrents: %w", err)
+		}
+	}
+
+	return metav1.Condition{
+		Type:   TypeMaintenanceServer,
+		Status: metav1.ConditionTrue,
+		Reason: "TorrentsPaused",
+		Message: fmt.Sprintf("In maintenance since %s, %d torrents paused",
+			maintenance.StartTime.UTC().Format(time.RFC3339), len(maintenance.PausedTorrents)),
+	}, nil
+}
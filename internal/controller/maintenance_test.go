@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Maintenance", func() {
+	const (
+		downloadingHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+		seedingHash     = "08ada5a7a6183aae1e09d831df6748d566095a10"
+		stoppedHash     = "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"
+		unmanagedHash   = "5f5e8848426129ab63cb4db717bb54193c1c1ad7"
+		addedHash       = "a3b1c2d4e5f60718293a4b5c6d7e8f9012345678"
+	)
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var fakeClient client.Client
+	var controllerReconciler *QBittorrentServerReconciler
+
+	addTorrent := func(hash, state string, managed bool) {
+		Expect(qb.client().AddTorrent(ctx, "magnet:?xt=urn:btih:"+hash)).To(Succeed())
+		qb.setState(hash, state)
+		if managed {
+			Expect(fakeClient.Create(ctx, &torrentv1beta1.Torrent{
+				ObjectMeta: metav1.ObjectMeta{Name: hash[:8], Namespace: "default"},
+				Status:     torrentv1beta1.TorrentStatus{Hash: hash},
+			})).To(Succeed())
+		}
+	}
+
+	getServer := func() *torrentv1beta1.QBittorrentServer {
+		server := &torrentv1beta1.QBittorrentServer{}
+		Expect(fakeClient.Get(ctx, types.NamespacedName{Name: DefaultServerName}, server)).To(Succeed())
+		return server
+	}
+
+	reconcileMaintenance := func(server *torrentv1beta1.QBittorrentServer) metav1.Condition {
+		condition, err := controllerReconciler.reconcileMaintenance(ctx, server)
+		Expect(err).NotTo(HaveOccurred())
+		return condition
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+
+		scheme := runtime.NewScheme()
+		Expect(torrentv1beta1.AddToScheme(scheme)).To(Succeed())
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&torrentv1beta1.QBittorrentServer{}).
+			WithObjects(&torrentv1beta1.QBittorrentServer{ObjectMeta: metav1.ObjectMeta{Name: DefaultServerName}}).
+			Build()
+		controllerReconciler = &QBittorrentServerReconciler{
+			Client:     fakeClient,
+			Scheme:     scheme,
+			Clients:    poolOf(qb.client()),
+			ServerName: DefaultServerName,
+		}
+	})
+
+	AfterEach(func() {
+		qb.Close()
+	})
+
+	It("should pause the active managed torrents and resume exactly those", func() {
+		addTorrent(downloadingHash, "downloading", true)
+		addTorrent(seedingHash, "stalledUP", true)
+		addTorrent(stoppedHash, "stoppedDL", true)
+		addTorrent(unmanagedHash, "downloading", false)
+
+		By("reporting no maintenance without the annotation")
+		Expect(reconcileMaintenance(getServer()).Reason).To(Equal("NoMaintenance"))
+
+		By("pausing the active managed torrents")
+		server := getServer()
+		server.Annotations = map[string]string{AnnotationMaintenance: "true"}
+		Expect(fakeClient.Update(ctx, server)).To(Succeed())
+		condition := reconcileMaintenance(server)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).To(HaveSuffix("2 torrents paused"))
+		Expect(qb.torrentState(downloadingHash)).To(Equal("stoppedDL"))
+		Expect(qb.torrentState(seedingHash)).To(Equal("stoppedUP"))
+		Expect(qb.torrentState(unmanagedHash)).To(Equal("downloading"))
+
+		// The paused torrents are recorded before pausing them
+		Expect(getServer().Status.Maintenance.PausedTorrents).To(Equal([]string{seedingHash, downloadingHash}))
+
+		By("pausing the torrents added during the maintenance")
+		addTorrent(addedHash, "downloading", true)
+		server = getServer()
+		Expect(reconcileMaintenance(server).Message).To(HaveSuffix("3 torrents paused"))
+		Expect(qb.torrentState(addedHash)).To(Equal("stoppedDL"))
+
+		By("resuming the paused torrents once the annotation is removed")
+		delete(server.Annotations, AnnotationMaintenance)
+		Expect(fakeClient.Update(ctx, server)).To(Succeed())
+		condition = reconcileMaintenance(server)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("TorrentsResumed"))
+		Expect(server.Status.Maintenance).To(BeNil())
+		for _, hash := range []string{downloadingHash, addedHash} {
+			Expect(qb.torrentState(hash)).To(Equal("downloading"))
+		}
+		Expect(qb.torrentState(seedingHash)).To(Equal("uploading"))
+		Expect(qb.torrentState(stoppedHash)).To(Equal("stoppedDL"))
+	})
+})
@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// The reasons of the conditions of the torrents held back with metadataOnly
+const (
+	reasonFetchingMetadata = "FetchingMetadata"
+	reasonMetadataReceived = "MetadataReceived"
+)
+
+// hasMetadata reports whether qBittorrent has the metadata of the torrent,
+// which magnets only get from their peers once added
+func hasMetadata(qbTorrent *qbittorrent.TorrentInfo) bool {
+	switch torrentv1beta1.TorrentState(qbTorrent.State) {
+	case torrentv1beta1.TorrentStateMetaDL, torrentv1beta1.TorrentStateForcedMetaDL:
+		return false
+	}
+	return qbTorrent.TotalSize > 0
+}
+
+// heldForMetadata reports whether the torrent was held back by reconcileMetadataOnly
+func heldForMetadata(torrent *torrentv1beta1.Torrent) bool {
+	available := meta.FindStatusCondition(torrent.Status.Conditions, TypeAvailableTorrent)
+	return available != nil &&
+		(available.Reason == reasonFetchingMetadata || available.Reason == reasonMetadataReceived)
+}
+
+// addStopCondition sets how the torrent with metadataOnly is added: a magnet
+// is stopped by qBittorrent once it has its metadata, a .torrent file which
+// already holds it is added stopped
+func addStopCondition(torrent *torrentv1beta1.Torrent, opts *qbittorrent.AddTorrentOptions) {
+	if !torrent.Spec.MetadataOnly {
+		return
+	}
+	if torrent.Spec.Source.MagnetURI == "" {
+		opts.Stopped = true
+		return
+	}
+	// The magnet fetches its metadata even while awaiting approval, it is
+	// stopped before downloading anything
+	opts.Stopped = false
+	opts.StopCondition = qbittorrent.StopConditionMetadataReceived
+}
+
+// reconcileMetadataOnly keeps the torrent with metadataOnly stopped once it
+// has its metadata, reported Pending, and starts it once metadataOnly is
+// unset. The magnets are stopped by qBittorrent itself when added with
+// metadataOnly, they are only stopped here when metadataOnly is set later or
+// when started by hand. It returns whether the torrent is held back.
+func (r *TorrentReconciler) reconcileMetadataOnly(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) (bool, error) {
+	logger := log.FromContext(ctx)
+	held := heldForMetadata(torrent)
+
+	if !torrent.Spec.MetadataOnly {
+		// The torrent awaiting approval is started once approved
+		if held && !awaitingApproval(torrent) {
+			logger.Info("Torrent no longer metadataOnly, starting it", "Name", torrent.Name)
+			if err := r.qbt(torrent).StartTorrent(ctx, qbTorrent.Hash); err != nil {
+				return false, fmt.Errorf("failed to start torrent: %w", err)
+			}
+			r.recordEvent(torrent, corev1.EventTypeNormal, "DownloadStarted",
+				"metadataOnly unset, torrent started on qBittorrent")
+		}
+		return false, nil
+	}
+
+	reason, message := reasonFetchingMetadata, "Fetching the metadata of the torrent from its peers"
+	if hasMetadata(qbTorrent) {
+		if phase := torrentPhase(qbTorrent); phase != torrentv1beta1.TorrentPhasePaused &&
+			phase != torrentv1beta1.TorrentPhaseCompleted {
+			logger.Info("Torrent with metadataOnly has its metadata, stopping it", "Name", torrent.Name)
+			if err := r.qbt(torrent).StopTorrent(ctx, qbTorrent.Hash); err != nil {
+				return false, fmt.Errorf("failed to stop torrent: %w", err)
+			}
+		}
+
+		reason = reasonMetadataReceived
+		message = fmt.Sprintf("Metadata received, %s in %s, set metadataOnly to false to download the content",
+			formatQuantity(qbTorrent.TotalSize), qbTorrent.Name)
+		if available := meta.FindStatusCondition(torrent.Status.Conditions, TypeAvailableTorrent); available == nil ||
+			available.Reason != reasonMetadataReceived {
+			r.recordEvent(torrent, corev1.EventTypeNormal, reasonMetadataReceived, message)
+		}
+	}
+
+	// Waiting is not a failure, the torrent is not degraded
+	setCondition(&torrent.Status.Conditions, metav1.Condition{
+		Type:    TypeAvailableTorrent,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+	setCondition(&torrent.Status.Conditions, metav1.Condition{
+		Type:    TypeDegradedTorrent,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+	return true, nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("Torrent metadataOnly", func() {
+	const magnetHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var controllerReconciler *TorrentReconciler
+	var recorder *record.FakeRecorder
+	var key types.NamespacedName
+
+	reconcileTorrent := func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getTorrent := func() *torrentv1beta1.Torrent {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent
+	}
+
+	availableReason := func() string {
+		return meta.FindStatusCondition(getTorrent().Status.Conditions, TypeAvailableTorrent).Reason
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		key = types.NamespacedName{Name: "metadata-only", Namespace: "default"}
+		recorder = record.NewFakeRecorder(20)
+		controllerReconciler = &TorrentReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Clients:  poolOf(qb.client()),
+			Recorder: recorder,
+			Config:   &OperatorConfig{},
+		}
+
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source:       torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + magnetHash},
+				MetadataOnly: true,
+			},
+		})).To(Succeed())
+		reconcileTorrent()
+		reconcileTorrent()
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		torrent := getTorrent()
+		controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+	})
+
+	It("should publish the metadata without downloading the content", func() {
+		Expect(qb.torrentState(magnetHash)).To(Equal("metaDL"))
+		reconcileTorrent()
+		Expect(getTorrent().Status.Phase).To(Equal(torrentv1beta1.TorrentPhasePending))
+		Expect(availableReason()).To(Equal("FetchingMetadata"))
+
+		By("publishing the files once qBittorrent stopped it with its metadata")
+		qb.receiveMetadata(magnetHash, 4<<30)
+		qb.setFiles(magnetHash, qbittorrent.FileInfo{Name: "movie/movie.mkv", Size: 4 << 30})
+		reconcileTorrent()
+		Expect(qb.torrentState(magnetHash)).To(Equal("stoppedDL"))
+		torrent := getTorrent()
+		Expect(torrent.Status.Phase).To(Equal(torrentv1beta1.TorrentPhasePending))
+		Expect(torrent.Status.TotalSize).To(Equal(int64(4 << 30)))
+		Expect(torrent.Status.Files).To(HaveLen(1))
+		Expect(torrent.Status.Files[0].Name).To(Equal("movie/movie.mkv"))
+		Expect(availableReason()).To(Equal("MetadataReceived"))
+		Expect(meta.IsStatusConditionFalse(torrent.Status.Conditions, TypeDegradedTorrent)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal MetadataReceived")))
+
+		By("stopping it again when started by hand")
+		qb.setState(magnetHash, "downloading")
+		reconcileTorrent()
+		Expect(qb.torrentState(magnetHash)).To(Equal("stoppedDL"))
+		Expect(recorder.Events).NotTo(Receive())
+
+		By("starting it once metadataOnly is unset")
+		torrent = getTorrent()
+		torrent.Spec.MetadataOnly = false
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		reconcileTorrent()
+		Expect(qb.torrentState(magnetHash)).To(Equal("downloading"))
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal DownloadStarted")))
+		reconcileTorrent()
+		torrent = getTorrent()
+		Expect(meta.IsStatusConditionTrue(torrent.Status.Conditions, TypeAvailableTorrent)).To(BeTrue())
+		Expect(torrent.Status.Files).To(BeEmpty())
+	})
+
+	It("should stop the torrent once it has its metadata when metadataOnly is set later", func() {
+		torrent := getTorrent()
+		torrent.Spec.MetadataOnly = false
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		qb.setSize(magnetHash, 1<<30)
+		qb.setState(magnetHash, "downloading")
+		reconcileTorrent()
+
+		torrent = getTorrent()
+		torrent.Spec.MetadataOnly = true
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		reconcileTorrent()
+		Expect(qb.torrentState(magnetHash)).To(Equal("stoppedDL"))
+		Expect(availableReason()).To(Equal("MetadataReceived"))
+	})
+})
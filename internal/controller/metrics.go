@@ -0,0 +1,222 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var (
+	// Bytes transferred by the torrents of each namespace, for accounting
+	// on a shared qBittorrent
+	downloadedBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "qbittorrent_operator_downloaded_bytes_total",
+		Help: "Bytes downloaded by the Torrents of a namespace",
+	}, []string{"namespace"})
+	uploadedBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "qbittorrent_operator_uploaded_bytes_total",
+		Help: "Bytes uploaded by the Torrents of a namespace",
+	}, []string{"namespace"})
+
+	torrentsDesc = prometheus.NewDesc("qbittorrent_operator_torrents",
+		"Number of Torrents by namespace, category and qBittorrent state",
+		[]string{"namespace", "category", "state"}, nil)
+
+	// One series per condition status, 1 for the current one, like the
+	// kube-state-metrics condition metrics
+	torrentConditionDesc = prometheus.NewDesc("qbittorrent_operator_torrent_condition",
+		"The conditions of a Torrent, 1 for the current status of each condition type",
+		[]string{"namespace", "torrent", "type", "status"}, nil)
+
+	// Speeds of the torrents of each namespace at their last sync, to spot
+	// the namespaces using most of a shared qBittorrent
+	downloadSpeedDesc = prometheus.NewDesc("qbittorrent_operator_download_speed_bytes",
+		"Download speed of the Torrents of a namespace at their last sync, in bytes per second",
+		[]string{"namespace"}, nil)
+	uploadSpeedDesc = prometheus.NewDesc("qbittorrent_operator_upload_speed_bytes",
+		"Upload speed of the Torrents of a namespace at their last sync, in bytes per second",
+		[]string{"namespace"}, nil)
+
+	// Download backlog of each namespace and category, for the Deployments
+	// processing the content to scale on, e.g. through the Prometheus adapter.
+	// The series stay at 0 while the Torrents are complete, so that the
+	// autoscalers scale down rather than miss the metric.
+	downloadQueueDesc = prometheus.NewDesc("qbittorrent_operator_download_queue_torrents",
+		"Number of Torrents of a namespace and category with content left to download",
+		[]string{"namespace", "category"}, nil)
+	downloadBacklogDesc = prometheus.NewDesc("qbittorrent_operator_download_backlog_bytes",
+		"Bytes left to download by the Torrents of a namespace and category at their last sync",
+		[]string{"namespace", "category"}, nil)
+)
+
+var conditionStatuses = []metav1.ConditionStatus{metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown}
+
+func init() {
+	metrics.Registry.MustRegister(downloadedBytes, uploadedBytes)
+}
+
+// torrentCollector counts the Torrents from the cache when scraped, so the
+// series follow creations and deletions without bookkeeping
+type torrentCollector struct {
+	reader client.Reader
+	// shard restricts the Torrents counted to the ones reconciled by the replica
+	shard Shard
+	// config may disable the condition series
+	config *OperatorConfig
+}
+
+func (c *torrentCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- torrentsDesc
+	ch <- torrentConditionDesc
+	ch <- downloadSpeedDesc
+	ch <- uploadSpeedDesc
+	ch <- downloadQueueDesc
+	ch <- downloadBacklogDesc
+}
+
+func (c *torrentCollector) Collect(ch chan<- prometheus.Metric) {
+	torrents := &torrentv1beta1.TorrentList{}
+	if err := c.reader.List(context.Background(), torrents); err != nil {
+		logf.Log.WithName("metrics").Error(err, "Failed to list Torrents")
+		return
+	}
+
+	conditions := true
+	if metricsConfig := c.config.Spec().Metrics; metricsConfig != nil && metricsConfig.TorrentConditions != nil {
+		conditions = *metricsConfig.TorrentConditions
+	}
+
+	counts := map[[3]string]int{}
+	// Download and upload speeds by namespace
+	speeds := map[string][2]int64{}
+	// Torrents and bytes left to download by namespace and category
+	backlogs := map[[2]string][2]int64{}
+	for _, torrent := range torrents.Items {
+		if !c.shard.Owns(&torrent) {
+			continue
+		}
+
+		category := torrent.Status.Category
+		if category == "" {
+			category = torrent.Spec.Category
+		}
+		state := string(torrent.Status.State)
+		if state == "" {
+			state = "unknown"
+		}
+		counts[[3]string{torrent.Namespace, category, state}]++
+		if transfer := torrent.Status.Transfer; transfer != nil {
+			speed := speeds[torrent.Namespace]
+			speeds[torrent.Namespace] = [2]int64{speed[0] + transfer.DownloadSpeed, speed[1] + transfer.UploadSpeed}
+		}
+		backlog := backlogs[[2]string{torrent.Namespace, category}]
+		// The size of a Torrent is unknown until qBittorrent has its metadata
+		if torrent.Status.TotalSize == 0 || torrent.Status.AmountLeft > 0 {
+			backlog = [2]int64{backlog[0] + 1, backlog[1] + torrent.Status.AmountLeft}
+		}
+		backlogs[[2]string{torrent.Namespace, category}] = backlog
+
+		if !conditions {
+			continue
+		}
+		for _, condition := range torrent.Status.Conditions {
+			for _, status := range conditionStatuses {
+				value := 0.0
+				if condition.Status == status {
+					value = 1
+				}
+				ch <- prometheus.MustNewConstMetric(torrentConditionDesc, prometheus.GaugeValue, value,
+					torrent.Namespace, torrent.Name, condition.Type, string(status))
+			}
+		}
+	}
+
+	for labels, count := range counts {
+		ch <- prometheus.MustNewConstMetric(torrentsDesc, prometheus.GaugeValue, float64(count),
+			labels[0], labels[1], labels[2])
+	}
+	for namespace, speed := range speeds {
+		ch <- prometheus.MustNewConstMetric(downloadSpeedDesc, prometheus.GaugeValue, float64(speed[0]), namespace)
+		ch <- prometheus.MustNewConstMetric(uploadSpeedDesc, prometheus.GaugeValue, float64(speed[1]), namespace)
+	}
+	for labels, backlog := range backlogs {
+		ch <- prometheus.MustNewConstMetric(downloadQueueDesc, prometheus.GaugeValue, float64(backlog[0]),
+			labels[0], labels[1])
+		ch <- prometheus.MustNewConstMetric(downloadBacklogDesc, prometheus.GaugeValue, float64(backlog[1]),
+			labels[0], labels[1])
+	}
+}
+
+// transferTracker turns the all-time transfer totals reported by qBittorrent
+// into increments of the namespace counters
+type transferTracker struct {
+	mu   sync.Mutex
+	last map[types.UID]transferSample
+}
+
+type transferSample struct {
+	downloaded int64
+	uploaded   int64
+}
+
+// observe adds the bytes transferred since the previous observation of the
+// torrent. The first observation only records a baseline, as the bytes
+// transferred before the operator started were counted by a previous process.
+func (t *transferTracker) observe(torrent *torrentv1beta1.Torrent, qbTorrent *qbittorrent.TorrentInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.last == nil {
+		t.last = map[types.UID]transferSample{}
+	}
+	current := transferSample{downloaded: qbTorrent.Downloaded, uploaded: qbTorrent.Uploaded}
+	previous, ok := t.last[torrent.UID]
+	t.last[torrent.UID] = current
+	if !ok {
+		return
+	}
+
+	downloadedBytes.WithLabelValues(torrent.Namespace).Add(float64(transferDelta(previous.downloaded, current.downloaded)))
+	uploadedBytes.WithLabelValues(torrent.Namespace).Add(float64(transferDelta(previous.uploaded, current.uploaded)))
+}
+
+// forget drops the baseline of a deleted torrent
+func (t *transferTracker) forget(torrent *torrentv1beta1.Torrent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.last, torrent.UID)
+}
+
+// transferDelta returns the bytes transferred between two totals. A total
+// going down means the torrent was re-added and counts from zero again.
+func transferDelta(previous, current int64) int64 {
+	if current < previous {
+		return current
+	}
+	return current - previous
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("Transfer metrics", func() {
+	It("should count the bytes transferred between observations", func() {
+		const namespace = "metrics-test"
+		torrent := &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: namespace, UID: "metrics-test-uid"},
+		}
+		tracker := &transferTracker{}
+
+		By("recording a baseline on the first observation")
+		tracker.observe(torrent, &qbittorrent.TorrentInfo{Downloaded: 1000, Uploaded: 500})
+		Expect(testutil.ToFloat64(downloadedBytes.WithLabelValues(namespace))).To(BeZero())
+
+		By("counting the increments")
+		tracker.observe(torrent, &qbittorrent.TorrentInfo{Downloaded: 1500, Uploaded: 800})
+		Expect(testutil.ToFloat64(downloadedBytes.WithLabelValues(namespace))).To(Equal(500.0))
+		Expect(testutil.ToFloat64(uploadedBytes.WithLabelValues(namespace))).To(Equal(300.0))
+
+		By("counting from zero when the torrent was re-added")
+		tracker.observe(torrent, &qbittorrent.TorrentInfo{Downloaded: 200, Uploaded: 800})
+		Expect(testutil.ToFloat64(downloadedBytes.WithLabelValues(namespace))).To(Equal(700.0))
+	})
+})
+
+var _ = Describe("Condition metrics", func() {
+	It("should export one series per condition status", func() {
+		ctx := context.Background()
+		torrent := &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: "condition-metrics", Namespace: "default"},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source: torrentv1beta1.TorrentSource{
+					MagnetURI: "magnet:?xt=urn:btih:c9e15763f722f23e98a29decdfae341b98d53056",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, torrent)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Delete(ctx, torrent)).To(Succeed())
+		})
+
+		torrent.Status.Conditions = []metav1.Condition{{
+			Type:               TypeDegradedTorrent,
+			Status:             metav1.ConditionTrue,
+			Reason:             "InvalidSource",
+			LastTransitionTime: metav1.Now(),
+		}}
+		Expect(k8sClient.Status().Update(ctx, torrent)).To(Succeed())
+
+		expected := `
+# HELP qbittorrent_operator_torrent_condition The conditions of a Torrent, 1 for the current status of each condition type
+# TYPE qbittorrent_operator_torrent_condition gauge
+qbittorrent_operator_torrent_condition{namespace="default",status="False",torrent="condition-metrics",type="Degraded"} 0
+qbittorrent_operator_torrent_condition{namespace="default",status="True",torrent="condition-metrics",type="Degraded"} 1
+qbittorrent_operator_torrent_condition{namespace="default",status="Unknown",torrent="condition-metrics",type="Degraded"} 0
+`
+		Expect(testutil.CollectAndCompare(&torrentCollector{reader: k8sClient}, strings.NewReader(expected),
+			"qbittorrent_operator_torrent_condition")).To(Succeed())
+	})
+})
+
+var _ = Describe("Speed metrics", func() {
+	It("should sum the speeds of the Torrents of each namespace", func() {
+		ctx := context.Background()
+		for i, hash := range []string{"c9e15763f722f23e98a29decdfae341b98d53056", "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"} {
+			torrent := &torrentv1beta1.Torrent{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("speed-metrics-%d", i), Namespace: "default"},
+				Spec: torrentv1beta1.TorrentSpec{
+					Source: torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + hash},
+				},
+			}
+			Expect(k8sClient.Create(ctx, torrent)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, torrent)).To(Succeed())
+			})
+
+			torrent.Status.Transfer = &torrentv1beta1.TransferStatus{DownloadSpeed: 1024, UploadSpeed: int64(256 * (i + 1))}
+			Expect(k8sClient.Status().Update(ctx, torrent)).To(Succeed())
+		}
+
+		expected := `
+# HELP qbittorrent_operator_download_speed_bytes Download speed of the Torrents of a namespace at their last sync, in bytes per second
+# TYPE qbittorrent_operator_download_speed_bytes gauge
+qbittorrent_operator_download_speed_bytes{namespace="default"} 2048
+# HELP qbittorrent_operator_upload_speed_bytes Upload speed of the Torrents of a namespace at their last sync, in bytes per second
+# TYPE qbittorrent_operator_upload_speed_bytes gauge
+qbittorrent_operator_upload_speed_bytes{namespace="default"} 768
+`
+		Expect(testutil.CollectAndCompare(&torrentCollector{reader: k8sClient}, strings.NewReader(expected),
+			"qbittorrent_operator_download_speed_bytes", "qbittorrent_operator_upload_speed_bytes")).To(Succeed())
+	})
+})
+
+var _ = Describe("Backlog metrics", func() {
+	It("should count the Torrents and bytes left to download by namespace and category", func() {
+		// Counted apart from the Torrents of the other specs
+		builder := fake.NewClientBuilder().WithScheme(k8sClient.Scheme())
+		statuses := []torrentv1beta1.TorrentStatus{
+			{Category: "movies", TotalSize: 4096, AmountLeft: 3072},
+			{Category: "movies", TotalSize: 2048, AmountLeft: 0},
+			{Category: "movies"},
+			{Category: "series", TotalSize: 1024, AmountLeft: 0},
+		}
+		for i, status := range statuses {
+			builder.WithObjects(&torrentv1beta1.Torrent{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("backlog-metrics-%d", i), Namespace: "default"},
+				Status:     status,
+			})
+		}
+
+		expected := `
+# HELP qbittorrent_operator_download_backlog_bytes Bytes left to download by the Torrents of a namespace and category at their last sync
+# TYPE qbittorrent_operator_download_backlog_bytes gauge
+qbittorrent_operator_download_backlog_bytes{category="movies",namespace="default"} 3072
+qbittorrent_operator_download_backlog_bytes{category="series",namespace="default"} 0
+# HELP qbittorrent_operator_download_queue_torrents Number of Torrents of a namespace and category with content left to download
+# TYPE qbittorrent_operator_download_queue_torrents gauge
+qbittorrent_operator_download_queue_torrents{category="movies",namespace="default"} 2
+qbittorrent_operator_download_queue_torrents{category="series",namespace="default"} 0
+`
+		Expect(testutil.CollectAndCompare(&torrentCollector{reader: builder.Build()}, strings.NewReader(expected),
+			"qbittorrent_operator_download_queue_torrents", "qbittorrent_operator_download_backlog_bytes")).To(Succeed())
+	})
+})
@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// MissingFilesRedownloadInterval is the least time between two downloads
+// of the missing files of a torrent, so that a volume remounted empty does
+// not have qBittorrent download the torrent again and again
+const MissingFilesRedownloadInterval = 10 * time.Minute
+
+// missingPieces reports whether files of the torrent were deleted out-of-band:
+// qBittorrent reports them missing, or a recheck stopped the torrent complete
+// before with pieces left to download
+func missingPieces(torrent *torrentv1beta1.Torrent, qbTorrent *qbittorrent.TorrentInfo) bool {
+	if torrentv1beta1.TorrentState(qbTorrent.State) == torrentv1beta1.TorrentStateMissingFiles {
+		return true
+	}
+	wasComplete := torrent.Status.TotalSize > 0 && torrent.Status.AmountLeft == 0
+	return wasComplete && qbTorrent.AmountLeft > 0 && torrentPhase(qbTorrent) == torrentv1beta1.TorrentPhasePaused
+}
+
+// reconcileMissingFiles downloads again the files of the torrent deleted
+// out-of-band, with missingFilesPolicy Redownload: the torrent with missing
+// files is rechecked, so that qBittorrent knows the pieces to download, and
+// resumed. It must run before the status is updated from the torrent.
+func (r *TorrentReconciler) reconcileMissingFiles(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) error {
+	if torrent.Spec.MissingFilesPolicy != torrentv1beta1.MissingFilesPolicyRedownload ||
+		!missingPieces(torrent, qbTorrent) {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+	if last := torrent.Status.MissingFilesRedownloadTime; last != nil &&
+		time.Since(last.Time) < MissingFilesRedownloadInterval {
+		logger.Info("Files of the torrent missing again, waiting before downloading them", "Name", torrent.Name,
+			"LastRedownload", last.Time)
+		return nil
+	}
+
+	message := fmt.Sprintf("A recheck found %s missing from %s, the torrent was resumed to download it again",
+		formatQuantity(qbTorrent.AmountLeft), qbTorrent.ContentPath)
+	if torrentv1beta1.TorrentState(qbTorrent.State) == torrentv1beta1.TorrentStateMissingFiles {
+		logger.Info("Files of the torrent missing, rechecking it", "Name", torrent.Name)
+		if err := r.qbt(torrent).RecheckTorrent(ctx, qbTorrent.Hash); err != nil {
+			return fmt.Errorf("failed to recheck torrent: %w", err)
+		}
+		message = fmt.Sprintf("Files missing from %s, the torrent was rechecked and resumed to download "+
+			"the missing pieces again", qbTorrent.ContentPath)
+	}
+	// qBittorrent starts the download of the torrent resumed while checking
+	// once the check is done
+	logger.Info("Resuming the torrent to download its missing files", "Name", torrent.Name)
+	if err := r.qbt(torrent).StartTorrent(ctx, qbTorrent.Hash); err != nil {
+		return fmt.Errorf("failed to start torrent: %w", err)
+	}
+
+	now := metav1.Now()
+	torrent.Status.MissingFilesRedownloadTime = &now
+	r.recordEvent(torrent, corev1.EventTypeWarning, "MissingFilesRedownloaded", message)
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Missing files", func() {
+	const magnetHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var controllerReconciler *TorrentReconciler
+	var recorder *record.FakeRecorder
+	var key types.NamespacedName
+
+	reconcileTorrent := func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getTorrent := func() *torrentv1beta1.Torrent {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent
+	}
+
+	// createCompleted creates a Torrent with the policy, complete on qBittorrent
+	createCompleted := func(policy torrentv1beta1.MissingFilesPolicy) {
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source:             torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + magnetHash},
+				MissingFilesPolicy: policy,
+			},
+		})).To(Succeed())
+		reconcileTorrent()
+		reconcileTorrent()
+		qb.setCompleted(magnetHash, 1<<30)
+		reconcileTorrent()
+		Expect(getTorrent().Status.AmountLeft).To(BeZero())
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		key = types.NamespacedName{Name: "missing-files", Namespace: "default"}
+		recorder = record.NewFakeRecorder(20)
+		controllerReconciler = &TorrentReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Clients:  poolOf(qb.client()),
+			Recorder: recorder,
+			Config:   &OperatorConfig{},
+		}
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		torrent := getTorrent()
+		controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+	})
+
+	It("should recheck and resume the torrent with missing files", func() {
+		createCompleted(torrentv1beta1.MissingFilesPolicyRedownload)
+
+		qb.setState(magnetHash, "missingFiles")
+		reconcileTorrent()
+		Expect(qb.callCount("/api/v2/torrents/recheck")).To(Equal(1))
+		Expect(qb.torrentState(magnetHash)).To(Equal("downloading"))
+		Expect(getTorrent().Status.MissingFilesRedownloadTime).NotTo(BeNil())
+		Expect(recorder.Events).To(Receive(HavePrefix("Warning MissingFilesRedownloaded Files missing from")))
+
+		By("waiting before downloading them again")
+		qb.setState(magnetHash, "missingFiles")
+		reconcileTorrent()
+		Expect(qb.callCount("/api/v2/torrents/recheck")).To(Equal(1))
+		Expect(qb.torrentState(magnetHash)).To(Equal("missingFiles"))
+		Expect(recorder.Events).NotTo(Receive())
+	})
+
+	It("should resume the complete torrent a recheck found pieces missing of", func() {
+		createCompleted(torrentv1beta1.MissingFilesPolicyRedownload)
+
+		qb.setSize(magnetHash, 1<<30)
+		qb.setState(magnetHash, "stoppedDL")
+		reconcileTorrent()
+		Expect(qb.callCount("/api/v2/torrents/recheck")).To(BeZero())
+		Expect(qb.torrentState(magnetHash)).To(Equal("downloading"))
+		Expect(recorder.Events).To(Receive(HavePrefix("Warning MissingFilesRedownloaded A recheck found 1Gi missing")))
+	})
+
+	It("should leave the torrent with missing files alone by default", func() {
+		createCompleted("")
+
+		qb.setState(magnetHash, "missingFiles")
+		reconcileTorrent()
+		Expect(qb.callCount("/api/v2/torrents/recheck")).To(BeZero())
+		Expect(qb.torrentState(magnetHash)).To(Equal("missingFiles"))
+		Expect(getTorrent().Status.Phase).To(Equal(torrentv1beta1.TorrentPhaseError))
+	})
+})
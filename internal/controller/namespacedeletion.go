@@ -0,0 +1,266 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// namespaceDeletions serializes the deletions of the Torrents of each
+// terminating namespace, so that the reconciles of its Torrents running
+// together do not delete them from qBittorrent twice
+type namespaceDeletions struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// lock locks the deletions of the namespace, returning the unlock function
+func (d *namespaceDeletions) lock(namespace string) func() {
+	d.mu.Lock()
+	if d.locks == nil {
+		d.locks = map[string]*sync.Mutex{}
+	}
+	lock, ok := d.locks[namespace]
+	if !ok {
+		lock = &sync.Mutex{}
+		d.locks[namespace] = lock
+	}
+	d.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// namespaceTerminating reports whether the namespace is being deleted
+func (r *TorrentReconciler) namespaceTerminating(ctx context.Context, name string) (bool, error) {
+	namespace := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, namespace); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return !namespace.DeletionTimestamp.IsZero() || namespace.Status.Phase == corev1.NamespaceTerminating, nil
+}
+
+// deleteNamespaceTorrents deletes the Torrents marked for deletion of the
+// terminating namespace of the torrent, on its server, at once: one
+// qBittorrent call per kind of deletion, then their finalizers removed in parallel, instead of a
+// reconcile per Torrent each retried on its own. It returns true once the
+// torrent is deleted along with them. The Torrents whose deletion is
+// force-released, disabled or protected are left to their own reconcile.
+func (r *TorrentReconciler) deleteNamespaceTorrents(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, error) {
+	logger := logf.FromContext(ctx)
+
+	terminating, err := r.namespaceTerminating(ctx, torrent.Namespace)
+	if err != nil {
+		return false, fmt.Errorf("failed to get namespace: %w", err)
+	}
+	if !terminating {
+		return false, nil
+	}
+
+	unlock := r.namespaceDeletions.lock(torrent.Namespace)
+	defer unlock()
+
+	// Step 1: Gather the Torrents of the namespace left to delete
+	torrents := &torrentv1beta1.TorrentList{}
+	if err := r.List(ctx, torrents, client.InNamespace(torrent.Namespace)); err != nil {
+		return false, fmt.Errorf("failed to list the Torrents of the namespace: %w", err)
+	}
+
+	// The torrents of qBittorrent are only listed to check the ownership of
+	// the torrents whose files are deleted
+	var index qbittorrent.TorrentIndex
+	backend := func() (qbittorrent.TorrentIndex, error) {
+		if index == nil {
+			infos, err := r.qbt(torrent).GetTorrentsInfo(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the torrents: %w", err)
+			}
+			index = qbittorrent.NewTorrentIndex(infos)
+		}
+		return index, nil
+	}
+
+	var deleted []*torrentv1beta1.Torrent
+	// The cross-seeds are deleted first, keeping the files they share
+	var keepFiles, deleteFiles []string
+	for i := range torrents.Items {
+		item := &torrents.Items[i]
+		// The Torrents of the other servers are deleted by their own batch
+		if r.serverName(item) != r.serverName(torrent) {
+			continue
+		}
+		eligible, err := r.eligibleForBatchDeletion(item, backend)
+		if err != nil {
+			return false, err
+		}
+		if !eligible {
+			continue
+		}
+		if policy := r.deletionPolicy(item); policy != torrentv1beta1.DeletionPolicyOrphan {
+			for _, crossSeed := range item.Status.CrossSeeds {
+				if crossSeed.Hash != "" {
+					keepFiles = append(keepFiles, crossSeed.Hash)
+				}
+			}
+			// No hardlink check Job can be created in the terminating
+			// namespace, the files it would check are kept
+			if item.Status.Hash != "" {
+				if policy == torrentv1beta1.DeletionPolicyKeepFiles || hardlinkCheckEnabled(item) {
+					keepFiles = append(keepFiles, item.Status.Hash)
+				} else {
+					deleteFiles = append(deleteFiles, item.Status.Hash)
+				}
+			}
+		}
+		deleted = append(deleted, item)
+	}
+
+	if !containsTorrent(deleted, torrent) {
+		return false, nil
+	}
+	logger.Info("Namespace terminating, deleting its Torrents from qBittorrent together",
+		"Namespace", torrent.Namespace, "Torrents", len(deleted))
+
+	// Step 2: Delete them from qBittorrent, a call for all the hashes
+	if len(keepFiles) > 0 {
+		if err := r.qbt(torrent).DeleteTorrent(ctx, strings.Join(keepFiles, "|"), false); err != nil {
+			return false, fmt.Errorf("failed to delete the torrents from qBittorrent: %w", err)
+		}
+	}
+	if len(deleteFiles) > 0 {
+		if err := r.qbt(torrent).DeleteTorrent(ctx, strings.Join(deleteFiles, "|"), true); err != nil {
+			return false, fmt.Errorf("failed to delete the torrents from qBittorrent: %w", err)
+		}
+	}
+
+	// Step 3: Remove their finalizers in parallel
+	var wg sync.WaitGroup
+	errs := make([]error, len(deleted))
+	for i, item := range deleted {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.transfers.forget(item)
+			r.requeues.forget(client.ObjectKeyFromObject(item))
+			r.passes.forget(item)
+			r.restores.forget(item)
+			r.resolver.forget(item)
+			controllerutil.RemoveFinalizer(item, TorrentFinalizer)
+			if err := r.Update(ctx, item); client.IgnoreNotFound(err) != nil {
+				errs[i] = fmt.Errorf("failed to remove the finalizer of %s: %w", item.Name, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return false, err
+	}
+
+	logger.Info("Finalizers removed from the Torrents of the terminating namespace",
+		"Namespace", torrent.Namespace, "Torrents", len(deleted))
+	return true, nil
+}
+
+// eligibleForBatchDeletion reports whether the Torrent is deleted with the
+// others of its terminating namespace: marked for deletion with the
+// finalizer, in the shard, and neither disabled, force-released, protected
+// nor holding the deletion of files it does not own. backend returns the
+// torrents of qBittorrent.
+func (r *TorrentReconciler) eligibleForBatchDeletion(item *torrentv1beta1.Torrent,
+	backend func() (qbittorrent.TorrentIndex, error)) (bool, error) {
+	if item.DeletionTimestamp.IsZero() || !controllerutil.ContainsFinalizer(item, TorrentFinalizer) ||
+		!r.Shard.Owns(item) || item.Annotations[AnnotationReconcile] == ReconcileDisabled ||
+		item.Annotations[AnnotationForceRelease] == "true" {
+		return false, nil
+	}
+	policy := r.deletionPolicy(item)
+	if policy == torrentv1beta1.DeletionPolicyOrphan {
+		return true, nil
+	}
+	if protection := item.Spec.DeletionProtection; protection != "" &&
+		protection != torrentv1beta1.DeletionProtectionNever {
+		return false, nil
+	}
+
+	// The files of a torrent the Torrent does not own are not deleted, it is
+	// left to its own reconcile holding its deletion
+	if item.Status.Hash == "" || policy == torrentv1beta1.DeletionPolicyKeepFiles {
+		return true, nil
+	}
+	index, err := backend()
+	if err != nil {
+		return false, err
+	}
+	info := index.Lookup(qbittorrent.InfoHashes{V1: item.Status.Hash})
+	return info == nil || ownershipMismatch(item, info) == "", nil
+}
+
+// containsTorrent reports whether the torrent is one of the torrents
+func containsTorrent(torrents []*torrentv1beta1.Torrent, torrent *torrentv1beta1.Torrent) bool {
+	for _, item := range torrents {
+		if item.UID == torrent.UID {
+			return true
+		}
+	}
+	return false
+}
+
+// terminatingNamespaceTorrents maps a terminating Namespace to its Torrents
+// marked for deletion, so that the ones waiting for a retry are deleted
+// right away
+func (r *TorrentReconciler) terminatingNamespaceTorrents(ctx context.Context, obj client.Object) []reconcile.Request {
+	torrents := &torrentv1beta1.TorrentList{}
+	if err := r.List(ctx, torrents, client.InNamespace(obj.GetName())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list the Torrents of a terminating namespace", "Namespace", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, torrent := range torrents.Items {
+		if !torrent.DeletionTimestamp.IsZero() && r.Shard.Owns(&torrent) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&torrent)})
+		}
+	}
+	return requests
+}
+
+// namespaceTerminatingChanged passes the updates of the terminating
+// Namespaces, as their Torrents are deleted
+var namespaceTerminatingChanged = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return !e.ObjectNew.GetDeletionTimestamp().IsZero()
+	},
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("Torrent namespace deletion", func() {
+	const (
+		moviesHash    = "c9e15763f722f23e98a29decdfae341b98d53056"
+		showsHash     = "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"
+		deletePath    = "/api/v2/torrents/delete"
+		holdFinalizer = "test.qbittorrent.io/hold"
+	)
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var controllerReconciler *TorrentReconciler
+	var namespace *corev1.Namespace
+	var moviesKey, showsKey types.NamespacedName
+
+	reconcileTorrent := func(key types.NamespacedName) {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	createTorrent := func(key types.NamespacedName, hash string, policy torrentv1beta1.DeletionPolicy) {
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source:         torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + hash},
+				DeletionPolicy: policy,
+			},
+		})).To(Succeed())
+		reconcileTorrent(key)
+		reconcileTorrent(key)
+		reconcileTorrent(key)
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		controllerReconciler = &TorrentReconciler{
+			Client:                 k8sClient,
+			Scheme:                 k8sClient.Scheme(),
+			Clients:                poolOf(qb.client()),
+			Recorder:               record.NewFakeRecorder(20),
+			BatchNamespaceDeletion: true,
+			Config:                 &OperatorConfig{},
+		}
+
+		// The finalizer keeps the namespace terminating once deleted
+		namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:       "terminating",
+			Finalizers: []string{holdFinalizer},
+		}}
+		Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
+
+		moviesKey = types.NamespacedName{Name: "movies", Namespace: namespace.Name}
+		showsKey = types.NamespacedName{Name: "shows", Namespace: namespace.Name}
+		createTorrent(moviesKey, moviesHash, "")
+		createTorrent(showsKey, showsHash, torrentv1beta1.DeletionPolicyKeepFiles)
+		Expect(qb.hashes()).To(ConsistOf(moviesHash, showsHash))
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		for _, key := range []types.NamespacedName{moviesKey, showsKey} {
+			torrent := &torrentv1beta1.Torrent{}
+			if err := k8sClient.Get(ctx, key, torrent); err == nil {
+				controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+				Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+			}
+		}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(namespace), namespace)).To(Succeed())
+		controllerutil.RemoveFinalizer(namespace, holdFinalizer)
+		Expect(k8sClient.Update(ctx, namespace)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, namespace))).To(Succeed())
+	})
+
+	deleteTorrents := func() {
+		for _, key := range []types.NamespacedName{moviesKey, showsKey} {
+			Expect(k8sClient.Delete(ctx, &torrentv1beta1.Torrent{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			})).To(Succeed())
+		}
+	}
+
+	It("should delete the Torrents of a terminating namespace together", func() {
+		deleteTorrents()
+		Expect(k8sClient.Delete(ctx, namespace)).To(Succeed())
+
+		calls := qb.callCount(deletePath)
+		reconcileTorrent(moviesKey)
+		Expect(qb.hashes()).To(BeEmpty())
+		// A call keeping the files, a call deleting them
+		Expect(qb.callCount(deletePath)).To(Equal(calls + 2))
+		for _, key := range []types.NamespacedName{moviesKey, showsKey} {
+			err := k8sClient.Get(ctx, key, &torrentv1beta1.Torrent{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		}
+
+		By("finding nothing left to delete for the other Torrent")
+		reconcileTorrent(showsKey)
+		Expect(qb.callCount(deletePath)).To(Equal(calls + 2))
+	})
+
+	It("should delete the Torrents one at a time while the namespace is active", func() {
+		deleteTorrents()
+
+		reconcileTorrent(moviesKey)
+		Expect(qb.hashes()).To(ConsistOf(showsHash))
+		err := k8sClient.Get(ctx, showsKey, &torrentv1beta1.Torrent{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should only delete together the Torrents eligible for it", func() {
+		listed := 0
+		backend := func() (qbittorrent.TorrentIndex, error) {
+			listed++
+			return qbittorrent.NewTorrentIndex([]qbittorrent.TorrentInfo{
+				{Hash: moviesHash, AddedOn: 1},
+				{Hash: showsHash, AddedOn: 2},
+			}), nil
+		}
+		eligible := func(mutate func(*torrentv1beta1.Torrent)) bool {
+			now := metav1.Now()
+			item := &torrentv1beta1.Torrent{ObjectMeta: metav1.ObjectMeta{
+				Name:              "item",
+				Namespace:         namespace.Name,
+				DeletionTimestamp: &now,
+				Finalizers:        []string{TorrentFinalizer},
+			}}
+			mutate(item)
+			ok, err := controllerReconciler.eligibleForBatchDeletion(item, backend)
+			Expect(err).NotTo(HaveOccurred())
+			return ok
+		}
+
+		Expect(eligible(func(*torrentv1beta1.Torrent) {})).To(BeTrue())
+		Expect(eligible(func(t *torrentv1beta1.Torrent) { t.DeletionTimestamp = nil })).To(BeFalse())
+		Expect(eligible(func(t *torrentv1beta1.Torrent) { t.Finalizers = nil })).To(BeFalse())
+		Expect(eligible(func(t *torrentv1beta1.Torrent) {
+			t.Annotations = map[string]string{AnnotationReconcile: ReconcileDisabled}
+		})).To(BeFalse())
+		Expect(eligible(func(t *torrentv1beta1.Torrent) {
+			t.Annotations = map[string]string{AnnotationForceRelease: "true"}
+		})).To(BeFalse())
+		Expect(eligible(func(t *torrentv1beta1.Torrent) {
+			t.Spec.DeletionProtection = torrentv1beta1.DeletionProtectionWhileIncomplete
+		})).To(BeFalse())
+		Expect(listed).To(BeZero())
+
+		By("checking the ownership of the torrents whose files are deleted")
+		Expect(eligible(func(t *torrentv1beta1.Torrent) {
+			t.Status.Hash, t.Status.AddedOn = moviesHash, 1
+		})).To(BeTrue())
+		Expect(eligible(func(t *torrentv1beta1.Torrent) {
+			t.Status.Hash, t.Status.AddedOn = showsHash, 1
+		})).To(BeFalse())
+		Expect(listed).To(Equal(2))
+
+		By("skipping the ownership check of the files kept")
+		Expect(eligible(func(t *torrentv1beta1.Torrent) {
+			t.Status.Hash, t.Status.AddedOn = showsHash, 1
+			t.Spec.DeletionPolicy = torrentv1beta1.DeletionPolicyKeepFiles
+		})).To(BeTrue())
+		Expect(eligible(func(t *torrentv1beta1.Torrent) {
+			t.Status.Hash, t.Status.AddedOn = showsHash, 1
+			t.Spec.DeletionPolicy = torrentv1beta1.DeletionPolicyOrphan
+			t.Spec.DeletionProtection = torrentv1beta1.DeletionProtectionWhileIncomplete
+		})).To(BeTrue())
+		Expect(listed).To(Equal(2))
+	})
+})
@@ -0,0 +1,257 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// Defaults of the network pressure of the operator config
+const (
+	DefaultNetworkDevice        = "eth0"
+	DefaultMinimumThrottleLimit = 16 << 10
+)
+
+const (
+	// networkRelief is the share of the ceiling the traffic has to drop
+	// below before the throttled torrents are restored
+	networkRelief = 0.8
+	// networkSampleInterval bounds how often the traffic is sampled, the
+	// Torrents reconciled meanwhile share the last sample
+	networkSampleInterval = 5 * time.Second
+	// nodeMetricsTimeout bounds the scrape of the node exporter
+	nodeMetricsTimeout = 5 * time.Second
+)
+
+// networkPressure samples the traffic compared to the ceiling of the network
+// pressure, for all the Torrents
+type networkPressure struct {
+	mu sync.Mutex
+	// source is the node exporter and device the bytes were read from
+	source    string
+	bytes     float64
+	sampledAt time.Time
+	rate      int64
+	saturated bool
+}
+
+// sample returns the traffic in bytes per second and whether the network is
+// saturated. The network stays saturated from when the traffic exceeds the
+// ceiling until it drops below networkRelief of it, so that restoring the
+// torrents does not saturate it right away. The node counters give no rate
+// on their first sample.
+func (p *networkPressure) sample(ctx context.Context, qbt *qbittorrent.Client,
+	settings *torrentv1beta1.NetworkPressure) (int64, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	device := settings.Device
+	if device == "" {
+		device = DefaultNetworkDevice
+	}
+	source := settings.NodeMetricsURL + "#" + device
+	now := time.Now()
+	if source == p.source && now.Sub(p.sampledAt) < networkSampleInterval {
+		return p.rate, p.saturated, nil
+	}
+
+	var rate int64
+	if settings.NodeMetricsURL != "" {
+		bytes, err := nodeNetworkBytes(ctx, settings.NodeMetricsURL, device)
+		if err != nil {
+			return 0, false, err
+		}
+		// The counters restart with the node
+		if source == p.source && bytes >= p.bytes {
+			rate = int64((bytes - p.bytes) / now.Sub(p.sampledAt).Seconds())
+		}
+		p.bytes = bytes
+	} else {
+		state, err := qbt.GetServerState(ctx)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to get the transfer speeds: %w", err)
+		}
+		rate = state.DownloadSpeed + state.UploadSpeed
+	}
+
+	ceiling := settings.Ceiling.Value()
+	switch {
+	case rate > ceiling:
+		p.saturated = true
+	case float64(rate) < networkRelief*float64(ceiling):
+		p.saturated = false
+	}
+	p.source, p.sampledAt, p.rate = source, now, rate
+	return p.rate, p.saturated, nil
+}
+
+// nodeNetworkBytes returns the bytes received and sent by the network device
+// of the node, read from the Prometheus text format of the node exporter
+func nodeNetworkBytes(ctx context.Context, metricsURL, device string) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, nodeMetricsTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metricsURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("invalid node metrics URL: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the node metrics: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to get the node metrics: status %d", resp.StatusCode)
+	}
+
+	label := `device="` + device + `"`
+	var bytes float64
+	found := 0
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "node_network_receive_bytes_total{") &&
+			!strings.HasPrefix(line, "node_network_transmit_bytes_total{") {
+			continue
+		}
+		end := strings.LastIndex(line, "}")
+		if end < 0 || !strings.Contains(line[:end], label) {
+			continue
+		}
+		fields := strings.Fields(line[end+1:])
+		if len(fields) == 0 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid node metric %q: %w", line, err)
+		}
+		bytes += value
+		found++
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read the node metrics: %w", err)
+	}
+	if found == 0 {
+		return 0, fmt.Errorf("no traffic of the network device %s in the node metrics", device)
+	}
+	return bytes, nil
+}
+
+// throttledLimit scales a speed or limit down by the ratio, keeping it at
+// least the minimum and at most the limit the torrent runs with
+func throttledLimit(current int64, ratio float64, minimum, limit int64) int64 {
+	throttled := max(int64(float64(current)*ratio), minimum)
+	if limit > 0 && limit < throttled {
+		return limit
+	}
+	return throttled
+}
+
+// reconcileThrottle scales the limits of the transferring torrent down while
+// the network of the node is saturated, further while the traffic stays above
+// the ceiling, and restores them once the network is relieved. It returns
+// whether the status changed.
+func (r *TorrentReconciler) reconcileThrottle(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) (bool, error) {
+	logger := log.FromContext(ctx)
+	settings := r.Config.Spec().NetworkPressure
+	throttle := torrent.Status.Throttle
+
+	var rate int64
+	saturated := false
+	if settings != nil {
+		var err error
+		if rate, saturated, err = r.pressure.sample(ctx, r.qbt(torrent), settings); err != nil {
+			return false, err
+		}
+	}
+
+	if !saturated {
+		if throttle == nil {
+			return false, nil
+		}
+		logger.Info("The node network is relieved, restoring the limits of the torrent")
+		torrent.Status.Throttle = nil
+		if err := r.restoreLimits(ctx, torrent, qbTorrent); err != nil {
+			torrent.Status.Throttle = throttle
+			return false, err
+		}
+		r.recordEvent(torrent, corev1.EventTypeNormal, "Unthrottled",
+			"The node network is relieved, the limits of the torrent are restored")
+		return true, nil
+	}
+
+	// Within the ceiling the throttled torrents are held until the network
+	// is relieved, and the others are left alone
+	ceiling := settings.Ceiling.Value()
+	if rate <= ceiling {
+		return false, nil
+	}
+	download, upload := qbTorrent.DLSpeed, qbTorrent.UPSpeed
+	if throttle != nil {
+		download, upload = throttle.DownloadLimit, throttle.UploadLimit
+	} else if download == 0 && upload == 0 {
+		// Idle torrents do not add to the traffic
+		return false, nil
+	}
+
+	ratio := float64(ceiling) / float64(rate)
+	minimum := int64(DefaultMinimumThrottleLimit)
+	if settings.MinimumLimit != nil {
+		minimum = settings.MinimumLimit.Value()
+	}
+	downloadLimit, uploadLimit := r.transferLimits(torrent)
+	download = throttledLimit(download, ratio, minimum, downloadLimit)
+	upload = throttledLimit(upload, ratio, minimum, uploadLimit)
+	if throttle != nil && download == throttle.DownloadLimit && upload == throttle.UploadLimit {
+		return false, nil
+	}
+
+	logger.Info("The node network is saturated, throttling the torrent", "Traffic", rate,
+		"DownloadLimit", download, "UploadLimit", upload)
+	if err := r.qbt(torrent).SetDownloadLimit(ctx, qbTorrent.Hash, download); err != nil {
+		return false, fmt.Errorf("failed to throttle the download: %w", err)
+	}
+	if err := r.qbt(torrent).SetUploadLimit(ctx, qbTorrent.Hash, upload); err != nil {
+		return false, fmt.Errorf("failed to throttle the upload: %w", err)
+	}
+	if throttle == nil {
+		r.recordEvent(torrent, corev1.EventTypeWarning, "Throttled", fmt.Sprintf(
+			"The node network is saturated at %s/s, throttling the torrent to %s/s down and %s/s up",
+			formatQuantity(rate), formatQuantity(download), formatQuantity(upload)))
+		throttle = &torrentv1beta1.ThrottleStatus{Since: metav1.Now()}
+	} else {
+		throttle = throttle.DeepCopy()
+	}
+	throttle.DownloadLimit, throttle.UploadLimit = download, upload
+	torrent.Status.Throttle = throttle
+	return true, nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Torrent network pressure", func() {
+	const magnetHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var controllerReconciler *TorrentReconciler
+	var recorder *record.FakeRecorder
+	var key types.NamespacedName
+
+	// reconcileTorrent reconciles the torrent with a new sample of the traffic
+	reconcileTorrent := func() {
+		controllerReconciler.pressure.sampledAt = time.Time{}
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getThrottle := func() *torrentv1beta1.ThrottleStatus {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent.Status.Throttle
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		key = types.NamespacedName{Name: "network-pressure", Namespace: "default"}
+		recorder = record.NewFakeRecorder(20)
+		config := &OperatorConfig{}
+		config.set(&torrentv1beta1.QBittorrentOperatorConfigSpec{
+			NetworkPressure: &torrentv1beta1.NetworkPressure{Ceiling: resource.MustParse("1Mi")},
+		})
+		controllerReconciler = &TorrentReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Clients:  poolOf(qb.client()),
+			Recorder: recorder,
+			Config:   config,
+		}
+
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source: torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + magnetHash},
+			},
+		})).To(Succeed())
+		reconcileTorrent()
+		reconcileTorrent()
+		qb.setState(magnetHash, "downloading")
+		qb.setSpeeds(magnetHash, 1<<20, 256<<10)
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+	})
+
+	It("should throttle the torrents above the ceiling and restore them once relieved", func() {
+		By("leaving the torrent alone within the ceiling")
+		qb.setServerSpeeds(768<<10, 256<<10)
+		reconcileTorrent()
+		Expect(getThrottle()).To(BeNil())
+
+		By("scaling the limits down by the excess of traffic")
+		qb.setServerSpeeds(1536<<10, 512<<10)
+		reconcileTorrent()
+		throttle := getThrottle()
+		Expect(throttle).NotTo(BeNil())
+		Expect(throttle.DownloadLimit).To(Equal(int64(512 << 10)))
+		Expect(throttle.UploadLimit).To(Equal(int64(128 << 10)))
+		Expect(qb.torrentInfo(magnetHash).DLLimit).To(Equal(int64(512 << 10)))
+		Expect(qb.torrentInfo(magnetHash).UPLimit).To(Equal(int64(128 << 10)))
+		Expect(recorder.Events).To(Receive(HavePrefix("Warning Throttled")))
+
+		By("scaling them further down while the traffic stays above the ceiling")
+		reconcileTorrent()
+		throttle = getThrottle()
+		Expect(throttle.DownloadLimit).To(Equal(int64(256 << 10)))
+		Expect(throttle.UploadLimit).To(Equal(int64(64 << 10)))
+
+		By("holding them until the traffic drops below the relief threshold")
+		qb.setServerSpeeds(768<<10, 128<<10)
+		reconcileTorrent()
+		Expect(getThrottle()).To(Equal(throttle))
+
+		By("restoring the limits once relieved")
+		qb.setServerSpeeds(512<<10, 128<<10)
+		reconcileTorrent()
+		Expect(getThrottle()).To(BeNil())
+		Expect(qb.torrentInfo(magnetHash).DLLimit).To(BeZero())
+		Expect(qb.torrentInfo(magnetHash).UPLimit).To(BeZero())
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal Unthrottled")))
+	})
+
+	It("should read the traffic of the device from the node exporter", func() {
+		exporter := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = io.WriteString(w, `# TYPE node_network_receive_bytes_total counter
+node_network_receive_bytes_total{device="eth0"} 1.5e+06
+node_network_receive_bytes_total{device="lo"} 9e+09
+node_network_transmit_bytes_total{device="eth0"} 500000
+node_network_transmit_bytes_total{device="lo"} 9e+09
+`)
+		}))
+		defer exporter.Close()
+
+		bytes, err := nodeNetworkBytes(ctx, exporter.URL, "eth0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bytes).To(Equal(2e6))
+		_, err = nodeNetworkBytes(ctx, exporter.URL, "eth1")
+		Expect(err).To(MatchError(ContainSubstring("eth1")))
+	})
+})
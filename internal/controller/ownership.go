@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// OwnershipTagPrefix prefixes the tag claiming a torrent on qBittorrent for
+// a Torrent, followed by the UID of the Torrent
+const OwnershipTagPrefix = "qbittorrent.io/owner="
+
+// TypeOwnedTorrent reports whether the torrent on qBittorrent carries the
+// ownership tag of the Torrent
+const TypeOwnedTorrent = "Owned"
+
+// reasonOwnershipMismatch is the reason of the conditions of the torrents
+// not owned by their Torrent
+const reasonOwnershipMismatch = "OwnershipMismatch"
+
+func ownershipTag(torrent *torrentv1beta1.Torrent) string {
+	return OwnershipTagPrefix + string(torrent.UID)
+}
+
+func hasOwnershipTag(torrent *torrentv1beta1.Torrent, qbTorrent *qbittorrent.TorrentInfo) bool {
+	return slices.Contains(qbTorrent.TagList(), ownershipTag(torrent))
+}
+
+// claimable reports whether the torrent without ownership tag may be claimed
+// by the Torrent: the one it found on qBittorrent, or the one it recorded
+// before the ownership tags. A torrent added again outside the operator is
+// not, it has another addition time.
+func claimable(torrent *torrentv1beta1.Torrent, qbTorrent *qbittorrent.TorrentInfo) bool {
+	if meta.IsStatusConditionFalse(torrent.Status.Conditions, TypeOwnedTorrent) {
+		return false
+	}
+	return torrent.Status.AddedOn == 0 || torrent.Status.AddedOn == qbTorrent.AddedOn
+}
+
+// reconcileOwnership claims the torrent with the ownership tag of the
+// Torrent, unless it was added again outside the operator. A mismatch is
+// kept until the tag is added by hand, handing the torrent over.
+func (r *TorrentReconciler) reconcileOwnership(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) error {
+	tag := ownershipTag(torrent)
+	if hasOwnershipTag(torrent, qbTorrent) {
+		setCondition(&torrent.Status.Conditions, metav1.Condition{
+			Type:    TypeOwnedTorrent,
+			Status:  metav1.ConditionTrue,
+			Reason:  "OwnershipTagged",
+			Message: "The torrent carries the ownership tag " + tag,
+		})
+		return nil
+	}
+
+	if !claimable(torrent, qbTorrent) {
+		message := fmt.Sprintf("The torrent was added again to qBittorrent outside the operator, "+
+			"add the tag %s to hand it over", tag)
+		if !meta.IsStatusConditionFalse(torrent.Status.Conditions, TypeOwnedTorrent) {
+			r.recordEvent(torrent, corev1.EventTypeWarning, reasonOwnershipMismatch, message)
+		}
+		setCondition(&torrent.Status.Conditions, metav1.Condition{
+			Type:    TypeOwnedTorrent,
+			Status:  metav1.ConditionFalse,
+			Reason:  reasonOwnershipMismatch,
+			Message: message,
+		})
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Claiming the torrent with the ownership tag", "Name", torrent.Name, "Tag", tag)
+	if err := r.qbt(torrent).AddTags(ctx, qbTorrent.Hash, []string{tag}); err != nil {
+		return fmt.Errorf("failed to add the ownership tag: %w", err)
+	}
+	setCondition(&torrent.Status.Conditions, metav1.Condition{
+		Type:    TypeOwnedTorrent,
+		Status:  metav1.ConditionTrue,
+		Reason:  "OwnershipClaimed",
+		Message: "The torrent was claimed with the ownership tag " + tag,
+	})
+	return nil
+}
+
+// ownershipMismatch returns why the files of the torrent on qBittorrent are
+// not the Torrent's to delete, or an empty string when they are. A torrent
+// whose ownership was never checked, e.g. deleted right after an upgrade, is
+// owned if claimable.
+func ownershipMismatch(torrent *torrentv1beta1.Torrent, qbTorrent *qbittorrent.TorrentInfo) string {
+	owned := hasOwnershipTag(torrent, qbTorrent) ||
+		(meta.FindStatusCondition(torrent.Status.Conditions, TypeOwnedTorrent) == nil && claimable(torrent, qbTorrent))
+	if !owned {
+		return fmt.Sprintf("The torrent on qBittorrent does not carry the ownership tag %s", ownershipTag(torrent))
+	}
+	if torrent.Status.ContentPath != "" && qbTorrent.ContentPath != torrent.Status.ContentPath {
+		return fmt.Sprintf("The content path %s on qBittorrent is not %s recorded in status",
+			qbTorrent.ContentPath, torrent.Status.ContentPath)
+	}
+	return ""
+}
+
+// holdForOwnership holds the deletion of the files of a torrent which is not
+// the Torrent's, protecting a torrent added by hand under the same hash. It
+// returns whether the deletion is held.
+func (r *TorrentReconciler) holdForOwnership(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, error) {
+	if torrent.Status.Hash == "" || r.deletionPolicy(torrent) == torrentv1beta1.DeletionPolicyKeepFiles {
+		return false, nil
+	}
+
+	qbTorrent, err := r.qbt(torrent).GetTorrentInfo(ctx, qbittorrent.InfoHashes{V1: torrent.Status.Hash})
+	if err != nil {
+		return false, fmt.Errorf("failed to get torrent info: %w", err)
+	}
+	// Nothing is left to delete once the torrent is gone from qBittorrent
+	if qbTorrent == nil {
+		return false, nil
+	}
+
+	message := ownershipMismatch(torrent, qbTorrent)
+	if message == "" {
+		return false, nil
+	}
+	log.FromContext(ctx).Info("Deletion of Torrent held, the torrent is not owned", "Name", torrent.Name,
+		"Reason", message)
+
+	r.setDegradedCondition(torrent, reasonOwnershipMismatch,
+		message+", set spec.deletionPolicy to KeepFiles or Orphan to delete the Torrent")
+	if err := r.Status().Update(ctx, torrent); err != nil {
+		return true, err
+	}
+	return true, nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("Torrent ownership", func() {
+	It("should only own the torrents carrying the ownership tag or claimable", func() {
+		torrent := &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{UID: "8f2c"},
+			Status:     torrentv1beta1.TorrentStatus{AddedOn: 1700000000, ContentPath: "/downloads/movie"},
+		}
+		tagged := &qbittorrent.TorrentInfo{Tags: "movies, qbittorrent.io/owner=8f2c", AddedOn: 1800000000,
+			ContentPath: "/downloads/movie"}
+		Expect(ownershipMismatch(torrent, tagged)).To(BeEmpty())
+
+		By("claiming the torrent recorded before the ownership tags")
+		untagged := &qbittorrent.TorrentInfo{AddedOn: 1700000000, ContentPath: "/downloads/movie"}
+		Expect(ownershipMismatch(torrent, untagged)).To(BeEmpty())
+
+		By("refusing the torrent added again outside the operator")
+		untagged.AddedOn = 1800000000
+		Expect(ownershipMismatch(torrent, untagged)).To(ContainSubstring("qbittorrent.io/owner=8f2c"))
+
+		By("refusing the torrent whose content moved")
+		tagged.ContentPath = "/downloads/other"
+		Expect(ownershipMismatch(torrent, tagged)).To(ContainSubstring("/downloads/other"))
+	})
+
+	Context("When deleting a Torrent", func() {
+		const (
+			magnetHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+			otherHash  = "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"
+		)
+
+		ctx := context.Background()
+		key := types.NamespacedName{Name: "ownership", Namespace: "default"}
+
+		var qb *fakeQBittorrent
+		var controllerReconciler *TorrentReconciler
+
+		reconcileTorrent := func() {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		getTorrent := func() *torrentv1beta1.Torrent {
+			torrent := &torrentv1beta1.Torrent{}
+			Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+			return torrent
+		}
+
+		BeforeEach(func() {
+			qb = newFakeQBittorrent()
+			controllerReconciler = &TorrentReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Clients:  poolOf(qb.client()),
+				Recorder: record.NewFakeRecorder(20),
+				Config:   &OperatorConfig{},
+			}
+
+			Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				Spec: torrentv1beta1.TorrentSpec{
+					Source: torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + magnetHash},
+				},
+			})).To(Succeed())
+			reconcileTorrent()
+			reconcileTorrent()
+			reconcileTorrent()
+			torrent := getTorrent()
+			Expect(torrent.Status.Hash).To(Equal(magnetHash))
+			Expect(meta.IsStatusConditionTrue(torrent.Status.Conditions, TypeOwnedTorrent)).To(BeTrue())
+		})
+
+		AfterEach(func() {
+			qb.Close()
+
+			torrent := &torrentv1beta1.Torrent{}
+			if err := k8sClient.Get(ctx, key, torrent); err == nil {
+				controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+				Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+			}
+		})
+
+		It("should hold the deletion of the files of a torrent added again by hand", func() {
+			By("adding the torrent again from the WebUI")
+			// Another torrent is left, qBittorrent did not restart
+			Expect(qb.client().AddTorrent(ctx, "magnet:?xt=urn:btih:"+otherHash)).To(Succeed())
+			Expect(qb.client().DeleteTorrent(ctx, magnetHash, false)).To(Succeed())
+			Expect(qb.client().AddTorrent(ctx, "magnet:?xt=urn:btih:"+magnetHash)).To(Succeed())
+			reconcileTorrent()
+			owned := meta.FindStatusCondition(getTorrent().Status.Conditions, TypeOwnedTorrent)
+			Expect(owned.Status).To(Equal(metav1.ConditionFalse))
+			Expect(owned.Reason).To(Equal(reasonOwnershipMismatch))
+
+			By("holding the deletion")
+			Expect(k8sClient.Delete(ctx, getTorrent())).To(Succeed())
+			reconcileTorrent()
+			Expect(qb.hashes()).To(ContainElement(magnetHash))
+			degraded := meta.FindStatusCondition(getTorrent().Status.Conditions, TypeDegradedTorrent)
+			Expect(degraded).NotTo(BeNil())
+			Expect(degraded.Reason).To(Equal(reasonOwnershipMismatch))
+
+			By("deleting it once handed over with the ownership tag")
+			Expect(qb.client().AddTags(ctx, magnetHash, []string{ownershipTag(getTorrent())})).To(Succeed())
+			reconcileTorrent()
+			Expect(qb.hashes()).NotTo(ContainElement(magnetHash))
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, key, &torrentv1beta1.Torrent{}))).To(BeTrue())
+		})
+
+		It("should delete a torrent it does not own when keeping its files", func() {
+			Expect(qb.client().AddTorrent(ctx, "magnet:?xt=urn:btih:"+otherHash)).To(Succeed())
+			Expect(qb.client().DeleteTorrent(ctx, magnetHash, false)).To(Succeed())
+			Expect(qb.client().AddTorrent(ctx, "magnet:?xt=urn:btih:"+magnetHash)).To(Succeed())
+			reconcileTorrent()
+
+			torrent := getTorrent()
+			torrent.Spec.DeletionPolicy = torrentv1beta1.DeletionPolicyKeepFiles
+			Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, torrent)).To(Succeed())
+			reconcileTorrent()
+			Expect(qb.hashes()).NotTo(ContainElement(magnetHash))
+		})
+	})
+})
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// maxPeerCounts bounds the clients and countries reported in status.peers,
+// as their MaxItems
+const maxPeerCounts = 10
+
+// unknownPeerValue counts the peers whose client or country is not known,
+// e.g. when the geolocation database of qBittorrent is disabled
+const unknownPeerValue = "unknown"
+
+// peerSummary summarizes the peers connected for a torrent
+func peerSummary(peers map[string]qbittorrent.PeerInfo) *torrentv1beta1.PeerSummary {
+	summary := &torrentv1beta1.PeerSummary{Connected: int32(len(peers))}
+
+	clients := map[string]int32{}
+	countries := map[string]int32{}
+	for _, peer := range peers {
+		if peer.Encrypted() {
+			summary.Encrypted++
+		}
+		clients[cmp.Or(peer.Client, unknownPeerValue)]++
+		countries[cmp.Or(strings.ToUpper(peer.CountryCode), unknownPeerValue)]++
+	}
+	summary.Clients = peerCounts(clients)
+	summary.Countries = peerCounts(countries)
+	return summary
+}
+
+// peerCounts returns the most common values first, by name on ties so that
+// the status does not change between reconciles
+func peerCounts(counts map[string]int32) []torrentv1beta1.PeerCount {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	peerCounts := make([]torrentv1beta1.PeerCount, 0, len(counts))
+	for name, count := range counts {
+		peerCounts = append(peerCounts, torrentv1beta1.PeerCount{Name: name, Count: count})
+	}
+	slices.SortFunc(peerCounts, func(a, b torrentv1beta1.PeerCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), strings.Compare(a.Name, b.Name))
+	})
+	if len(peerCounts) > maxPeerCounts {
+		peerCounts = peerCounts[:maxPeerCounts]
+	}
+	return peerCounts
+}
+
+// reconcilePeers summarizes the peers of the torrent in status with
+// spec.reportPeers, and clears it otherwise. It returns whether the status
+// changed.
+func (r *TorrentReconciler) reconcilePeers(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) (bool, error) {
+	if !torrent.Spec.ReportPeers {
+		updated := torrent.Status.Peers != nil
+		torrent.Status.Peers = nil
+		return updated, nil
+	}
+
+	peers, err := r.qbt(torrent).GetTorrentPeers(ctx, qbTorrent.Hash)
+	if err != nil {
+		return false, fmt.Errorf("failed to get torrent peers: %w", err)
+	}
+
+	summary := peerSummary(peers)
+	if previous := torrent.Status.Peers; previous != nil &&
+		previous.Connected == summary.Connected && previous.Encrypted == summary.Encrypted &&
+		slices.Equal(previous.Clients, summary.Clients) && slices.Equal(previous.Countries, summary.Countries) {
+		return false, nil
+	}
+	torrent.Status.Peers = summary
+	return true, nil
+}
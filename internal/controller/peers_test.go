@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("Peer summary", func() {
+	It("should count the peers by client, country and encryption", func() {
+		summary := peerSummary(map[string]qbittorrent.PeerInfo{
+			"203.0.113.1:51413": {Client: "qBittorrent 4.6.5", CountryCode: "de", Flags: "D E"},
+			"203.0.113.2:51413": {Client: "qBittorrent 4.6.5", CountryCode: "fr", Flags: "U"},
+			"203.0.113.3:6881":  {Client: "Transmission 4.0.6", Flags: "e"},
+		})
+		Expect(summary.Connected).To(Equal(int32(3)))
+		Expect(summary.Encrypted).To(Equal(int32(2)))
+		Expect(summary.Clients).To(Equal([]torrentv1beta1.PeerCount{
+			{Name: "qBittorrent 4.6.5", Count: 2},
+			{Name: "Transmission 4.0.6", Count: 1},
+		}))
+		Expect(summary.Countries).To(Equal([]torrentv1beta1.PeerCount{
+			{Name: "DE", Count: 1},
+			{Name: "FR", Count: 1},
+			{Name: "unknown", Count: 1},
+		}))
+	})
+
+	It("should only report the most common clients", func() {
+		peers := map[string]qbittorrent.PeerInfo{}
+		for i := range 15 {
+			peers[fmt.Sprintf("203.0.113.%d:6881", i)] = qbittorrent.PeerInfo{Client: fmt.Sprintf("client-%02d", i)}
+		}
+		summary := peerSummary(peers)
+		Expect(summary.Connected).To(Equal(int32(15)))
+		Expect(summary.Clients).To(HaveLen(maxPeerCounts))
+		Expect(summary.Clients[0].Name).To(Equal("client-00"))
+	})
+
+	It("should report no peers", func() {
+		summary := peerSummary(nil)
+		Expect(summary.Connected).To(BeZero())
+		Expect(summary.Clients).To(BeNil())
+	})
+})
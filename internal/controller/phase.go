@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// torrentPhase derives the phase of the torrent from its qBittorrent state.
+// Transient states, such as checking or moving the content, are reported by
+// whether the content is complete.
+func torrentPhase(qbTorrent *qbittorrent.TorrentInfo) torrentv1beta1.TorrentPhase {
+	switch torrentv1beta1.TorrentState(qbTorrent.State) {
+	case torrentv1beta1.TorrentStateError, torrentv1beta1.TorrentStateMissingFiles:
+		return torrentv1beta1.TorrentPhaseError
+	case torrentv1beta1.TorrentStatePausedUP, torrentv1beta1.TorrentStateStoppedUP:
+		return torrentv1beta1.TorrentPhaseCompleted
+	case torrentv1beta1.TorrentStatePausedDL, torrentv1beta1.TorrentStateStoppedDL:
+		return torrentv1beta1.TorrentPhasePaused
+	case torrentv1beta1.TorrentStateUploading, torrentv1beta1.TorrentStateForcedUP,
+		torrentv1beta1.TorrentStateQueuedUP, torrentv1beta1.TorrentStateStalledUP,
+		torrentv1beta1.TorrentStateCheckingUP:
+		return torrentv1beta1.TorrentPhaseSeeding
+	case torrentv1beta1.TorrentStateDownloading, torrentv1beta1.TorrentStateForcedDL,
+		torrentv1beta1.TorrentStateMetaDL, torrentv1beta1.TorrentStateForcedMetaDL,
+		torrentv1beta1.TorrentStateQueuedDL, torrentv1beta1.TorrentStateStalledDL,
+		torrentv1beta1.TorrentStateCheckingDL, torrentv1beta1.TorrentStateAllocating:
+		return torrentv1beta1.TorrentPhaseDownloading
+	case torrentv1beta1.TorrentStateCheckingResumeData, torrentv1beta1.TorrentStateUnknown:
+		return torrentv1beta1.TorrentPhasePending
+	}
+
+	// Moving, or a state unknown to this release
+	if qbTorrent.TotalSize > 0 && qbTorrent.AmountLeft == 0 {
+		return torrentv1beta1.TorrentPhaseSeeding
+	}
+	return torrentv1beta1.TorrentPhaseDownloading
+}
+
+// torrentStopped reports whether the torrent is stopped, complete or not.
+// The stopped complete torrents are in the Completed phase, not Paused.
+func torrentStopped(qbTorrent *qbittorrent.TorrentInfo) bool {
+	switch torrentv1beta1.TorrentState(qbTorrent.State) {
+	case torrentv1beta1.TorrentStatePausedDL, torrentv1beta1.TorrentStatePausedUP,
+		torrentv1beta1.TorrentStateStoppedDL, torrentv1beta1.TorrentStateStoppedUP:
+		return true
+	}
+	return false
+}
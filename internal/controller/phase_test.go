@@ -0,0 +1,43 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("Torrent phase", func() {
+	DescribeTable("should be derived from the qBittorrent state",
+		func(state string, amountLeft int64, phase torrentv1beta1.TorrentPhase) {
+			qbTorrent := &qbittorrent.TorrentInfo{State: state, TotalSize: 100, AmountLeft: amountLeft}
+			Expect(torrentPhase(qbTorrent)).To(Equal(phase))
+		},
+		Entry("downloading", "downloading", int64(40), torrentv1beta1.TorrentPhaseDownloading),
+		Entry("stalled download", "stalledDL", int64(40), torrentv1beta1.TorrentPhaseDownloading),
+		Entry("seeding", "uploading", int64(0), torrentv1beta1.TorrentPhaseSeeding),
+		Entry("stopped complete", "stoppedUP", int64(0), torrentv1beta1.TorrentPhaseCompleted),
+		Entry("paused incomplete", "pausedDL", int64(40), torrentv1beta1.TorrentPhasePaused),
+		Entry("missing files", "missingFiles", int64(0), torrentv1beta1.TorrentPhaseError),
+		Entry("loading", "checkingResumeData", int64(40), torrentv1beta1.TorrentPhasePending),
+		Entry("moving complete", "moving", int64(0), torrentv1beta1.TorrentPhaseSeeding),
+		Entry("unlisted state", "somethingNew", int64(40), torrentv1beta1.TorrentPhaseDownloading),
+	)
+})
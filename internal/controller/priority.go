@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// downloadingStates are the qBittorrent states of the torrents using the
+// download bandwidth
+var downloadingStates = []torrentv1beta1.TorrentState{
+	torrentv1beta1.TorrentStateDownloading,
+	torrentv1beta1.TorrentStateForcedDL,
+	torrentv1beta1.TorrentStateMetaDL,
+}
+
+// torrentPriority returns the priority of the torrent, Normal if unset and
+// High while boosted by the deadline escalation
+func torrentPriority(torrent *torrentv1beta1.Torrent) torrentv1beta1.TorrentPriority {
+	if deadlineEscalated(torrent) && torrent.Spec.DeadlineEscalation.BoostPriority {
+		return torrentv1beta1.TorrentPriorityHigh
+	}
+	if torrent.Spec.Priority == "" {
+		return torrentv1beta1.TorrentPriorityNormal
+	}
+	return torrent.Spec.Priority
+}
+
+// reconcilePriority moves the torrent in the qBittorrent queue when its
+// priority changed, and throttles the download of a low priority torrent while
+// high priority ones are downloading. It returns whether the status changed.
+func (r *TorrentReconciler) reconcilePriority(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) (bool, error) {
+	logger := log.FromContext(ctx)
+	updated := false
+
+	priority := torrentPriority(torrent)
+	if torrent.Status.QueuedPriority != priority {
+		var err error
+		switch priority {
+		case torrentv1beta1.TorrentPriorityHigh:
+			logger.Info("Moving torrent to the top of the queue", "Priority", priority)
+			err = r.qbt(torrent).TopPriority(ctx, qbTorrent.Hash)
+		case torrentv1beta1.TorrentPriorityLow:
+			logger.Info("Moving torrent to the bottom of the queue", "Priority", priority)
+			err = r.qbt(torrent).BottomPriority(ctx, qbTorrent.Hash)
+		}
+		if errors.Is(err, qbittorrent.ErrQueueingDisabled) {
+			// Only the limits rank the torrents without queue. The position
+			// is set once queueing is enabled in qBittorrent.
+			logger.V(1).Info("Torrent queueing is disabled, the queue position is left unchanged")
+		} else if err != nil {
+			return false, fmt.Errorf("failed to set the queue position: %w", err)
+		} else {
+			torrent.Status.QueuedPriority = priority
+			updated = true
+		}
+	}
+
+	preempt := false
+	if r.LowPriorityDownloadLimit > 0 && priority == torrentv1beta1.TorrentPriorityLow &&
+		!isTorrentComplete(qbTorrent) && !limitsLifted(torrent) {
+		var err error
+		if preempt, err = r.highPriorityDownloading(ctx); err != nil {
+			return false, err
+		}
+	}
+	if preempt == torrent.Status.Preempted {
+		return updated, nil
+	}
+
+	torrent.Status.Preempted = preempt
+	limit, _ := r.transferLimits(torrent)
+	if preempt {
+		logger.Info("High priority torrents are downloading, throttling the download", "Limit", limit)
+	} else {
+		logger.Info("No high priority torrent is downloading, restoring the download limit", "Limit", limit)
+	}
+	// The limits of a torrent throttled by the network pressure are restored with it
+	if torrent.Status.Throttle == nil {
+		if err := r.qbt(torrent).SetDownloadLimit(ctx, qbTorrent.Hash, limit); err != nil {
+			torrent.Status.Preempted = !preempt
+			return false, fmt.Errorf("failed to set the download limit: %w", err)
+		}
+	}
+	return true, nil
+}
+
+// transferLimits returns the download and upload limits the torrent runs with
+// besides the network pressure: the ones of the spec unless lifted by the
+// deadline escalation, the download one lowered while it is preempted
+func (r *TorrentReconciler) transferLimits(torrent *torrentv1beta1.Torrent) (int64, int64) {
+	var download, upload int64
+	if limits := torrent.Spec.Limits; limits != nil && !limitsLifted(torrent) {
+		if limits.DownloadLimit != nil {
+			download = *limits.DownloadLimit
+		}
+		if limits.UploadLimit != nil {
+			upload = *limits.UploadLimit
+		}
+	}
+	// The spec limit is kept when lower than the preemption one
+	if torrent.Status.Preempted && (download == 0 || download > r.LowPriorityDownloadLimit) {
+		download = r.LowPriorityDownloadLimit
+	}
+	return download, upload
+}
+
+// highPriorityDownloading reports whether high priority torrents are downloading
+func (r *TorrentReconciler) highPriorityDownloading(ctx context.Context) (bool, error) {
+	torrents := &torrentv1beta1.TorrentList{}
+	if err := r.List(ctx, torrents); err != nil {
+		return false, fmt.Errorf("failed to list Torrents: %w", err)
+	}
+	for _, torrent := range torrents.Items {
+		if torrentPriority(&torrent) == torrentv1beta1.TorrentPriorityHigh &&
+			slices.Contains(downloadingStates, torrent.Status.State) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// encryptionModes are the values of the encryption preference of qBittorrent
+var encryptionModes = map[torrentv1beta1.EncryptionMode]int32{
+	torrentv1beta1.EncryptionModePrefer:  0,
+	torrentv1beta1.EncryptionModeRequire: 1,
+	torrentv1beta1.EncryptionModeDisable: 2,
+}
+
+// encryptionMode returns the mode of an encryption preference of qBittorrent
+func encryptionMode(encryption int32) string {
+	for mode, value := range encryptionModes {
+		if value == encryption {
+			return string(mode)
+		}
+	}
+	return strconv.Itoa(int(encryption))
+}
+
+// privacyDrift returns the privacy settings of the spec qBittorrent differs
+// from, and the preferences reverting them by their name in
+// /api/v2/app/preferences
+func privacyDrift(privacy *torrentv1beta1.Privacy,
+	preferences *qbittorrent.Preferences) ([]torrentv1beta1.FieldDrift, map[string]any) {
+	var drift []torrentv1beta1.FieldDrift
+	changes := map[string]any{}
+
+	if privacy.Encryption != "" {
+		if desired := encryptionModes[privacy.Encryption]; desired != preferences.Encryption {
+			drift = append(drift, torrentv1beta1.FieldDrift{
+				Field:   "preferences.privacy.encryption",
+				Desired: string(privacy.Encryption),
+				Actual:  encryptionMode(preferences.Encryption),
+			})
+			changes["encryption"] = desired
+		}
+	}
+
+	toggles := []struct {
+		field, preference string
+		desired           *bool
+		actual            bool
+	}{
+		{"anonymousMode", "anonymous_mode", privacy.AnonymousMode, preferences.AnonymousMode},
+		{"dht", "dht", privacy.DHT, preferences.DHT},
+		{"pex", "pex", privacy.PeX, preferences.PeX},
+		{"lsd", "lsd", privacy.LSD, preferences.LSD},
+	}
+	for _, toggle := range toggles {
+		if toggle.desired == nil || *toggle.desired == toggle.actual {
+			continue
+		}
+		drift = append(drift, torrentv1beta1.FieldDrift{
+			Field:   "preferences.privacy." + toggle.field,
+			Desired: strconv.FormatBool(*toggle.desired),
+			Actual:  strconv.FormatBool(toggle.actual),
+		})
+		changes[toggle.preference] = *toggle.desired
+	}
+	return drift, changes
+}
+
+// reconcilePrivacy compares the privacy preferences of qBittorrent with the
+// spec. The drift is reverted with the Enforce drift policy, the default, and
+// reported in status with Accept.
+func (r *QBittorrentServerReconciler) reconcilePrivacy(ctx context.Context,
+	server *torrentv1beta1.QBittorrentServer, preferences *qbittorrent.Preferences,
+	status *torrentv1beta1.QBittorrentServerStatus) error {
+	if server.Spec.Preferences == nil || server.Spec.Preferences.Privacy == nil {
+		return nil
+	}
+	privacy := server.Spec.Preferences.Privacy
+	status.DriftRevertTime = server.Status.DriftRevertTime
+
+	drift, changes := privacyDrift(privacy, preferences)
+	if len(drift) == 0 {
+		return nil
+	}
+	if privacy.DriftPolicy == torrentv1beta1.DriftActionAccept {
+		status.Drift = drift
+		return nil
+	}
+
+	// A setting changed in the WebUI is logged, it may not be an accident
+	log.FromContext(ctx).Info("Reverting privacy drift", "Drift", drift)
+	if err := r.qbt(server).SetPreferences(ctx, changes); err != nil {
+		return fmt.Errorf("failed to revert the privacy preferences: %w", err)
+	}
+	now := metav1.Now()
+	status.DriftRevertTime = &now
+	return nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("Privacy", func() {
+	It("should report the drift of the settings of the spec", func() {
+		privacy := &torrentv1beta1.Privacy{
+			Encryption:    torrentv1beta1.EncryptionModeRequire,
+			AnonymousMode: ptr.To(true),
+			DHT:           ptr.To(false),
+			LSD:           ptr.To(false),
+		}
+		preferences := &qbittorrent.Preferences{Encryption: 0, AnonymousMode: true, DHT: true, PeX: true}
+
+		drift, changes := privacyDrift(privacy, preferences)
+		Expect(drift).To(Equal([]torrentv1beta1.FieldDrift{
+			{Field: "preferences.privacy.encryption", Desired: "Require", Actual: "Prefer"},
+			{Field: "preferences.privacy.dht", Desired: "false", Actual: "true"},
+		}))
+		Expect(changes).To(Equal(map[string]any{"encryption": int32(1), "dht": false}))
+	})
+
+	It("should leave the unset settings to the WebUI", func() {
+		drift, changes := privacyDrift(&torrentv1beta1.Privacy{}, &qbittorrent.Preferences{Encryption: 2, DHT: true})
+		Expect(drift).To(BeEmpty())
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("should report an unknown encryption value", func() {
+		Expect(encryptionMode(1)).To(Equal("Require"))
+		Expect(encryptionMode(7)).To(Equal("7"))
+	})
+})
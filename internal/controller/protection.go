@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// TypeDeletionProtectedTorrent is set to True while the deletion of the
+// Torrent is held by spec.deletionProtection
+const TypeDeletionProtectedTorrent = "DeletionProtected"
+
+// deletionHold returns why the deletion of the torrent is held by its
+// deletion protection, or an empty string when it can proceed
+func deletionHold(torrent *torrentv1beta1.Torrent, qbTorrent *qbittorrent.TorrentInfo) string {
+	protection := torrent.Spec.DeletionProtection
+	if protection == "" || protection == torrentv1beta1.DeletionProtectionNever {
+		return ""
+	}
+
+	if !isTorrentComplete(qbTorrent) {
+		return fmt.Sprintf("Torrent is incomplete, %d bytes left to download", qbTorrent.AmountLeft)
+	}
+	if protection != torrentv1beta1.DeletionProtectionWhileSeedingBelowRatio {
+		return ""
+	}
+
+	// The ratio limit of the spec, or the one set on qBittorrent. Negative
+	// limits mean no limit, or the global one which is not known.
+	ratioLimit := qbTorrent.RatioLimit
+	if torrent.Spec.Limits != nil && torrent.Spec.Limits.RatioLimit != "" {
+		if ratio, err := strconv.ParseFloat(torrent.Spec.Limits.RatioLimit, 64); err == nil {
+			ratioLimit = ratio
+		}
+	}
+	if ratioLimit >= 0 && qbTorrent.Ratio < ratioLimit {
+		return fmt.Sprintf("Torrent is seeding below its ratio limit, ratio %.2f of %.2f",
+			qbTorrent.Ratio, ratioLimit)
+	}
+	return ""
+}
+
+// holdDeletion reports whether the deletion of the torrent is held by its
+// deletion protection, recording it in an Event and the DeletionProtected
+// condition. The deletion proceeds once the protection no longer applies, or
+// is set to Never.
+func (r *TorrentReconciler) holdDeletion(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	protection := torrent.Spec.DeletionProtection
+	if protection == "" || protection == torrentv1beta1.DeletionProtectionNever || torrent.Status.Hash == "" {
+		return false, nil
+	}
+
+	qbTorrent, err := r.qbt(torrent).GetTorrentInfo(ctx, qbittorrent.InfoHashes{V1: torrent.Status.Hash})
+	if err != nil {
+		return false, fmt.Errorf("failed to get torrent info: %w", err)
+	}
+	// Nothing is left to protect once the torrent is gone from qBittorrent
+	if qbTorrent == nil {
+		return false, nil
+	}
+
+	message := deletionHold(torrent, qbTorrent)
+	if message == "" {
+		return false, nil
+	}
+
+	logger.Info("Deletion of Torrent held by its deletion protection", "Name", torrent.Name,
+		"DeletionProtection", protection, "Reason", message)
+
+	// The Event is only recorded when the deletion starts being held
+	condition := meta.FindStatusCondition(torrent.Status.Conditions, TypeDeletionProtectedTorrent)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Message != message {
+		if condition == nil || condition.Status != metav1.ConditionTrue {
+			r.Recorder.Event(torrent, corev1.EventTypeWarning, "DeletionProtected",
+				message+", set spec.deletionProtection to Never to delete it")
+		}
+		setCondition(&torrent.Status.Conditions, metav1.Condition{
+			Type:    TypeDeletionProtectedTorrent,
+			Status:  metav1.ConditionTrue,
+			Reason:  string(protection),
+			Message: message,
+		})
+		if err := r.Status().Update(ctx, torrent); err != nil {
+			logger.Error(err, "Failed to update Torrent status")
+			return true, err
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("Deletion protection", func() {
+	var torrent *torrentv1beta1.Torrent
+	var incomplete, seeding *qbittorrent.TorrentInfo
+
+	BeforeEach(func() {
+		torrent = &torrentv1beta1.Torrent{}
+		incomplete = &qbittorrent.TorrentInfo{State: "downloading", TotalSize: 100, AmountLeft: 40}
+		seeding = &qbittorrent.TorrentInfo{State: "uploading", TotalSize: 100, Ratio: 0.5, RatioLimit: -2}
+	})
+
+	It("should never hold the deletion by default", func() {
+		Expect(deletionHold(torrent, incomplete)).To(BeEmpty())
+
+		torrent.Spec.DeletionProtection = torrentv1beta1.DeletionProtectionNever
+		Expect(deletionHold(torrent, incomplete)).To(BeEmpty())
+	})
+
+	It("should hold the deletion of incomplete torrents", func() {
+		torrent.Spec.DeletionProtection = torrentv1beta1.DeletionProtectionWhileIncomplete
+		Expect(deletionHold(torrent, incomplete)).To(ContainSubstring("40 bytes left"))
+		Expect(deletionHold(torrent, seeding)).To(BeEmpty())
+	})
+
+	It("should hold the deletion of torrents seeding below the ratio limit", func() {
+		torrent.Spec.DeletionProtection = torrentv1beta1.DeletionProtectionWhileSeedingBelowRatio
+		Expect(deletionHold(torrent, incomplete)).NotTo(BeEmpty())
+
+		// The global ratio limit of qBittorrent is not known
+		Expect(deletionHold(torrent, seeding)).To(BeEmpty())
+
+		seeding.RatioLimit = 1
+		Expect(deletionHold(torrent, seeding)).To(ContainSubstring("ratio 0.50 of 1.00"))
+
+		// The ratio limit of the spec wins
+		torrent.Spec.Limits = &torrentv1beta1.TorrentLimits{RatioLimit: "0.5"}
+		Expect(deletionHold(torrent, seeding)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/clientpool"
+)
+
+// Default name of the QBittorrentOperatorConfig applied by the operator
+const DefaultConfigName = "default"
+
+// Default intervals of the Torrent and QBittorrentServer reconciles, when not set by the
+// QBittorrentOperatorConfig
+const (
+	DefaultRefreshInterval = 30 * time.Second
+	DefaultRetryInterval   = 10 * time.Second
+)
+
+// Condition type of the QBittorrentOperatorConfig status
+const TypeAppliedConfig = "Applied"
+
+// OperatorConfig holds the spec of the QBittorrentOperatorConfig applied by
+// the operator, shared by the reconcilers. A nil OperatorConfig, or one
+// without a QBittorrentOperatorConfig, leaves every setting to its flag.
+type OperatorConfig struct {
+	spec atomic.Pointer[torrentv1beta1.QBittorrentOperatorConfigSpec]
+}
+
+// Spec returns the applied spec, empty without a QBittorrentOperatorConfig.
+// It must not be modified.
+func (c *OperatorConfig) Spec() *torrentv1beta1.QBittorrentOperatorConfigSpec {
+	if c != nil {
+		if spec := c.spec.Load(); spec != nil {
+			return spec
+		}
+	}
+	return &torrentv1beta1.QBittorrentOperatorConfigSpec{}
+}
+
+// set replaces the applied spec, nil when the QBittorrentOperatorConfig is deleted
+func (c *OperatorConfig) set(spec *torrentv1beta1.QBittorrentOperatorConfigSpec) {
+	c.spec.Store(spec)
+}
+
+// durationOr returns the duration, or the fallback if unset
+func durationOr(d *metav1.Duration, fallback time.Duration) time.Duration {
+	if d == nil {
+		return fallback
+	}
+	return d.Duration
+}
+
+// refreshInterval returns the interval between the refreshes of a Torrent
+func (r *TorrentReconciler) refreshInterval() time.Duration {
+	return durationOr(r.Config.Spec().RefreshInterval, DefaultRefreshInterval)
+}
+
+// retryInterval returns the interval before a failed Torrent is reconciled again
+func (r *TorrentReconciler) retryInterval() time.Duration {
+	return durationOr(r.Config.Spec().RetryInterval, DefaultRetryInterval)
+}
+
+// refreshInterval returns the interval between the refreshes of the QBittorrentServer
+func (r *QBittorrentServerReconciler) refreshInterval() time.Duration {
+	return durationOr(r.Config.Spec().RefreshInterval, DefaultRefreshInterval)
+}
+
+// retryInterval returns the interval before a failed QBittorrentServer is reconciled again
+func (r *QBittorrentServerReconciler) retryInterval() time.Duration {
+	return durationOr(r.Config.Spec().RetryInterval, DefaultRetryInterval)
+}
+
+// deletionPolicy returns the deletion policy of the torrent, the default one
+// of the operator config if unset
+func (r *TorrentReconciler) deletionPolicy(torrent *torrentv1beta1.Torrent) torrentv1beta1.DeletionPolicy {
+	if torrent.Spec.DeletionPolicy != "" {
+		return torrent.Spec.DeletionPolicy
+	}
+	if policy := r.Config.Spec().DefaultDeletionPolicy; policy != "" {
+		return policy
+	}
+	return torrentv1beta1.DeletionPolicyDelete
+}
+
+// QBittorrentOperatorConfigReconciler applies the QBittorrentOperatorConfig
+// to the running operator
+type QBittorrentOperatorConfigReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Clients are the qBittorrent clients the settings apply to
+	Clients *clientpool.Pool
+
+	// ConfigName is the name of the QBittorrentOperatorConfig applied
+	ConfigName string
+	// Config receives the applied spec
+	Config *OperatorConfig
+}
+
+// +kubebuilder:rbac:groups=torrent.qbittorrent.io,resources=qbittorrentoperatorconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=torrent.qbittorrent.io,resources=qbittorrentoperatorconfigs/status,verbs=get;update;patch
+
+// Reconcile applies the QBittorrentOperatorConfig, or restores the settings
+// of the flags when it is deleted
+func (r *QBittorrentOperatorConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Step 1: Only the config named by the flags is applied
+	if req.Name != r.ConfigName {
+		logger.V(1).Info("Ignoring QBittorrentOperatorConfig not applied by the operator", "Name", req.Name)
+		return ctrl.Result{}, nil
+	}
+
+	config := &torrentv1beta1.QBittorrentOperatorConfig{}
+	if err := r.Get(ctx, req.NamespacedName, config); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "Failed to get QBittorrentOperatorConfig")
+			return ctrl.Result{}, err
+		}
+		logger.Info("QBittorrentOperatorConfig deleted, using the settings of the flags")
+		r.Config.set(nil)
+		r.setRateLimit(0, 0)
+		return ctrl.Result{}, nil
+	}
+
+	// Step 2: Apply the spec
+	spec := config.Spec.DeepCopy()
+	r.Config.set(spec)
+	rateLimit, burst := 0, 0
+	if spec.RateLimit != nil {
+		rateLimit, burst = int(spec.RateLimit.RequestsPerSecond), int(spec.RateLimit.Burst)
+	}
+	r.setRateLimit(rateLimit, burst)
+	logger.Info("Applied the QBittorrentOperatorConfig",
+		"RefreshInterval", durationOr(spec.RefreshInterval, DefaultRefreshInterval),
+		"RetryInterval", durationOr(spec.RetryInterval, DefaultRetryInterval),
+		"DefaultDeletionPolicy", spec.DefaultDeletionPolicy,
+		"RateLimit", rateLimit,
+	)
+
+	// Step 3: Report the applied generation
+	config.Status.ObservedGeneration = config.Generation
+	setCondition(&config.Status.Conditions, metav1.Condition{
+		Type:               TypeAppliedConfig,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Applied",
+		Message:            "The settings are applied by the operator",
+		ObservedGeneration: config.Generation,
+	})
+	if err := r.Status().Update(ctx, config); err != nil {
+		logger.Error(err, "Failed to update QBittorrentOperatorConfig status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *QBittorrentOperatorConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.ConfigName == "" {
+		r.ConfigName = DefaultConfigName
+	}
+
+	// Status updates are ignored, only spec changes are applied
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&torrentv1beta1.QBittorrentOperatorConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("qbittorrentoperatorconfig").
+		Complete(r)
+}
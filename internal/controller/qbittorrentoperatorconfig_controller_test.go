@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("QBittorrentOperatorConfig Controller", func() {
+	ctx := context.Background()
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: DefaultConfigName}}
+
+	var config *OperatorConfig
+	var torrentReconciler *TorrentReconciler
+	var controllerReconciler *QBittorrentOperatorConfigReconciler
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(torrentv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&torrentv1beta1.QBittorrentOperatorConfig{}).
+			WithObjects(&torrentv1beta1.QBittorrentOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: DefaultConfigName, Generation: 2},
+				Spec: torrentv1beta1.QBittorrentOperatorConfigSpec{
+					RefreshInterval:       &metav1.Duration{Duration: time.Minute},
+					DeletionRetryTimeout:  &metav1.Duration{},
+					DefaultDeletionPolicy: torrentv1beta1.DeletionPolicyOrphan,
+					RateLimit:             &torrentv1beta1.RateLimit{RequestsPerSecond: 20},
+				},
+			}).
+			Build()
+
+		config = &OperatorConfig{}
+		torrentReconciler = &TorrentReconciler{DeletionRetryTimeout: time.Hour, Config: config}
+		controllerReconciler = &QBittorrentOperatorConfigReconciler{
+			Client:     fakeClient,
+			Scheme:     scheme,
+			Clients:    poolOf(qbittorrent.NewClient("http://qbittorrent.invalid")),
+			ConfigName: DefaultConfigName,
+			Config:     config,
+		}
+	})
+
+	It("should keep the settings of the flags without a config", func() {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(torrentReconciler.refreshInterval()).To(Equal(DefaultRefreshInterval))
+		Expect(torrentReconciler.retryInterval()).To(Equal(DefaultRetryInterval))
+		Expect(torrentReconciler.deletionRetryTimeout()).To(Equal(time.Hour))
+		Expect(torrentReconciler.deletionPolicy(torrent)).To(Equal(torrentv1beta1.DeletionPolicyDelete))
+
+		// The reconcilers created without a config, e.g. in tests, use the defaults too
+		Expect((&TorrentReconciler{}).refreshInterval()).To(Equal(DefaultRefreshInterval))
+	})
+
+	It("should apply the config and report it", func() {
+		_, err := controllerReconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("overriding the settings set in the config")
+		Expect(torrentReconciler.refreshInterval()).To(Equal(time.Minute))
+		Expect(torrentReconciler.deletionRetryTimeout()).To(BeZero())
+		Expect(torrentReconciler.deletionPolicy(&torrentv1beta1.Torrent{})).
+			To(Equal(torrentv1beta1.DeletionPolicyOrphan))
+
+		By("keeping the other ones")
+		Expect(torrentReconciler.retryInterval()).To(Equal(DefaultRetryInterval))
+		Expect(torrentReconciler.statusStaleThreshold()).To(Equal(DefaultStatusStaleThreshold))
+		Expect(torrentReconciler.deletionPolicy(&torrentv1beta1.Torrent{
+			Spec: torrentv1beta1.TorrentSpec{DeletionPolicy: torrentv1beta1.DeletionPolicyKeepFiles},
+		})).To(Equal(torrentv1beta1.DeletionPolicyKeepFiles))
+
+		By("reporting the applied generation")
+		applied := &torrentv1beta1.QBittorrentOperatorConfig{}
+		Expect(controllerReconciler.Get(ctx, request.NamespacedName, applied)).To(Succeed())
+		Expect(applied.Status.ObservedGeneration).To(Equal(int64(2)))
+		Expect(meta.IsStatusConditionTrue(applied.Status.Conditions, TypeAppliedConfig)).To(BeTrue())
+	})
+
+	It("should restore the settings of the flags when the config is deleted", func() {
+		_, err := controllerReconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(controllerReconciler.Delete(ctx, &torrentv1beta1.QBittorrentOperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: DefaultConfigName},
+		})).To(Succeed())
+
+		_, err = controllerReconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(torrentReconciler.refreshInterval()).To(Equal(DefaultRefreshInterval))
+		Expect(torrentReconciler.deletionRetryTimeout()).To(Equal(time.Hour))
+	})
+
+	It("should ignore the other configs", func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: "other"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.Spec().RefreshInterval).To(BeNil())
+	})
+})
@@ -0,0 +1,729 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/clientpool"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// Default name of the QBittorrentServer reporting on the configured qBittorrent
+const DefaultServerName = "default"
+
+// QBittorrentServerReconciler keeps a qBittorrent client per
+// QBittorrentServer and refreshes their status. The server created by the
+// operator is reached with the client of the flags, the others at their
+// spec.url.
+type QBittorrentServerReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Clients has the qBittorrent client of each QBittorrentServer
+	Clients *clientpool.Pool
+	// NewClient creates the client of a QBittorrentServer at its spec.url,
+	// with the options of the flags. qbittorrent.NewClient is used if nil.
+	NewClient func(url string) *qbittorrent.Client
+
+	// ServerName is the name of the QBittorrentServer created by the operator
+	ServerName string
+	// Namespace is the namespace of the operator, the only one the Secrets
+	// referenced by the spec are read from
+	Namespace string
+
+	// TLS are the TLS options of the operator flags, completed by the spec of
+	// the QBittorrentServer created by the operator
+	TLS qbittorrent.TLSOptions
+	// ProxyURL is the proxy of the operator flags, replaced by the one of the
+	// spec of the QBittorrentServer created by the operator. The proxy
+	// environment variables are used if empty.
+	ProxyURL string
+	// Headers are the headers of the operator flags added to every request,
+	// completed by the spec of the QBittorrentServer created by the operator
+	Headers http.Header
+	// APIReader reads the Secrets and ConfigMaps of the spec without caching them
+	APIReader client.Reader
+	// Config is the QBittorrentOperatorConfig applied, whose disk reserve is
+	// reported on and whose intervals pace the refreshes. Nil leaves them to
+	// the flags and the defaults.
+	Config *OperatorConfig
+
+	// mu serializes the changes of the clients and guards connections
+	mu sync.Mutex
+	// connections are the clients of the servers, by name
+	connections map[string]*connection
+}
+
+// connection is the state of the client of a QBittorrentServer
+type connection struct {
+	name string
+	// url and credentials are the ones of the spec the client was created
+	// with, empty for the client of the flags
+	url         string
+	credentials *clientpool.Credentials
+
+	// appliedTLS, appliedProxyURL and appliedHeaders are the settings the
+	// client is configured with
+	appliedTLS      qbittorrent.TLSOptions
+	appliedProxyURL string
+	appliedHeaders  http.Header
+
+	// appliedBannedPeers are the peers of spec.bannedPeers banned on qBittorrent
+	appliedBannedPeers []string
+	// ipFilter is the IP filter qBittorrent was configured with
+	ipFilter ipFilterState
+}
+
+// Condition types for QBittorrentServer status
+const (
+	// Status used to indicate if qBittorrent is reachable
+	TypeAvailableServer = "Available"
+	// Status used to indicate if qBittorrent cannot be queried
+	TypeDegradedServer = "Degraded"
+)
+
+// +kubebuilder:rbac:groups=torrent.qbittorrent.io,resources=qbittorrentservers,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=torrent.qbittorrent.io,resources=qbittorrentservers/status,verbs=get;update;patch
+// Allow the controller to read the CA bundles of the qBittorrent WebUI, and
+// the banned peers. The Secrets are only read in the namespace of the
+// operator, with the Role of config/rbac/secret_reader_role.yaml.
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get
+// Allow the controller to store the downloaded IP filter blocklists
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=create;update
+
+// Reconcile refreshes the QBittorrentServer status from qBittorrent
+func (r *QBittorrentServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Step 1: Get the server, removing the client of a deleted one
+	server := &torrentv1beta1.QBittorrentServer{}
+	if err := r.Get(ctx, req.NamespacedName, server); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.disconnect(ctx, req.Name)
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get QBittorrentServer")
+		return ctrl.Result{}, err
+	}
+
+	// Step 2: Create the client and apply the connection settings, the URL,
+	// the credentials or the CA bundle may have been changed
+	conn, err := r.connect(ctx, server.Name, server)
+	if err != nil {
+		logger.Error(err, "Failed to configure the connection")
+		return r.fail(ctx, server, "InvalidConnectionConfig", err)
+	}
+
+	// Step 2.1: Log in to qBittorrent, again if the previous login failed
+	if _, err := r.Clients.Client(ctx, server.Name); err != nil {
+		logger.Error(err, "Failed to log in to qBittorrent")
+		reason := "FailedToLogin"
+		if errors.Is(err, qbittorrent.ErrLoginRejected) {
+			reason = "AuthFailed"
+		}
+		setConnectionConditions(&server.Status.Conditions, err)
+		return r.fail(ctx, server, reason, err)
+	}
+
+	// Step 3: Query qBittorrent
+	status, err := r.serverStatus(ctx, server)
+	if err != nil {
+		logger.Error(err, "Failed to get qBittorrent server state")
+		setConnectionConditions(&server.Status.Conditions, err)
+		return r.fail(ctx, server, "FailedToGetServerState", err)
+	}
+
+	// Step 3.1: Ban the peers listed in the bannedPeers ConfigMap
+	bannedPeers, err := r.banListedPeers(ctx, conn, server)
+	if err != nil {
+		logger.Error(err, "Failed to ban the listed peers")
+		return r.fail(ctx, server, "FailedToBanPeers", err)
+	}
+	status.BannedPeers = bannedPeers
+
+	// Step 3.2: Configure the IP filter, reloading the blocklist on schedule
+	ipFilterReloadTime, err := r.reconcileIPFilter(ctx, conn, server)
+	if err != nil {
+		logger.Error(err, "Failed to configure the IP filter")
+		return r.fail(ctx, server, "FailedToConfigureIPFilter", err)
+	}
+	status.IPFilterReloadTime = ipFilterReloadTime
+
+	// Step 3.3: Get the preferences managed by the following steps
+	preferences, err := r.qbt(server).GetPreferences(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get the qBittorrent preferences")
+		return r.fail(ctx, server, "FailedToGetPreferences", err)
+	}
+
+	// Step 3.4: Report the listen port, pinning or rotating it
+	if err := r.reconcileListenPort(ctx, server, preferences, status); err != nil {
+		logger.Error(err, "Failed to configure the listen port")
+		return r.fail(ctx, server, "FailedToConfigureListenPort", err)
+	}
+
+	// Step 3.5: Apply the queueing preferences, reporting the queueing
+	queueingCondition, err := r.reconcileQueueing(ctx, server, preferences)
+	if err != nil {
+		logger.Error(err, "Failed to configure the queueing")
+		return r.fail(ctx, server, "FailedToConfigureQueueing", err)
+	}
+
+	// Step 3.6: Pin the privacy preferences, reverting or accepting their drift
+	if err := r.reconcilePrivacy(ctx, server, preferences, status); err != nil {
+		logger.Error(err, "Failed to configure the privacy preferences")
+		return r.fail(ctx, server, "FailedToConfigurePrivacy", err)
+	}
+
+	// Step 3.7: Pause the managed torrents during maintenance, or resume them
+	maintenanceCondition, err := r.reconcileMaintenance(ctx, server)
+	if err != nil {
+		logger.Error(err, "Failed to reconcile the maintenance")
+		return r.fail(ctx, server, "FailedToReconcileMaintenance", err)
+	}
+
+	// Step 3.8: Remove the categories and tags of the managed torrents left unused
+	janitor, err := r.reconcileJanitor(ctx, server)
+	if err != nil {
+		logger.Error(err, "Failed to remove the unused categories and tags")
+		return r.fail(ctx, server, "FailedToRemoveUnusedCategoriesAndTags", err)
+	}
+
+	// Step 3.9: Summarize the managed Torrents
+	inventory, err := r.inventory(ctx, server)
+	if err != nil {
+		logger.Error(err, "Failed to summarize the Torrents")
+		return r.fail(ctx, server, "FailedToSummarizeTorrents", err)
+	}
+
+	// Step 4: Update the status, keeping the conditions and the maintenance
+	status.Conditions = server.Status.Conditions
+	status.Maintenance = server.Status.Maintenance
+	status.Janitor = janitor
+	status.Inventory = inventory
+	setCondition(&status.Conditions, queueingCondition)
+	setCondition(&status.Conditions, maintenanceCondition)
+	setConnectionConditions(&status.Conditions, nil)
+	setVersionCondition(&status.Conditions, status.WebAPIVersion)
+	setDiskPressureCondition(&status.Conditions, status, r.Config.Spec())
+	setHealthConditions(&status.Conditions, TypeAvailableServer, TypeDegradedServer,
+		true, "ServerReachable", "qBittorrent is reachable")
+	server.Status = *status
+	if err := r.Status().Update(ctx, server); err != nil {
+		logger.Error(err, "Failed to update QBittorrentServer status")
+		return ctrl.Result{}, err
+	}
+
+	// Step 5: Refresh after the refresh interval
+	return ctrl.Result{RequeueAfter: r.refreshInterval()}, nil
+}
+
+// fail reports the error on the health conditions of the server, and retries
+// after the retry interval
+func (r *QBittorrentServerReconciler) fail(ctx context.Context, server *torrentv1beta1.QBittorrentServer,
+	reason string, err error) (ctrl.Result, error) {
+	setHealthConditions(&server.Status.Conditions, TypeAvailableServer, TypeDegradedServer,
+		false, reason, err.Error())
+	if err := r.Status().Update(ctx, server); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update QBittorrentServer status")
+	}
+
+	return ctrl.Result{RequeueAfter: r.retryInterval()}, nil
+}
+
+// serverStatus builds the QBittorrentServer status from the qBittorrent API
+func (r *QBittorrentServerReconciler) serverStatus(ctx context.Context,
+	server *torrentv1beta1.QBittorrentServer) (*torrentv1beta1.QBittorrentServerStatus, error) {
+	version, err := r.qbt(server).GetVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	webAPIVersion, err := r.qbt(server).GetWebAPIVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	state, err := r.qbt(server).GetServerState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := metav1.Now()
+	return &torrentv1beta1.QBittorrentServerStatus{
+		URL:               r.qbt(server).BaseURL(),
+		Version:           version,
+		WebAPIVersion:     webAPIVersion,
+		ConnectionStatus:  state.ConnectionStatus,
+		DHTNodes:          state.DHTNodes,
+		DownloadSpeed:     state.DownloadSpeed,
+		UploadSpeed:       state.UploadSpeed,
+		SessionDownloaded: state.SessionDownload,
+		SessionUploaded:   state.SessionUpload,
+		FreeSpaceOnDisk:   resource.NewQuantity(state.FreeSpaceOnDisk, resource.BinarySI),
+		LastUpdated:       &now,
+	}, nil
+}
+
+// ConfigureConnection configures the qBittorrent client of the flags with the
+// connection settings of the QBittorrentServer created by the operator, if it
+// exists and can be read, and adds the clients of the other servers. It is
+// called before the manager starts, so that the operator can log in to
+// qBittorrent.
+func (r *QBittorrentServerReconciler) ConfigureConnection(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	servers := &torrentv1beta1.QBittorrentServerList{}
+	if err := r.reader().List(ctx, servers); err != nil {
+		if !apierrors.IsForbidden(err) {
+			return err
+		}
+		// The operator restricted to namespaces cannot read cluster-scoped objects
+		logger.Info("Not allowed to read the QBittorrentServers, using the connection settings of the flags",
+			"Name", r.ServerName)
+		_, err = r.connect(ctx, r.ServerName, nil)
+		return err
+	}
+
+	var flagServer *torrentv1beta1.QBittorrentServer
+	for i := range servers.Items {
+		server := &servers.Items[i]
+		if server.Name == r.ServerName {
+			flagServer = server
+			continue
+		}
+		if server.Spec.URL == "" {
+			continue
+		}
+		// The failure is reported on the server once it is reconciled
+		if _, err := r.connect(ctx, server.Name, server); err != nil {
+			logger.Error(err, "Failed to configure the connection", "Name", server.Name)
+		}
+	}
+	_, err := r.connect(ctx, r.ServerName, flagServer)
+	return err
+}
+
+// Connect adds the client of the QBittorrentServer to the pool when it is
+// missing, e.g. when the server was created after the start of a replica not
+// reconciling the QBittorrentServers
+func (r *QBittorrentServerReconciler) Connect(ctx context.Context, name string) error {
+	if r.Clients.Get(name) != nil {
+		return nil
+	}
+	server := &torrentv1beta1.QBittorrentServer{}
+	if err := r.reader().Get(ctx, types.NamespacedName{Name: name}, server); err != nil {
+		return fmt.Errorf("failed to get the QBittorrentServer %s: %w", name, err)
+	}
+	_, err := r.connect(ctx, name, server)
+	return err
+}
+
+// connect creates the client of the server, or replaces it when its URL or
+// credentials changed, and configures it with the connection settings of
+// the server. The server is nil when it cannot be read.
+func (r *QBittorrentServerReconciler) connect(ctx context.Context, name string,
+	server *torrentv1beta1.QBittorrentServer) (*connection, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conn, err := r.ensureClient(ctx, name, server)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.configureTLS(ctx, conn, server); err != nil {
+		return nil, err
+	}
+	if err := r.configureProxy(ctx, conn, server); err != nil {
+		return nil, err
+	}
+	if err := r.configureHeaders(ctx, conn, server); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// ensureClient returns the connection of the server, adding its client to
+// the pool. The server created by the operator keeps the client of the flags,
+// the others get one at their spec.url.
+func (r *QBittorrentServerReconciler) ensureClient(ctx context.Context, name string,
+	server *torrentv1beta1.QBittorrentServer) (*connection, error) {
+	if r.connections == nil {
+		r.connections = map[string]*connection{}
+	}
+	conn := r.connections[name]
+
+	if name == r.ServerName {
+		if r.Clients.Get(name) == nil {
+			return nil, fmt.Errorf("no qBittorrent client for the QBittorrentServer %s", name)
+		}
+		if conn == nil {
+			conn = &connection{name: name}
+			r.connections[name] = conn
+		}
+		return conn, nil
+	}
+
+	// The client of a server whose url was removed is not used anymore
+	if server == nil || server.Spec.URL == "" {
+		delete(r.connections, name)
+		r.Clients.Remove(name)
+		return nil, fmt.Errorf("the QBittorrentServer %s has no url", name)
+	}
+	var credentials *clientpool.Credentials
+	if server.Spec.CredentialsSecret != nil {
+		username, password, err := r.readBasicAuth(ctx, server.Spec.CredentialsSecret, "credentials")
+		if err != nil {
+			return nil, err
+		}
+		credentials = &clientpool.Credentials{Username: username, Password: password}
+	}
+	if conn != nil && conn.url == server.Spec.URL && equalCredentials(conn.credentials, credentials) &&
+		r.Clients.Get(name) != nil {
+		return conn, nil
+	}
+
+	log.FromContext(ctx).Info("Creating the qBittorrent client", "Name", name, "URL", server.Spec.URL)
+	newClient := r.NewClient
+	if newClient == nil {
+		newClient = qbittorrent.NewClient
+	}
+	qbt := newClient(server.Spec.URL)
+	if rateLimit := r.Config.Spec().RateLimit; rateLimit != nil {
+		qbt.SetRateLimit(int(rateLimit.RequestsPerSecond), int(rateLimit.Burst))
+	}
+	r.Clients.Add(name, qbt, credentials)
+	conn = &connection{name: name, url: server.Spec.URL, credentials: credentials}
+	r.connections[name] = conn
+	return conn, nil
+}
+
+// equalCredentials reports whether the credentials are the same, or both unset
+func equalCredentials(a, b *clientpool.Credentials) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// disconnect removes the client of a deleted server. The client of the flags
+// is kept for the server created by the operator.
+func (r *QBittorrentServerReconciler) disconnect(ctx context.Context, name string) {
+	if name == r.ServerName {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.connections[name]; !ok && r.Clients.Get(name) == nil {
+		return
+	}
+	log.FromContext(ctx).Info("QBittorrentServer deleted, removing its qBittorrent client", "Name", name)
+	delete(r.connections, name)
+	r.Clients.Remove(name)
+}
+
+// configureTLS configures the qBittorrent client with the TLS options of the
+// flags, for the server created by the operator, and of the server, when
+// they changed
+func (r *QBittorrentServerReconciler) configureTLS(ctx context.Context, conn *connection,
+	server *torrentv1beta1.QBittorrentServer) error {
+	var opts qbittorrent.TLSOptions
+	if conn.name == r.ServerName {
+		opts = r.TLS
+		opts.CABundles = slices.Clone(r.TLS.CABundles)
+	}
+	if server != nil && server.Spec.TLS != nil {
+		if server.Spec.TLS.CABundle != nil {
+			caBundle, err := r.readCABundle(ctx, server.Spec.TLS.CABundle)
+			if err != nil {
+				return err
+			}
+			opts.CABundles = append(opts.CABundles, caBundle)
+		}
+		opts.InsecureSkipVerify = opts.InsecureSkipVerify || server.Spec.TLS.InsecureSkipTLSVerify
+		if server.Spec.TLS.ClientCertificate != nil {
+			certificate, key, err := r.readClientCertificate(ctx, server.Spec.TLS.ClientCertificate)
+			if err != nil {
+				return err
+			}
+			opts.ClientCertificate, opts.ClientKey = certificate, key
+		}
+	}
+
+	if opts.Equal(conn.appliedTLS) {
+		return nil
+	}
+
+	config, err := opts.Config()
+	if err != nil {
+		return err
+	}
+	logger := log.FromContext(ctx)
+	if opts.InsecureSkipVerify {
+		logger.Info("WARNING: the qBittorrent certificate is not verified, the connection is open to " +
+			"man-in-the-middle attacks. Trust its CA instead of setting insecureSkipTLSVerify.")
+	}
+	logger.Info("Configuring the qBittorrent client TLS",
+		"Name", conn.name,
+		"CABundles", len(opts.CABundles),
+		"InsecureSkipVerify", opts.InsecureSkipVerify,
+		"ClientCertificate", len(opts.ClientCertificate) > 0,
+	)
+	r.Clients.Get(conn.name).SetTLSConfig(config)
+	conn.appliedTLS = opts
+	return nil
+}
+
+// configureProxy configures the qBittorrent client with the proxy of the
+// flags, for the server created by the operator, or of the server, when it
+// changed
+func (r *QBittorrentServerReconciler) configureProxy(ctx context.Context, conn *connection,
+	server *torrentv1beta1.QBittorrentServer) error {
+	var proxy string
+	if conn.name == r.ServerName {
+		proxy = r.ProxyURL
+	}
+	if server != nil && server.Spec.ProxyURL != "" {
+		proxy = server.Spec.ProxyURL
+	}
+
+	var proxyURL *url.URL
+	if proxy != "" {
+		var err error
+		if proxyURL, err = url.Parse(proxy); err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if server != nil && server.Spec.ProxyCredentialsSecret != nil {
+			username, password, err := r.readBasicAuth(ctx, server.Spec.ProxyCredentialsSecret, "proxy credentials")
+			if err != nil {
+				return err
+			}
+			proxyURL.User = url.UserPassword(username, password)
+		}
+		proxy = proxyURL.String()
+	}
+	if proxy == conn.appliedProxyURL {
+		return nil
+	}
+
+	// The proxy URL may hold credentials
+	log.FromContext(ctx).Info("Configuring the qBittorrent client proxy",
+		"Name", conn.name, "ProxyURL", proxyURL.Redacted())
+	r.Clients.Get(conn.name).SetProxy(proxyURL)
+	conn.appliedProxyURL = proxy
+	return nil
+}
+
+// readBasicAuth reads a username and password from their
+// kubernetes.io/basic-auth Secret, the credentials of what
+func (r *QBittorrentServerReconciler) readBasicAuth(ctx context.Context,
+	ref *torrentv1beta1.SecretReference, what string) (string, string, error) {
+	name, err := r.secretName(ref.Namespace, ref.Name)
+	if err != nil {
+		return "", "", err
+	}
+	secret := &corev1.Secret{}
+	if err := r.reader().Get(ctx, name, secret); err != nil {
+		return "", "", fmt.Errorf("failed to get %s Secret: %w", what, err)
+	}
+	username, password := secret.Data[corev1.BasicAuthUsernameKey], secret.Data[corev1.BasicAuthPasswordKey]
+	if len(username) == 0 {
+		return "", "", fmt.Errorf("%s Secret %s must hold %s", what, name, corev1.BasicAuthUsernameKey)
+	}
+	return string(username), string(password), nil
+}
+
+// configureHeaders configures the qBittorrent client with the headers of the
+// flags, for the server created by the operator, and of the server, when
+// they changed
+func (r *QBittorrentServerReconciler) configureHeaders(ctx context.Context, conn *connection,
+	server *torrentv1beta1.QBittorrentServer) error {
+	var headers http.Header
+	if conn.name == r.ServerName {
+		headers = r.Headers.Clone()
+	}
+	if server != nil && server.Spec.HeadersSecret != nil {
+		name, err := r.secretName(server.Spec.HeadersSecret.Namespace, server.Spec.HeadersSecret.Name)
+		if err != nil {
+			return err
+		}
+		secret := &corev1.Secret{}
+		if err := r.reader().Get(ctx, name, secret); err != nil {
+			return fmt.Errorf("failed to get headers Secret: %w", err)
+		}
+		if headers == nil {
+			headers = http.Header{}
+		}
+		for key, value := range secret.Data {
+			headers.Set(key, string(value))
+		}
+	}
+
+	if maps.EqualFunc(headers, conn.appliedHeaders, slices.Equal) {
+		return nil
+	}
+
+	// The header values are credentials, only their names are logged
+	log.FromContext(ctx).Info("Configuring the qBittorrent client headers",
+		"Name", conn.name, "Headers", slices.Sorted(maps.Keys(headers)))
+	r.Clients.Get(conn.name).SetHeaders(headers)
+	conn.appliedHeaders = headers
+	return nil
+}
+
+// readCABundle reads the CA bundle from its Secret or ConfigMap
+func (r *QBittorrentServerReconciler) readCABundle(ctx context.Context,
+	source *torrentv1beta1.CABundleSource) ([]byte, error) {
+	if source.Secret != nil {
+		key, err := r.secretName(source.Namespace, source.Secret.Name)
+		if err != nil {
+			return nil, err
+		}
+		secret := &corev1.Secret{}
+		if err := r.reader().Get(ctx, key, secret); err != nil {
+			return nil, fmt.Errorf("failed to get CA bundle Secret: %w", err)
+		}
+		data, ok := secret.Data[source.Secret.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in CA bundle Secret %s", source.Secret.Key, key)
+		}
+		return data, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: source.Namespace, Name: source.ConfigMap.Name}
+	if err := r.reader().Get(ctx, key, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get CA bundle ConfigMap: %w", err)
+	}
+	data, ok := configMap.Data[source.ConfigMap.Key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in CA bundle ConfigMap %s", source.ConfigMap.Key, key)
+	}
+	return []byte(data), nil
+}
+
+// readClientCertificate reads the client certificate and key from their Secret
+func (r *QBittorrentServerReconciler) readClientCertificate(ctx context.Context,
+	ref *torrentv1beta1.SecretReference) ([]byte, []byte, error) {
+	name, err := r.secretName(ref.Namespace, ref.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	secret := &corev1.Secret{}
+	if err := r.reader().Get(ctx, name, secret); err != nil {
+		return nil, nil, fmt.Errorf("failed to get client certificate Secret: %w", err)
+	}
+	certificate, privateKey := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	if len(certificate) == 0 || len(privateKey) == 0 {
+		return nil, nil, fmt.Errorf("client certificate Secret %s must hold %s and %s",
+			name, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+	return certificate, privateKey, nil
+}
+
+// secretName returns the name of a Secret referenced by the spec. Only the
+// Secrets of the namespace of the operator can be read, so that the ones of
+// other namespaces cannot be exfiltrated through the QBittorrentServer.
+func (r *QBittorrentServerReconciler) secretName(namespace, name string) (types.NamespacedName, error) {
+	if r.Namespace == "" {
+		return types.NamespacedName{}, fmt.Errorf(
+			"secret %s/%s cannot be read without the namespace of the operator, set --operator-namespace", namespace, name)
+	}
+	if namespace != r.Namespace {
+		return types.NamespacedName{}, fmt.Errorf("secret %s/%s must be in the namespace of the operator %s",
+			namespace, name, r.Namespace)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
+
+// reader returns the reader of the objects not watched by the controller
+func (r *QBittorrentServerReconciler) reader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// ensureServer creates the QBittorrentServer reported on, if missing
+func (r *QBittorrentServerReconciler) ensureServer(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	server := &torrentv1beta1.QBittorrentServer{}
+	err := r.Get(ctx, types.NamespacedName{Name: r.ServerName}, server)
+	if err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	logger.Info("Creating QBittorrentServer", "Name", r.ServerName)
+	server.Name = r.ServerName
+	if err := r.Create(ctx, server); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *QBittorrentServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.ServerName == "" {
+		r.ServerName = DefaultServerName
+	}
+
+	// The server is created once the manager is elected leader and the cache started
+	if err := mgr.Add(manager.RunnableFunc(r.ensureServer)); err != nil {
+		return err
+	}
+
+	// Status updates are ignored, the status is refreshed periodically
+	// instead. The maintenance annotation takes effect at once.
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&torrentv1beta1.QBittorrentServer{}, builder.WithPredicates(
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		Named("qbittorrentserver").
+		Complete(r)
+}
+
+// managedTorrents lists the Torrents downloaded on the server
+func (r *QBittorrentServerReconciler) managedTorrents(ctx context.Context,
+	server *torrentv1beta1.QBittorrentServer) ([]torrentv1beta1.Torrent, error) {
+	torrents := &torrentv1beta1.TorrentList{}
+	if err := r.List(ctx, torrents); err != nil {
+		return nil, fmt.Errorf("failed to list Torrents: %w", err)
+	}
+	return slices.DeleteFunc(torrents.Items, func(torrent torrentv1beta1.Torrent) bool {
+		return cmp.Or(torrent.Spec.ServerName, r.ServerName) != server.Name
+	}), nil
+}
@@ -0,0 +1,336 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("QBittorrentServer Controller", func() {
+	Context("When reconciling the server created by the operator", func() {
+		ctx := context.Background()
+		typeNamespacedName := types.NamespacedName{Name: DefaultServerName}
+
+		var qbServer *httptest.Server
+		var controllerReconciler *QBittorrentServerReconciler
+
+		BeforeEach(func() {
+			By("starting a fake qBittorrent WebUI")
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v2/app/version", func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = fmt.Fprint(w, "v5.0.4")
+			})
+			mux.HandleFunc("/api/v2/app/webapiVersion", func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = fmt.Fprint(w, "2.11.2")
+			})
+			mux.HandleFunc("/api/v2/sync/maindata", func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = fmt.Fprint(w, `{"rid":1,"server_state":{"connection_status":"connected","dht_nodes":312,`+
+					`"dl_info_speed":1024,"up_info_speed":2048,"dl_info_data":1073741824,"up_info_data":536870912,`+
+					`"free_space_on_disk":10737418240}}`)
+			})
+			mux.HandleFunc("/api/v2/app/preferences", func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = fmt.Fprint(w, `{"listen_port":51413,"random_port":false,"queueing_enabled":true,`+
+					`"max_active_downloads":3,"max_active_uploads":5,"max_active_torrents":5}`)
+			})
+			qbServer = httptest.NewServer(mux)
+
+			controllerReconciler = &QBittorrentServerReconciler{
+				Client:     k8sClient,
+				Scheme:     k8sClient.Scheme(),
+				Clients:    poolOf(qbittorrent.NewClient(qbServer.URL)),
+				ServerName: DefaultServerName,
+				Namespace:  "default",
+			}
+
+			By("creating the QBittorrentServer")
+			Expect(controllerReconciler.ensureServer(ctx)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			qbServer.Close()
+
+			resource := &torrentv1beta1.QBittorrentServer{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+
+			By("Cleanup the specific resource instance QBittorrentServer")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should publish the qBittorrent global state", func() {
+			By("Reconciling the created resource")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			server := &torrentv1beta1.QBittorrentServer{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, server)).To(Succeed())
+			Expect(server.Status.Version).To(Equal("v5.0.4"))
+			Expect(server.Status.WebAPIVersion).To(Equal("2.11.2"))
+			Expect(server.Status.ConnectionStatus).To(Equal("connected"))
+			Expect(server.Status.DHTNodes).To(Equal(int64(312)))
+			Expect(server.Status.SessionDownloaded).To(Equal(int64(1073741824)))
+			Expect(server.Status.FreeSpaceOnDisk.String()).To(Equal("10Gi"))
+			Expect(server.Status.ListenPort).To(Equal(int32(51413)))
+			Expect(meta.IsStatusConditionTrue(server.Status.Conditions, TypeQueueingServer)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(server.Status.Conditions, TypeAvailableServer)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(server.Status.Conditions, TypeConnectedServer)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(server.Status.Conditions, TypeAuthFailedServer)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(server.Status.Conditions, TypeVersionUnsupportedServer)).To(BeTrue())
+			Expect(meta.FindStatusCondition(server.Status.Conditions, TypeDiskPressureServer)).To(BeNil())
+		})
+
+		It("should trust the CA bundle of the spec", func() {
+			By("serving the fake qBittorrent WebUI over HTTPS")
+			tlsServer := httptest.NewTLSServer(qbServer.Config.Handler)
+			defer tlsServer.Close()
+			controllerReconciler.Clients = poolOf(qbittorrent.NewClient(tlsServer.URL))
+
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "qbittorrent-ca", Namespace: "default"},
+				Data: map[string]string{
+					"ca.crt": string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsServer.Certificate().Raw})),
+				},
+			}
+			Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, configMap)).To(Succeed())
+			})
+
+			server := &torrentv1beta1.QBittorrentServer{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, server)).To(Succeed())
+			server.Spec.TLS = &torrentv1beta1.ServerTLS{
+				CABundle: &torrentv1beta1.CABundleSource{
+					Namespace: "default",
+					ConfigMap: &torrentv1beta1.KeySelector{Name: "qbittorrent-ca", Key: "ca.crt"},
+				},
+			}
+			Expect(k8sClient.Update(ctx, server)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, server)).To(Succeed())
+			Expect(server.Status.Version).To(Equal("v5.0.4"))
+			Expect(meta.IsStatusConditionTrue(server.Status.Conditions, TypeAvailableServer)).To(BeTrue())
+		})
+
+		It("should reach qBittorrent through the proxy with the credentials of the Secret", func() {
+			By("starting a proxy forwarding to the fake qBittorrent WebUI")
+			var proxyAuthorization string
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				proxyAuthorization = r.Header.Get("Proxy-Authorization")
+				qbServer.Config.Handler.ServeHTTP(w, r)
+			}))
+			defer proxy.Close()
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "qbittorrent-proxy", Namespace: "default"},
+				Type:       corev1.SecretTypeBasicAuth,
+				Data: map[string][]byte{
+					corev1.BasicAuthUsernameKey: []byte("operator"),
+					corev1.BasicAuthPasswordKey: []byte("secret"),
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+			})
+
+			server := &torrentv1beta1.QBittorrentServer{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, server)).To(Succeed())
+			server.Spec.ProxyURL = proxy.URL
+			server.Spec.ProxyCredentialsSecret = &torrentv1beta1.SecretReference{Namespace: "default", Name: "qbittorrent-proxy"}
+			Expect(k8sClient.Update(ctx, server)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, server)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(server.Status.Conditions, TypeAvailableServer)).To(BeTrue())
+			Expect(proxyAuthorization).To(Equal("Basic b3BlcmF0b3I6c2VjcmV0"))
+		})
+
+		It("should not read the Secrets outside of the namespace of the operator", func() {
+			server := &torrentv1beta1.QBittorrentServer{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, server)).To(Succeed())
+			server.Spec.HeadersSecret = &torrentv1beta1.SecretReference{Namespace: "kube-system", Name: "bootstrap-token"}
+			Expect(k8sClient.Update(ctx, server)).To(Succeed())
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, server)).To(Succeed())
+			degraded := meta.FindStatusCondition(server.Status.Conditions, TypeDegradedServer)
+			Expect(degraded).NotTo(BeNil())
+			Expect(degraded.Reason).To(Equal("InvalidConnectionConfig"))
+			Expect(degraded.Message).To(ContainSubstring("must be in the namespace of the operator default"))
+		})
+
+		It("should report a degraded server when qBittorrent is unreachable", func() {
+			qbServer.Close()
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+
+			server := &torrentv1beta1.QBittorrentServer{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, server)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(server.Status.Conditions, TypeDegradedServer)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(server.Status.Conditions, TypeConnectedServer)).To(BeTrue())
+		})
+
+		It("should refresh and retry after the intervals of the operator config", func() {
+			request := reconcile.Request{NamespacedName: typeNamespacedName}
+			result, err := controllerReconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(DefaultRefreshInterval))
+
+			controllerReconciler.Config = &OperatorConfig{}
+			controllerReconciler.Config.set(&torrentv1beta1.QBittorrentOperatorConfigSpec{
+				RefreshInterval: &metav1.Duration{Duration: time.Minute},
+				RetryInterval:   &metav1.Duration{Duration: 15 * time.Second},
+			})
+			result, err = controllerReconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+			qbServer.Close()
+			result, err = controllerReconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(15 * time.Second))
+		})
+
+		It("should keep a client per QBittorrentServer with a url", func() {
+			seedboxName := types.NamespacedName{Name: "seedbox"}
+			seedbox := &torrentv1beta1.QBittorrentServer{
+				ObjectMeta: metav1.ObjectMeta{Name: seedboxName.Name},
+				Spec:       torrentv1beta1.QBittorrentServerSpec{URL: qbServer.URL},
+			}
+			Expect(k8sClient.Create(ctx, seedbox)).To(Succeed())
+
+			By("adding the client of the server to the pool")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: seedboxName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(controllerReconciler.Clients.Get("seedbox")).NotTo(BeNil())
+			Expect(controllerReconciler.Clients.Get(DefaultServerName)).NotTo(BeIdenticalTo(
+				controllerReconciler.Clients.Get("seedbox")))
+
+			Expect(k8sClient.Get(ctx, seedboxName, seedbox)).To(Succeed())
+			Expect(seedbox.Status.Version).To(Equal("v5.0.4"))
+			Expect(meta.IsStatusConditionTrue(seedbox.Status.Conditions, TypeAvailableServer)).To(BeTrue())
+
+			By("removing the client of the deleted server")
+			Expect(k8sClient.Delete(ctx, seedbox)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: seedboxName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(controllerReconciler.Clients.Get("seedbox")).To(BeNil())
+			Expect(controllerReconciler.Clients.Get(DefaultServerName)).NotTo(BeNil())
+		})
+
+		It("should not connect a QBittorrentServer without a url", func() {
+			seedboxName := types.NamespacedName{Name: "seedbox"}
+			seedbox := &torrentv1beta1.QBittorrentServer{ObjectMeta: metav1.ObjectMeta{Name: seedboxName.Name}}
+			Expect(k8sClient.Create(ctx, seedbox)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, seedbox)).To(Succeed())
+			})
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: seedboxName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(controllerReconciler.Clients.Get("seedbox")).To(BeNil())
+
+			Expect(k8sClient.Get(ctx, seedboxName, seedbox)).To(Succeed())
+			degraded := meta.FindStatusCondition(seedbox.Status.Conditions, TypeDegradedServer)
+			Expect(degraded).NotTo(BeNil())
+			Expect(degraded.Reason).To(Equal("InvalidConnectionConfig"))
+		})
+	})
+
+	Context("When diagnosing the connection", func() {
+		var conditions []metav1.Condition
+
+		BeforeEach(func() {
+			conditions = nil
+		})
+
+		It("should tell rejected credentials from an unreachable qBittorrent", func() {
+			setConnectionConditions(&conditions, fmt.Errorf("failed to login: %w", qbittorrent.ErrLoginRejected))
+			Expect(meta.IsStatusConditionTrue(conditions, TypeAuthFailedServer)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(conditions, TypeConnectedServer)).To(BeTrue())
+
+			setConnectionConditions(&conditions, fmt.Errorf("connection refused"))
+			Expect(meta.FindStatusCondition(conditions, TypeConnectedServer).Reason).To(Equal("ServerUnreachable"))
+			// The credentials are not known to be fixed until qBittorrent answers
+			Expect(meta.IsStatusConditionTrue(conditions, TypeAuthFailedServer)).To(BeTrue())
+
+			setConnectionConditions(&conditions, nil)
+			Expect(meta.IsStatusConditionTrue(conditions, TypeConnectedServer)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(conditions, TypeAuthFailedServer)).To(BeTrue())
+		})
+
+		It("should report the unsupported WebUI API versions", func() {
+			setVersionCondition(&conditions, "2.2.1")
+			Expect(meta.IsStatusConditionTrue(conditions, TypeVersionUnsupportedServer)).To(BeTrue())
+
+			setVersionCondition(&conditions, "2.11.2")
+			Expect(meta.IsStatusConditionFalse(conditions, TypeVersionUnsupportedServer)).To(BeTrue())
+		})
+
+		It("should report the disk pressure below the disk reserve", func() {
+			status := &torrentv1beta1.QBittorrentServerStatus{FreeSpaceOnDisk: resource.NewQuantity(1<<30, resource.BinarySI)}
+			config := &torrentv1beta1.QBittorrentOperatorConfigSpec{}
+			setDiskPressureCondition(&conditions, status, config)
+			Expect(conditions).To(BeEmpty())
+
+			config.DiskReserve = ptr.To(resource.MustParse("10Gi"))
+			setDiskPressureCondition(&conditions, status, config)
+			Expect(meta.IsStatusConditionTrue(conditions, TypeDiskPressureServer)).To(BeTrue())
+
+			config.DiskReserve = ptr.To(resource.MustParse("512Mi"))
+			setDiskPressureCondition(&conditions, status, config)
+			Expect(meta.IsStatusConditionFalse(conditions, TypeDiskPressureServer)).To(BeTrue())
+		})
+	})
+})
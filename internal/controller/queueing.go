@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// TypeQueueingServer reports whether qBittorrent queues the torrents, and so
+// whether the queue positions of the Torrent priorities take effect
+const TypeQueueingServer = "Queueing"
+
+// queueingChanges returns the queueing preferences of the spec that differ
+// from the ones of qBittorrent, by their name in /api/v2/app/preferences
+func queueingChanges(queueing *torrentv1beta1.Queueing, preferences *qbittorrent.Preferences) map[string]any {
+	changes := map[string]any{}
+	if queueing.Enabled != nil && *queueing.Enabled != preferences.QueueingEnabled {
+		changes["queueing_enabled"] = *queueing.Enabled
+	}
+	if queueing.MaxActiveDownloads != nil && *queueing.MaxActiveDownloads != preferences.MaxActiveDownloads {
+		changes["max_active_downloads"] = *queueing.MaxActiveDownloads
+	}
+	if queueing.MaxActiveUploads != nil && *queueing.MaxActiveUploads != preferences.MaxActiveUploads {
+		changes["max_active_uploads"] = *queueing.MaxActiveUploads
+	}
+	if queueing.MaxActiveTorrents != nil && *queueing.MaxActiveTorrents != preferences.MaxActiveTorrents {
+		changes["max_active_torrents"] = *queueing.MaxActiveTorrents
+	}
+	if queueing.IgnoreSlowTorrents != nil && *queueing.IgnoreSlowTorrents != preferences.DontCountSlowTorrents {
+		changes["dont_count_slow_torrents"] = *queueing.IgnoreSlowTorrents
+	}
+	return changes
+}
+
+// applyQueueingChanges updates the preferences with the changes set on qBittorrent
+func applyQueueingChanges(queueing *torrentv1beta1.Queueing, preferences *qbittorrent.Preferences) {
+	if queueing.Enabled != nil {
+		preferences.QueueingEnabled = *queueing.Enabled
+	}
+	if queueing.MaxActiveDownloads != nil {
+		preferences.MaxActiveDownloads = *queueing.MaxActiveDownloads
+	}
+	if queueing.MaxActiveUploads != nil {
+		preferences.MaxActiveUploads = *queueing.MaxActiveUploads
+	}
+	if queueing.MaxActiveTorrents != nil {
+		preferences.MaxActiveTorrents = *queueing.MaxActiveTorrents
+	}
+	if queueing.IgnoreSlowTorrents != nil {
+		preferences.DontCountSlowTorrents = *queueing.IgnoreSlowTorrents
+	}
+}
+
+// activeLimit formats a limit of active torrents, -1 meaning no limit
+func activeLimit(limit int32) string {
+	if limit < 0 {
+		return "unlimited"
+	}
+	return strconv.Itoa(int(limit))
+}
+
+// queueingCondition documents how the queueing of qBittorrent and the Torrent
+// priorities combine: qBittorrent decides which torrents run, the priorities
+// only order its queue and throttle the Low priority downloads
+func queueingCondition(preferences *qbittorrent.Preferences) metav1.Condition {
+	if !preferences.QueueingEnabled {
+		return metav1.Condition{
+			Type:   TypeQueueingServer,
+			Status: metav1.ConditionFalse,
+			Reason: "QueueingDisabled",
+			Message: "qBittorrent runs every torrent at once, the Torrent priorities do not move them in a queue " +
+				"and only --low-priority-download-limit ranks them",
+		}
+	}
+	return metav1.Condition{
+		Type:   TypeQueueingServer,
+		Status: metav1.ConditionTrue,
+		Reason: "QueueingEnabled",
+		Message: fmt.Sprintf("qBittorrent runs at most %s downloads, %s uploads and %s torrents at once and "+
+			"queues the others, High priority Torrents are moved to the top of the queue and Low priority ones "+
+			"to the bottom", activeLimit(preferences.MaxActiveDownloads), activeLimit(preferences.MaxActiveUploads),
+			activeLimit(preferences.MaxActiveTorrents)),
+	}
+}
+
+// reconcileQueueing sets the queueing preferences of the spec that drifted,
+// and returns the condition reporting the queueing of qBittorrent
+func (r *QBittorrentServerReconciler) reconcileQueueing(ctx context.Context,
+	server *torrentv1beta1.QBittorrentServer, preferences *qbittorrent.Preferences) (metav1.Condition, error) {
+	if server.Spec.Preferences != nil && server.Spec.Preferences.Queueing != nil {
+		queueing := server.Spec.Preferences.Queueing
+		if changes := queueingChanges(queueing, preferences); len(changes) > 0 {
+			log.FromContext(ctx).Info("Setting the qBittorrent queueing preferences", "Preferences", changes)
+			if err := r.qbt(server).SetPreferences(ctx, changes); err != nil {
+				return metav1.Condition{}, fmt.Errorf("failed to set the queueing preferences: %w", err)
+			}
+			applyQueueingChanges(queueing, preferences)
+		}
+	}
+	return queueingCondition(preferences), nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("Queueing", func() {
+	preferences := func() *qbittorrent.Preferences {
+		return &qbittorrent.Preferences{
+			QueueingEnabled:    true,
+			MaxActiveDownloads: 3,
+			MaxActiveUploads:   5,
+			MaxActiveTorrents:  5,
+		}
+	}
+
+	It("should only change the preferences that drifted", func() {
+		queueing := &torrentv1beta1.Queueing{
+			Enabled:            ptr.To(true),
+			MaxActiveDownloads: ptr.To(int32(10)),
+			MaxActiveTorrents:  ptr.To(int32(5)),
+			IgnoreSlowTorrents: ptr.To(true),
+		}
+		Expect(queueingChanges(queueing, preferences())).To(Equal(map[string]any{
+			"max_active_downloads":     int32(10),
+			"dont_count_slow_torrents": true,
+		}))
+
+		current := preferences()
+		applyQueueingChanges(queueing, current)
+		Expect(queueingChanges(queueing, current)).To(BeEmpty())
+		Expect(current.MaxActiveUploads).To(Equal(int32(5)))
+	})
+
+	It("should leave the unset preferences to the WebUI", func() {
+		Expect(queueingChanges(&torrentv1beta1.Queueing{}, preferences())).To(BeEmpty())
+	})
+
+	It("should report whether the priorities move the torrents in the queue", func() {
+		condition := queueingCondition(preferences())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).To(ContainSubstring("at most 3 downloads, 5 uploads and 5 torrents"))
+
+		unlimited := preferences()
+		unlimited.MaxActiveTorrents = -1
+		Expect(queueingCondition(unlimited).Message).To(ContainSubstring("5 uploads and unlimited torrents"))
+
+		disabled := preferences()
+		disabled.QueueingEnabled = false
+		condition = queueingCondition(disabled)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("QueueingDisabled"))
+	})
+})
@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+// maxLastErrorLength bounds the error recorded in the reconcile stats, as
+// validated by the CRD
+const maxLastErrorLength = 1024
+
+// recordReconcile records a reconcile of the torrent in its reconcile stats.
+// The reconcile failed if it returned an error, or left the torrent Degraded.
+func recordReconcile(torrent *torrentv1beta1.Torrent, err error) {
+	stats := torrent.Status.ReconcileStats
+	if stats == nil {
+		stats = &torrentv1beta1.ReconcileStats{}
+		torrent.Status.ReconcileStats = stats
+	}
+	now := metav1.Now()
+	stats.LastReconcileTime = &now
+
+	lastError := ""
+	if err != nil {
+		lastError = err.Error()
+	} else if degraded := meta.FindStatusCondition(torrent.Status.Conditions, TypeDegradedTorrent); degraded != nil &&
+		degraded.Status == metav1.ConditionTrue {
+		lastError = degraded.Message
+	}
+	if lastError == "" {
+		stats.ConsecutiveFailures = 0
+		stats.LastError = ""
+		return
+	}
+
+	stats.ConsecutiveFailures++
+	if len(lastError) > maxLastErrorLength {
+		// Cut on a rune boundary
+		cut := maxLastErrorLength - len("...")
+		for cut > 0 && !utf8.RuneStart(lastError[cut]) {
+			cut--
+		}
+		lastError = lastError[:cut] + "..."
+	}
+	stats.LastError = lastError
+}
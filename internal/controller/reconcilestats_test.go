@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Reconcile stats", func() {
+	It("should count the failed reconciles in a row", func() {
+		torrent := &torrentv1beta1.Torrent{}
+		r := &TorrentReconciler{}
+
+		By("counting a returned error")
+		recordReconcile(torrent, errors.New("connection refused"))
+		Expect(torrent.Status.ReconcileStats.LastReconcileTime).NotTo(BeNil())
+		Expect(torrent.Status.ReconcileStats.ConsecutiveFailures).To(Equal(int32(1)))
+		Expect(torrent.Status.ReconcileStats.LastError).To(Equal("connection refused"))
+
+		By("counting a reconcile leaving the torrent Degraded")
+		r.setDegradedCondition(torrent, "FailedToAddTorrent", "qBittorrent rejected the torrent")
+		recordReconcile(torrent, nil)
+		Expect(torrent.Status.ReconcileStats.ConsecutiveFailures).To(Equal(int32(2)))
+		Expect(torrent.Status.ReconcileStats.LastError).To(Equal("qBittorrent rejected the torrent"))
+
+		By("resetting the count after a successful reconcile")
+		r.setAvailableCondition(torrent, "TorrentActive", "Torrent is active on qBittorrent")
+		recordReconcile(torrent, nil)
+		Expect(torrent.Status.ReconcileStats.ConsecutiveFailures).To(BeZero())
+		Expect(torrent.Status.ReconcileStats.LastError).To(BeEmpty())
+	})
+
+	It("should truncate long errors on a rune boundary", func() {
+		torrent := &torrentv1beta1.Torrent{}
+		recordReconcile(torrent, errors.New(strings.Repeat("é", maxLastErrorLength)))
+
+		lastError := torrent.Status.ReconcileStats.LastError
+		Expect(len(lastError)).To(BeNumerically("<=", maxLastErrorLength))
+		Expect(utf8.ValidString(lastError)).To(BeTrue())
+		Expect(lastError).To(HaveSuffix("..."))
+	})
+})
@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+// DefaultDeletionRetryTimeout is how long the deletion of a Torrent from
+// qBittorrent is retried by default
+const DefaultDeletionRetryTimeout = time.Hour
+
+// deletionRetryTimeout returns the deletion retry timeout of the operator
+// config, else of the flags
+func (r *TorrentReconciler) deletionRetryTimeout() time.Duration {
+	return durationOr(r.Config.Spec().DeletionRetryTimeout, r.DeletionRetryTimeout)
+}
+
+// deletionRetryExpired reports whether the deletion of the torrent from
+// qBittorrent has been retried for longer than the deletion retry timeout
+func (r *TorrentReconciler) deletionRetryExpired(torrent *torrentv1beta1.Torrent) bool {
+	timeout := r.deletionRetryTimeout()
+	if timeout <= 0 || torrent.DeletionTimestamp.IsZero() {
+		return false
+	}
+	return time.Since(torrent.DeletionTimestamp.Time) >= timeout
+}
+
+// deletionFailed records a failure to delete the torrent from qBittorrent and
+// retries it, until the deletion retry timeout after which the finalizer is
+// removed anyway
+func (r *TorrentReconciler) deletionFailed(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	reason string, err error) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if r.deletionRetryExpired(torrent) {
+		return r.releaseFinalizer(ctx, torrent, fmt.Sprintf(
+			"Deletion from qBittorrent still failing after %s, the torrent may be left on qBittorrent: %s",
+			r.deletionRetryTimeout(), err))
+	}
+
+	// Update resource status to reflect the error
+	r.setDegradedCondition(torrent, reason, err.Error())
+	recordReconcile(torrent, nil)
+	if err := r.Status().Update(ctx, torrent); err != nil {
+		logger.Error(err, "Failed to update Torrent status")
+	}
+
+	// Retry after the retry interval
+	return ctrl.Result{RequeueAfter: r.retryInterval()}, nil
+}
+
+// releaseFinalizer removes the finalizer of a deleted Torrent without deleting
+// it from qBittorrent, recording an OrphanedOnBackend Event
+func (r *TorrentReconciler) releaseFinalizer(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	message string) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	logger.Info("Removing finalizer without deleting Torrent from qBittorrent", "Name", torrent.Name,
+		"Reason", message)
+	r.Recorder.Event(torrent, corev1.EventTypeWarning, "OrphanedOnBackend", message)
+
+	r.transfers.forget(torrent)
+	r.requeues.forget(client.ObjectKeyFromObject(torrent))
+	r.passes.forget(torrent)
+	r.restores.forget(torrent)
+	r.resolver.forget(torrent)
+	controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+	if err := r.Update(ctx, torrent); err != nil {
+		logger.Error(err, "Failed to remove finalizer")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
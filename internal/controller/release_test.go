@@ -0,0 +1,46 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Deletion retry timeout", func() {
+	deletedAgo := func(ago time.Duration) *torrentv1beta1.Torrent {
+		deleted := metav1.NewTime(time.Now().Add(-ago))
+		return &torrentv1beta1.Torrent{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &deleted}}
+	}
+
+	It("should retry the deletion until the timeout", func() {
+		r := &TorrentReconciler{DeletionRetryTimeout: time.Hour}
+		Expect(r.deletionRetryExpired(deletedAgo(time.Minute))).To(BeFalse())
+		Expect(r.deletionRetryExpired(deletedAgo(2 * time.Hour))).To(BeTrue())
+		Expect(r.deletionRetryExpired(&torrentv1beta1.Torrent{})).To(BeFalse())
+	})
+
+	It("should retry the deletion forever when disabled", func() {
+		r := &TorrentReconciler{}
+		Expect(r.deletionRetryExpired(deletedAgo(24 * time.Hour))).To(BeFalse())
+	})
+})
@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// requeueJitter is the fraction by which the requeue delays are randomized,
+// so that the objects reconciled together are not requeued together
+const requeueJitter = 0.2
+
+// jitter randomizes a requeue delay by up to requeueJitter either way
+func jitter(delay time.Duration) time.Duration {
+	if delay <= 0 {
+		return delay
+	}
+	return delay + time.Duration((rand.Float64()*2-1)*requeueJitter*float64(delay))
+}
+
+// requeueSpreader randomizes the requeue delays. At startup all the objects
+// are reconciled at once, so their first requeue is spread over the whole
+// delay instead of only jittered.
+type requeueSpreader struct {
+	seen sync.Map
+}
+
+// delay returns the randomized requeue delay of an object
+func (s *requeueSpreader) delay(key types.NamespacedName, delay time.Duration) time.Duration {
+	if delay <= 0 {
+		return delay
+	}
+	if _, seen := s.seen.LoadOrStore(key, struct{}{}); !seen {
+		return time.Duration(rand.Int64N(int64(delay))) + 1
+	}
+	return jitter(delay)
+}
+
+// forget drops a deleted object
+func (s *requeueSpreader) forget(key types.NamespacedName) {
+	s.seen.Delete(key)
+}
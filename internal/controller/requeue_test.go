@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Requeue spreading", func() {
+	key := types.NamespacedName{Name: "dataset", Namespace: "media-server"}
+
+	It("should jitter the requeue delays by up to 20%", func() {
+		for range 100 {
+			Expect(jitter(30 * time.Second)).To(BeNumerically("~", 30*time.Second, 6*time.Second))
+		}
+		Expect(jitter(0)).To(BeZero())
+	})
+
+	It("should spread the first requeue over the whole delay", func() {
+		spreader := &requeueSpreader{}
+		first := spreader.delay(key, 30*time.Second)
+		Expect(first).To(BeNumerically(">", 0))
+		Expect(first).To(BeNumerically("<=", 30*time.Second))
+
+		for range 100 {
+			Expect(spreader.delay(key, 30*time.Second)).To(BeNumerically("~", 30*time.Second, 6*time.Second))
+		}
+
+		// A Torrent created again with the same name is spread again
+		spreader.forget(key)
+		_, seen := spreader.seen.Load(key)
+		Expect(seen).To(BeFalse())
+	})
+
+	It("should leave the objects not requeued alone", func() {
+		spreader := &requeueSpreader{}
+		Expect(spreader.delay(key, 0)).To(BeZero())
+
+		// The object is first requeued afterwards, it is still spread
+		_, seen := spreader.seen.Load(key)
+		Expect(seen).To(BeFalse())
+	})
+})
@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// startupIndexMaxAge is how long the list of the torrents answers the first
+// lookups of the Torrents before it is listed again
+const startupIndexMaxAge = 5 * time.Second
+
+// startupResolver answers the first lookup of each Torrent from a list of the
+// torrents of its server shared by all the Torrents of the server. At startup
+// all the Torrents are reconciled at once, each listing the torrents
+// otherwise. The concurrent reconciles wait for a single list. The next
+// lookups of a Torrent list the torrents again, so that it sees the torrent
+// it added. It is not persisted, the first lookups after the operator
+// restarts use a new list.
+type startupResolver struct {
+	mu       sync.Mutex
+	resolved map[types.UID]bool
+	// lists are the lists of the torrents of the servers, by name
+	lists map[string]*startupList
+}
+
+// startupList is the list of the torrents of a server
+type startupList struct {
+	index  qbittorrent.TorrentIndex
+	listed time.Time
+	// listing is closed once the list in progress is done
+	listing chan struct{}
+}
+
+// lookup returns the torrent matching the hashes on the server, as GetTorrentInfo
+func (t *startupResolver) lookup(ctx context.Context, server string, qbt *qbittorrent.Client,
+	torrent *torrentv1beta1.Torrent, hashes qbittorrent.InfoHashes) (*qbittorrent.TorrentInfo, error) {
+	t.mu.Lock()
+	if t.resolved[torrent.UID] {
+		t.mu.Unlock()
+		return qbt.GetTorrentInfo(ctx, hashes)
+	}
+
+	if t.lists == nil {
+		t.lists = map[string]*startupList{}
+	}
+	list := t.lists[server]
+	if list == nil {
+		list = &startupList{}
+		t.lists[server] = list
+	}
+	for list.index == nil || time.Since(list.listed) >= startupIndexMaxAge {
+		// Another reconcile is listing the torrents, its list is shared
+		if listing := list.listing; listing != nil {
+			t.mu.Unlock()
+			select {
+			case <-listing:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			t.mu.Lock()
+			continue
+		}
+
+		listing := make(chan struct{})
+		list.listing = listing
+		t.mu.Unlock()
+		infos, err := qbt.GetTorrentsInfo(ctx)
+		t.mu.Lock()
+		list.listing = nil
+		close(listing)
+		if err != nil {
+			t.mu.Unlock()
+			return nil, err
+		}
+		list.index = qbittorrent.NewTorrentIndex(infos)
+		list.listed = time.Now()
+	}
+	defer t.mu.Unlock()
+
+	if t.resolved == nil {
+		t.resolved = map[types.UID]bool{}
+	}
+	t.resolved[torrent.UID] = true
+	// The reconcile gets its own copy of the shared torrent
+	if qbTorrent := list.index.Lookup(hashes); qbTorrent != nil {
+		found := *qbTorrent
+		return &found, nil
+	}
+	return nil, nil
+}
+
+// forget drops a deleted torrent
+func (t *startupResolver) forget(torrent *torrentv1beta1.Torrent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.resolved, torrent.UID)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("Startup resolver", func() {
+	const (
+		magnetHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+		otherHash  = "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"
+	)
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		Expect(qb.client().AddTorrent(ctx, "magnet:?xt=urn:btih:"+magnetHash)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		qb.Close()
+	})
+
+	It("should answer the first lookups of the Torrents from a single list", func() {
+		resolver := &startupResolver{}
+		qbt := qb.client()
+
+		var wg sync.WaitGroup
+		for i := range 10 {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				torrent := &torrentv1beta1.Torrent{ObjectMeta: metav1.ObjectMeta{UID: types.UID(fmt.Sprint(i))}}
+				qbTorrent, err := resolver.lookup(ctx, DefaultServerName, qbt, torrent, qbittorrent.InfoHashes{V1: magnetHash})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(qbTorrent).NotTo(BeNil())
+			}()
+		}
+		wg.Wait()
+		Expect(qb.callCount("/api/v2/torrents/info")).To(Equal(1))
+
+		By("listing the torrents again for the next lookups, seeing the ones added since")
+		Expect(qbt.AddTorrent(ctx, "magnet:?xt=urn:btih:"+otherHash)).To(Succeed())
+		torrent := &torrentv1beta1.Torrent{ObjectMeta: metav1.ObjectMeta{UID: "0"}}
+		qbTorrent, err := resolver.lookup(ctx, DefaultServerName, qbt, torrent, qbittorrent.InfoHashes{V1: otherHash})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(qbTorrent).NotTo(BeNil())
+		Expect(qb.callCount("/api/v2/torrents/info")).To(Equal(2))
+
+		By("listing the torrents of another server apart")
+		other := newFakeQBittorrent()
+		defer other.Close()
+		torrent = &torrentv1beta1.Torrent{ObjectMeta: metav1.ObjectMeta{UID: "seedbox"}}
+		qbTorrent, err = resolver.lookup(ctx, "seedbox", other.client(), torrent, qbittorrent.InfoHashes{V1: magnetHash})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(qbTorrent).To(BeNil())
+		Expect(other.callCount("/api/v2/torrents/info")).To(Equal(1))
+	})
+})
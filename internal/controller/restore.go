@@ -0,0 +1,221 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// restoredSettings are the settings a torrent had on qBittorrent, captured
+// from the status of its Torrent before it is added again
+type restoredSettings struct {
+	category string
+	tags     []string
+	// downloadLimit, uploadLimit, ratioLimit and seedingTimeLimit are the
+	// limits changed in the WebUI and accepted as drift
+	downloadLimit, uploadLimit *int64
+	ratioLimit                 *float64
+	seedingTimeLimit           *time.Duration
+	// trackers are the fallback trackers added by the stalled recovery
+	trackers []string
+	// filePriorities are the priorities of the files reported in status, by
+	// name, the ones with the normal priority left out
+	filePriorities map[string]int
+}
+
+// captureSettings returns the settings of the torrent recorded in its status
+func captureSettings(torrent *torrentv1beta1.Torrent) *restoredSettings {
+	settings := &restoredSettings{tags: torrent.Status.Tags}
+	policy := torrentv1beta1.DriftPolicy{}
+	if torrent.Spec.DriftPolicy != nil {
+		policy = *torrent.Spec.DriftPolicy
+	}
+	if policy.Category != torrentv1beta1.DriftActionEnforce {
+		settings.category = torrent.Status.Category
+	}
+
+	for _, drift := range torrent.Status.Drift {
+		switch drift.Field {
+		case "limits.downloadLimit":
+			if limit, err := strconv.ParseInt(drift.Actual, 10, 64); err == nil {
+				settings.downloadLimit = &limit
+			}
+		case "limits.uploadLimit":
+			if limit, err := strconv.ParseInt(drift.Actual, 10, 64); err == nil {
+				settings.uploadLimit = &limit
+			}
+		case "limits.ratioLimit":
+			limit, err := strconv.ParseFloat(drift.Actual, 64)
+			if value, ok := shareLimitValue(drift.Actual); ok {
+				limit, err = float64(value), nil
+			}
+			if err == nil {
+				settings.ratioLimit = &limit
+			}
+		case "limits.seedingTimeLimit":
+			limit, err := time.ParseDuration(drift.Actual)
+			if value, ok := shareLimitValue(drift.Actual); ok {
+				limit, err = time.Duration(value)*time.Minute, nil
+			}
+			if err == nil {
+				settings.seedingTimeLimit = &limit
+			}
+		}
+	}
+
+	if recovery := torrent.Status.TrackerRecovery; recovery != nil {
+		settings.trackers = recovery.AddedTrackers
+	}
+	for _, file := range torrent.Status.Files {
+		// The truncated names no longer match the files
+		if file.Priority != 1 && !strings.HasPrefix(file.Name, "...") {
+			if settings.filePriorities == nil {
+				settings.filePriorities = map[string]int{}
+			}
+			settings.filePriorities[file.Name] = int(file.Priority)
+		}
+	}
+	return settings
+}
+
+// shareLimitValue returns the qBittorrent value of the global and unlimited
+// share limits reported in the drift
+func shareLimitValue(limit string) (int64, bool) {
+	switch limit {
+	case "global":
+		return shareLimitGlobal, true
+	case "unlimited":
+		return shareLimitUnlimited, true
+	}
+	return 0, false
+}
+
+// apply sets the captured settings known when adding the torrent
+func (s *restoredSettings) apply(opts *qbittorrent.AddTorrentOptions) {
+	if s.category != "" {
+		opts.Category = s.category
+	}
+	opts.Tags = s.tags
+	if s.downloadLimit != nil {
+		opts.DownloadLimit = *s.downloadLimit
+	}
+	if s.uploadLimit != nil {
+		opts.UploadLimit = *s.uploadLimit
+	}
+	if s.ratioLimit != nil {
+		opts.RatioLimit = s.ratioLimit
+	}
+	if s.seedingTimeLimit != nil {
+		opts.SeedingTimeLimit = s.seedingTimeLimit
+	}
+}
+
+// settingsRestorer keeps the settings captured from the Torrents added again
+// until they are all re-applied. It is not persisted, the trackers and file
+// priorities of a torrent added again right before the operator restarts
+// are not re-applied.
+type settingsRestorer struct {
+	mu       sync.Mutex
+	settings map[types.UID]*restoredSettings
+}
+
+// capture captures the settings of the torrent from its status, unless
+// captured already by a previous attempt to add it
+func (t *settingsRestorer) capture(torrent *torrentv1beta1.Torrent) *restoredSettings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if settings, ok := t.settings[torrent.UID]; ok {
+		return settings
+	}
+	if t.settings == nil {
+		t.settings = map[types.UID]*restoredSettings{}
+	}
+	settings := captureSettings(torrent)
+	t.settings[torrent.UID] = settings
+	return settings
+}
+
+func (t *settingsRestorer) get(torrent *torrentv1beta1.Torrent) *restoredSettings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.settings[torrent.UID]
+}
+
+// forget drops the settings once re-applied, or of a deleted torrent
+func (t *settingsRestorer) forget(torrent *torrentv1beta1.Torrent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.settings, torrent.UID)
+}
+
+// restoreSettings re-applies the trackers and file priorities captured
+// before the torrent was added again, once qBittorrent knows its files. The
+// other settings were set when adding it.
+func (r *TorrentReconciler) restoreSettings(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) error {
+	settings := r.restores.get(torrent)
+	if settings == nil {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+
+	if len(settings.filePriorities) > 0 {
+		files, err := r.qbt(torrent).GetTorrentFiles(ctx, qbTorrent.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to get torrent files: %w", err)
+		}
+		// A magnet has no files until its metadata is received
+		if len(files) == 0 {
+			return nil
+		}
+		indexes := map[int][]int{}
+		for _, file := range files {
+			if priority, ok := settings.filePriorities[file.Name]; ok && priority != file.Priority {
+				indexes[priority] = append(indexes[priority], file.Index)
+			}
+		}
+		for priority, files := range indexes {
+			logger.Info("Restoring the priority of the files", "Name", torrent.Name, "Priority", priority, "Files", len(files))
+			if err := r.qbt(torrent).SetFilePriority(ctx, qbTorrent.Hash, files, priority); err != nil {
+				return fmt.Errorf("failed to restore the priority of the files: %w", err)
+			}
+		}
+	}
+	if len(settings.trackers) > 0 {
+		logger.Info("Restoring the fallback trackers", "Name", torrent.Name, "Trackers", settings.trackers)
+		if err := r.qbt(torrent).AddTrackers(ctx, qbTorrent.Hash, settings.trackers); err != nil {
+			return fmt.Errorf("failed to restore the fallback trackers: %w", err)
+		}
+	}
+
+	r.restores.forget(torrent)
+	r.recordEvent(torrent, corev1.EventTypeNormal, "SettingsRestored",
+		"Torrent added again to qBittorrent with its previous settings")
+	return nil
+}
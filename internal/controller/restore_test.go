@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("Torrent settings restore", func() {
+	const (
+		magnetHash      = "c9e15763f722f23e98a29decdfae341b98d53056"
+		otherHash       = "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"
+		fallbackTracker = "udp://tracker.example.com:1337/announce"
+	)
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var controllerReconciler *TorrentReconciler
+	var recorder *record.FakeRecorder
+	var key types.NamespacedName
+
+	reconcileTorrent := func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getTorrent := func() *torrentv1beta1.Torrent {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		key = types.NamespacedName{Name: "restore", Namespace: "default"}
+		recorder = record.NewFakeRecorder(20)
+		controllerReconciler = &TorrentReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Clients:  poolOf(qb.client()),
+			Recorder: recorder,
+			Config:   &OperatorConfig{},
+		}
+
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source: torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + magnetHash},
+				Limits: &torrentv1beta1.TorrentLimits{DownloadLimit: ptr.To[int64](1048576)},
+			},
+		})).To(Succeed())
+		reconcileTorrent()
+		reconcileTorrent()
+		reconcileTorrent()
+		Expect(getTorrent().Status.Hash).To(Equal(magnetHash))
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		torrent := getTorrent()
+		controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+	})
+
+	It("should add a torrent deleted from qBittorrent again with its previous settings", func() {
+		By("deleting the torrent from the WebUI")
+		// Another torrent is left, qBittorrent did not restart
+		Expect(qb.client().AddTorrent(ctx, "magnet:?xt=urn:btih:"+otherHash)).To(Succeed())
+		Expect(qb.client().DeleteTorrent(ctx, magnetHash, false)).To(Succeed())
+		torrent := getTorrent()
+		torrent.Status.Category = "archive"
+		torrent.Status.Tags = []string{"keep"}
+		torrent.Status.Drift = []torrentv1beta1.FieldDrift{
+			{Field: "limits.downloadLimit", Desired: "1048576", Actual: "2097152"},
+		}
+		torrent.Status.TrackerRecovery = &torrentv1beta1.TrackerRecoveryStatus{AddedTrackers: []string{fallbackTracker}}
+		torrent.Status.Files = []torrentv1beta1.TorrentFileStatus{
+			{Name: "big_buck_bunny/movie.mp4", Size: 276134947, Priority: 1},
+			{Name: "big_buck_bunny/poster.jpg", Size: 310380, Priority: 0},
+		}
+		Expect(k8sClient.Status().Update(ctx, torrent)).To(Succeed())
+
+		By("adding it again with the settings known when adding it")
+		reconcileTorrent()
+		info := qb.torrentInfo(magnetHash)
+		Expect(info.Category).To(Equal("archive"))
+		Expect(info.TagList()).To(ConsistOf("keep", ownershipTag(torrent)))
+		Expect(info.DLLimit).To(BeEquivalentTo(2097152))
+
+		By("waiting for the metadata before restoring the file priorities")
+		reconcileTorrent()
+		Expect(qb.addedTrackers(magnetHash)).To(BeEmpty())
+
+		By("restoring the file priorities and the trackers once the files are known")
+		qb.setFiles(magnetHash,
+			qbittorrent.FileInfo{Name: "big_buck_bunny/movie.mp4", Size: 276134947, Priority: 1},
+			qbittorrent.FileInfo{Name: "big_buck_bunny/poster.jpg", Size: 310380, Priority: 1},
+		)
+		reconcileTorrent()
+		files := qb.torrentFiles(magnetHash)
+		Expect(files[0].Priority).To(Equal(1))
+		Expect(files[1].Priority).To(Equal(0))
+		Expect(qb.addedTrackers(magnetHash)).To(Equal([]string{fallbackTracker}))
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal SettingsRestored")))
+
+		By("restoring them only once")
+		reconcileTorrent()
+		Expect(qb.addedTrackers(magnetHash)).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// Condition types for QBittorrentServer status diagnosing the connection, so
+// that the Torrents do not each report the same failure
+const (
+	// Status used to indicate if the qBittorrent WebUI answers the operator
+	TypeConnectedServer = "Connected"
+	// Status used to indicate if qBittorrent rejects the credentials of the operator
+	TypeAuthFailedServer = "AuthFailed"
+	// Status used to indicate if the qBittorrent WebUI API is too old for the operator
+	TypeVersionUnsupportedServer = "VersionUnsupported"
+	// Status used to indicate if the free disk space is below the disk reserve
+	TypeDiskPressureServer = "DiskPressure"
+)
+
+// MinWebAPIVersion is the oldest qBittorrent WebUI API the operator supports,
+// the one of qBittorrent 4.2 bringing the tags
+const MinWebAPIVersion = "2.3.0"
+
+// setConnectionConditions reports whether the operator could log in to
+// qBittorrent and reach it
+func setConnectionConditions(conditions *[]metav1.Condition, err error) {
+	switch {
+	case err == nil:
+		setCondition(conditions, metav1.Condition{
+			Type:    TypeConnectedServer,
+			Status:  metav1.ConditionTrue,
+			Reason:  "ServerReachable",
+			Message: "The qBittorrent WebUI answers the operator",
+		})
+		setCondition(conditions, metav1.Condition{
+			Type:    TypeAuthFailedServer,
+			Status:  metav1.ConditionFalse,
+			Reason:  "LoggedIn",
+			Message: "qBittorrent accepted the credentials of the operator",
+		})
+	case errors.Is(err, qbittorrent.ErrLoginRejected):
+		setCondition(conditions, metav1.Condition{
+			Type:    TypeConnectedServer,
+			Status:  metav1.ConditionFalse,
+			Reason:  "AuthFailed",
+			Message: "qBittorrent rejected the credentials of the operator",
+		})
+		setCondition(conditions, metav1.Condition{
+			Type:    TypeAuthFailedServer,
+			Status:  metav1.ConditionTrue,
+			Reason:  "LoginRejected",
+			Message: err.Error() + ", check the qBittorrent credentials of the operator",
+		})
+	default:
+		// The credentials are not known to be wrong until qBittorrent answers
+		setCondition(conditions, metav1.Condition{
+			Type:    TypeConnectedServer,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ServerUnreachable",
+			Message: err.Error(),
+		})
+	}
+}
+
+// setVersionCondition reports whether the WebUI API of qBittorrent is
+// supported, leaving the condition out when its version cannot be parsed
+func setVersionCondition(conditions *[]metav1.Condition, webAPIVersion string) {
+	current, err := version.ParseGeneric(webAPIVersion)
+	if err != nil {
+		meta.RemoveStatusCondition(conditions, TypeVersionUnsupportedServer)
+		return
+	}
+	if current.LessThan(version.MustParseGeneric(MinWebAPIVersion)) {
+		setCondition(conditions, metav1.Condition{
+			Type:   TypeVersionUnsupportedServer,
+			Status: metav1.ConditionTrue,
+			Reason: "WebAPITooOld",
+			Message: fmt.Sprintf("The qBittorrent WebUI API %s is older than %s, upgrade qBittorrent to 4.2 or later",
+				webAPIVersion, MinWebAPIVersion),
+		})
+		return
+	}
+	setCondition(conditions, metav1.Condition{
+		Type:    TypeVersionUnsupportedServer,
+		Status:  metav1.ConditionFalse,
+		Reason:  "WebAPISupported",
+		Message: fmt.Sprintf("The qBittorrent WebUI API %s is supported", webAPIVersion),
+	})
+}
+
+// setDiskPressureCondition reports whether the free disk space of qBittorrent
+// is below the disk reserve, leaving the condition out without reserve
+func setDiskPressureCondition(conditions *[]metav1.Condition, status *torrentv1beta1.QBittorrentServerStatus,
+	config *torrentv1beta1.QBittorrentOperatorConfigSpec) {
+	if config.DiskReserve == nil || status.FreeSpaceOnDisk == nil {
+		meta.RemoveStatusCondition(conditions, TypeDiskPressureServer)
+		return
+	}
+	if status.FreeSpaceOnDisk.Cmp(*config.DiskReserve) < 0 {
+		setCondition(conditions, metav1.Condition{
+			Type:   TypeDiskPressureServer,
+			Status: metav1.ConditionTrue,
+			Reason: "BelowDiskReserve",
+			Message: fmt.Sprintf("The free disk space %s is below the disk reserve %s, the new Torrents are held Pending",
+				formatQuantity(status.FreeSpaceOnDisk.Value()), formatQuantity(config.DiskReserve.Value())),
+		})
+		return
+	}
+	setCondition(conditions, metav1.Condition{
+		Type:    TypeDiskPressureServer,
+		Status:  metav1.ConditionFalse,
+		Reason:  "AboveDiskReserve",
+		Message: fmt.Sprintf("The free disk space %s is above the disk reserve", formatQuantity(status.FreeSpaceOnDisk.Value())),
+	})
+}
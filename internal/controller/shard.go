@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"hash/fnv"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Shard is the part of the Torrents reconciled by an operator replica, when
+// the Torrents are sharded across replicas
+type Shard struct {
+	// Index of the replica, from 0 to Count-1
+	Index int
+	// Count of replicas, the Torrents are not sharded when 1 or less
+	Count int
+}
+
+// Enabled reports whether the Torrents are sharded
+func (s Shard) Enabled() bool {
+	return s.Count > 1
+}
+
+// Owns reports whether the object is reconciled by the replica. The objects
+// are assigned by consistent hashing of their UID, so that only a fraction of
+// them moves to another replica when the count changes.
+func (s Shard) Owns(obj client.Object) bool {
+	if !s.Enabled() {
+		return true
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(obj.GetUID()))
+	return jumpHash(h.Sum64(), s.Count) == s.Index
+}
+
+// jumpHash is the jump consistent hash of Lamping and Veach, mapping a key to
+// one of buckets buckets
+func jumpHash(key uint64, buckets int) int {
+	b, j := int64(-1), int64(0)
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
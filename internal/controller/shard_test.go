@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Sharding", func() {
+	torrents := make([]*torrentv1beta1.Torrent, 1000)
+	for i := range torrents {
+		torrents[i] = &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{UID: types.UID(fmt.Sprintf("uid-%d", i))},
+		}
+	}
+
+	owner := func(torrent *torrentv1beta1.Torrent, count int) int {
+		owners := []int{}
+		for index := range count {
+			if (Shard{Index: index, Count: count}).Owns(torrent) {
+				owners = append(owners, index)
+			}
+		}
+		Expect(owners).To(HaveLen(1))
+		return owners[0]
+	}
+
+	It("should reconcile all the Torrents when disabled", func() {
+		for _, torrent := range torrents {
+			Expect(Shard{}.Owns(torrent)).To(BeTrue())
+			Expect(Shard{Count: 1}.Owns(torrent)).To(BeTrue())
+		}
+	})
+
+	It("should assign each Torrent to exactly one replica", func() {
+		counts := map[int]int{}
+		for _, torrent := range torrents {
+			counts[owner(torrent, 3)]++
+		}
+		for index := range 3 {
+			Expect(counts[index]).To(BeNumerically("~", 333, 60))
+		}
+	})
+
+	It("should move few Torrents when a replica is added", func() {
+		moved := 0
+		for _, torrent := range torrents {
+			if owner(torrent, 3) != owner(torrent, 4) {
+				moved++
+			}
+		}
+		Expect(moved).To(BeNumerically("~", 250, 60))
+	})
+})
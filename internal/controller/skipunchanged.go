@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// DefaultFullResyncInterval bounds how long the reconciles of an unchanged
+// Torrent are skipped with skipUnchanged
+const DefaultFullResyncInterval = 10 * time.Minute
+
+// controlAnnotationPrefix is the prefix of the annotations controlling the
+// reconcile, e.g. the actions. The ones reflecting the torrent are left out
+// of the effective spec, as the operator writes them.
+const controlAnnotationPrefix = "qbittorrent.io/"
+
+// effectiveSpecHash returns the hash of what a full reconcile of the torrent
+// acts on besides qBittorrent: its spec, labels and control annotations, and
+// the operator config
+func effectiveSpecHash(torrent *torrentv1beta1.Torrent, config *torrentv1beta1.QBittorrentOperatorConfigSpec) string {
+	annotations := map[string]string{}
+	for key, value := range torrent.Annotations {
+		if strings.HasPrefix(key, controlAnnotationPrefix) {
+			annotations[key] = value
+		}
+	}
+	// Maps are marshalled in key order, the hash does not depend on the
+	// order the fields were applied in
+	data, _ := json.Marshal(struct {
+		Spec        torrentv1beta1.TorrentSpec
+		Labels      map[string]string
+		Annotations map[string]string
+		Config      *torrentv1beta1.QBittorrentOperatorConfigSpec
+	}{torrent.Spec, torrent.Labels, annotations, config})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// backendFingerprint returns what a full reconcile acts on of the torrent,
+// leaving out the counters and speeds changing all the time
+func backendFingerprint(qbTorrent *qbittorrent.TorrentInfo) string {
+	private := qbTorrent.Private != nil && *qbTorrent.Private
+	inactiveSeedingTimeLimit := int64(0)
+	if qbTorrent.InactiveSeedingTimeLimit != nil {
+		inactiveSeedingTimeLimit = *qbTorrent.InactiveSeedingTimeLimit
+	}
+	return fmt.Sprintf("%s|%s|%s|%s|%s|%s|%d|%d|%d|%d|%g|%d|%d|%t", qbTorrent.Hash, qbTorrent.State,
+		qbTorrent.Category, qbTorrent.Tags, qbTorrent.SavePath, qbTorrent.ContentPath, qbTorrent.TotalSize,
+		qbTorrent.AmountLeft, qbTorrent.DLLimit, qbTorrent.UPLimit, qbTorrent.RatioLimit,
+		qbTorrent.SeedingTimeLimit, inactiveSeedingTimeLimit, private)
+}
+
+// passSettled reports whether a full reconcile left nothing waiting, such as
+// the checksum Job or a cross-seed just added, whose progress the next
+// reconciles must not skip
+func passSettled(torrent *torrentv1beta1.Torrent, qbTorrent *qbittorrent.TorrentInfo) bool {
+	if torrent.Spec.Checksums != nil && torrent.Spec.Checksums.Enabled &&
+		torrent.Status.ChecksumConfigMap == "" && isTorrentComplete(qbTorrent) {
+		return false
+	}
+	for _, crossSeed := range torrent.Status.CrossSeeds {
+		if crossSeed.Hash == "" {
+			return false
+		}
+	}
+	// The transfer budget counts the bytes of every pass, which change the
+	// fingerprint of nothing, and starts the torrent again the next day
+	if torrent.Spec.DailyTransferBudget != nil {
+		return false
+	}
+	return true
+}
+
+// fullPassTracker remembers the torrent of the last full reconcile of each
+// Torrent. It is not persisted, the first reconcile after the operator
+// starts is a full one.
+type fullPassTracker struct {
+	mu   sync.Mutex
+	last map[types.UID]fullPass
+}
+
+type fullPass struct {
+	specHash string
+	backend  string
+	time     time.Time
+}
+
+// unchanged reports whether neither the effective spec nor the torrent
+// changed since the last full reconcile, less than resync ago
+func (t *fullPassTracker) unchanged(torrent *torrentv1beta1.Torrent, specHash string,
+	qbTorrent *qbittorrent.TorrentInfo, resync time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	last, ok := t.last[torrent.UID]
+	return ok && last.specHash == specHash && torrent.Status.SpecHash == specHash &&
+		last.backend == backendFingerprint(qbTorrent) && time.Since(last.time) < resync
+}
+
+// record remembers a full reconcile of the torrent
+func (t *fullPassTracker) record(torrent *torrentv1beta1.Torrent, specHash string, qbTorrent *qbittorrent.TorrentInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.last == nil {
+		t.last = map[types.UID]fullPass{}
+	}
+	t.last[torrent.UID] = fullPass{specHash: specHash, backend: backendFingerprint(qbTorrent), time: time.Now()}
+}
+
+// forget drops the full reconcile of a deleted torrent, or of one whose
+// reconcile did not settle
+func (t *fullPassTracker) forget(torrent *torrentv1beta1.Torrent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.last, torrent.UID)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Torrent unchanged reconciles", func() {
+	const (
+		magnetHash     = "c9e15763f722f23e98a29decdfae341b98d53056"
+		propertiesPath = "/api/v2/torrents/properties"
+	)
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var config *OperatorConfig
+	var controllerReconciler *TorrentReconciler
+	var key types.NamespacedName
+
+	reconcileTorrent := func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getTorrent := func() *torrentv1beta1.Torrent {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		key = types.NamespacedName{Name: "skip-unchanged", Namespace: "default"}
+		config = &OperatorConfig{}
+		config.set(&torrentv1beta1.QBittorrentOperatorConfigSpec{SkipUnchanged: &torrentv1beta1.SkipUnchanged{}})
+		controllerReconciler = &TorrentReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Clients:  poolOf(qb.client()),
+			Recorder: record.NewFakeRecorder(20),
+			Config:   config,
+		}
+
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source: torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + magnetHash},
+			},
+		})).To(Succeed())
+		reconcileTorrent()
+		reconcileTorrent()
+		qb.setCompleted(magnetHash, 1<<20)
+		reconcileTorrent()
+		Expect(getTorrent().Status.SpecHash).NotTo(BeEmpty())
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		torrent := getTorrent()
+		controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+	})
+
+	It("should skip the calls while neither the spec nor the torrent changed", func() {
+		calls := qb.callCount(propertiesPath)
+		reconcileTorrent()
+		reconcileTorrent()
+		Expect(qb.callCount(propertiesPath)).To(Equal(calls))
+
+		By("reconciling the changed spec")
+		torrent := getTorrent()
+		torrent.Spec.Category = "movies"
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		reconcileTorrent()
+		Expect(qb.callCount(propertiesPath)).To(Equal(calls + 1))
+		specHash := getTorrent().Status.SpecHash
+		reconcileTorrent()
+		Expect(qb.callCount(propertiesPath)).To(Equal(calls + 1))
+
+		By("reconciling the changed torrent")
+		qb.setState(magnetHash, "stoppedUP")
+		reconcileTorrent()
+		Expect(qb.callCount(propertiesPath)).To(Equal(calls + 2))
+		Expect(getTorrent().Status.SpecHash).To(Equal(specHash))
+		Expect(getTorrent().Status.Phase).To(Equal(torrentv1beta1.TorrentPhaseCompleted))
+	})
+
+	It("should reconcile every time without skipUnchanged", func() {
+		config.set(&torrentv1beta1.QBittorrentOperatorConfigSpec{})
+		calls := qb.callCount(propertiesPath)
+		reconcileTorrent()
+		reconcileTorrent()
+		Expect(qb.callCount(propertiesPath)).To(Equal(calls + 2))
+		Expect(getTorrent().Status.SpecHash).To(BeEmpty())
+	})
+})
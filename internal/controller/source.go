@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// resolveSource returns the info hashes of the torrent source, so that all
+// sources are looked up on qBittorrent the same way. For .torrent sources the
+// decoded file is returned too, to be uploaded if the torrent is missing.
+//
+// A torrentURL is only fetched until the torrent is found on qBittorrent, the
+// hash recorded in status is used from then on.
+func (r *TorrentReconciler) resolveSource(ctx context.Context,
+	torrent *torrentv1beta1.Torrent) (qbittorrent.InfoHashes, []byte, error) {
+	logger := log.FromContext(ctx)
+	source := torrent.Spec.Source
+
+	switch {
+	case source.MagnetURI != "":
+		logger.V(1).Info("Getting torrent hashes from magnet URI", "MagnetURI", source.MagnetURI)
+		hashes, err := qbittorrent.GetInfoHashes(source.MagnetURI)
+		return hashes, nil, err
+
+	case len(source.TorrentData) > 0:
+		file, err := qbittorrent.ParseTorrentFile(source.TorrentData)
+		if err != nil {
+			return qbittorrent.InfoHashes{}, nil, err
+		}
+		return file.InfoHashes, source.TorrentData, nil
+
+	case source.TorrentURL != "":
+		if torrent.Status.Hash != "" {
+			// The ID qBittorrent lists the torrent under
+			return qbittorrent.InfoHashes{V1: torrent.Status.Hash}, nil, nil
+		}
+		return r.fetchTorrentFile(ctx, torrent, source.TorrentURL)
+
+	default:
+		return qbittorrent.InfoHashes{}, nil, errors.New("the torrent source is empty")
+	}
+}
+
+// fetchTorrentFile downloads and decodes the .torrent file of a torrentURL source
+func (r *TorrentReconciler) fetchTorrentFile(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	torrentURL string) (qbittorrent.InfoHashes, []byte, error) {
+	data, err := r.qbt(torrent).FetchTorrentFile(ctx, torrentURL)
+	if err != nil {
+		return qbittorrent.InfoHashes{}, nil, err
+	}
+
+	file, err := qbittorrent.ParseTorrentFile(data)
+	if err != nil {
+		// The server may serve something else transiently, such as an error
+		// page, so the error is not reported as an invalid source
+		return qbittorrent.InfoHashes{}, nil, fmt.Errorf("fetched %s: %v", torrentURL, err)
+	}
+	return file.InfoHashes, data, nil
+}
+
+// addTorrent adds the torrent source to qBittorrent. torrentFile is the
+// decoded file of .torrent sources, if already known.
+func (r *TorrentReconciler) addTorrent(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	torrentFile []byte) error {
+	opts, err := r.addTorrentOptions(torrent)
+	if err != nil {
+		return err
+	}
+	if settings := r.restores.get(torrent); settings != nil {
+		settings.apply(&opts)
+	}
+	if tag := ownershipTag(torrent); !slices.Contains(opts.Tags, tag) {
+		opts.Tags = append(slices.Clone(opts.Tags), tag)
+	}
+
+	if torrent.Spec.Source.MagnetURI != "" {
+		return r.qbt(torrent).AddTorrentWithOptions(ctx, torrent.Spec.Source.MagnetURI, opts)
+	}
+
+	if torrentFile == nil {
+		if _, torrentFile, err = r.fetchTorrentFile(ctx, torrent, torrent.Spec.Source.TorrentURL); err != nil {
+			return err
+		}
+	}
+	return r.qbt(torrent).AddTorrentFileWithOptions(ctx, torrentFile, opts)
+}
+
+// isInvalidSource reports whether the error is caused by the torrent source
+// itself, and will not go away until the spec changes
+func isInvalidSource(err error) bool {
+	var magnetErr *qbittorrent.MagnetParseError
+	var fileErr *qbittorrent.TorrentFileError
+	return errors.As(err, &magnetErr) || errors.As(err, &fileErr)
+}
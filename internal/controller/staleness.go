@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+// TypeStatusStaleTorrent is set to True when the status has not been
+// refreshed from qBittorrent for longer than the status stale threshold
+const TypeStatusStaleTorrent = "StatusStale"
+
+// DefaultStatusStaleThreshold is how long the status may go without being
+// refreshed from qBittorrent before it is reported as stale
+const DefaultStatusStaleThreshold = 5 * time.Minute
+
+// statusStaleThreshold returns the status stale threshold of the operator
+// config, else of the flags, the default one if unset
+func (r *TorrentReconciler) statusStaleThreshold() time.Duration {
+	if threshold := r.Config.Spec().StatusStaleThreshold; threshold != nil {
+		return threshold.Duration
+	}
+	if r.StatusStaleThreshold <= 0 {
+		return DefaultStatusStaleThreshold
+	}
+	return r.StatusStaleThreshold
+}
+
+// markSynced records that the status was refreshed from qBittorrent
+func (r *TorrentReconciler) markSynced(torrent *torrentv1beta1.Torrent) {
+	now := metav1.NewTime(time.Now())
+	torrent.Status.LastSyncedTime = &now
+
+	setCondition(&torrent.Status.Conditions, metav1.Condition{
+		Type:    TypeStatusStaleTorrent,
+		Status:  metav1.ConditionFalse,
+		Reason:  "Synced",
+		Message: "Status is refreshed from qBittorrent",
+	})
+}
+
+// markStaleIfExpired sets the StatusStale condition when the status could not
+// be refreshed from qBittorrent for longer than the status stale threshold
+func (r *TorrentReconciler) markStaleIfExpired(torrent *torrentv1beta1.Torrent, reason string) {
+	lastSynced := torrent.Status.LastSyncedTime
+	if lastSynced != nil && time.Since(lastSynced.Time) < r.statusStaleThreshold() {
+		return
+	}
+
+	message := "Status was never refreshed from qBittorrent"
+	if lastSynced != nil {
+		message = fmt.Sprintf("Status was last refreshed from qBittorrent at %s",
+			lastSynced.UTC().Format(time.RFC3339))
+	}
+	setCondition(&torrent.Status.Conditions, metav1.Condition{
+		Type:    TypeStatusStaleTorrent,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+}
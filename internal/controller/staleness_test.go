@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Status staleness", func() {
+	var torrent *torrentv1beta1.Torrent
+	r := &TorrentReconciler{StatusStaleThreshold: time.Minute}
+
+	BeforeEach(func() {
+		torrent = &torrentv1beta1.Torrent{}
+	})
+
+	It("should record when the status was synced", func() {
+		r.markSynced(torrent)
+		Expect(torrent.Status.LastSyncedTime).NotTo(BeNil())
+		Expect(meta.IsStatusConditionFalse(torrent.Status.Conditions, TypeStatusStaleTorrent)).To(BeTrue())
+	})
+
+	It("should only report the status as stale past the threshold", func() {
+		r.markSynced(torrent)
+		r.markStaleIfExpired(torrent, "FailedToGetTorrentInfo")
+		Expect(meta.IsStatusConditionFalse(torrent.Status.Conditions, TypeStatusStaleTorrent)).To(BeTrue())
+
+		lastSynced := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+		torrent.Status.LastSyncedTime = &lastSynced
+		r.markStaleIfExpired(torrent, "FailedToGetTorrentInfo")
+		condition := meta.FindStatusCondition(torrent.Status.Conditions, TypeStatusStaleTorrent)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("FailedToGetTorrentInfo"))
+	})
+
+	It("should report a status never synced as stale", func() {
+		r.markStaleIfExpired(torrent, "FailedToGetTorrentInfo")
+		Expect(meta.IsStatusConditionTrue(torrent.Status.Conditions, TypeStatusStaleTorrent)).To(BeTrue())
+	})
+})
@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// DefaultStalledFor is how long a download stays stalled before the next
+// fallback tracker of its TorrentPolicy is added
+const DefaultStalledFor = 10 * time.Minute
+
+// stalledRecovery returns the stalled recovery of the TorrentPolicies of the
+// namespace of the torrent, nil if none sets it. As for the defaults, the
+// first policy in name order setting it wins.
+func (r *TorrentReconciler) stalledRecovery(ctx context.Context,
+	torrent *torrentv1beta1.Torrent) (*torrentv1beta1.StalledRecovery, error) {
+	policies := &torrentv1beta1.TorrentPolicyList{}
+	if err := r.List(ctx, policies, client.InNamespace(torrent.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list torrent policies: %w", err)
+	}
+	slices.SortFunc(policies.Items, func(a, b torrentv1beta1.TorrentPolicy) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, policy := range policies.Items {
+		if policy.Spec.StalledRecovery != nil {
+			return policy.Spec.StalledRecovery, nil
+		}
+	}
+	return nil, nil
+}
+
+// nextFallbackTracker returns the first fallback tracker not added yet, empty
+// once all of them were
+func nextFallbackTracker(fallbacks, added []string) string {
+	for _, tracker := range fallbacks {
+		if !slices.Contains(added, tracker) {
+			return tracker
+		}
+	}
+	return ""
+}
+
+// isDownloadResumed reports whether a download stalled before is transferring
+// again, or complete
+func isDownloadResumed(qbTorrent *qbittorrent.TorrentInfo) bool {
+	switch torrentv1beta1.TorrentState(qbTorrent.State) {
+	case torrentv1beta1.TorrentStateDownloading, torrentv1beta1.TorrentStateForcedDL:
+		return true
+	}
+	return qbTorrent.TotalSize > 0 && qbTorrent.AmountLeft == 0
+}
+
+// reconcileTrackerRecovery adds the fallback trackers of the TorrentPolicy to
+// a download stalled for too long, one at a time so that the tracker reviving
+// it is known, and reannounces it. It returns whether the status changed.
+func (r *TorrentReconciler) reconcileTrackerRecovery(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) (bool, error) {
+	logger := log.FromContext(ctx)
+	recovery := torrent.Status.TrackerRecovery
+
+	if torrentv1beta1.TorrentState(qbTorrent.State) != torrentv1beta1.TorrentStateStalledDL {
+		if recovery == nil || recovery.StalledSince == nil {
+			return false, nil
+		}
+
+		// The download resumed, or was stopped
+		recovery = recovery.DeepCopy()
+		if isDownloadResumed(qbTorrent) && recovery.LastAddedTime != nil &&
+			!recovery.LastAddedTime.Before(recovery.StalledSince) {
+			recovery.RevivedBy = recovery.AddedTrackers[len(recovery.AddedTrackers)-1]
+			logger.Info("The stalled download resumed after adding a fallback tracker", "Tracker", recovery.RevivedBy)
+			r.recordEvent(torrent, corev1.EventTypeNormal, "DownloadRevived",
+				"The stalled download resumed after adding the fallback tracker "+recovery.RevivedBy)
+		}
+		recovery.StalledSince = nil
+		torrent.Status.TrackerRecovery = recovery
+		return true, nil
+	}
+
+	// Private torrents only announce to the trackers of their .torrent file
+	if qbTorrent.Private != nil && *qbTorrent.Private {
+		return false, nil
+	}
+	policy, err := r.stalledRecovery(ctx, torrent)
+	if err != nil || policy == nil {
+		return false, err
+	}
+
+	now := metav1.Now()
+	if recovery == nil || recovery.StalledSince == nil {
+		recovery = recovery.DeepCopy()
+		if recovery == nil {
+			recovery = &torrentv1beta1.TrackerRecoveryStatus{}
+		}
+		recovery.StalledSince = &now
+		torrent.Status.TrackerRecovery = recovery
+		return true, nil
+	}
+
+	since := recovery.StalledSince.Time
+	if recovery.LastAddedTime != nil && recovery.LastAddedTime.After(since) {
+		since = recovery.LastAddedTime.Time
+	}
+	if time.Since(since) < durationOr(policy.StalledFor, DefaultStalledFor) {
+		return false, nil
+	}
+	tracker := nextFallbackTracker(policy.FallbackTrackers, recovery.AddedTrackers)
+	if tracker == "" {
+		logger.V(1).Info("The download is stalled and every fallback tracker was added")
+		return false, nil
+	}
+
+	logger.Info("The download is stalled, adding a fallback tracker", "Tracker", tracker)
+	if err := r.qbt(torrent).AddTrackers(ctx, qbTorrent.Hash, []string{tracker}); err != nil {
+		return false, fmt.Errorf("failed to add the fallback tracker: %w", err)
+	}
+	if err := r.qbt(torrent).Reannounce(ctx, qbTorrent.Hash); err != nil {
+		return false, fmt.Errorf("failed to reannounce the torrent: %w", err)
+	}
+	r.recordEvent(torrent, corev1.EventTypeNormal, "FallbackTrackerAdded",
+		"Added the fallback tracker "+tracker+" to the stalled download")
+
+	recovery = recovery.DeepCopy()
+	recovery.AddedTrackers = append(recovery.AddedTrackers, tracker)
+	recovery.LastAddedTime = &now
+	torrent.Status.TrackerRecovery = recovery
+	return true, nil
+}
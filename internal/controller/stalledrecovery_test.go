@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Torrent stalled recovery", func() {
+	const (
+		magnetHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+		tracker1   = "udp://tracker.opentrackr.org:1337/announce"
+		tracker2   = "https://tracker.example.org/announce"
+	)
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var controllerReconciler *TorrentReconciler
+	var key types.NamespacedName
+	var policy *torrentv1beta1.TorrentPolicy
+
+	reconcileTorrent := func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getRecovery := func() *torrentv1beta1.TrackerRecoveryStatus {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent.Status.TrackerRecovery
+	}
+
+	// stallFor makes the download look stalled, or waiting since the last
+	// fallback tracker, for the given time
+	stallFor := func(d time.Duration) {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		since := metav1.NewTime(time.Now().Add(-d))
+		torrent.Status.TrackerRecovery.StalledSince = &since
+		if torrent.Status.TrackerRecovery.LastAddedTime != nil {
+			torrent.Status.TrackerRecovery.LastAddedTime = &since
+		}
+		Expect(k8sClient.Status().Update(ctx, torrent)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		key = types.NamespacedName{Name: "stalled-recovery", Namespace: "default"}
+
+		policy = &torrentv1beta1.TorrentPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "fallback-trackers", Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentPolicySpec{
+				StalledRecovery: &torrentv1beta1.StalledRecovery{
+					FallbackTrackers: []string{tracker1, tracker2},
+					StalledFor:       &metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+		controllerReconciler = &TorrentReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Clients:  poolOf(qb.client()),
+			Recorder: record.NewFakeRecorder(20),
+			Config:   &OperatorConfig{},
+		}
+
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source: torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + magnetHash},
+			},
+		})).To(Succeed())
+		reconcileTorrent()
+		reconcileTorrent()
+		Expect(qb.hashes()).To(Equal([]string{magnetHash}))
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, policy))).To(Succeed())
+	})
+
+	It("should add the fallback trackers one at a time and record the one reviving the download", func() {
+		By("noting when the download stalled")
+		qb.setState(magnetHash, "stalledDL")
+		reconcileTorrent()
+		recovery := getRecovery()
+		Expect(recovery).NotTo(BeNil())
+		Expect(recovery.StalledSince).NotTo(BeNil())
+		Expect(recovery.AddedTrackers).To(BeEmpty())
+
+		By("waiting for stalledFor before adding a tracker")
+		stallFor(time.Minute)
+		reconcileTorrent()
+		Expect(qb.addedTrackers(magnetHash)).To(BeEmpty())
+
+		By("adding the first fallback tracker and reannouncing")
+		stallFor(6 * time.Minute)
+		reconcileTorrent()
+		Expect(qb.addedTrackers(magnetHash)).To(Equal([]string{tracker1}))
+		Expect(qb.callCount("/api/v2/torrents/reannounce")).To(Equal(1))
+		reconcileTorrent()
+		Expect(qb.addedTrackers(magnetHash)).To(Equal([]string{tracker1}))
+
+		By("adding the next one while the download stays stalled")
+		stallFor(6 * time.Minute)
+		reconcileTorrent()
+		Expect(qb.addedTrackers(magnetHash)).To(Equal([]string{tracker1, tracker2}))
+		stallFor(6 * time.Minute)
+		reconcileTorrent()
+		Expect(qb.addedTrackers(magnetHash)).To(Equal([]string{tracker1, tracker2}))
+
+		By("recording the tracker reviving the download")
+		qb.setState(magnetHash, "downloading")
+		reconcileTorrent()
+		recovery = getRecovery()
+		Expect(recovery.StalledSince).To(BeNil())
+		Expect(recovery.AddedTrackers).To(Equal([]string{tracker1, tracker2}))
+		Expect(recovery.RevivedBy).To(Equal(tracker2))
+	})
+
+	It("should leave stalled downloads alone without a policy", func() {
+		Expect(k8sClient.Delete(ctx, policy)).To(Succeed())
+		qb.setState(magnetHash, "stalledDL")
+		reconcileTorrent()
+		Expect(getRecovery()).To(BeNil())
+		Expect(qb.callCount("/api/v2/torrents/addTrackers")).To(BeZero())
+	})
+})
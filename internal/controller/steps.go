@@ -0,0 +1,729 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// torrentStep is a step of the reconcile of a Torrent. Once done, the
+// reconcile returns its result and error without running the next steps.
+type torrentStep func(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error)
+
+// torrentPass is a reconcile of a Torrent, holding what its steps learn
+// for the next ones
+type torrentPass struct {
+	*TorrentReconciler
+
+	// hashes and torrentFile are resolved from the source of the Torrent
+	hashes      qbittorrent.InfoHashes
+	torrentFile []byte
+	// info is the torrent in qBittorrent, nil while missing
+	info *qbittorrent.TorrentInfo
+	// updated is whether the status reflecting the torrent changed
+	updated bool
+	// specHash is the effective spec hash recorded with skipUnchanged
+	specHash string
+}
+
+// next continues the reconcile with the next step
+func next() (bool, ctrl.Result, error) {
+	return false, ctrl.Result{}, nil
+}
+
+// retry ends the reconcile degraded with the error of a step, reconciling
+// again after the retry interval
+func (p *torrentPass) retry(torrent *torrentv1beta1.Torrent, reason string, err error) (bool, ctrl.Result, error) {
+	// Update resource status to reflect the error
+	p.setDegradedCondition(torrent, reason, err.Error())
+
+	// Retry after the retry interval
+	return true, ctrl.Result{RequeueAfter: p.retryInterval()}, nil
+}
+
+// refresh ends the reconcile, reconciling again after the refresh interval
+func (p *torrentPass) refresh() (bool, ctrl.Result, error) {
+	return true, ctrl.Result{RequeueAfter: p.refreshInterval()}, nil
+}
+
+// steps returns the steps of the reconcile, in order
+func (p *torrentPass) steps() []torrentStep {
+	return []torrentStep{
+		p.stepBackend,
+		p.stepResolveSource,
+		p.stepLookup,
+		p.stepRestartGracePeriod,
+		p.stepDependencies,
+		p.stepDiskSpaceBeforeAdd,
+		p.stepCapacity,
+		p.stepAdd,
+		p.stepDiskSpace,
+		p.stepMetadataOnly,
+		p.stepApproval,
+		p.stepRestoreSettings,
+		p.stepOwnership,
+		p.stepMissingFiles,
+		p.stepStatus,
+		p.stepDrift,
+		p.stepDeadline,
+		p.stepPriority,
+		p.stepExcludedFiles,
+		p.stepFiles,
+		p.stepTransfer,
+		p.stepPeers,
+		p.stepWebSeeds,
+		p.stepTrackerRecovery,
+		p.stepThrottle,
+		p.stepBackendAnnotations,
+		p.stepBanPeers,
+		p.stepAction,
+		p.stepLabelTags,
+		p.stepContentVerification,
+		p.stepChecksums,
+		p.stepCrossSeeds,
+		p.stepAvailable,
+	}
+}
+
+// stepBackend points to the QBittorrentServer while qBittorrent rejects the
+// credentials of the operator, the failure is diagnosed there, and waits for
+// the QBittorrentServer to be Connected again, leaving the rest of the status
+// as it was before the outage
+func (p *torrentPass) stepBackend(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	unavailable := p.setBackendCondition(ctx, torrent)
+	serverName := p.serverName(torrent)
+	if err := p.Clients.LoginError(serverName); errors.Is(err, qbittorrent.ErrLoginRejected) {
+		p.setDegradedCondition(torrent, "BackendAuthFailed", fmt.Sprintf(
+			"qBittorrent rejected the credentials of the operator, see the AuthFailed condition of the QBittorrentServer %s",
+			serverName))
+		return true, ctrl.Result{RequeueAfter: p.retryInterval()}, nil
+	}
+	if unavailable {
+		log.FromContext(ctx).Info("QBittorrentServer not Connected, waiting for it", "Server", serverName)
+		return true, ctrl.Result{RequeueAfter: p.retryInterval()}, nil
+	}
+	return next()
+}
+
+// stepResolveSource resolves the hashes and the torrent file of the source
+func (p *torrentPass) stepResolveSource(ctx context.Context,
+	torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	hashes, torrentFile, err := p.resolveSource(ctx, torrent)
+	if err != nil {
+		logger.Error(err, "Failed to get torrent hashes")
+
+		// An invalid source will not become valid on retry, wait for the
+		// spec to change instead of requeueing
+		if isInvalidSource(err) {
+			p.setDegradedCondition(torrent, "InvalidSource", err.Error())
+			return true, ctrl.Result{}, nil
+		}
+		return p.retry(torrent, "FailedToResolveSource", err)
+	}
+	logger.V(1).Info("Torrent hashes", "InfohashV1", hashes.V1, "InfohashV2", hashes.V2)
+
+	p.hashes, p.torrentFile = hashes, torrentFile
+	return next()
+}
+
+// stepLookup finds the torrent in qBittorrent, hybrid torrents may be listed
+// under either hash. The first lookups after the operator starts share a
+// single list of the torrents.
+func (p *torrentPass) stepLookup(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	info, err := p.resolver.lookup(ctx, p.serverName(torrent), p.qbt(torrent), torrent, p.hashes)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to get Torrent info")
+
+		// Update resource status to reflect the error, and whether it is stale
+		p.setDegradedCondition(torrent, "FailedToGetTorrentInfo", err.Error())
+		p.markStaleIfExpired(torrent, "FailedToGetTorrentInfo")
+
+		// Retry after the retry interval
+		return true, ctrl.Result{RequeueAfter: p.retryInterval()}, nil
+	}
+
+	// qBittorrent answered, the status is refreshed below
+	p.markSynced(torrent)
+	p.info = info
+	return next()
+}
+
+// stepRestartGracePeriod waits for a missing torrent after a restart:
+// qBittorrent lists the torrents while loading them, so a missing torrent is
+// not added again until the grace period ends
+func (p *torrentPass) stepRestartGracePeriod(ctx context.Context,
+	torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	if p.info != nil || !p.qbt(torrent).InRestartGracePeriod() {
+		return next()
+	}
+	log.FromContext(ctx).Info("Torrent not found in qBittorrent after a restart, waiting for it to be loaded",
+		"Name", torrent.Name)
+
+	setCondition(&torrent.Status.Conditions, metav1.Condition{
+		Type:    TypeAvailableTorrent,
+		Status:  metav1.ConditionUnknown,
+		Reason:  "BackendRestarted",
+		Message: "qBittorrent restarted, waiting for it to load the torrent before adding it again",
+	})
+
+	return true, ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+}
+
+// stepDependencies holds a missing torrent back until its dependencies are complete
+func (p *torrentPass) stepDependencies(ctx context.Context,
+	torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	if p.info != nil || len(torrent.Spec.DependsOn) == 0 {
+		return next()
+	}
+	pending, err := p.pendingDependencies(ctx, torrent)
+	if errors.Is(err, errDependencyCycle) {
+		return p.retry(torrent, "DependencyCycle", err)
+	}
+	if err != nil {
+		return p.retry(torrent, "FailedToGetDependencies", err)
+	}
+	if len(pending) == 0 {
+		return next()
+	}
+	log.FromContext(ctx).Info("Waiting for the dependencies before adding the Torrent",
+		"Name", torrent.Name, "Pending", pending)
+
+	// Waiting is not a failure, the torrent is not degraded
+	message := "Waiting for the Torrents " + strings.Join(pending, ", ") + " to complete"
+	torrent.Status.Phase = torrentv1beta1.TorrentPhasePending
+	setCondition(&torrent.Status.Conditions, metav1.Condition{
+		Type:    TypeAvailableTorrent,
+		Status:  metav1.ConditionFalse,
+		Reason:  "WaitingForDependencies",
+		Message: message,
+	})
+	setCondition(&torrent.Status.Conditions, metav1.Condition{
+		Type:    TypeDegradedTorrent,
+		Status:  metav1.ConditionFalse,
+		Reason:  "WaitingForDependencies",
+		Message: message,
+	})
+
+	// The dependents are also reconciled as soon as a dependency completes
+	return p.refresh()
+}
+
+// stepDiskSpaceBeforeAdd holds a missing torrent back while its content does
+// not fit on disk. The size of magnets is only known once added, see
+// stepDiskSpace, and the content of the torrents with metadataOnly is not
+// downloaded.
+func (p *torrentPass) stepDiskSpaceBeforeAdd(ctx context.Context,
+	torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	if _, checked := p.diskReserve(); p.info != nil || !checked || p.torrentFile == nil ||
+		torrent.Spec.MetadataOnly {
+		return next()
+	}
+	logger := log.FromContext(ctx)
+
+	file, err := qbittorrent.ParseTorrentFile(p.torrentFile)
+	if err != nil {
+		p.setDegradedCondition(torrent, "InvalidSource", err.Error())
+		return true, ctrl.Result{}, nil
+	}
+	shortfall, err := p.diskSpaceShortfall(ctx, torrent, file.TotalSize)
+	if err != nil {
+		logger.Error(err, "Failed to check the disk space")
+		return p.retry(torrent, "FailedToCheckDiskSpace", err)
+	}
+	if shortfall > 0 {
+		logger.Info("The content of the Torrent does not fit on disk, waiting before adding it",
+			"Name", torrent.Name, "Size", file.TotalSize, "Shortfall", shortfall)
+		p.holdForDiskSpace(torrent, file.TotalSize, shortfall)
+		return p.refresh()
+	}
+	return next()
+}
+
+// stepCapacity holds a missing torrent back while qBittorrent has the
+// maxManagedTorrents of its QBittorrentServer
+func (p *torrentPass) stepCapacity(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	if p.info != nil {
+		return next()
+	}
+	logger := log.FromContext(ctx)
+
+	exceeded, count, limit, err := p.capacityExceeded(ctx, torrent)
+	if err != nil {
+		logger.Error(err, "Failed to check the capacity of qBittorrent")
+		return p.retry(torrent, "FailedToCheckCapacity", err)
+	}
+	if exceeded {
+		logger.Info("qBittorrent has the maxManagedTorrents, waiting before adding the Torrent",
+			"Name", torrent.Name, "Torrents", count, "MaxManagedTorrents", limit)
+		p.holdForCapacity(torrent, count, limit)
+		return p.refresh()
+	}
+	releaseCapacity(torrent)
+	return next()
+}
+
+// stepAdd adds a missing torrent to qBittorrent, or reports it would be
+// added with dry-run
+func (p *torrentPass) stepAdd(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	if p.info != nil {
+		return next()
+	}
+	logger := log.FromContext(ctx)
+
+	if p.qbt(torrent).DryRun() {
+		logger.Info("Dry run, Torrent not found in qBittorrent would be added", "Name", torrent.Name)
+
+		// Report the pending operation without adding the torrent
+		setCondition(&torrent.Status.Conditions, metav1.Condition{
+			Type:    TypeAvailableTorrent,
+			Status:  metav1.ConditionFalse,
+			Reason:  "DryRun",
+			Message: "Torrent not found in qBittorrent, it would be added without dry-run",
+		})
+
+		return p.refresh()
+	}
+	logger.Info("Torrent not found in qBittorrent, adding it", "Name", torrent.Name)
+
+	// A torrent added before is missing, e.g. deleted from the WebUI or
+	// lost while migrating qBittorrent: its settings are captured from
+	// the status to add it again with them. A changed source is a new torrent.
+	if p.hashes.Matches(&qbittorrent.TorrentInfo{Hash: torrent.Status.Hash}) {
+		p.restores.capture(torrent)
+	}
+
+	// Add the Torrent Resource to qBittorrent
+	if err := p.addTorrent(ctx, torrent, p.torrentFile); err != nil {
+		logger.Error(err, "Failed to add Torrent to qBittorrent")
+		return p.retry(torrent, "FailedToAddTorrent", err)
+	}
+
+	// Update status reflecting the torrent info and set the available condition
+	torrent.Status.Phase = torrentv1beta1.TorrentPhasePending
+	p.setAvailableCondition(torrent, "TorrentAdded", "Torrent added to qBittorrent")
+
+	return true, ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+}
+
+// stepDiskSpace stops the torrent while its content does not fit on disk,
+// once qBittorrent knows its size
+func (p *torrentPass) stepDiskSpace(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	held, err := p.reconcileDiskSpace(ctx, torrent, p.info)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to check the disk space")
+		return p.retry(torrent, "FailedToCheckDiskSpace", err)
+	}
+	if !held {
+		return next()
+	}
+
+	// The torrent is reported Pending rather than stopped
+	p.updateTorrentStatus(ctx, torrent, p.info)
+	torrent.Status.Phase = torrentv1beta1.TorrentPhasePending
+	return p.refresh()
+}
+
+// stepMetadataOnly keeps the torrent stopped once its metadata is received,
+// with metadataOnly
+func (p *torrentPass) stepMetadataOnly(ctx context.Context,
+	torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	held, err := p.reconcileMetadataOnly(ctx, torrent, p.info)
+	if err != nil {
+		logger.Error(err, "Failed to hold the torrent with metadataOnly")
+		return p.retry(torrent, "FailedToHoldForMetadata", err)
+	}
+	if !held {
+		return next()
+	}
+
+	// The torrent is reported Pending rather than stopped, with the
+	// files of its metadata
+	p.updateTorrentStatus(ctx, torrent, p.info)
+	torrent.Status.Phase = torrentv1beta1.TorrentPhasePending
+	if hasMetadata(p.info) {
+		if err := p.reconcileExcludedFiles(ctx, torrent, p.info); err != nil {
+			logger.Error(err, "Failed to exclude files")
+			return p.retry(torrent, "FailedToExcludeFiles", err)
+		}
+		if _, err := p.reconcileFiles(ctx, torrent, p.info); err != nil {
+			logger.Error(err, "Failed to reconcile files")
+			return p.retry(torrent, "FailedToGetFiles", err)
+		}
+	}
+	return p.refresh()
+}
+
+// stepApproval keeps the torrent stopped until approved, with approvalRequired
+func (p *torrentPass) stepApproval(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	held, err := p.reconcileApproval(ctx, torrent, p.info)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to hold the torrent until approved")
+		return p.retry(torrent, "FailedToHoldForApproval", err)
+	}
+	if !held {
+		return next()
+	}
+
+	// The torrent is reported Pending rather than stopped, the
+	// annotation triggers a reconcile once approved
+	p.updateTorrentStatus(ctx, torrent, p.info)
+	torrent.Status.Phase = torrentv1beta1.TorrentPhasePending
+	return p.refresh()
+}
+
+// stepRestoreSettings re-applies the trackers and file priorities of a
+// torrent added again
+func (p *torrentPass) stepRestoreSettings(ctx context.Context,
+	torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	if err := p.restoreSettings(ctx, torrent, p.info); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to restore the settings of the torrent")
+		return p.retry(torrent, "FailedToRestoreSettings", err)
+	}
+	return next()
+}
+
+// stepOwnership claims the torrent with the ownership tag, before its
+// addition time is updated in status
+func (p *torrentPass) stepOwnership(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	if err := p.reconcileOwnership(ctx, torrent, p.info); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to claim the torrent")
+		return p.retry(torrent, "FailedToClaimTorrent", err)
+	}
+	return next()
+}
+
+// stepMissingFiles downloads again the files deleted out-of-band, with
+// missingFilesPolicy Redownload, before the status reflects them
+func (p *torrentPass) stepMissingFiles(ctx context.Context,
+	torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	if err := p.reconcileMissingFiles(ctx, torrent, p.info); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to download the missing files")
+		return p.retry(torrent, "FailedToRedownloadMissingFiles", err)
+	}
+	return next()
+}
+
+// stepStatus updates the status reflecting the torrent info. The rest of
+// the reconcile is skipped while neither the effective spec nor the torrent
+// changed since the last full reconcile, with skipUnchanged.
+func (p *torrentPass) stepStatus(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	p.updated = p.updateTorrentStatus(ctx, torrent, p.info)
+	p.transfers.observe(torrent, p.info)
+
+	if skip := p.Config.Spec().SkipUnchanged; skip != nil {
+		p.specHash = effectiveSpecHash(torrent, p.Config.Spec())
+		if p.passes.unchanged(torrent, p.specHash, p.info,
+			durationOr(skip.FullResyncInterval, DefaultFullResyncInterval)) {
+			log.FromContext(ctx).V(1).Info("Neither the spec nor the torrent changed, skipping the reconcile",
+				"Name", torrent.Name)
+			p.setAvailableCondition(torrent, "TorrentActive", "Torrent is active on qBittorrent")
+			return p.refresh()
+		}
+	}
+	// Only a full reconcile reaching its end is skipped after
+	p.passes.forget(torrent)
+	return next()
+}
+
+// stepDrift reverts the drift from the spec, or records it in status
+func (p *torrentPass) stepDrift(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	drift, err := p.reconcileDrift(ctx, torrent, p.info)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to reconcile drift")
+		return p.retry(torrent, "FailedToReconcileDrift", err)
+	}
+	if !slices.Equal(torrent.Status.Drift, drift) {
+		torrent.Status.Drift = drift
+		p.updated = true
+	}
+	return next()
+}
+
+// stepDeadline reports the torrent against its completion deadline,
+// escalating it when close
+func (p *torrentPass) stepDeadline(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	updated, err := p.reconcileDeadline(ctx, torrent, p.info)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to reconcile completion deadline")
+		return p.retry(torrent, "FailedToReconcileDeadline", err)
+	}
+	p.updated = p.updated || updated
+	return next()
+}
+
+// stepPriority ranks the torrent in the queue and preempts its bandwidth by priority
+func (p *torrentPass) stepPriority(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	updated, err := p.reconcilePriority(ctx, torrent, p.info)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to reconcile priority")
+		return p.retry(torrent, "FailedToReconcilePriority", err)
+	}
+	p.updated = p.updated || updated
+	return next()
+}
+
+// stepExcludedFiles skips the files matching excludeFiles
+func (p *torrentPass) stepExcludedFiles(ctx context.Context,
+	torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	if err := p.reconcileExcludedFiles(ctx, torrent, p.info); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to exclude files")
+		return p.retry(torrent, "FailedToExcludeFiles", err)
+	}
+	return next()
+}
+
+// stepFiles reports the progress of the files with statusDetail Files
+func (p *torrentPass) stepFiles(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	updated, err := p.reconcileFiles(ctx, torrent, p.info)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to reconcile files")
+		return p.retry(torrent, "FailedToGetFiles", err)
+	}
+	p.updated = p.updated || updated
+	return next()
+}
+
+// stepTransfer reports the bytes transferred by the torrent, and stops it
+// once its daily transfer budget is consumed
+func (p *torrentPass) stepTransfer(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	previous := torrent.Status.Transfer
+	transferUpdated, err := p.reconcileTransfer(ctx, torrent, p.info)
+	if err != nil {
+		logger.Error(err, "Failed to reconcile transfer")
+		return p.retry(torrent, "FailedToGetProperties", err)
+	}
+	budgetUpdated, err := p.reconcileTransferBudget(ctx, torrent, p.info, previous)
+	if err != nil {
+		logger.Error(err, "Failed to enforce the daily transfer budget")
+		return p.retry(torrent, "FailedToEnforceTransferBudget", err)
+	}
+	p.updated = p.updated || transferUpdated || budgetUpdated
+	return next()
+}
+
+// stepPeers summarizes the peers with reportPeers
+func (p *torrentPass) stepPeers(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	updated, err := p.reconcilePeers(ctx, torrent, p.info)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to reconcile peers")
+		return p.retry(torrent, "FailedToGetPeers", err)
+	}
+	p.updated = p.updated || updated
+	return next()
+}
+
+// stepWebSeeds adds the web seeds of the spec
+func (p *torrentPass) stepWebSeeds(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	updated, err := p.reconcileWebSeeds(ctx, torrent, p.info)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to reconcile web seeds")
+		return p.retry(torrent, "FailedToSetWebSeeds", err)
+	}
+	p.updated = p.updated || updated
+	return next()
+}
+
+// stepTrackerRecovery adds the fallback trackers of the TorrentPolicy to a
+// stalled download
+func (p *torrentPass) stepTrackerRecovery(ctx context.Context,
+	torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	updated, err := p.reconcileTrackerRecovery(ctx, torrent, p.info)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to recover the stalled download")
+		return p.retry(torrent, "FailedToAddFallbackTracker", err)
+	}
+	p.updated = p.updated || updated
+	return next()
+}
+
+// stepThrottle throttles the torrent while the node network is saturated.
+// It is the last step updating the status reflecting the torrent info.
+func (p *torrentPass) stepThrottle(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	updated, err := p.reconcileThrottle(ctx, torrent, p.info)
+	if err != nil {
+		logger.Error(err, "Failed to throttle the torrent")
+		return p.retry(torrent, "FailedToThrottleTorrent", err)
+	}
+	p.updated = p.updated || updated
+	if p.updated {
+		logger.Info("Updating status reflecting the torrent info", "Name", torrent.Name)
+	}
+	return next()
+}
+
+// stepBackendAnnotations surfaces the backend tags, category and content as annotations
+func (p *torrentPass) stepBackendAnnotations(ctx context.Context,
+	torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	if !p.updateBackendAnnotations(torrent, p.info) {
+		return next()
+	}
+	logger := log.FromContext(ctx)
+
+	logger.Info("Updating annotations reflecting the backend torrent", "Name", torrent.Name)
+	if err := p.updateMetadata(ctx, torrent); err != nil {
+		logger.Error(err, "Failed to update Torrent annotations")
+		return true, ctrl.Result{}, err
+	}
+	return next()
+}
+
+// stepBanPeers bans the peers of the ban-peers annotation
+func (p *torrentPass) stepBanPeers(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	banned, err := p.banAnnotatedPeers(ctx, torrent)
+	if err != nil {
+		logger.Error(err, "Failed to ban peers")
+		return p.retry(torrent, "FailedToBanPeers", err)
+	}
+	if banned {
+		if err := p.updateMetadata(ctx, torrent); err != nil {
+			logger.Error(err, "Failed to remove the ban-peers annotation")
+			return true, ctrl.Result{}, err
+		}
+	}
+	return next()
+}
+
+// stepAction pauses, resumes or rechecks the torrent on request
+func (p *torrentPass) stepAction(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	acted, err := p.runAnnotatedAction(ctx, torrent, p.info)
+	if err != nil {
+		logger.Error(err, "Failed to run the annotated action")
+		return p.retry(torrent, "FailedToRunAction", err)
+	}
+	if acted {
+		if err := p.updateMetadata(ctx, torrent); err != nil {
+			logger.Error(err, "Failed to remove the action annotation")
+			return true, ctrl.Result{}, err
+		}
+	}
+	return next()
+}
+
+// stepLabelTags mirrors the selected labels into qBittorrent tags
+func (p *torrentPass) stepLabelTags(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	if len(p.LabelTagKeys) == 0 {
+		return next()
+	}
+	if err := p.syncLabelTags(ctx, torrent, p.info); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to sync label tags")
+		return p.retry(torrent, "FailedToSyncLabelTags", err)
+	}
+	return next()
+}
+
+// stepContentVerification verifies the content is on the volume once
+// complete, before the torrent is Available and its content is used
+func (p *torrentPass) stepContentVerification(ctx context.Context,
+	torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	if torrent.Spec.ContentVerification == nil || !torrent.Spec.ContentVerification.Enabled {
+		return next()
+	}
+	verified, err := p.reconcileContentVerification(ctx, torrent, p.info)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to verify the content")
+		torrent.Status.Phase = torrentv1beta1.TorrentPhaseError
+		return p.retry(torrent, "ContentVerificationFailed", err)
+	}
+	if verified {
+		return next()
+	}
+
+	// Verifying is not a failure, the torrent is not degraded. The Job is
+	// owned by the Torrent, its completion triggers a reconcile.
+	message := "Verifying the content of the torrent on the volume"
+	torrent.Status.Phase = torrentv1beta1.TorrentPhaseDownloading
+	setCondition(&torrent.Status.Conditions, metav1.Condition{
+		Type:    TypeAvailableTorrent,
+		Status:  metav1.ConditionFalse,
+		Reason:  "VerifyingContent",
+		Message: message,
+	})
+	setCondition(&torrent.Status.Conditions, metav1.Condition{
+		Type:    TypeDegradedTorrent,
+		Status:  metav1.ConditionFalse,
+		Reason:  "VerifyingContent",
+		Message: message,
+	})
+	return p.refresh()
+}
+
+// stepChecksums publishes checksums once the content is complete
+func (p *torrentPass) stepChecksums(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	if torrent.Spec.Checksums == nil || !torrent.Spec.Checksums.Enabled ||
+		torrent.Status.ChecksumConfigMap != "" || !isTorrentComplete(p.info) {
+		return next()
+	}
+	logger := log.FromContext(ctx)
+
+	published, err := p.reconcileChecksums(ctx, torrent)
+	if err != nil {
+		logger.Error(err, "Failed to publish checksums")
+		return p.retry(torrent, "FailedToPublishChecksums", err)
+	}
+	if !published {
+		// The checksum Job is owned by the Torrent, its completion triggers a reconcile
+		logger.V(1).Info("Waiting for checksums to be computed", "Name", torrent.Name)
+	}
+	return next()
+}
+
+// stepCrossSeeds cross-seeds the content once it is complete
+func (p *torrentPass) stepCrossSeeds(ctx context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	if (len(torrent.Spec.CrossSeed) == 0 && len(torrent.Status.CrossSeeds) == 0) || !isTorrentComplete(p.info) {
+		return next()
+	}
+	if err := p.reconcileCrossSeeds(ctx, torrent, p.info); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to reconcile cross-seeds")
+		return p.retry(torrent, "FailedToCrossSeed", err)
+	}
+	return next()
+}
+
+// stepAvailable sets the success condition, written with the rest of the
+// status, and records the pass with skipUnchanged
+func (p *torrentPass) stepAvailable(_ context.Context, torrent *torrentv1beta1.Torrent) (bool, ctrl.Result, error) {
+	p.setAvailableCondition(torrent, "TorrentActive", "Torrent is active on qBittorrent")
+	torrent.Status.SpecHash = p.specHash
+	if p.specHash != "" && passSettled(torrent, p.info) {
+		p.passes.record(torrent, p.specHash, p.info)
+	}
+	return next()
+}
@@ -32,7 +32,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
-	torrentv1alpha1 "github.com/guidonguido/qbittorrent-operator/api/v1alpha1"
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -59,7 +59,7 @@ var _ = BeforeSuite(func() {
 	ctx, cancel = context.WithCancel(context.TODO())
 
 	var err error
-	err = torrentv1alpha1.AddToScheme(scheme.Scheme)
+	err = torrentv1beta1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
 	// +kubebuilder:scaffold:scheme
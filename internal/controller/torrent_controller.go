@@ -18,25 +18,123 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
-	torrentv1alpha1 "github.com/guidonguido/qbittorrent-operator/api/v1alpha1"
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/clientpool"
 	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
 )
 
 // TorrentReconciler reconciles a Torrent object
 type TorrentReconciler struct {
 	client.Client
-	Scheme    *runtime.Scheme
-	QBTClient *qbittorrent.Client
+	Scheme *runtime.Scheme
+	// Clients has the qBittorrent client of each QBittorrentServer
+	Clients *clientpool.Pool
+	// ServerName is the name of the QBittorrentServer of the Torrents without
+	// spec.serverName, DefaultServerName if empty
+	ServerName string
+	// Connect adds the client of a QBittorrentServer missing from the pool.
+	// The Torrents of a server without a client fail when nil.
+	Connect func(ctx context.Context, name string) error
+	// Clientset is used for API calls not supported by the controller-runtime
+	// client, such as reading pod logs
+	Clientset kubernetes.Interface
+	// Recorder records the Events of the Torrents
+	Recorder record.EventRecorder
+
+	// LabelTagKeys are the label keys mirrored into qBittorrent tags,
+	// prefixed with LabelTagPrefix. Label sync is disabled when either is empty.
+	LabelTagKeys   []string
+	LabelTagPrefix string
+
+	// IsolateNamespaces prefixes the categories and the label tags with the
+	// namespace of the Torrent, for tenants sharing the qBittorrent server
+	IsolateNamespaces bool
+	// SavePathRoot confines the save paths to a folder per namespace under it,
+	// with IsolateNamespaces. The save paths are not confined when empty.
+	SavePathRoot string
+
+	// LowPriorityDownloadLimit is the download limit, in bytes per second, of
+	// the low priority torrents while high priority ones are downloading.
+	// Preemption is disabled when 0.
+	LowPriorityDownloadLimit int64
+
+	// DeletionRetryTimeout bounds how long the deletion from qBittorrent of a
+	// deleted Torrent is retried, before its finalizer is removed anyway.
+	// Retried forever when 0.
+	DeletionRetryTimeout time.Duration
+	// DeletionBatchWindow is how long the deletions from qBittorrent are
+	// gathered before being made together. Each Torrent is deleted on its
+	// own when 0.
+	DeletionBatchWindow time.Duration
+
+	// StatusStaleThreshold is how long the status may go without being
+	// refreshed from qBittorrent before the StatusStale condition is set.
+	// DefaultStatusStaleThreshold is used when 0.
+	StatusStaleThreshold time.Duration
+
+	// MaxConcurrentReconciles is the number of Torrents reconciled at once,
+	// one while qBittorrent is saturated or failing. One when 0.
+	MaxConcurrentReconciles int
+
+	// Shard restricts the Torrents reconciled to the ones of this replica,
+	// when the Torrents are sharded across replicas
+	Shard Shard
+
+	// BatchNamespaceDeletion deletes the Torrents of a terminating namespace
+	// from qBittorrent together, watching the Namespaces. Reading the
+	// cluster-scoped Namespaces is not allowed when restricted to namespaces.
+	BatchNamespaceDeletion bool
+	// ServerConditions mirrors the Connected condition of the QBittorrentServer
+	// into the BackendUnavailable condition of the Torrents, watching it. The
+	// QBittorrentServer is not reported on when restricted to namespaces.
+	ServerConditions bool
+
+	// Config is the QBittorrentOperatorConfig applied, overriding the
+	// settings above. Nil leaves them to the flags.
+	Config *OperatorConfig
+
+	// transfers feeds the per-namespace transfer metrics
+	transfers transferTracker
+	// requeues spreads the requeues of the Torrents over time
+	requeues requeueSpreader
+	// pressure samples the node network for the throttling of the Torrents
+	pressure networkPressure
+	// passes remembers the full reconciles, for skipUnchanged
+	passes fullPassTracker
+	// namespaceDeletions serializes the deletions of terminating namespaces
+	namespaceDeletions namespaceDeletions
+	// deletions gathers the deletions from qBittorrent, with DeletionBatchWindow
+	deletions deletionQueue
+	// restores keeps the settings of the torrents added again until re-applied
+	restores settingsRestorer
+	// resolver answers the first lookups of the Torrents from a shared list
+	resolver startupResolver
+	// backpressure reduces the reconciles while qBittorrent is saturated
+	backpressure backpressure
 }
 
 // Conditions pattern
@@ -53,6 +151,33 @@ const (
 // Finalizer name for cleanup
 const TorrentFinalizer = "torrent.qbittorrent.io/finalizer"
 
+// AnnotationReconcile set to ReconcileDisabled makes the controller leave the
+// Torrent and qBittorrent untouched, deleting the Torrent only removes the resource
+const (
+	AnnotationReconcile = "qbittorrent.io/reconcile"
+	ReconcileDisabled   = "disabled"
+)
+
+// AnnotationForceRelease set to "true" on a deleted Torrent removes its
+// finalizer right away, leaving the torrent on qBittorrent
+const AnnotationForceRelease = "qbittorrent.io/force-release"
+
+// Annotations reflecting the tags and category set on qBittorrent
+const (
+	AnnotationBackendTags     = "torrent.qbittorrent.io/tags"
+	AnnotationBackendCategory = "torrent.qbittorrent.io/category"
+)
+
+// Annotations for the import scripts of Sonarr, Radarr or autobrr, which
+// know a download by its hash, in upper case like their download IDs, its
+// original name and where it ends up. The content path is only set once
+// complete, as it may still move until then.
+const (
+	AnnotationBackendHash        = "torrent.qbittorrent.io/hash"
+	AnnotationBackendName        = "torrent.qbittorrent.io/original-name"
+	AnnotationBackendContentPath = "torrent.qbittorrent.io/content-path"
+)
+
 // RBAC rules for the controller
 // Allow the controller to manage the Torrent resource
 // +kubebuilder:rbac:groups=torrent.qbittorrent.io,resources=torrents,verbs=get;list;watch;create;update;patch;delete
@@ -60,6 +185,15 @@ const TorrentFinalizer = "torrent.qbittorrent.io/finalizer"
 // +kubebuilder:rbac:groups=torrent.qbittorrent.io,resources=torrents/status,verbs=get;update;patch
 // Allow the controller to manage the Torrent finalizers
 // +kubebuilder:rbac:groups=torrent.qbittorrent.io,resources=torrents/finalizers,verbs=update
+// Allow the controller to run checksum Jobs and publish their results
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update
+// Allow the controller to delete the Torrents of terminating namespaces together
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// Allow the controller to record Events
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -71,16 +205,52 @@ const TorrentFinalizer = "torrent.qbittorrent.io/finalizer"
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.21.0/pkg/reconcile
 func (r *TorrentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	// While the qBittorrent of the Torrent is saturated or failing, the
+	// Torrents are reconciled one at a time and requeued later instead of
+	// failing at once
+	server := r.requestServerName(ctx, req)
+	delay := r.backpressureDelay(server)
+	if !r.backpressure.admit(delay) {
+		log.FromContext(ctx).V(1).Info("qBittorrent saturated, delaying Torrent", "Request", req, "delay", delay)
+		return ctrl.Result{RequeueAfter: jitter(delay)}, nil
+	}
+	defer r.backpressure.done()
+
+	result, err := r.reconcileRequest(ctx, req)
+	if delay := r.backpressureDelay(server); err == nil && result.RequeueAfter > 0 && result.RequeueAfter < delay {
+		result.RequeueAfter = delay
+	}
+
+	// Torrents requeued with the same delay would reach qBittorrent together
+	result.RequeueAfter = r.requeues.delay(req.NamespacedName, result.RequeueAfter)
+	return result, err
+}
+
+func (r *TorrentReconciler) reconcileRequest(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Reconciling Torrent", "Request", req)
 
+	// The qBittorrent calls are audited as made for this Torrent
+	ctx = qbittorrent.WithAuditObject(ctx, "Torrent "+req.String())
+
 	// Step 1: Get the Torrent Resource
-	torrent := &torrentv1alpha1.Torrent{}
+	torrent := &torrentv1beta1.Torrent{}
 	if err := r.Get(ctx, req.NamespacedName, torrent); err != nil {
 		logger.Error(err, "Failed to get Torrent")
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Step 1.1: Skip the Torrent while its reconciliation is disabled
+	if torrent.Annotations[AnnotationReconcile] == ReconcileDisabled {
+		return r.handleReconcileDisabled(ctx, torrent)
+	}
+
+	// Step 1.2: Log in to qBittorrent, unless logged in already
+	if err := r.login(ctx, torrent); err != nil {
+		logger.Error(err, "Failed to get the qBittorrent client")
+		return ctrl.Result{}, err
+	}
+
 	// Step 2: Check if the Torrent Resource is marked for deletion
 	if !torrent.DeletionTimestamp.IsZero() {
 		// Step 2.1: Delete the Torrent Resource from qBittorrent
@@ -102,32 +272,153 @@ func (r *TorrentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	return r.reconcile(ctx, torrent)
 }
 
-func (r *TorrentReconciler) handleDeletion(ctx context.Context, torrent *torrentv1alpha1.Torrent) (ctrl.Result, error) {
+// handleReconcileDisabled only lets a Torrent marked for deletion go,
+// without deleting it from qBittorrent
+func (r *TorrentReconciler) handleReconcileDisabled(ctx context.Context,
+	torrent *torrentv1beta1.Torrent) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if torrent.DeletionTimestamp.IsZero() || !controllerutil.ContainsFinalizer(torrent, TorrentFinalizer) {
+		logger.Info("Reconciliation disabled, skipping Torrent", "Name", torrent.Name)
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("Reconciliation disabled, removing finalizer without deleting Torrent from qBittorrent",
+		"Name", torrent.Name)
+	r.transfers.forget(torrent)
+	r.requeues.forget(client.ObjectKeyFromObject(torrent))
+	r.passes.forget(torrent)
+	r.restores.forget(torrent)
+	r.resolver.forget(torrent)
+	controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+	if err := r.Update(ctx, torrent); err != nil {
+		logger.Error(err, "Failed to remove finalizer")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *TorrentReconciler) handleDeletion(ctx context.Context, torrent *torrentv1beta1.Torrent) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Handling Torrent Deletion", "Name", torrent.Name)
 
-	// Step 2.2: Delete the Torrent Resource from qBittorrent
-	if torrent.Status.Hash != "" {
-		logger.Info("Deleting Torrent from qBittorrent", "Name", torrent.Name)
+	// Step 2.0: Delete the Torrents of a terminating namespace together,
+	// falling back to deleting the Torrent on its own
+	if r.BatchNamespaceDeletion {
+		deleted, err := r.deleteNamespaceTorrents(ctx, torrent)
+		if err != nil {
+			logger.Error(err, "Failed to delete the Torrents of the terminating namespace together")
+		}
+		if deleted {
+			return ctrl.Result{}, nil
+		}
+	}
 
-		// Delete the Torrent Resource from qBittorrent and delete the files by default
-		if err := r.QBTClient.DeleteTorrent(ctx, torrent.Status.Hash, true); err != nil {
-			logger.Error(err, "Failed to delete Torrent from qBittorrent")
+	// Step 2.1.1: Let the Torrent go without deleting it from qBittorrent
+	// when its finalizer is force-released
+	if torrent.Annotations[AnnotationForceRelease] == "true" {
+		return r.releaseFinalizer(ctx, torrent,
+			"Finalizer force-released, the torrent is left on qBittorrent")
+	}
+
+	// Step 2.2: Leave qBittorrent untouched when the torrent is orphaned
+	orphan := r.deletionPolicy(torrent) == torrentv1beta1.DeletionPolicyOrphan
+	if orphan {
+		logger.Info("Deletion policy is Orphan, keeping Torrent in qBittorrent", "Name", torrent.Name)
+	}
+
+	// Step 2.2.1: Hold the deletion while the torrent is protected
+	if !orphan {
+		held, err := r.holdDeletion(ctx, torrent)
+		if err != nil {
+			logger.Error(err, "Failed to check the deletion protection")
+
+			// Retry until the deletion retry timeout, then let the Torrent go
+			return r.deletionFailed(ctx, torrent, "FailedToCheckDeletionProtection", err)
+		}
+		if held {
+			// Check again after the refresh interval
+			return ctrl.Result{RequeueAfter: r.refreshInterval()}, nil
+		}
+	}
+
+	// Step 2.2.2: Hold the deletion of the files of a torrent the Torrent does not own
+	if !orphan {
+		held, err := r.holdForOwnership(ctx, torrent)
+		if err != nil {
+			logger.Error(err, "Failed to check the ownership of the torrent")
 
-			// Update resource status to reflect the error
-			r.setDegradedCondition(torrent, "FailedToDeleteTorrent", err.Error())
-			if err := r.Status().Update(ctx, torrent); err != nil {
-				logger.Error(err, "Failed to update Torrent status")
+			// Retry until the deletion retry timeout, then let the Torrent go
+			return r.deletionFailed(ctx, torrent, "FailedToCheckOwnership", err)
+		}
+		if held {
+			// Check again after the refresh interval
+			return ctrl.Result{RequeueAfter: r.refreshInterval()}, nil
+		}
+	}
+
+	// Step 2.2.3: Keep the files hardlinked elsewhere, with hardlinkCheck
+	keepFiles := false
+	if !orphan {
+		var held bool
+		var err error
+		held, keepFiles, err = r.holdForHardlinks(ctx, torrent)
+		if err != nil {
+			logger.Error(err, "Failed to check the hardlinks of the files")
+
+			// Retry until the deletion retry timeout, then let the Torrent go
+			return r.deletionFailed(ctx, torrent, "FailedToCheckHardlinks", err)
+		}
+		if held {
+			// The Job triggers a reconcile once done
+			return ctrl.Result{RequeueAfter: r.refreshInterval()}, nil
+		}
+	}
+
+	// Step 2.3: Delete the cross-seeds, sharing the content of the Torrent Resource
+	if !orphan {
+		if err := r.deleteCrossSeeds(ctx, torrent); err != nil {
+			logger.Error(err, "Failed to delete cross-seeds from qBittorrent")
+
+			// Retry until the deletion retry timeout, then let the Torrent go
+			return r.deletionFailed(ctx, torrent, "FailedToDeleteCrossSeed", err)
+		}
+	}
+
+	// Step 2.4: Delete the Torrent Resource from qBittorrent
+	if torrent.Status.Hash != "" && !orphan {
+		deleteFiles := r.deletionPolicy(torrent) != torrentv1beta1.DeletionPolicyKeepFiles && !keepFiles
+		logger.Info("Deleting Torrent from qBittorrent", "Name", torrent.Name, "DeleteFiles", deleteFiles)
+
+		// Delete the Torrent Resource from qBittorrent, with the files unless KeepFiles is set
+		// or they are hardlinked elsewhere.
+		// With a batch window, it is deleted along with the other deleted Torrents.
+		var err error
+		if r.DeletionBatchWindow > 0 {
+			var deleted bool
+			if deleted, err = r.deletions.delete(r.serverName(torrent), torrent.Status.Hash, deleteFiles); !deleted {
+				logger.Info("Torrent queued for deletion from qBittorrent", "Name", torrent.Name)
+				return ctrl.Result{RequeueAfter: r.DeletionBatchWindow}, nil
 			}
+		} else {
+			err = r.backend(torrent).Remove(ctx, torrent.Status.Hash, deleteFiles)
+		}
+		if err != nil {
+			logger.Error(err, "Failed to delete Torrent from qBittorrent")
 
-			// Retry after 10 seconds
-			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+			// Retry until the deletion retry timeout, then let the Torrent go
+			return r.deletionFailed(ctx, torrent, "FailedToDeleteTorrent", err)
 		}
 		logger.Info("Successfully deleted Torrent from qBittorrent", "Name", torrent.Name)
 	}
 
 	// Remove the finalizer from the Torrent Resource
 	// so that kubernetes can delete the resource
+	r.transfers.forget(torrent)
+	r.requeues.forget(client.ObjectKeyFromObject(torrent))
+	r.passes.forget(torrent)
+	r.restores.forget(torrent)
+	r.resolver.forget(torrent)
 	controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
 	if err := r.Update(ctx, torrent); err != nil {
 		logger.Error(err, "Failed to remove finalizer")
@@ -138,114 +429,131 @@ func (r *TorrentReconciler) handleDeletion(ctx context.Context, torrent *torrent
 	return ctrl.Result{}, nil
 }
 
-func (r *TorrentReconciler) reconcile(ctx context.Context, torrent *torrentv1alpha1.Torrent) (ctrl.Result, error) {
-	logger := log.FromContext(ctx)
-	logger.Info("Reconciling Torrent", "Name", torrent.Name)
-
-	logger.V(1).Info("Getting torrent hash from magnet URI", "MagnetURI", torrent.Spec.MagnetURI)
-	hash, err := qbittorrent.GetTorrentHash(torrent.Spec.MagnetURI)
-	if err != nil {
-		logger.Error(err, "Failed to get torrent hash")
-		return ctrl.Result{}, err
+// reconcile brings qBittorrent to the spec of the Torrent. The changes to the
+// status made along the way are written at once at the end of the pass,
+// whichever step it stopped at, with the reconcile stats.
+func (r *TorrentReconciler) reconcile(ctx context.Context, torrent *torrentv1beta1.Torrent) (ctrl.Result, error) {
+	original := torrent.Status.DeepCopy()
+	result, err := r.reconcileTorrent(ctx, torrent)
+	recordReconcile(torrent, err)
+	if statusErr := r.writeStatus(ctx, torrent, original); statusErr != nil && err == nil {
+		return ctrl.Result{}, statusErr
 	}
-	logger.V(1).Info("Torrent hash", "Hash", hash)
-
-	// Step 4.1: Check if the Torrent Resource exists in qBittorrent
-	torrentInfo, err := r.QBTClient.GetTorrentInfo(ctx, hash)
-	if err != nil {
-		logger.Error(err, "Failed to get Torrent info")
-
-		// Update resource status to reflect the error
-		r.setDegradedCondition(torrent, "FailedToGetTorrentInfo", err.Error())
-		if err := r.Status().Update(ctx, torrent); err != nil {
-			logger.Error(err, "Failed to update Torrent status")
-		}
+	return result, err
+}
 
-		// Retry after 10 seconds
-		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+// writeStatus writes the status of the Torrent if it changed from the original one
+func (r *TorrentReconciler) writeStatus(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	original *torrentv1beta1.TorrentStatus) error {
+	if equality.Semantic.DeepEqual(original, &torrent.Status) {
+		return nil
 	}
+	if err := r.Status().Update(ctx, torrent); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update Torrent status")
+		return err
+	}
+	return nil
+}
 
-	// Step 4.2: Check if the Torrent Resource exists in qBittorrent
-	if torrentInfo == nil {
-		logger.Info("Torrent not found in qBittorrent, adding it", "Name", torrent.Name)
-
-		// Add the Torrent Resource to qBittorrent
-		if err := r.QBTClient.AddTorrent(ctx, torrent.Spec.MagnetURI); err != nil {
-			logger.Error(err, "Failed to add Torrent to qBittorrent")
+// updateMetadata updates the Torrent, keeping the changes to its status not
+// written yet. The API server answers with the stored status.
+func (r *TorrentReconciler) updateMetadata(ctx context.Context, torrent *torrentv1beta1.Torrent) error {
+	status := torrent.Status.DeepCopy()
+	err := r.Update(ctx, torrent)
+	torrent.Status = *status
+	return err
+}
 
-			// Update resource status to reflect the error
-			r.setDegradedCondition(torrent, "FailedToAddTorrent", err.Error())
-			if err := r.Status().Update(ctx, torrent); err != nil {
-				logger.Error(err, "Failed to update Torrent status")
-			}
+// reconcileTorrent runs the steps of the reconcile of the Torrent in order,
+// until one of them is done
+func (r *TorrentReconciler) reconcileTorrent(ctx context.Context, torrent *torrentv1beta1.Torrent) (ctrl.Result, error) {
+	log.FromContext(ctx).Info("Reconciling Torrent", "Name", torrent.Name)
 
-			// Retry after 10 seconds
-			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	pass := &torrentPass{TorrentReconciler: r}
+	for _, step := range pass.steps() {
+		if done, result, err := step(ctx, torrent); done {
+			return result, err
 		}
+	}
 
-		// Step 4.3: Update status reflecting the torrent info and set the available condition
-		r.setAvailableCondition(torrent, "TorrentAdded", "Torrent added to qBittorrent")
-		if err := r.Status().Update(ctx, torrent); err != nil {
-			logger.Error(err, "Failed to update Torrent status")
-		}
+	// Return success and requeue after the refresh interval
+	return ctrl.Result{RequeueAfter: r.refreshInterval()}, nil
+}
 
-		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+// addTorrentOptions returns the qBittorrent add parameters matching the torrent spec
+func (r *TorrentReconciler) addTorrentOptions(torrent *torrentv1beta1.Torrent) (qbittorrent.AddTorrentOptions, error) {
+	savePath, err := r.backendSavePath(torrent)
+	if err != nil {
+		return qbittorrent.AddTorrentOptions{}, err
 	}
+	opts := qbittorrent.AddTorrentOptions{
+		SavePath: savePath,
+		Category: r.backendCategory(torrent),
+		Stopped:  awaitingApproval(torrent),
+	}
+	addStopCondition(torrent, &opts)
 
-	// Step 4.3: Update status reflecting the torrent info
-	updated := r.updateTorrentStatus(ctx, torrent, torrentInfo)
-
-	if updated {
-		logger.Info("Updating status reflecting the torrent info", "Name", torrent.Name)
-		if err := r.Status().Update(ctx, torrent); err != nil {
-			logger.Error(err, "Failed to update Torrent status")
-			return ctrl.Result{}, err
+	limits := torrent.Spec.Limits
+	if limits == nil {
+		return opts, nil
+	}
+	if limits.DownloadLimit != nil {
+		opts.DownloadLimit = *limits.DownloadLimit
+	}
+	if limits.UploadLimit != nil {
+		opts.UploadLimit = *limits.UploadLimit
+	}
+	if limits.RatioLimit != "" {
+		ratio, err := strconv.ParseFloat(limits.RatioLimit, 64)
+		if err != nil {
+			return opts, fmt.Errorf("invalid ratio limit %q: %w", limits.RatioLimit, err)
 		}
+		opts.RatioLimit = &ratio
 	}
-
-	// Step 4.4: Set success condition
-	// Update resource status to reflect the success
-	r.setAvailableCondition(torrent, "TorrentActive", "Torrent is active on qBittorrent")
-	if err := r.Status().Update(ctx, torrent); err != nil {
-		logger.Error(err, "Failed to update Torrent status")
+	if limits.SeedingTimeLimit != nil {
+		opts.SeedingTimeLimit = &limits.SeedingTimeLimit.Duration
 	}
 
-	// Step 4.5: Return success and requeue after 30 seconds
-	return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	return opts, nil
 }
 
-// set the Degraded condition to True
-func (r *TorrentReconciler) setDegradedCondition(torrent *torrentv1alpha1.Torrent, reason, message string) {
-	condition := metav1.Condition{
-		Type:               TypeDegradedTorrent,
-		Status:             metav1.ConditionTrue,
-		Reason:             reason,
-		Message:            message,
-		LastTransitionTime: metav1.NewTime(time.Now()),
+// isTorrentComplete reports whether all the torrent content has been downloaded
+func isTorrentComplete(qbTorrent *qbittorrent.TorrentInfo) bool {
+	switch torrentv1beta1.TorrentState(qbTorrent.State) {
+	case torrentv1beta1.TorrentStateCheckingDL, torrentv1beta1.TorrentStateCheckingUP,
+		torrentv1beta1.TorrentStateCheckingResumeData, torrentv1beta1.TorrentStateMetaDL,
+		torrentv1beta1.TorrentStateMoving, torrentv1beta1.TorrentStateMissingFiles,
+		torrentv1beta1.TorrentStateError:
+		return false
 	}
-	meta.SetStatusCondition(&torrent.Status.Conditions, condition)
+	return qbTorrent.TotalSize > 0 && qbTorrent.AmountLeft == 0
+}
 
-	// Remove available condition if it exists
-	meta.RemoveStatusCondition(&torrent.Status.Conditions, TypeAvailableTorrent)
+// set the Degraded condition to True, and the Available condition to False,
+// recording a Degraded Event when the torrent becomes degraded
+func (r *TorrentReconciler) setDegradedCondition(torrent *torrentv1beta1.Torrent, reason, message string) {
+	if !meta.IsStatusConditionTrue(torrent.Status.Conditions, TypeDegradedTorrent) {
+		r.recordEvent(torrent, corev1.EventTypeWarning, "Degraded", reason+": "+message)
+	}
+	setHealthConditions(&torrent.Status.Conditions, TypeAvailableTorrent, TypeDegradedTorrent,
+		false, reason, message)
 }
 
-// set the Available condition to True
-func (r *TorrentReconciler) setAvailableCondition(torrent *torrentv1alpha1.Torrent, reason, message string) {
-	condition := metav1.Condition{
-		Type:               TypeAvailableTorrent,
-		Status:             metav1.ConditionTrue,
-		Reason:             reason,
-		Message:            message,
-		LastTransitionTime: metav1.NewTime(time.Now()),
+// recordEvent records an Event of the torrent, throttled by the recorder
+func (r *TorrentReconciler) recordEvent(torrent *torrentv1beta1.Torrent, eventtype, reason, message string) {
+	if r.Recorder != nil {
+		r.Recorder.Event(torrent, eventtype, reason, message)
 	}
-	meta.SetStatusCondition(&torrent.Status.Conditions, condition)
+}
 
-	// Remove degraded condition if it exists
-	meta.RemoveStatusCondition(&torrent.Status.Conditions, TypeDegradedTorrent)
+// set the Available condition to True, and the Degraded condition to False
+func (r *TorrentReconciler) setAvailableCondition(torrent *torrentv1beta1.Torrent, reason, message string) {
+	setHealthConditions(&torrent.Status.Conditions, TypeAvailableTorrent, TypeDegradedTorrent,
+		true, reason, message)
 }
 
 // updateTorrentStatus updates the torrent status from qBittorrent data
-func (r *TorrentReconciler) updateTorrentStatus(ctx context.Context, torrent *torrentv1alpha1.Torrent, qbTorrent *qbittorrent.TorrentInfo) bool {
+func (r *TorrentReconciler) updateTorrentStatus(ctx context.Context, torrent *torrentv1beta1.Torrent, qbTorrent *qbittorrent.TorrentInfo) bool {
 	logger := log.FromContext(ctx)
 	updated := false
 
@@ -260,11 +568,24 @@ func (r *TorrentReconciler) updateTorrentStatus(ctx context.Context, torrent *to
 		updated = true
 	}
 
-	if torrent.Status.State != qbTorrent.State {
+	if state := torrentv1beta1.TorrentState(qbTorrent.State); torrent.Status.State != state {
 		logger.Info("Torrent state changed",
 			"old_state", torrent.Status.State,
-			"new_state", qbTorrent.State)
-		torrent.Status.State = qbTorrent.State
+			"new_state", state)
+		if state == torrentv1beta1.TorrentStateStalledDL {
+			r.recordEvent(torrent, corev1.EventTypeWarning, "Stalled", "The download is stalled, no peer is sending data")
+		}
+		torrent.Status.State = state
+		updated = true
+	}
+
+	if phase := torrentPhase(qbTorrent); torrent.Status.Phase != phase {
+		torrent.Status.Phase = phase
+		updated = true
+	}
+
+	if allowed := allowedActions(torrent.Status.State); !equality.Semantic.DeepEqual(torrent.Status.AllowedActions, allowed) {
+		torrent.Status.AllowedActions = allowed
 		updated = true
 	}
 
@@ -293,6 +614,16 @@ func (r *TorrentReconciler) updateTorrentStatus(ctx context.Context, torrent *to
 		updated = true
 	}
 
+	if torrent.Status.Category != qbTorrent.Category {
+		torrent.Status.Category = qbTorrent.Category
+		updated = true
+	}
+
+	if tags := qbTorrent.TagList(); !slices.Equal(torrent.Status.Tags, tags) {
+		torrent.Status.Tags = tags
+		updated = true
+	}
+
 	if updated {
 		logger.V(1).Info("Status fields updated", "hash", qbTorrent.Hash)
 	}
@@ -300,10 +631,81 @@ func (r *TorrentReconciler) updateTorrentStatus(ctx context.Context, torrent *to
 	return updated
 }
 
+// updateBackendAnnotations sets the annotations reflecting the backend tags and category,
+// and the ones for the import scripts. It returns true if the annotations changed.
+func (r *TorrentReconciler) updateBackendAnnotations(torrent *torrentv1beta1.Torrent, qbTorrent *qbittorrent.TorrentInfo) bool {
+	contentPath := ""
+	if isTorrentComplete(qbTorrent) {
+		contentPath = qbTorrent.ContentPath
+	}
+	desired := map[string]string{
+		AnnotationBackendTags:        strings.Join(qbTorrent.TagList(), ","),
+		AnnotationBackendCategory:    qbTorrent.Category,
+		AnnotationBackendHash:        strings.ToUpper(qbTorrent.Hash),
+		AnnotationBackendName:        qbTorrent.Name,
+		AnnotationBackendContentPath: contentPath,
+	}
+
+	updated := false
+	for key, value := range desired {
+		current, ok := torrent.Annotations[key]
+		if value == "" {
+			if ok {
+				delete(torrent.Annotations, key)
+				updated = true
+			}
+			continue
+		}
+		if current != value {
+			if torrent.Annotations == nil {
+				torrent.Annotations = map[string]string{}
+			}
+			torrent.Annotations[key] = value
+			updated = true
+		}
+	}
+
+	return updated
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *TorrentReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&torrentv1alpha1.Torrent{}).
-		Named("torrent").
+	if err := metrics.Registry.Register(&torrentCollector{reader: mgr.GetClient(), shard: r.Shard, config: r.Config}); err != nil {
+		return err
+	}
+	if r.DeletionBatchWindow > 0 {
+		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			return r.deletions.run(ctx, r.Clients, r.DeletionBatchWindow)
+		})); err != nil {
+			return err
+		}
+	}
+
+	// The Jobs and ConfigMaps are created for the Torrents of the shard only.
+	// The status updates are ignored, each pass writes the reconcile stats,
+	// but for the completions waited for by the dependent Torrents.
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&torrentv1beta1.Torrent{}, builder.WithPredicates(
+			predicate.NewPredicateFuncs(r.Shard.Owns),
+			predicate.Or(
+				predicate.GenerationChangedPredicate{},
+				predicate.AnnotationChangedPredicate{},
+				predicate.LabelChangedPredicate{},
+			),
+		)).
+		Owns(&batchv1.Job{}).
+		Owns(&corev1.ConfigMap{}).
+		Watches(&torrentv1beta1.Torrent{}, handler.EnqueueRequestsFromMapFunc(r.dependentTorrents),
+			builder.WithPredicates(dependencyCompleted))
+	if r.BatchNamespaceDeletion {
+		b = b.Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.terminatingNamespaceTorrents),
+			builder.WithPredicates(namespaceTerminatingChanged))
+	}
+	if r.ServerConditions {
+		b = b.Watches(&torrentv1beta1.QBittorrentServer{}, handler.EnqueueRequestsFromMapFunc(r.serverTorrents),
+			builder.WithPredicates(serverConnectedChanged))
+	}
+	return b.Named("torrent").
+		WithOptions(crcontroller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
@@ -18,16 +18,26 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	torrentv1alpha1 "github.com/guidonguido/qbittorrent-operator/api/v1alpha1"
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
 )
 
 var _ = Describe("Torrent Controller", func() {
@@ -40,18 +50,22 @@ var _ = Describe("Torrent Controller", func() {
 			Name:      resourceName,
 			Namespace: "default", // TODO(user):Modify as needed
 		}
-		torrent := &torrentv1alpha1.Torrent{}
+		torrent := &torrentv1beta1.Torrent{}
 
 		BeforeEach(func() {
 			By("creating the custom resource for the Kind Torrent")
 			err := k8sClient.Get(ctx, typeNamespacedName, torrent)
 			if err != nil && errors.IsNotFound(err) {
-				resource := &torrentv1alpha1.Torrent{
+				resource := &torrentv1beta1.Torrent{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      resourceName,
 						Namespace: "default",
 					},
-					// TODO(user): Specify other spec details if needed.
+					Spec: torrentv1beta1.TorrentSpec{
+						Source: torrentv1beta1.TorrentSource{
+							MagnetURI: "magnet:?xt=urn:btih:dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c&dn=Big+Buck+Bunny",
+						},
+					},
 				}
 				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
 			}
@@ -59,7 +73,7 @@ var _ = Describe("Torrent Controller", func() {
 
 		AfterEach(func() {
 			// TODO(user): Cleanup logic after each test, like removing the resource instance.
-			resource := &torrentv1alpha1.Torrent{}
+			resource := &torrentv1beta1.Torrent{}
 			err := k8sClient.Get(ctx, typeNamespacedName, resource)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -69,8 +83,9 @@ var _ = Describe("Torrent Controller", func() {
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
 			controllerReconciler := &TorrentReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:  k8sClient,
+				Scheme:  k8sClient.Scheme(),
+				Clients: poolOf(qbittorrent.NewClient("http://127.0.0.1:0")),
 			}
 
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -81,4 +96,153 @@ var _ = Describe("Torrent Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+
+	Context("When reconciliation is disabled", func() {
+		ctx := context.Background()
+		typeNamespacedName := types.NamespacedName{Name: "reconcile-disabled", Namespace: "default"}
+
+		It("should remove the finalizer without calling qBittorrent", func() {
+			resource := &torrentv1beta1.Torrent{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        typeNamespacedName.Name,
+					Namespace:   typeNamespacedName.Namespace,
+					Annotations: map[string]string{AnnotationReconcile: ReconcileDisabled},
+					Finalizers:  []string{TorrentFinalizer},
+				},
+				Spec: torrentv1beta1.TorrentSpec{
+					Source: torrentv1beta1.TorrentSource{
+						MagnetURI: "magnet:?xt=urn:btih:dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c&dn=Big+Buck+Bunny",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			// Without a qBittorrent client, any call to qBittorrent would panic
+			controllerReconciler := &TorrentReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, typeNamespacedName, &torrentv1beta1.Torrent{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("When the status changes during a pass", func() {
+		ctx := context.Background()
+		typeNamespacedName := types.NamespacedName{Name: "single-status-write", Namespace: "default"}
+
+		var qbServer *httptest.Server
+		var torrentsInfo string
+		var statusUpdates int
+		var controllerReconciler *TorrentReconciler
+
+		BeforeEach(func() {
+			By("starting a fake qBittorrent WebUI")
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v2/torrents/info", func(w http.ResponseWriter, _ *http.Request) {
+				if torrentsInfo == "" {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				_, _ = fmt.Fprint(w, torrentsInfo)
+			})
+			mux.HandleFunc("/api/v2/torrents/properties", func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = fmt.Fprint(w, `{"total_downloaded":276445467,"total_uploaded":1048576,"share_ratio":0.004}`)
+			})
+			mux.HandleFunc("/api/v2/torrents/addTags", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			qbServer = httptest.NewServer(mux)
+
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(torrentv1beta1.AddToScheme(scheme)).To(Succeed())
+
+			statusUpdates = 0
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithStatusSubresource(&torrentv1beta1.Torrent{}).
+				WithObjects(&torrentv1beta1.Torrent{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       typeNamespacedName.Name,
+						Namespace:  typeNamespacedName.Namespace,
+						Finalizers: []string{TorrentFinalizer},
+					},
+					Spec: torrentv1beta1.TorrentSpec{
+						Source: torrentv1beta1.TorrentSource{
+							MagnetURI: "magnet:?xt=urn:btih:dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c&dn=Big+Buck+Bunny",
+						},
+					},
+				}).
+				WithInterceptorFuncs(interceptor.Funcs{
+					SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string,
+						obj client.Object, opts ...client.SubResourceUpdateOption) error {
+						statusUpdates++
+						return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+					},
+				}).
+				Build()
+
+			controllerReconciler = &TorrentReconciler{
+				Client:  fakeClient,
+				Scheme:  scheme,
+				Clients: poolOf(qbittorrent.NewClient(qbServer.URL)),
+			}
+		})
+
+		AfterEach(func() {
+			qbServer.Close()
+		})
+
+		It("should write the status once when the torrent is active", func() {
+			torrentsInfo = `[{"hash":"dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c","name":"Big Buck Bunny",` +
+				`"state":"uploading","size":276445467,"total_size":276445467,"amount_left":0,"progress":1}]`
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statusUpdates).To(Equal(1))
+
+			torrent := &torrentv1beta1.Torrent{}
+			Expect(controllerReconciler.Get(ctx, typeNamespacedName, torrent)).To(Succeed())
+			Expect(torrent.Status.Hash).To(Equal("dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"))
+			available := meta.FindStatusCondition(torrent.Status.Conditions, TypeAvailableTorrent)
+			Expect(available).NotTo(BeNil())
+			Expect(available.Reason).To(Equal("TorrentActive"))
+		})
+
+		It("should annotate the complete torrent for the import scripts", func() {
+			torrentsInfo = `[{"hash":"dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c","name":"Big Buck Bunny",` +
+				`"category":"radarr","content_path":"/downloads/movies/Big Buck Bunny",` +
+				`"state":"uploading","size":276445467,"total_size":276445467,"amount_left":0,"progress":1}]`
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			torrent := &torrentv1beta1.Torrent{}
+			Expect(controllerReconciler.Get(ctx, typeNamespacedName, torrent)).To(Succeed())
+			Expect(torrent.Annotations).To(HaveKeyWithValue(AnnotationBackendCategory, "radarr"))
+			Expect(torrent.Annotations).To(HaveKeyWithValue(AnnotationBackendHash, "DD8255ECDC7CA55FB0BBF81323D87062DB1F6D1C"))
+			Expect(torrent.Annotations).To(HaveKeyWithValue(AnnotationBackendName, "Big Buck Bunny"))
+			Expect(torrent.Annotations).To(HaveKeyWithValue(AnnotationBackendContentPath, "/downloads/movies/Big Buck Bunny"))
+		})
+
+		It("should write the status once when the pass fails", func() {
+			torrentsInfo = ""
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+			Expect(statusUpdates).To(Equal(1))
+
+			torrent := &torrentv1beta1.Torrent{}
+			Expect(controllerReconciler.Get(ctx, typeNamespacedName, torrent)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(torrent.Status.Conditions, TypeDegradedTorrent)).To(BeTrue())
+		})
+	})
 })
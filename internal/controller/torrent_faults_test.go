@@ -0,0 +1,167 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("Torrent reconciler under qBittorrent faults", func() {
+	const hash = "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "faults", Namespace: "default"}
+
+	var qb *fakeQBittorrent
+	var qbClient *qbittorrent.Client
+	var controllerReconciler *TorrentReconciler
+
+	reconcileTorrent := func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getTorrent := func() *torrentv1beta1.Torrent {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent
+	}
+
+	expectDegraded := func(reason string) *metav1.Condition {
+		degraded := meta.FindStatusCondition(getTorrent().Status.Conditions, TypeDegradedTorrent)
+		Expect(degraded).NotTo(BeNil())
+		Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+		Expect(degraded.Reason).To(Equal(reason))
+		return degraded
+	}
+
+	expectRecovered := func() {
+		qb.setFaults(fakeFaults{})
+		reconcileTorrent()
+		torrent := getTorrent()
+		Expect(meta.IsStatusConditionTrue(torrent.Status.Conditions, TypeAvailableTorrent)).To(BeTrue())
+		Expect(torrent.Status.ReconcileStats.ConsecutiveFailures).To(BeZero())
+		// The torrent is never taken as missing from qBittorrent, and added again
+		Expect(qb.callCount("/api/v2/torrents/add")).To(Equal(1))
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		qbClient = qb.client()
+		qbClient.SetRequestTimeout(time.Second)
+		controllerReconciler = &TorrentReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Clients:  poolOf(qbClient),
+			Recorder: record.NewFakeRecorder(20),
+		}
+
+		By("syncing a Torrent before injecting the faults")
+		Expect(qbClient.Login(ctx, "admin", "adminadmin")).To(Succeed())
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source: torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + hash},
+			},
+		})).To(Succeed())
+		reconcileTorrent()
+		reconcileTorrent()
+		reconcileTorrent()
+		Expect(getTorrent().Status.Hash).To(Equal(hash))
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		torrent := getTorrent()
+		controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+	})
+
+	It("should retry the passes failed by random 502s", func() {
+		qb.setFaults(fakeFaults{Seed: 1679, ErrorRate: 0.5})
+
+		failures := 0
+		for range 10 {
+			reconcileTorrent()
+			if meta.IsStatusConditionTrue(getTorrent().Status.Conditions, TypeDegradedTorrent) {
+				failures++
+			}
+		}
+		Expect(qb.injectedFaults()).NotTo(BeZero())
+		Expect(failures).To(BeNumerically(">", 0))
+		Expect(failures).To(BeNumerically("<", 10))
+
+		expectRecovered()
+	})
+
+	It("should fail the reconcile of a server without a client instead of calling it", func() {
+		controllerReconciler.ServerName = "missing"
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).To(MatchError(ContainSubstring("no qBittorrent client for the QBittorrentServer missing")))
+
+		controllerReconciler.ServerName = ""
+		expectRecovered()
+	})
+
+	It("should give up on slow answers at the request timeout", func() {
+		qb.setFaults(fakeFaults{Delay: 2 * time.Second})
+
+		reconcileTorrent()
+		degraded := expectDegraded("FailedToGetTorrentInfo")
+		Expect(degraded.Message).To(ContainSubstring("Client.Timeout exceeded"))
+
+		expectRecovered()
+	})
+
+	It("should log in again when the session expires", func() {
+		qb.expireSession()
+
+		reconcileTorrent()
+		torrent := getTorrent()
+		Expect(meta.IsStatusConditionTrue(torrent.Status.Conditions, TypeAvailableTorrent)).To(BeTrue())
+		Expect(qb.loginCount()).To(Equal(2))
+		// An expired session means that qBittorrent restarted
+		Expect(qbClient.InRestartGracePeriod()).To(BeTrue())
+	})
+
+	It("should not take a partial torrent list as the torrent missing", func() {
+		qb.setFaults(fakeFaults{TruncateJSON: true})
+
+		reconcileTorrent()
+		degraded := expectDegraded("FailedToGetTorrentInfo")
+		Expect(degraded.Message).To(ContainSubstring("failed to parse torrents info list"))
+
+		expectRecovered()
+	})
+})
@@ -0,0 +1,303 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Torrent lifecycle against a fake qBittorrent", func() {
+	const hash = "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"
+	const magnetURI = "magnet:?xt=urn:btih:" + hash + "&dn=Big+Buck+Bunny"
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var recorder *record.FakeRecorder
+	var controllerReconciler *TorrentReconciler
+
+	createTorrent := func(name string) types.NamespacedName {
+		key := types.NamespacedName{Name: name, Namespace: "default"}
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source: torrentv1beta1.TorrentSource{MagnetURI: magnetURI},
+			},
+		})).To(Succeed())
+		return key
+	}
+
+	reconcileTorrent := func(key types.NamespacedName) reconcile.Result {
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	getTorrent := func(key types.NamespacedName) *torrentv1beta1.Torrent {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent
+	}
+
+	// deleteTorrent deletes the Torrent, releasing its finalizer if the spec
+	// left it behind
+	deleteTorrent := func(key types.NamespacedName) {
+		torrent := &torrentv1beta1.Torrent{}
+		if err := k8sClient.Get(ctx, key, torrent); errors.IsNotFound(err) {
+			return
+		}
+		if controllerutil.RemoveFinalizer(torrent, TorrentFinalizer) {
+			Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		}
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		recorder = record.NewFakeRecorder(20)
+		controllerReconciler = &TorrentReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Clients:  poolOf(qb.client()),
+			Recorder: recorder,
+		}
+	})
+
+	AfterEach(func() {
+		qb.Close()
+	})
+
+	Context("When a Torrent is created and deleted", func() {
+		key := types.NamespacedName{Name: "lifecycle", Namespace: "default"}
+
+		AfterEach(func() {
+			deleteTorrent(key)
+		})
+
+		It("should add the torrent, sync its status, and delete it with the Torrent", func() {
+			createTorrent(key.Name)
+
+			By("adding the finalizer first")
+			Expect(reconcileTorrent(key).RequeueAfter).NotTo(BeZero())
+			Expect(controllerutil.ContainsFinalizer(getTorrent(key), TorrentFinalizer)).To(BeTrue())
+			Expect(qb.callCount("/api/v2/torrents/add")).To(BeZero())
+
+			By("adding the torrent to qBittorrent")
+			reconcileTorrent(key)
+			Expect(qb.hashes()).To(Equal([]string{hash}))
+			torrent := getTorrent(key)
+			Expect(torrent.Status.Phase).To(Equal(torrentv1beta1.TorrentPhasePending))
+			available := meta.FindStatusCondition(torrent.Status.Conditions, TypeAvailableTorrent)
+			Expect(available).NotTo(BeNil())
+			Expect(available.Reason).To(Equal("TorrentAdded"))
+
+			By("syncing the status from qBittorrent")
+			reconcileTorrent(key)
+			torrent = getTorrent(key)
+			Expect(torrent.Status.Hash).To(Equal(hash))
+			Expect(torrent.Status.State).To(Equal(torrentv1beta1.TorrentStateMetaDL))
+			Expect(torrent.Status.Phase).To(Equal(torrentv1beta1.TorrentPhaseDownloading))
+			Expect(meta.FindStatusCondition(torrent.Status.Conditions, TypeAvailableTorrent).Reason).
+				To(Equal("TorrentActive"))
+			Expect(meta.IsStatusConditionFalse(torrent.Status.Conditions, TypeDegradedTorrent)).To(BeTrue())
+
+			By("following the state transitions of qBittorrent")
+			qb.setState(hash, string(torrentv1beta1.TorrentStateStalledDL))
+			reconcileTorrent(key)
+			Expect(getTorrent(key).Status.State).To(Equal(torrentv1beta1.TorrentStateStalledDL))
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning Stalled")))
+
+			qb.setState(hash, string(torrentv1beta1.TorrentStateStoppedUP))
+			reconcileTorrent(key)
+			Expect(getTorrent(key).Status.Phase).To(Equal(torrentv1beta1.TorrentPhaseCompleted))
+
+			By("deleting the torrent from qBittorrent with the Torrent")
+			Expect(k8sClient.Delete(ctx, getTorrent(key))).To(Succeed())
+			reconcileTorrent(key)
+			Expect(qb.hashes()).To(BeEmpty())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, key, &torrentv1beta1.Torrent{}))).To(BeTrue())
+		})
+	})
+
+	Context("When the deletion from qBittorrent fails", func() {
+		key := types.NamespacedName{Name: "deletion-retry", Namespace: "default"}
+
+		BeforeEach(func() {
+			createTorrent(key.Name)
+			reconcileTorrent(key)
+			reconcileTorrent(key)
+			reconcileTorrent(key)
+			Expect(getTorrent(key).Status.Hash).To(Equal(hash))
+
+			qb.setFailDelete(true)
+			Expect(k8sClient.Delete(ctx, getTorrent(key))).To(Succeed())
+		})
+
+		AfterEach(func() {
+			deleteTorrent(key)
+		})
+
+		It("should keep the finalizer and retry the deletion", func() {
+			By("keeping the Torrent while the deletion fails")
+			Expect(reconcileTorrent(key).RequeueAfter).NotTo(BeZero())
+			torrent := getTorrent(key)
+			Expect(controllerutil.ContainsFinalizer(torrent, TorrentFinalizer)).To(BeTrue())
+			degraded := meta.FindStatusCondition(torrent.Status.Conditions, TypeDegradedTorrent)
+			Expect(degraded).NotTo(BeNil())
+			Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+			Expect(degraded.Reason).To(Equal("FailedToDeleteTorrent"))
+			Expect(torrent.Status.ReconcileStats.ConsecutiveFailures).To(Equal(int32(1)))
+			Expect(qb.hashes()).To(Equal([]string{hash}))
+
+			By("deleting the Torrent once qBittorrent accepts the deletion")
+			qb.setFailDelete(false)
+			reconcileTorrent(key)
+			Expect(qb.hashes()).To(BeEmpty())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, key, &torrentv1beta1.Torrent{}))).To(BeTrue())
+		})
+
+		It("should let the Torrent go after the deletion retry timeout", func() {
+			controllerReconciler.DeletionRetryTimeout = time.Millisecond
+			time.Sleep(time.Millisecond)
+
+			reconcileTorrent(key)
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, key, &torrentv1beta1.Torrent{}))).To(BeTrue())
+			Expect(qb.hashes()).To(Equal([]string{hash}))
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning OrphanedOnBackend")))
+		})
+	})
+
+	Context("When two Torrents have the same hash", func() {
+		first := types.NamespacedName{Name: "duplicate-first", Namespace: "default"}
+		second := types.NamespacedName{Name: "duplicate-second", Namespace: "default"}
+
+		AfterEach(func() {
+			deleteTorrent(first)
+			deleteTorrent(second)
+		})
+
+		It("should add the torrent once and sync both Torrents", func() {
+			createTorrent(first.Name)
+			createTorrent(second.Name)
+			for range 3 {
+				reconcileTorrent(first)
+				reconcileTorrent(second)
+			}
+
+			Expect(qb.callCount("/api/v2/torrents/add")).To(Equal(1))
+			Expect(qb.hashes()).To(Equal([]string{hash}))
+			for _, key := range []types.NamespacedName{first, second} {
+				torrent := getTorrent(key)
+				Expect(torrent.Status.Hash).To(Equal(hash))
+				Expect(meta.IsStatusConditionTrue(torrent.Status.Conditions, TypeAvailableTorrent)).To(BeTrue())
+			}
+		})
+	})
+
+	Context("When the backend torrent has a category and tags", func() {
+		key := types.NamespacedName{Name: "backend-tags", Namespace: "default"}
+
+		AfterEach(func() {
+			deleteTorrent(key)
+		})
+
+		It("should reflect them in the status and the annotations", func() {
+			createTorrent(key.Name)
+			reconcileTorrent(key)
+			reconcileTorrent(key)
+
+			By("reflecting the category and the tags set in qBittorrent")
+			qb.setCategoryAndTags(hash, "movies", "hd", "public")
+			reconcileTorrent(key)
+			torrent := getTorrent(key)
+			Expect(torrent.Status.Category).To(Equal("movies"))
+			Expect(torrent.Status.Tags).To(Equal([]string{"hd", "public"}))
+			Expect(torrent.Annotations).To(HaveKeyWithValue(AnnotationBackendCategory, "movies"))
+			Expect(torrent.Annotations).To(HaveKeyWithValue(AnnotationBackendTags, "hd,public"))
+
+			By("following the removal of a tag")
+			qb.setCategoryAndTags(hash, "movies", "hd")
+			reconcileTorrent(key)
+			torrent = getTorrent(key)
+			Expect(torrent.Status.Tags).To(Equal([]string{"hd"}))
+			Expect(torrent.Annotations).To(HaveKeyWithValue(AnnotationBackendTags, "hd"))
+
+			By("dropping the annotations once the category and the tags are empty")
+			qb.setCategoryAndTags(hash, "")
+			reconcileTorrent(key)
+			torrent = getTorrent(key)
+			Expect(torrent.Status.Category).To(BeEmpty())
+			Expect(torrent.Status.Tags).To(BeEmpty())
+			Expect(torrent.Annotations).NotTo(HaveKey(AnnotationBackendCategory))
+			Expect(torrent.Annotations).NotTo(HaveKey(AnnotationBackendTags))
+			Expect(torrent.Annotations).To(HaveKeyWithValue(AnnotationBackendHash, strings.ToUpper(hash)))
+		})
+	})
+
+	Context("When qBittorrent is unavailable", func() {
+		key := types.NamespacedName{Name: "backend-outage", Namespace: "default"}
+
+		AfterEach(func() {
+			deleteTorrent(key)
+		})
+
+		It("should report the outage and recover from it", func() {
+			createTorrent(key.Name)
+			reconcileTorrent(key)
+			reconcileTorrent(key)
+			reconcileTorrent(key)
+
+			By("degrading the Torrent while qBittorrent is down")
+			qb.setDown(true)
+			Expect(reconcileTorrent(key).RequeueAfter).NotTo(BeZero())
+			reconcileTorrent(key)
+			torrent := getTorrent(key)
+			degraded := meta.FindStatusCondition(torrent.Status.Conditions, TypeDegradedTorrent)
+			Expect(degraded).NotTo(BeNil())
+			Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+			Expect(degraded.Reason).To(Equal("FailedToGetTorrentInfo"))
+			Expect(meta.IsStatusConditionFalse(torrent.Status.Conditions, TypeAvailableTorrent)).To(BeTrue())
+			Expect(torrent.Status.ReconcileStats.ConsecutiveFailures).To(Equal(int32(2)))
+			Expect(torrent.Status.Hash).To(Equal(hash), "the last known status is kept")
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning Degraded FailedToGetTorrentInfo")))
+
+			By("recovering once qBittorrent is back")
+			qb.setDown(false)
+			reconcileTorrent(key)
+			torrent = getTorrent(key)
+			Expect(meta.IsStatusConditionTrue(torrent.Status.Conditions, TypeAvailableTorrent)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(torrent.Status.Conditions, TypeDegradedTorrent)).To(BeTrue())
+			Expect(torrent.Status.ReconcileStats.ConsecutiveFailures).To(BeZero())
+			Expect(qb.callCount("/api/v2/torrents/add")).To(Equal(1))
+		})
+	})
+})
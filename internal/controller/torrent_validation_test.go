@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+// These specs exercise the CEL rules of the CRD through the API server, so
+// they also hold where the admission webhook is not deployed
+var _ = Describe("Torrent validation rules", func() {
+	const magnetURI = "magnet:?xt=urn:btih:dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c&dn=Big+Buck+Bunny"
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "validation-rules", Namespace: "default"}
+
+	newTorrent := func(source torrentv1beta1.TorrentSource) *torrentv1beta1.Torrent {
+		return &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec:       torrentv1beta1.TorrentSpec{Source: source},
+		}
+	}
+
+	// expectInvalid checks the API server rejected the request with the message of the rule
+	expectInvalid := func(err error, message string) {
+		GinkgoHelper()
+		Expect(errors.IsInvalid(err)).To(BeTrue(), "expected an Invalid error, got %v", err)
+		Expect(err.Error()).To(ContainSubstring(message))
+	}
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, newTorrent(torrentv1beta1.TorrentSource{})))).To(Succeed())
+	})
+
+	Context("When a Torrent is created", func() {
+		It("should admit a valid Torrent", func() {
+			torrent := newTorrent(torrentv1beta1.TorrentSource{MagnetURI: magnetURI})
+			torrent.Spec.Category = "movies/hd"
+			torrent.Spec.CompletionDeadline = &metav1.Duration{Duration: 6 * time.Hour}
+			torrent.Spec.Limits = &torrentv1beta1.TorrentLimits{
+				RatioLimit:       "2.0",
+				SeedingTimeLimit: &metav1.Duration{Duration: 72 * time.Hour},
+			}
+			Expect(k8sClient.Create(ctx, torrent)).To(Succeed())
+		})
+
+		It("should require exactly one source", func() {
+			err := k8sClient.Create(ctx, newTorrent(torrentv1beta1.TorrentSource{}))
+			expectInvalid(err, "exactly one of magnetURI, torrentURL and torrentData must be set")
+
+			err = k8sClient.Create(ctx, newTorrent(torrentv1beta1.TorrentSource{
+				MagnetURI:  magnetURI,
+				TorrentURL: "https://tracker.example.com/file.torrent",
+			}))
+			expectInvalid(err, "exactly one of magnetURI, torrentURL and torrentData must be set")
+		})
+
+		It("should reject a magnet URI without a valid info hash", func() {
+			for _, invalid := range []string{
+				"magnet:?dn=Big+Buck+Bunny",
+				"magnet:?xt=urn:btih:dd8255ecdc7ca55fb0bbf81323d87062db1f6d1",
+				"https://example.com/?xt=urn:btih:dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c",
+			} {
+				err := k8sClient.Create(ctx, newTorrent(torrentv1beta1.TorrentSource{MagnetURI: invalid}))
+				expectInvalid(err, "magnetURI must contain a valid btih or btmh info hash")
+			}
+		})
+
+		It("should reject durations out of range", func() {
+			torrent := newTorrent(torrentv1beta1.TorrentSource{MagnetURI: magnetURI})
+			torrent.Spec.CompletionDeadline = &metav1.Duration{}
+			expectInvalid(k8sClient.Create(ctx, torrent), "completionDeadline must be a positive duration")
+
+			torrent = newTorrent(torrentv1beta1.TorrentSource{MagnetURI: magnetURI})
+			torrent.Spec.Limits = &torrentv1beta1.TorrentLimits{
+				SeedingTimeLimit: &metav1.Duration{Duration: -time.Hour},
+			}
+			expectInvalid(k8sClient.Create(ctx, torrent), "seedingTimeLimit must be a non-negative duration")
+
+			torrent = newTorrent(torrentv1beta1.TorrentSource{MagnetURI: magnetURI})
+			torrent.Spec.DeadlineEscalation = &torrentv1beta1.DeadlineEscalation{
+				Before: metav1.Duration{Duration: time.Hour},
+			}
+			expectInvalid(k8sClient.Create(ctx, torrent), "deadlineEscalation requires completionDeadline to be set")
+		})
+	})
+
+	Context("When a Torrent is updated", func() {
+		var torrent *torrentv1beta1.Torrent
+
+		BeforeEach(func() {
+			torrent = newTorrent(torrentv1beta1.TorrentSource{MagnetURI: magnetURI})
+			Expect(k8sClient.Create(ctx, torrent)).To(Succeed())
+		})
+
+		It("should reject a change of the source", func() {
+			torrent.Spec.Source.MagnetURI = "magnet:?xt=urn:btih:3WBFL3G4PSSV7MF37AJSHWDQMLNR63I4"
+			expectInvalid(k8sClient.Update(ctx, torrent), "source is immutable")
+		})
+
+		It("should admit changes of the mutable fields", func() {
+			torrent.Spec.Category = "movies"
+			torrent.Spec.Limits = &torrentv1beta1.TorrentLimits{RatioLimit: "1.5"}
+			Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		})
+
+		It("should admit status-only updates", func() {
+			torrent.Status.Phase = torrentv1beta1.TorrentPhaseDownloading
+			torrent.Status.Hash = "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"
+			Expect(k8sClient.Status().Update(ctx, torrent)).To(Succeed())
+
+			stored := &torrentv1beta1.Torrent{}
+			Expect(k8sClient.Get(ctx, key, stored)).To(Succeed())
+			Expect(stored.Status.Phase).To(Equal(torrentv1beta1.TorrentPhaseDownloading))
+		})
+	})
+})
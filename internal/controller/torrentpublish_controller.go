@@ -0,0 +1,479 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/clientpool"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+const (
+	// Key of the ConfigMap entry holding the published .torrent file
+	PublishedTorrentKey = "torrent"
+
+	defaultPublishImage = "alpine:3.20"
+)
+
+// Markers around the base64 encoded .torrent file in the logs of the Job
+const (
+	beginTorrentMarker = "-----BEGIN TORRENT-----"
+	endTorrentMarker   = "-----END TORRENT-----"
+)
+
+// Build the .torrent file of CONTENT_PATH with mktorrent and print it base64
+// encoded between markers, so that it is told apart from other logs.
+const publishScript = `set -e
+command -v mktorrent >/dev/null || apk add --no-cache mktorrent >/dev/null
+set -- -o /tmp/published.torrent
+if [ -n "$PRIVATE" ]; then set -- "$@" -p; fi
+if [ -n "$PIECE_LENGTH" ]; then set -- "$@" -l "$PIECE_LENGTH"; fi
+if [ -n "$COMMENT" ]; then set -- "$@" -c "$COMMENT"; fi
+for tracker in $TRACKERS; do set -- "$@" -a "$tracker"; done
+for seed in $WEB_SEEDS; do set -- "$@" -w "$seed"; done
+mktorrent "$@" "$CONTENT_PATH" >/dev/null
+echo "` + beginTorrentMarker + `"
+base64 /tmp/published.torrent
+echo "` + endTorrentMarker + `"
+`
+
+// TorrentPublishReconciler reconciles a TorrentPublish object
+type TorrentPublishReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Clients has the qBittorrent client of each QBittorrentServer
+	Clients *clientpool.Pool
+	// ServerName is the name of the QBittorrentServer the torrents are
+	// published on, DefaultServerName if empty
+	ServerName string
+	// Clientset is used to read the logs of the Jobs building the .torrent files
+	Clientset kubernetes.Interface
+
+	// IsolateNamespaces and SavePathRoot confine the category and the save
+	// path of the published torrents as the ones of the Torrents
+	IsolateNamespaces bool
+	SavePathRoot      string
+
+	// Shard restricts the TorrentPublishes reconciled to the ones of this replica
+	Shard Shard
+}
+
+// +kubebuilder:rbac:groups=torrent.qbittorrent.io,resources=torrentpublishes,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=torrent.qbittorrent.io,resources=torrentpublishes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=torrent.qbittorrent.io,resources=torrentpublishes/finalizers,verbs=update
+
+// Reconcile builds the .torrent file of the content with a Job, then seeds it
+// from qBittorrent without checking the content, which is already complete.
+func (r *TorrentPublishReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// The qBittorrent calls are audited as made for this TorrentPublish
+	ctx = qbittorrent.WithAuditObject(ctx, "TorrentPublish "+req.String())
+
+	// Step 1: Get the TorrentPublish
+	publish := &torrentv1beta1.TorrentPublish{}
+	if err := r.Get(ctx, req.NamespacedName, publish); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get TorrentPublish")
+		return ctrl.Result{}, err
+	}
+
+	// Step 1.1: Log in to qBittorrent, unless logged in already
+	if err := login(ctx, r.Clients, serverNameOr(r.ServerName)); err != nil {
+		logger.Error(err, "Failed to get the qBittorrent client")
+		return ctrl.Result{}, err
+	}
+
+	// Step 2: Stop seeding once deleted, the content is never deleted
+	if !publish.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(publish, TorrentFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		if publish.Status.InfoHash != "" {
+			logger.Info("Removing published torrent from qBittorrent", "InfoHash", publish.Status.InfoHash)
+			if err := r.qbt().DeleteTorrent(ctx, publish.Status.InfoHash, false); err != nil {
+				logger.Error(err, "Failed to remove published torrent from qBittorrent")
+
+				// Retry after 10 seconds
+				return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+			}
+		}
+		controllerutil.RemoveFinalizer(publish, TorrentFinalizer)
+		if err := r.Update(ctx, publish); err != nil {
+			logger.Error(err, "Failed to remove finalizer")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Step 3: Add the finalizer, removing the torrent from qBittorrent on deletion
+	if controllerutil.AddFinalizer(publish, TorrentFinalizer) {
+		if err := r.Update(ctx, publish); err != nil {
+			logger.Error(err, "Failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Step 4: Build the .torrent file
+	if publish.Status.TorrentConfigMap == "" {
+		built, err := r.buildTorrent(ctx, publish)
+		if err != nil {
+			logger.Error(err, "Failed to build the torrent")
+
+			// Update resource status to reflect the error
+			publish.Status.Phase = torrentv1beta1.TorrentPublishPhaseFailed
+			setHealthConditions(&publish.Status.Conditions, TypeAvailableTorrent, TypeDegradedTorrent,
+				false, "FailedToBuildTorrent", err.Error())
+			if err := r.Status().Update(ctx, publish); err != nil {
+				logger.Error(err, "Failed to update TorrentPublish status")
+			}
+
+			// Retry after 10 seconds
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+		if !built {
+			publish.Status.Phase = torrentv1beta1.TorrentPublishPhaseBuilding
+			setHealthConditions(&publish.Status.Conditions, TypeAvailableTorrent, TypeDegradedTorrent,
+				false, "BuildingTorrent", "The .torrent file is being built")
+			if err := r.Status().Update(ctx, publish); err != nil {
+				logger.Error(err, "Failed to update TorrentPublish status")
+				return ctrl.Result{}, err
+			}
+
+			// The Job completion triggers the next reconcile
+			return ctrl.Result{}, nil
+		}
+		if err := r.Status().Update(ctx, publish); err != nil {
+			logger.Error(err, "Failed to update TorrentPublish status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Step 5: Seed the torrent from qBittorrent
+	qbTorrent, err := r.seedTorrent(ctx, publish)
+	if err != nil {
+		logger.Error(err, "Failed to seed the torrent")
+
+		// Update resource status to reflect the error
+		publish.Status.Phase = torrentv1beta1.TorrentPublishPhaseFailed
+		setHealthConditions(&publish.Status.Conditions, TypeAvailableTorrent, TypeDegradedTorrent,
+			false, "FailedToSeedTorrent", err.Error())
+		if err := r.Status().Update(ctx, publish); err != nil {
+			logger.Error(err, "Failed to update TorrentPublish status")
+		}
+
+		// Retry after 10 seconds
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+	if qbTorrent == nil {
+		// Check again once qBittorrent added the torrent
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	// Step 6: Report the state of the torrent
+	publish.Status.State = torrentv1beta1.TorrentState(qbTorrent.State)
+	switch publish.Status.State {
+	case torrentv1beta1.TorrentStateError, torrentv1beta1.TorrentStateMissingFiles:
+		publish.Status.Phase = torrentv1beta1.TorrentPublishPhaseFailed
+		setHealthConditions(&publish.Status.Conditions, TypeAvailableTorrent, TypeDegradedTorrent,
+			false, "ContentNotFound", fmt.Sprintf("qBittorrent reports %s, check that %s is in %s in the qBittorrent container",
+				publish.Status.State, publish.Spec.Path, contentMountPath(publish)))
+	default:
+		publish.Status.Phase = torrentv1beta1.TorrentPublishPhaseSeeding
+		setHealthConditions(&publish.Status.Conditions, TypeAvailableTorrent, TypeDegradedTorrent,
+			true, "TorrentSeeding", "Torrent is seeded by qBittorrent")
+	}
+	if err := r.Status().Update(ctx, publish); err != nil {
+		logger.Error(err, "Failed to update TorrentPublish status")
+		return ctrl.Result{}, err
+	}
+
+	// Step 7: Refresh after 30 seconds
+	return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+}
+
+// buildTorrent runs the Job building the .torrent file and publishes it in a
+// ConfigMap. It returns true once the ConfigMap exists and status references it.
+func (r *TorrentPublishReconciler) buildTorrent(ctx context.Context, publish *torrentv1beta1.TorrentPublish) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	// Step 1: Get or create the Job
+	job := &batchv1.Job{}
+	jobKey := types.NamespacedName{Name: truncateName(publish.Name, "-torrent"), Namespace: publish.Namespace}
+	if err := r.Get(ctx, jobKey, job); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get publish job: %w", err)
+		}
+
+		job = publishJob(publish)
+		if err := controllerutil.SetControllerReference(publish, job, r.Scheme); err != nil {
+			return false, fmt.Errorf("failed to set owner reference on publish job: %w", err)
+		}
+
+		logger.Info("Creating publish Job", "Job", job.Name)
+		if err := r.Create(ctx, job); err != nil {
+			return false, fmt.Errorf("failed to create publish job: %w", err)
+		}
+		return false, nil
+	}
+
+	// Step 2: Wait for the Job to finish
+	if job.Status.Failed > 0 && job.Status.Succeeded == 0 && job.Status.Active == 0 {
+		return false, fmt.Errorf("publish job %s failed, delete it to retry", job.Name)
+	}
+	if job.Status.Succeeded == 0 {
+		logger.V(1).Info("Publish Job still running", "Job", job.Name)
+		return false, nil
+	}
+
+	// Step 3: Collect the .torrent file from the Job logs
+	output, err := jobOutput(ctx, r.Client, r.Clientset, job)
+	if err != nil {
+		return false, err
+	}
+	data, err := decodeTorrentOutput(output)
+	if err != nil {
+		return false, err
+	}
+	if len(data) > maxChecksumDataSize {
+		return false, fmt.Errorf("the .torrent file is too large for a ConfigMap (%d bytes), set a larger pieceLength",
+			len(data))
+	}
+	file, err := qbittorrent.ParseTorrentFile(data)
+	if err != nil {
+		return false, err
+	}
+
+	// Step 4: Publish the .torrent file
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      truncateName(publish.Name, "-torrent"),
+			Namespace: publish.Namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Labels = publishLabels(publish)
+		configMap.BinaryData = map[string][]byte{PublishedTorrentKey: data}
+		return controllerutil.SetControllerReference(publish, configMap, r.Scheme)
+	}); err != nil {
+		return false, fmt.Errorf("failed to publish the torrent: %w", err)
+	}
+	logger.Info("Built torrent", "ConfigMap", configMap.Name, "InfoHash", file.InfoHashes.V1)
+
+	publish.Status.Name = file.Name
+	publish.Status.InfoHash = file.InfoHashes.V1
+	publish.Status.MagnetURI = publishedMagnet(file.InfoHashes.V1, file.Name, publish.Spec.Trackers, publish.Spec.WebSeeds)
+	publish.Status.TorrentConfigMap = configMap.Name
+
+	// The Job is not needed anymore
+	if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+		!apierrors.IsNotFound(err) {
+		logger.Error(err, "Failed to delete publish Job", "Job", job.Name)
+	}
+
+	return true, nil
+}
+
+// seedTorrent adds the published torrent to qBittorrent, skipping the hash
+// check, and returns it once qBittorrent lists it
+func (r *TorrentPublishReconciler) seedTorrent(ctx context.Context,
+	publish *torrentv1beta1.TorrentPublish) (*qbittorrent.TorrentInfo, error) {
+	logger := log.FromContext(ctx)
+
+	hashes := qbittorrent.InfoHashes{V1: publish.Status.InfoHash}
+	qbTorrent, err := r.qbt().GetTorrentInfo(ctx, hashes)
+	if err != nil || qbTorrent != nil {
+		return qbTorrent, err
+	}
+	if r.qbt().InRestartGracePeriod() {
+		// qBittorrent may still be loading the torrent after a restart
+		logger.Info("Published torrent not found in qBittorrent after a restart, waiting for it to be loaded")
+		return nil, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: publish.Status.TorrentConfigMap, Namespace: publish.Namespace}
+	if err := r.Get(ctx, key, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get the torrent ConfigMap: %w", err)
+	}
+	data, ok := configMap.BinaryData[PublishedTorrentKey]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in torrent ConfigMap %s", PublishedTorrentKey, key)
+	}
+
+	savePath := path.Join(contentMountPath(publish), path.Dir(publish.Spec.Path))
+	category := publish.Spec.Category
+	if r.IsolateNamespaces {
+		category = namespaceCategory(publish.Namespace, category)
+		if r.SavePathRoot != "" {
+			if savePath, err = confineSavePath(r.SavePathRoot, publish.Namespace, savePath); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	logger.Info("Adding published torrent to qBittorrent", "SavePath", savePath)
+	if err := r.qbt().AddTorrentFileWithOptions(ctx, data, qbittorrent.AddTorrentOptions{
+		SavePath:     savePath,
+		Category:     category,
+		SkipChecking: true,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to add the published torrent: %w", err)
+	}
+	return nil, nil
+}
+
+// contentMountPath returns where the content volume is mounted
+func contentMountPath(publish *torrentv1beta1.TorrentPublish) string {
+	if publish.Spec.ContentVolume.MountPath == "" {
+		return defaultMountPath
+	}
+	return publish.Spec.ContentVolume.MountPath
+}
+
+// publishJob builds the Job building the .torrent file of the content
+func publishJob(publish *torrentv1beta1.TorrentPublish) *batchv1.Job {
+	image := publish.Spec.Image
+	if image == "" {
+		image = defaultPublishImage
+	}
+	mountPath := contentMountPath(publish)
+
+	env := []corev1.EnvVar{
+		{Name: "CONTENT_PATH", Value: path.Join(mountPath, publish.Spec.Path)},
+		{Name: "TRACKERS", Value: strings.Join(publish.Spec.Trackers, "\n")},
+		{Name: "WEB_SEEDS", Value: strings.Join(publish.Spec.WebSeeds, "\n")},
+		{Name: "COMMENT", Value: publish.Spec.Comment},
+	}
+	if publish.Spec.Private {
+		env = append(env, corev1.EnvVar{Name: "PRIVATE", Value: "true"})
+	}
+	if publish.Spec.PieceLength != 0 {
+		env = append(env, corev1.EnvVar{Name: "PIECE_LENGTH", Value: strconv.Itoa(int(publish.Spec.PieceLength))})
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      truncateName(publish.Name, "-torrent"),
+			Namespace: publish.Namespace,
+			Labels:    publishLabels(publish),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To[int32](2),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: publishLabels(publish),
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:    "mktorrent",
+						Image:   image,
+						Command: []string{"sh", "-c", publishScript},
+						Env:     env,
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      "content",
+							MountPath: mountPath,
+							ReadOnly:  true,
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "content",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: publish.Spec.ContentVolume.ClaimName,
+								ReadOnly:  true,
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// decodeTorrentOutput extracts the .torrent file printed by the Job
+func decodeTorrentOutput(output string) ([]byte, error) {
+	_, rest, found := strings.Cut(output, beginTorrentMarker)
+	if !found {
+		return nil, fmt.Errorf("no .torrent file found in the publish job logs")
+	}
+	encoded, _, found := strings.Cut(rest, endTorrentMarker)
+	if !found {
+		return nil, fmt.Errorf("truncated .torrent file in the publish job logs")
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(encoded), ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid .torrent file in the publish job logs: %w", err)
+	}
+	return data, nil
+}
+
+// publishedMagnet builds the magnet URI of the published torrent
+func publishedMagnet(infoHash, name string, trackers, webSeeds []string) string {
+	magnet := "magnet:?xt=urn:btih:" + infoHash + "&dn=" + url.QueryEscape(name)
+	for _, tracker := range trackers {
+		magnet += "&tr=" + url.QueryEscape(tracker)
+	}
+	for _, webSeed := range webSeeds {
+		magnet += "&ws=" + url.QueryEscape(webSeed)
+	}
+	return magnet
+}
+
+// publishLabels returns the labels set on objects created for a TorrentPublish
+func publishLabels(publish *torrentv1beta1.TorrentPublish) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/managed-by":        "qbittorrent-operator",
+		"torrent.qbittorrent.io/publish-name": truncateName(publish.Name, ""),
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TorrentPublishReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&torrentv1beta1.TorrentPublish{}, builder.WithPredicates(predicate.NewPredicateFuncs(r.Shard.Owns))).
+		Owns(&batchv1.Job{}).
+		Named("torrentpublish").
+		Complete(r)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TorrentPublish", func() {
+	It("should decode the .torrent file printed by the publish job", func() {
+		output := "fetch https://dl-cdn.alpinelinux.org/alpine\n" +
+			beginTorrentMarker + "\nZDQ6bmFt\nZTE6YWU=\n" + endTorrentMarker + "\n"
+
+		data, err := decodeTorrentOutput(output)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("d4:name1:ae"))
+	})
+
+	It("should reject logs without a complete .torrent file", func() {
+		_, err := decodeTorrentOutput("mktorrent: not found\n")
+		Expect(err).To(HaveOccurred())
+
+		_, err = decodeTorrentOutput(beginTorrentMarker + "\nZDQ6bmFt\n")
+		Expect(err).To(MatchError(ContainSubstring("truncated")))
+	})
+
+	It("should build the magnet URI of the published torrent", func() {
+		magnet := publishedMagnet("c9e15763f722f23e98a29decdfae341b98d53056", "images 2025",
+			[]string{"udp://tracker.example.com:6969/announce"}, []string{"https://cdn.example.com/"})
+		Expect(magnet).To(Equal("magnet:?xt=urn:btih:c9e15763f722f23e98a29decdfae341b98d53056" +
+			"&dn=images+2025&tr=udp%3A%2F%2Ftracker.example.com%3A6969%2Fannounce" +
+			"&ws=https%3A%2F%2Fcdn.example.com%2F"))
+	})
+})
@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// transferStatus returns the transfer status sampled from the torrent
+// properties, with the current speeds. The last activity time moves when the torrent downloaded or
+// uploaded bytes since the previous sample, or is kept otherwise.
+func transferStatus(previous *torrentv1beta1.TransferStatus,
+	properties *qbittorrent.TorrentProperties, now metav1.Time) *torrentv1beta1.TransferStatus {
+	status := &torrentv1beta1.TransferStatus{
+		Downloaded:        properties.TotalDownloaded,
+		DownloadedSession: properties.TotalDownloadedSession,
+		Uploaded:          properties.TotalUploaded,
+		UploadedSession:   properties.TotalUploadedSession,
+		DownloadSpeed:     properties.DownloadSpeed,
+		UploadSpeed:       properties.UploadSpeed,
+	}
+
+	switch {
+	case previous == nil:
+		// The first sample has nothing to compare with, the torrent only
+		// counts as active once it transferred some bytes
+		if status.Downloaded > 0 || status.Uploaded > 0 {
+			status.LastActivityTime = &now
+		}
+	case status.Downloaded != previous.Downloaded || status.Uploaded != previous.Uploaded:
+		status.LastActivityTime = &now
+	default:
+		status.LastActivityTime = previous.LastActivityTime
+	}
+	return status
+}
+
+// reconcileTransfer reports the bytes transferred by the torrent in status.
+// It returns whether the status changed.
+func (r *TorrentReconciler) reconcileTransfer(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) (bool, error) {
+	properties, err := r.qbt(torrent).GetTorrentProperties(ctx, qbTorrent.Hash)
+	if err != nil {
+		return false, fmt.Errorf("failed to get torrent properties: %w", err)
+	}
+
+	status := transferStatus(torrent.Status.Transfer, properties, metav1.Now())
+	if torrent.Status.Transfer != nil && *status == *torrent.Status.Transfer {
+		return false, nil
+	}
+	torrent.Status.Transfer = status
+	return true, nil
+}
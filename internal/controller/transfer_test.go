@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+var _ = Describe("Transfer status", func() {
+	earlier := metav1.NewTime(time.Now().Add(-time.Hour))
+	now := metav1.Now()
+
+	It("should not report a torrent which never transferred as active", func() {
+		status := transferStatus(nil, &qbittorrent.TorrentProperties{}, now)
+		Expect(status.LastActivityTime).To(BeNil())
+
+		status = transferStatus(nil, &qbittorrent.TorrentProperties{TotalDownloaded: 1024}, now)
+		Expect(status.Downloaded).To(Equal(int64(1024)))
+		Expect(status.LastActivityTime).To(Equal(&now))
+	})
+
+	It("should only move the last activity time when bytes were transferred", func() {
+		properties := &qbittorrent.TorrentProperties{
+			TotalDownloaded:        2048,
+			TotalDownloadedSession: 1024,
+			TotalUploaded:          512,
+		}
+		previous := transferStatus(nil, properties, earlier)
+
+		status := transferStatus(previous, properties, now)
+		Expect(*status).To(Equal(*previous))
+
+		properties.TotalUploaded = 1024
+		properties.TotalUploadedSession = 512
+		status = transferStatus(previous, properties, now)
+		Expect(status.UploadedSession).To(Equal(int64(512)))
+		Expect(status.LastActivityTime).To(Equal(&now))
+	})
+
+	It("should report the current speeds", func() {
+		previous := transferStatus(nil, &qbittorrent.TorrentProperties{
+			TotalDownloaded: 2048,
+			DownloadSpeed:   1024,
+			UploadSpeed:     256,
+		}, earlier)
+		Expect(previous.DownloadSpeed).To(Equal(int64(1024)))
+		Expect(previous.UploadSpeed).To(Equal(int64(256)))
+
+		// A torrent which stopped transferring reports no speed, but keeps
+		// its last activity time
+		status := transferStatus(previous, &qbittorrent.TorrentProperties{TotalDownloaded: 2048}, now)
+		Expect(status.DownloadSpeed).To(BeZero())
+		Expect(status.UploadSpeed).To(BeZero())
+		Expect(status.LastActivityTime).To(Equal(&earlier))
+	})
+})
@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// budgetWindow is the window of spec.dailyTransferBudget, days start at
+// midnight UTC
+const budgetWindow = 24 * time.Hour
+
+// sessionTransferred returns the bytes the torrent downloaded and uploaded
+// between two samples of its transfer. The session counters restart with
+// qBittorrent, everything of the new session is then counted.
+func sessionTransferred(previous, current *torrentv1beta1.TransferStatus) int64 {
+	if previous == nil || current == nil {
+		return 0
+	}
+	before := previous.DownloadedSession + previous.UploadedSession
+	after := current.DownloadedSession + current.UploadedSession
+	if after < before {
+		return after
+	}
+	return after - before
+}
+
+// reconcileTransferBudget counts the bytes the torrent transferred since the
+// previous transfer sample against its daily transfer budget. The torrent is
+// stopped once the budget is consumed, also when resumed by hand in the
+// meantime, and started again once the next day starts or the budget is
+// raised. It returns whether the status changed.
+func (r *TorrentReconciler) reconcileTransferBudget(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo, previous *torrentv1beta1.TransferStatus) (bool, error) {
+	logger := log.FromContext(ctx)
+	last := torrent.Status.TransferBudget
+	stopped := torrentStopped(qbTorrent)
+
+	if torrent.Spec.DailyTransferBudget == nil {
+		if last == nil {
+			return false, nil
+		}
+		// The torrent stopped by the budget removed since is started again
+		if last.ExhaustedTime != nil && stopped {
+			logger.Info("Daily transfer budget removed, starting the torrent", "Name", torrent.Name)
+			if err := r.qbt(torrent).StartTorrent(ctx, qbTorrent.Hash); err != nil {
+				return false, fmt.Errorf("failed to start torrent: %w", err)
+			}
+		}
+		torrent.Status.TransferBudget = nil
+		return true, nil
+	}
+
+	now := metav1.Now()
+	budget := &torrentv1beta1.TransferBudgetStatus{WindowStart: metav1.NewTime(now.UTC().Truncate(budgetWindow))}
+	if last != nil {
+		budget.ExhaustedTime = last.ExhaustedTime
+		if last.WindowStart.Equal(&budget.WindowStart) {
+			budget.Consumed = last.Consumed
+		}
+	}
+	budget.Consumed += sessionTransferred(previous, torrent.Status.Transfer)
+	limit := torrent.Spec.DailyTransferBudget.Value()
+	// The bytes are counted even when the calls below fail, the transfer
+	// sample they come from is updated already
+	torrent.Status.TransferBudget = budget
+
+	switch {
+	case budget.Consumed >= limit && !stopped:
+		logger.Info("Daily transfer budget consumed, stopping the torrent", "Name", torrent.Name,
+			"Consumed", budget.Consumed, "Budget", limit)
+		if err := r.qbt(torrent).StopTorrent(ctx, qbTorrent.Hash); err != nil {
+			return false, fmt.Errorf("failed to stop torrent: %w", err)
+		}
+		if budget.ExhaustedTime == nil {
+			budget.ExhaustedTime = &now
+			r.recordEvent(torrent, corev1.EventTypeWarning, "TransferBudgetExhausted", fmt.Sprintf(
+				"The torrent transferred %s today, its daily budget of %s, it was stopped until %s",
+				formatQuantity(budget.Consumed), torrent.Spec.DailyTransferBudget.String(),
+				budget.WindowStart.Add(budgetWindow).Format(time.RFC3339)))
+		}
+	case budget.Consumed < limit && budget.ExhaustedTime != nil:
+		// A torrent stopped since by hand stays stopped
+		if stopped {
+			logger.Info("Daily transfer budget renewed, starting the torrent", "Name", torrent.Name)
+			if err := r.qbt(torrent).StartTorrent(ctx, qbTorrent.Hash); err != nil {
+				return false, fmt.Errorf("failed to start torrent: %w", err)
+			}
+		}
+		budget.ExhaustedTime = nil
+		r.recordEvent(torrent, corev1.EventTypeNormal, "TransferBudgetRenewed", fmt.Sprintf(
+			"The torrent transferred %s of its daily budget of %s, it was started again",
+			formatQuantity(budget.Consumed), torrent.Spec.DailyTransferBudget.String()))
+	}
+	return !equality.Semantic.DeepEqual(last, budget), nil
+}
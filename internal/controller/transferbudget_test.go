@@ -0,0 +1,177 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+var _ = Describe("Torrent daily transfer budget", func() {
+	const magnetHash = "c9e15763f722f23e98a29decdfae341b98d53056"
+
+	ctx := context.Background()
+
+	var qb *fakeQBittorrent
+	var controllerReconciler *TorrentReconciler
+	var recorder *record.FakeRecorder
+	var key types.NamespacedName
+
+	reconcileTorrent := func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getTorrent := func() *torrentv1beta1.Torrent {
+		torrent := &torrentv1beta1.Torrent{}
+		Expect(k8sClient.Get(ctx, key, torrent)).To(Succeed())
+		return torrent
+	}
+
+	BeforeEach(func() {
+		qb = newFakeQBittorrent()
+		key = types.NamespacedName{Name: "transfer-budget", Namespace: "default"}
+		recorder = record.NewFakeRecorder(20)
+		controllerReconciler = &TorrentReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Clients:  poolOf(qb.client()),
+			Recorder: recorder,
+			Config:   &OperatorConfig{},
+		}
+
+		Expect(k8sClient.Create(ctx, &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source:              torrentv1beta1.TorrentSource{MagnetURI: "magnet:?xt=urn:btih:" + magnetHash},
+				DailyTransferBudget: ptr.To(resource.MustParse("1Mi")),
+			},
+		})).To(Succeed())
+		reconcileTorrent()
+		reconcileTorrent()
+		qb.setState(magnetHash, "downloading")
+		reconcileTorrent()
+		budget := getTorrent().Status.TransferBudget
+		Expect(budget).NotTo(BeNil())
+		Expect(budget.Consumed).To(BeZero())
+		Expect(budget.WindowStart.Time).To(BeTemporally("~", time.Now().UTC().Truncate(24*time.Hour)))
+
+		By("counting the bytes downloaded and uploaded since the previous sync")
+		qb.setTransferred(magnetHash, 600<<10, 0)
+		reconcileTorrent()
+		Expect(getTorrent().Status.TransferBudget.Consumed).To(Equal(int64(600 << 10)))
+		Expect(qb.torrentState(magnetHash)).To(Equal("downloading"))
+
+		By("stopping the torrent once the budget is consumed")
+		qb.setTransferred(magnetHash, 900<<10, 200<<10)
+		reconcileTorrent()
+		budget = getTorrent().Status.TransferBudget
+		Expect(budget.Consumed).To(Equal(int64(1100 << 10)))
+		Expect(budget.ExhaustedTime).NotTo(BeNil())
+		Expect(qb.torrentState(magnetHash)).To(Equal("stoppedDL"))
+		Eventually(recorder.Events).Should(Receive(HavePrefix("Warning TransferBudgetExhausted")))
+	})
+
+	AfterEach(func() {
+		qb.Close()
+
+		torrent := getTorrent()
+		controllerutil.RemoveFinalizer(torrent, TorrentFinalizer)
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, torrent))).To(Succeed())
+	})
+
+	It("should keep the torrent stopped until the next day", func() {
+		By("stopping the torrent resumed by hand again")
+		qb.setState(magnetHash, "downloading")
+		reconcileTorrent()
+		Expect(qb.torrentState(magnetHash)).To(Equal("stoppedDL"))
+		Expect(recorder.Events).NotTo(Receive(HavePrefix("Warning TransferBudgetExhausted")))
+
+		By("starting it again once the next day starts")
+		torrent := getTorrent()
+		torrent.Status.TransferBudget.WindowStart = metav1.NewTime(
+			torrent.Status.TransferBudget.WindowStart.Add(-24 * time.Hour))
+		Expect(k8sClient.Status().Update(ctx, torrent)).To(Succeed())
+		reconcileTorrent()
+		budget := getTorrent().Status.TransferBudget
+		Expect(budget.Consumed).To(BeZero())
+		Expect(budget.ExhaustedTime).To(BeNil())
+		Expect(qb.torrentState(magnetHash)).To(Equal("downloading"))
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal TransferBudgetRenewed")))
+	})
+
+	It("should keep the seeding torrent stopped until the next day", func() {
+		// nextDay moves the window of the budget to the day before
+		nextDay := func() {
+			torrent := getTorrent()
+			torrent.Status.TransferBudget.WindowStart = metav1.NewTime(
+				torrent.Status.TransferBudget.WindowStart.Add(-24 * time.Hour))
+			Expect(k8sClient.Status().Update(ctx, torrent)).To(Succeed())
+			reconcileTorrent()
+		}
+
+		By("completing the torrent the next day")
+		nextDay()
+		qb.setCompleted(magnetHash, 1<<20)
+		reconcileTorrent()
+		Expect(qb.torrentState(magnetHash)).To(Equal("uploading"))
+
+		By("stopping the seeding torrent once the budget is consumed")
+		qb.setTransferred(magnetHash, 900<<10, 2<<20)
+		reconcileTorrent()
+		Expect(qb.torrentState(magnetHash)).To(Equal("stoppedUP"))
+		stops := qb.callCount("/api/v2/torrents/stop")
+		reconcileTorrent()
+		Expect(qb.callCount("/api/v2/torrents/stop")).To(Equal(stops))
+
+		By("starting it again once the next day starts")
+		nextDay()
+		Expect(getTorrent().Status.TransferBudget.ExhaustedTime).To(BeNil())
+		Expect(qb.torrentState(magnetHash)).To(Equal("uploading"))
+	})
+
+	It("should start the torrent again once the budget is removed", func() {
+		torrent := getTorrent()
+		torrent.Spec.DailyTransferBudget = nil
+		Expect(k8sClient.Update(ctx, torrent)).To(Succeed())
+		reconcileTorrent()
+		Expect(getTorrent().Status.TransferBudget).To(BeNil())
+		Expect(qb.torrentState(magnetHash)).To(Equal("downloading"))
+	})
+
+	It("should count the whole session after a restart of qBittorrent", func() {
+		Expect(sessionTransferred(
+			&torrentv1beta1.TransferStatus{DownloadedSession: 900, UploadedSession: 200},
+			&torrentv1beta1.TransferStatus{DownloadedSession: 100, UploadedSession: 50},
+		)).To(Equal(int64(150)))
+		Expect(sessionTransferred(nil, &torrentv1beta1.TransferStatus{DownloadedSession: 100})).To(BeZero())
+	})
+})
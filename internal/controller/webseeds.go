@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// webSeedChanges returns the web seeds of the spec missing from the torrent,
+// and the ones added before and since removed from the spec. The web seeds
+// of the torrent file, never added by the operator, are left untouched.
+func webSeedChanges(desired, added, current []string) (missing, removed []string) {
+	for _, url := range desired {
+		if !slices.Contains(current, url) && !slices.Contains(missing, url) {
+			missing = append(missing, url)
+		}
+	}
+	for _, url := range added {
+		if !slices.Contains(desired, url) && slices.Contains(current, url) {
+			removed = append(removed, url)
+		}
+	}
+	return missing, removed
+}
+
+// reconcileWebSeeds keeps the web seeds of the torrent in line with
+// spec.webSeeds, adding them back when removed from the WebUI. It returns
+// whether the status changed.
+func (r *TorrentReconciler) reconcileWebSeeds(ctx context.Context, torrent *torrentv1beta1.Torrent,
+	qbTorrent *qbittorrent.TorrentInfo) (bool, error) {
+	logger := log.FromContext(ctx)
+	if len(torrent.Spec.WebSeeds) == 0 && len(torrent.Status.WebSeeds) == 0 {
+		return false, nil
+	}
+
+	current, err := r.qbt(torrent).GetWebSeeds(ctx, qbTorrent.Hash)
+	if err != nil {
+		return false, fmt.Errorf("failed to get web seeds: %w", err)
+	}
+
+	missing, removed := webSeedChanges(torrent.Spec.WebSeeds, torrent.Status.WebSeeds, current)
+	if len(removed) > 0 {
+		logger.Info("Removing web seeds", "WebSeeds", removed)
+		if err := r.qbt(torrent).RemoveWebSeeds(ctx, qbTorrent.Hash, removed); err != nil {
+			return false, fmt.Errorf("failed to remove web seeds: %w", err)
+		}
+	}
+	if len(missing) > 0 {
+		logger.Info("Adding web seeds", "WebSeeds", missing)
+		if err := r.qbt(torrent).AddWebSeeds(ctx, qbTorrent.Hash, missing); err != nil {
+			return false, fmt.Errorf("failed to add web seeds: %w", err)
+		}
+	}
+
+	added := slices.Clone(torrent.Spec.WebSeeds)
+	slices.Sort(added)
+	added = slices.Compact(added)
+	if slices.Equal(torrent.Status.WebSeeds, added) {
+		return false, nil
+	}
+	torrent.Status.WebSeeds = added
+	return true, nil
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Web seeds", func() {
+	const (
+		origin = "https://datasets.example.com/imagenet/"
+		mirror = "https://mirror.example.com/imagenet/"
+		file   = "http://files.example.org/imagenet/"
+	)
+
+	It("should add the web seeds of the spec missing from the torrent", func() {
+		missing, removed := webSeedChanges([]string{origin, mirror, origin}, nil, []string{file, mirror})
+		Expect(missing).To(Equal([]string{origin}))
+		Expect(removed).To(BeEmpty())
+	})
+
+	It("should only remove the web seeds added by the operator", func() {
+		missing, removed := webSeedChanges([]string{origin}, []string{origin, mirror}, []string{file, origin, mirror})
+		Expect(missing).To(BeEmpty())
+		Expect(removed).To(Equal([]string{mirror}))
+
+		_, removed = webSeedChanges(nil, []string{origin}, []string{file})
+		Expect(removed).To(BeEmpty())
+	})
+})
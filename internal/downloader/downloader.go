@@ -0,0 +1,40 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package downloader defines the download clients the torrents of the
+// operator are managed on. qBittorrent is the reference implementation, in
+// the qbittorrent package; other clients such as Transmission or Deluge
+// implement Backend to be supported.
+package downloader
+
+import "context"
+
+// Backend is a download client managing torrents. The torrents are known by
+// their hash, the v1 info hash in lower case hex, or the v2 one truncated to
+// the length of a v1 hash for v2-only torrents.
+//
+// Only the calls the controller makes through the interface are part of it;
+// adding a torrent and reading its status still need the options and the
+// stats of qBittorrent, and are added here once routed through it.
+type Backend interface {
+	// Remove removes the torrent, with its downloaded files if deleteFiles.
+	// Removing a torrent not there is not an error.
+	Remove(ctx context.Context, hash string, deleteFiles bool) error
+	// Pause stops the transfers of the torrent
+	Pause(ctx context.Context, hash string) error
+	// Resume starts the transfers of the paused torrent
+	Resume(ctx context.Context, hash string) error
+}
@@ -0,0 +1,308 @@
+// Package logging masks the secrets found in the log output of the operator:
+// tracker passkeys, session IDs, cookies and credentials. Info hashes are kept.
+package logging
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+// Redacted replaces the secrets in the log output
+const Redacted = "REDACTED"
+
+var (
+	// urlPattern matches the magnet links and the URLs embedded in a text
+	urlPattern = regexp.MustCompile(`(?i)\b(?:magnet:\?|(?:https?|udp|wss?)://)[^\s"'<>]+`)
+	// cookiePattern matches the qbittorrent session cookie, e.g. in a header
+	cookiePattern = regexp.MustCompile(`\bSID=[^;\s"]+`)
+	// tokenPattern matches the values shaped like a passkey or an API key
+	tokenPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{16,}$`)
+	hexPattern   = regexp.MustCompile(`^[0-9A-Fa-f]+$`)
+	// jsonMemberPattern matches the JSON members with a string value, e.g.
+	// "web_ui_password":"secret"
+	jsonMemberPattern = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// jsonStringPattern matches the JSON strings
+	jsonStringPattern = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+)
+
+// sensitiveNames are the log keys and URL query parameters, lowercase and
+// without separators, whose values are always masked
+var sensitiveNames = []string{
+	"password", "passwd", "passkey", "authkey", "torrentpass", "apikey", "token", "secret",
+	"cookie", "authorization", "sessionid", "credential",
+}
+
+// IsSensitiveName reports whether the values of a log key, of a query
+// parameter or of a JSON member are secrets
+func IsSensitiveName(name string) bool {
+	name = strings.NewReplacer("_", "", "-", "", ".", "").Replace(strings.ToLower(name))
+	switch name {
+	case "sid", "pk", "key", "auth":
+		return true
+	}
+	for _, sensitive := range sensitiveNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// isToken reports whether s looks like a passkey. BitTorrent v1 and v2 info
+// hashes, 40 and 64 hex characters long, are not.
+func isToken(s string) bool {
+	if !tokenPattern.MatchString(s) {
+		return false
+	}
+	return !hexPattern.MatchString(s) || (len(s) != 40 && len(s) != 64)
+}
+
+// Redact masks the secrets of the URLs, magnet links and session cookies
+// found in s
+func Redact(s string) string {
+	s = urlPattern.ReplaceAllStringFunc(s, RedactURL)
+	return cookiePattern.ReplaceAllString(s, "SID="+Redacted)
+}
+
+// RedactJSON masks the secrets of a JSON text, possibly truncated: the
+// string values of the members with a sensitive name, and the secrets of the
+// URLs and magnet links of the other strings, once unescaped
+func RedactJSON(s string) string {
+	s = jsonMemberPattern.ReplaceAllStringFunc(s, func(member string) string {
+		match := jsonMemberPattern.FindStringSubmatch(member)
+		if !IsSensitiveName(match[1]) {
+			return member
+		}
+		return `"` + match[1] + `"` + match[2] + `"` + Redacted + `"`
+	})
+	s = jsonStringPattern.ReplaceAllStringFunc(s, func(quoted string) string {
+		var value string
+		if err := json.Unmarshal([]byte(quoted), &value); err != nil {
+			return quoted
+		}
+		redacted := Redact(value)
+		if redacted == value {
+			return quoted
+		}
+		encoded, _ := json.Marshal(redacted)
+		return string(encoded)
+	})
+	// The string cut by the truncation of the text is not matched above
+	return Redact(s)
+}
+
+// RedactURL masks the secrets of a URL: the password, the sensitive query
+// parameters and the path segments looking like passkeys. The trackers and
+// web seeds of a magnet link are redacted, its info hashes are kept.
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Redacted
+	}
+
+	if strings.EqualFold(u.Scheme, "magnet") {
+		u.RawQuery = redactQuery(u.RawQuery, func(name, value string) string {
+			switch name {
+			case "tr", "ws", "as", "xs":
+				return RedactURL(value)
+			}
+			if strings.HasPrefix(name, "tr.") {
+				return RedactURL(value)
+			}
+			return value
+		})
+		return u.String()
+	}
+
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), Redacted)
+		}
+	}
+	if u.Path != "" {
+		segments := strings.Split(u.Path, "/")
+		changed := false
+		for i, segment := range segments {
+			// Passkeys are also used as file names, e.g. <passkey>.torrent
+			name, ext, _ := strings.Cut(segment, ".")
+			if isToken(name) {
+				segments[i] = Redacted
+				if ext != "" {
+					segments[i] += "." + ext
+				}
+				changed = true
+			}
+		}
+		if changed {
+			u.Path = strings.Join(segments, "/")
+			u.RawPath = ""
+		}
+	}
+	u.RawQuery = redactQuery(u.RawQuery, func(name, value string) string {
+		if IsSensitiveName(name) || isToken(value) {
+			return Redacted
+		}
+		return value
+	})
+	return u.String()
+}
+
+// redactQuery replaces the values of a raw query, keeping the order and the
+// encoding of the parameters left unchanged
+func redactQuery(rawQuery string, redact func(name, value string) string) string {
+	if rawQuery == "" {
+		return rawQuery
+	}
+
+	params := strings.Split(rawQuery, "&")
+	for i, param := range params {
+		rawName, rawValue, found := strings.Cut(param, "=")
+		if !found {
+			continue
+		}
+		name, err := url.QueryUnescape(rawName)
+		if err != nil {
+			params[i] = rawName + "=" + Redacted
+			continue
+		}
+		value, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			params[i] = rawName + "=" + Redacted
+			continue
+		}
+		if redacted := redact(name, value); redacted != value {
+			params[i] = rawName + "=" + url.QueryEscape(redacted)
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// redactedError masks the secrets of the message of an error, keeping the
+// error in the chain
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return Redact(e.err.Error())
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// redactValue masks the secrets of a logged value
+func redactValue(key string, value any) any {
+	if IsSensitiveName(key) {
+		return Redacted
+	}
+
+	switch v := value.(type) {
+	case string:
+		return Redact(v)
+	case []string:
+		redacted := make([]string, len(v))
+		for i, s := range v {
+			redacted[i] = Redact(s)
+		}
+		return redacted
+	case error:
+		return &redactedError{err: v}
+	case *url.URL:
+		return RedactURL(v.String())
+	case map[string]string:
+		redacted := make(map[string]string, len(v))
+		for k, s := range v {
+			if IsSensitiveName(k) {
+				redacted[k] = Redacted
+			} else {
+				redacted[k] = Redact(s)
+			}
+		}
+		return redacted
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for k, item := range v {
+			redacted[k] = redactValue(k, item)
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			redacted[i] = redactValue("", item)
+		}
+		return redacted
+	}
+	return value
+}
+
+// redactKeysAndValues masks the secrets of the key/value pairs of a log line
+func redactKeysAndValues(keysAndValues []any) []any {
+	redacted := make([]any, len(keysAndValues))
+	copy(redacted, keysAndValues)
+	for i := 1; i < len(redacted); i += 2 {
+		key, _ := redacted[i-1].(string)
+		redacted[i] = redactValue(key, redacted[i])
+	}
+	return redacted
+}
+
+// redactingSink masks the secrets of the log lines before writing them to
+// the wrapped sink
+type redactingSink struct {
+	sink logr.LogSink
+}
+
+var _ logr.CallDepthLogSink = &redactingSink{}
+
+// NewRedactingLogger returns a logger masking the secrets of the messages,
+// errors and values logged through it before passing them to logger
+func NewRedactingLogger(logger logr.Logger) logr.Logger {
+	if logger.GetSink() == nil {
+		return logger
+	}
+	return logr.New(&redactingSink{sink: logger.GetSink()})
+}
+
+func (s *redactingSink) Init(info logr.RuntimeInfo) {
+	// The wrapped sink is already initialized, only skip the frame of this sink
+	if sink, ok := s.sink.(logr.CallDepthLogSink); ok {
+		s.sink = sink.WithCallDepth(1)
+	}
+}
+
+func (s *redactingSink) Enabled(level int) bool {
+	return s.sink.Enabled(level)
+}
+
+func (s *redactingSink) Info(level int, msg string, keysAndValues ...any) {
+	s.sink.Info(level, Redact(msg), redactKeysAndValues(keysAndValues)...)
+}
+
+func (s *redactingSink) Error(err error, msg string, keysAndValues ...any) {
+	var redacted error
+	if err != nil {
+		redacted = &redactedError{err: err}
+	}
+	s.sink.Error(redacted, Redact(msg), redactKeysAndValues(keysAndValues)...)
+}
+
+func (s *redactingSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return &redactingSink{sink: s.sink.WithValues(redactKeysAndValues(keysAndValues)...)}
+}
+
+func (s *redactingSink) WithName(name string) logr.LogSink {
+	return &redactingSink{sink: s.sink.WithName(name)}
+}
+
+func (s *redactingSink) WithCallDepth(depth int) logr.LogSink {
+	sink, ok := s.sink.(logr.CallDepthLogSink)
+	if !ok {
+		return s
+	}
+	return &redactingSink{sink: sink.WithCallDepth(depth)}
+}
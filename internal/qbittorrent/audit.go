@@ -0,0 +1,94 @@
+package qbittorrent
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/guidonguido/qbittorrent-operator/internal/logging"
+)
+
+// AuditEvent records a call changing the state of qbittorrent
+type AuditEvent struct {
+	Time time.Time `json:"time"`
+	// Object is the resource the call was made for, e.g. Torrent default/ubuntu
+	Object string `json:"object,omitempty"`
+	// Operation is the qbittorrent API endpoint, e.g. torrents/delete
+	Operation string `json:"operation"`
+	// Hashes of the torrents affected by the call, if known
+	Hashes string `json:"hashes,omitempty"`
+	// Parameters of the call relevant to what was changed, with the secrets
+	// such as the tracker passkeys of the magnet URIs redacted
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// Result is success, failure, or dryRun when the call was skipped
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// AuditLog writes an AuditEvent per line, as JSON, for every mutating call
+// of the clients it is set on
+type AuditLog struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewAuditLog creates an audit log writing to w
+func NewAuditLog(w io.Writer) *AuditLog {
+	return &AuditLog{encoder: json.NewEncoder(w)}
+}
+
+type auditObjectKey struct{}
+
+// WithAuditObject returns a context recording object as the resource the
+// qbittorrent calls made with it are made for
+func WithAuditObject(ctx context.Context, object string) context.Context {
+	return context.WithValue(ctx, auditObjectKey{}, object)
+}
+
+// record writes the event of a call, its parameters and error redacted as
+// in the logs. Nothing is recorded on a nil audit log.
+func (a *AuditLog) record(ctx context.Context, path, hashes string, parameters map[string]string,
+	dryRun bool, err error) {
+	if a == nil {
+		return
+	}
+
+	event := AuditEvent{
+		Time:       time.Now().UTC(),
+		Operation:  strings.TrimPrefix(path, "/api/v2/"),
+		Hashes:     hashes,
+		Parameters: redactParameters(parameters),
+		Result:     "success",
+	}
+	event.Object, _ = ctx.Value(auditObjectKey{}).(string)
+	switch {
+	case dryRun:
+		event.Result = "dryRun"
+	case err != nil:
+		event.Result = "failure"
+		event.Error = logging.Redact(err.Error())
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.encoder.Encode(event); err != nil {
+		log.FromContext(ctx).WithName("qbittorrent-client").Error(err, "Failed to write audit event")
+	}
+}
+
+// redactParameters returns a copy of the parameters with their secrets redacted
+func redactParameters(parameters map[string]string) map[string]string {
+	if parameters == nil {
+		return nil
+	}
+	redacted := make(map[string]string, len(parameters))
+	for name, value := range parameters {
+		redacted[name] = logging.Redact(value)
+	}
+	return redacted
+}
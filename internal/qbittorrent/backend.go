@@ -0,0 +1,35 @@
+package qbittorrent
+
+import (
+	"context"
+
+	"github.com/guidonguido/qbittorrent-operator/internal/downloader"
+)
+
+// Backend is the reference implementation of downloader.Backend, managing
+// the torrents on qBittorrent with a Client
+type Backend struct {
+	client *Client
+}
+
+var _ downloader.Backend = (*Backend)(nil)
+
+// NewBackend returns the downloader.Backend of the client
+func NewBackend(client *Client) *Backend {
+	return &Backend{client: client}
+}
+
+// Remove removes the torrent, qBittorrent ignores the unknown hashes
+func (b *Backend) Remove(ctx context.Context, hash string, deleteFiles bool) error {
+	return b.client.DeleteTorrent(ctx, hash, deleteFiles)
+}
+
+// Pause stops the torrent
+func (b *Backend) Pause(ctx context.Context, hash string) error {
+	return b.client.StopTorrent(ctx, hash)
+}
+
+// Resume starts the stopped torrent
+func (b *Backend) Resume(ctx context.Context, hash string) error {
+	return b.client.StartTorrent(ctx, hash)
+}
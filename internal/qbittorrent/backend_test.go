@@ -0,0 +1,38 @@
+package qbittorrent
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/guidonguido/qbittorrent-operator/internal/downloader"
+)
+
+func TestBackend(t *testing.T) {
+	hash := "c9e15763f722f23e98a29decdfae341b98d53056"
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+	}))
+	defer server.Close()
+
+	var backend downloader.Backend = NewBackend(NewClient(server.URL))
+	ctx := context.Background()
+
+	if err := backend.Pause(ctx, hash); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := backend.Resume(ctx, hash); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := backend.Remove(ctx, hash, true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{"/api/v2/torrents/stop", "/api/v2/torrents/start", "/api/v2/torrents/delete"}
+	if !slices.Equal(paths, expected) {
+		t.Errorf("Expected calls %v, got %v", expected, paths)
+	}
+
+}
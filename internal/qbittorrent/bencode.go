@@ -0,0 +1,303 @@
+package qbittorrent
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TorrentFile is the metadata of a .torrent file needed to reconcile it
+type TorrentFile struct {
+	// Name is the suggested name of the content, from the info dictionary
+	Name string
+	// InfoHashes are computed from the info dictionary
+	InfoHashes InfoHashes
+	// TotalSize is the size of the content in bytes, summed over its files
+	TotalSize int64
+}
+
+// TorrentFileError is returned when a .torrent file cannot be decoded
+type TorrentFileError struct {
+	Err error
+}
+
+func (e *TorrentFileError) Error() string {
+	return fmt.Sprintf("invalid torrent file: %v", e.Err)
+}
+
+func (e *TorrentFileError) Unwrap() error {
+	return e.Err
+}
+
+// ParseTorrentFile decodes a bencoded .torrent file and computes its info
+// hashes, the v1 one for files with pieces and the v2 one for files with
+// meta version 2. Hybrid torrents have both. Files larger than
+// MaxTorrentFileSize are rejected.
+func ParseTorrentFile(data []byte) (*TorrentFile, error) {
+	if len(data) > MaxTorrentFileSize {
+		return nil, &TorrentFileError{Err: fmt.Errorf("larger than %d bytes", MaxTorrentFileSize)}
+	}
+	file, err := parseTorrentFile(data)
+	if err != nil {
+		return nil, &TorrentFileError{Err: err}
+	}
+	return file, nil
+}
+
+func parseTorrentFile(data []byte) (*TorrentFile, error) {
+	d := &bencodeDecoder{data: data}
+
+	// The raw bytes of the info dictionary are hashed, so the top level
+	// dictionary is walked by hand to keep track of where it starts and ends
+	if err := d.expect('d'); err != nil {
+		return nil, err
+	}
+	var rawInfo []byte
+	var info map[string]any
+	keys := map[string]bool{}
+	for d.peek() != 'e' {
+		offset := d.pos
+		key, err := d.decodeString()
+		if err != nil {
+			return nil, err
+		}
+		// A second info dictionary would make the hash ambiguous
+		if keys[key] {
+			return nil, fmt.Errorf("duplicate key %q at offset %d", key, offset)
+		}
+		keys[key] = true
+		start := d.pos
+		value, err := d.decode()
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of key %q: %w", key, err)
+		}
+		if key == "info" {
+			dict, ok := value.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("info is not a dictionary")
+			}
+			rawInfo = d.data[start:d.pos]
+			info = dict
+		}
+	}
+	if err := d.expect('e'); err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.data) {
+		return nil, fmt.Errorf("unexpected data after the torrent dictionary at offset %d", d.pos)
+	}
+	if info == nil {
+		return nil, fmt.Errorf("no info dictionary")
+	}
+
+	file := &TorrentFile{}
+	if name, ok := info["name"].(string); ok {
+		file.Name = name
+	}
+	if _, ok := info["pieces"]; ok {
+		sum := sha1.Sum(rawInfo)
+		file.InfoHashes.V1 = hex.EncodeToString(sum[:])
+	}
+	if version, ok := info["meta version"].(int64); ok && version == 2 {
+		sum := sha256.Sum256(rawInfo)
+		file.InfoHashes.V2 = hex.EncodeToString(sum[:])
+	}
+	if file.InfoHashes.V1 == "" && file.InfoHashes.V2 == "" {
+		return nil, fmt.Errorf("info dictionary has neither pieces nor meta version 2")
+	}
+	file.TotalSize = contentSize(info)
+
+	return file, nil
+}
+
+// contentSize sums the lengths of the files of the info dictionary: the
+// single file length, the v1 files list or else the v2 file tree
+func contentSize(info map[string]any) int64 {
+	if length, ok := info["length"].(int64); ok {
+		return length
+	}
+	var size int64
+	if files, ok := info["files"].([]any); ok {
+		for _, entry := range files {
+			if file, ok := entry.(map[string]any); ok {
+				if length, ok := file["length"].(int64); ok {
+					size += length
+				}
+			}
+		}
+		return size
+	}
+
+	// The files of the v2 tree are dictionaries under an empty key
+	var walk func(tree map[string]any)
+	walk = func(tree map[string]any) {
+		for name, value := range tree {
+			node, ok := value.(map[string]any)
+			if !ok {
+				continue
+			}
+			if name == "" {
+				if length, ok := node["length"].(int64); ok {
+					size += length
+				}
+				continue
+			}
+			walk(node)
+		}
+	}
+	if tree, ok := info["file tree"].(map[string]any); ok {
+		walk(tree)
+	}
+	return size
+}
+
+// bencodeDecoder decodes bencoded values into int64, string, []any and
+// map[string]any
+type bencodeDecoder struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+// maxBencodeDepth bounds the nesting of lists and dictionaries, torrent files
+// only nest a few levels
+const maxBencodeDepth = 32
+
+func (d *bencodeDecoder) peek() byte {
+	if d.pos >= len(d.data) {
+		return 0
+	}
+	return d.data[d.pos]
+}
+
+func (d *bencodeDecoder) expect(c byte) error {
+	if d.peek() != c {
+		return fmt.Errorf("expected %q at offset %d", c, d.pos)
+	}
+	d.pos++
+	return nil
+}
+
+func (d *bencodeDecoder) decode() (any, error) {
+	if d.depth > maxBencodeDepth {
+		return nil, fmt.Errorf("nesting deeper than %d levels at offset %d", maxBencodeDepth, d.pos)
+	}
+	d.depth++
+	defer func() { d.depth-- }()
+
+	switch c := d.peek(); {
+	case c == 'i':
+		return d.decodeInt()
+	case c == 'l':
+		d.pos++
+		list := []any{}
+		for d.peek() != 'e' {
+			value, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		d.pos++
+		return list, nil
+	case c == 'd':
+		d.pos++
+		dict := map[string]any{}
+		for d.peek() != 'e' {
+			offset := d.pos
+			key, err := d.decodeString()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := dict[key]; ok {
+				return nil, fmt.Errorf("duplicate key %q at offset %d", key, offset)
+			}
+			value, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			dict[key] = value
+		}
+		d.pos++
+		return dict, nil
+	case c >= '0' && c <= '9':
+		return d.decodeString()
+	case c == 0:
+		return nil, fmt.Errorf("unexpected end of data")
+	default:
+		return nil, fmt.Errorf("unexpected %q at offset %d", c, d.pos)
+	}
+}
+
+// decodeInt decodes i<digits>e
+func (d *bencodeDecoder) decodeInt() (int64, error) {
+	if err := d.expect('i'); err != nil {
+		return 0, err
+	}
+	end := d.indexFrom('e')
+	if end < 0 {
+		return 0, fmt.Errorf("unterminated integer at offset %d", d.pos)
+	}
+	digits := string(d.data[d.pos:end])
+	if !isCanonicalInt(digits, true) {
+		return 0, fmt.Errorf("invalid integer %q at offset %d", digits, d.pos)
+	}
+	value, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid integer at offset %d: %w", d.pos, err)
+	}
+	d.pos = end + 1
+	return value, nil
+}
+
+// decodeString decodes <length>:<bytes>
+func (d *bencodeDecoder) decodeString() (string, error) {
+	colon := d.indexFrom(':')
+	if colon < 0 {
+		return "", fmt.Errorf("invalid string at offset %d", d.pos)
+	}
+	digits := string(d.data[d.pos:colon])
+	length, err := strconv.Atoi(digits)
+	if err != nil || !isCanonicalInt(digits, false) {
+		return "", fmt.Errorf("invalid string length at offset %d", d.pos)
+	}
+	start := colon + 1
+	if length > len(d.data)-start {
+		return "", fmt.Errorf("string at offset %d exceeds the data", d.pos)
+	}
+	d.pos = start + length
+	return string(d.data[start:d.pos]), nil
+}
+
+// isCanonicalInt reports whether the digits are the only bencoding of their
+// value: no sign other than a leading minus if signed, no leading zero and no
+// negative zero. Other encodings would hash differently than on qBittorrent.
+func isCanonicalInt(digits string, signed bool) bool {
+	if signed && strings.HasPrefix(digits, "-") {
+		digits = digits[1:]
+		if digits == "0" {
+			return false
+		}
+	}
+	if digits == "" || (len(digits) > 1 && digits[0] == '0') {
+		return false
+	}
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *bencodeDecoder) indexFrom(c byte) int {
+	for i := d.pos; i < len(d.data); i++ {
+		if d.data[i] == c {
+			return i
+		}
+	}
+	return -1
+}
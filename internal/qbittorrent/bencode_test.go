@@ -0,0 +1,128 @@
+package qbittorrent
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const (
+	v1Info     = "d6:lengthi5e4:name4:test12:piece lengthi16384e6:pieces20:aaaaaaaaaaaaaaaaaaaae"
+	hybridInfo = "d6:lengthi5e12:meta versioni2e4:name6:hybrid12:piece lengthi16384e6:pieces20:aaaaaaaaaaaaaaaaaaaae"
+)
+
+func TestParseTorrentFile_V1(t *testing.T) {
+	file, err := ParseTorrentFile([]byte("d8:announce23:udp://tracker.test:69694:info" + v1Info + "e"))
+	if err != nil {
+		t.Fatalf("Expected torrent file to parse, got %v", err)
+	}
+
+	if file.Name != "test" {
+		t.Errorf("Expected name 'test', got '%s'", file.Name)
+	}
+	if file.InfoHashes.V1 != "c51a652658874d442e871ff7c284c828051b7c36" || file.InfoHashes.V2 != "" {
+		t.Errorf("Expected the v1 hash of the info dictionary, got %+v", file.InfoHashes)
+	}
+	if file.TotalSize != 5 {
+		t.Errorf("Expected a size of 5 bytes, got %d", file.TotalSize)
+	}
+}
+
+func TestParseTorrentFile_TotalSize(t *testing.T) {
+	for name, tt := range map[string]struct {
+		info string
+		want int64
+	}{
+		"files": {
+			info: "d5:filesld6:lengthi3e4:pathl1:aeed6:lengthi4e4:pathl1:beee4:name3:dir12:piece lengthi16384e6:pieces20:aaaaaaaaaaaaaaaaaaaae",
+			want: 7,
+		},
+		"file tree": {
+			info: "d9:file treed1:ad0:d6:lengthi3eee3:dird1:bd0:d6:lengthi4eeeee12:meta versioni2e4:name3:dir12:piece lengthi16384ee",
+			want: 7,
+		},
+	} {
+		file, err := ParseTorrentFile([]byte("d4:info" + tt.info + "e"))
+		if err != nil {
+			t.Fatalf("%s: expected torrent file to parse, got %v", name, err)
+		}
+		if file.TotalSize != tt.want {
+			t.Errorf("%s: expected a size of %d bytes, got %d", name, tt.want, file.TotalSize)
+		}
+	}
+}
+
+func TestParseTorrentFile_Hybrid(t *testing.T) {
+	file, err := ParseTorrentFile([]byte("d4:info" + hybridInfo + "e"))
+	if err != nil {
+		t.Fatalf("Expected torrent file to parse, got %v", err)
+	}
+
+	if file.InfoHashes.V1 != "e8ecd3d7afc03d49db64b8b10ccf68dbba81be04" ||
+		file.InfoHashes.V2 != "efc0ba1fc677db7e39e1114fcf6bf2f6fe89d3fb0f829e467bbb1983645dd73e" {
+		t.Errorf("Expected both hashes of the info dictionary, got %+v", file.InfoHashes)
+	}
+}
+
+func TestParseTorrentFile_Errors(t *testing.T) {
+	for name, data := range map[string]string{
+		"empty":           "",
+		"not a dict":      "l4:infoe",
+		"no info":         "d8:announce4:teste",
+		"info not a dict": "d4:info4:teste",
+		"no pieces":       "d4:infod4:name4:testee",
+		"truncated":       "d4:info" + v1Info[:20],
+		"bad string":      "d4:info" + "d4:name99:teste" + "e",
+		"bad integer":     "d4:infod6:lengthi5x5eee",
+		"trailing data":   "d4:info" + v1Info + "e" + "garbage",
+		"too deep":        "d4:info" + strings.Repeat("l", 100) + strings.Repeat("e", 100) + "e",
+		"leading zero":    "d4:infod6:lengthi05eee",
+		"negative zero":   "d4:infod6:lengthi-0eee",
+		"signed length":   "d4:infod4:name+4:testee",
+		"padded length":   "d4:infod4:name04:testee",
+		"duplicate key":   "d4:infod4:name4:test4:name4:testee",
+		"duplicate info":  "d4:info" + v1Info + "4:info" + hybridInfo + "e",
+		"too large":       "d4:info" + v1Info + "7:comment" + strconv.Itoa(MaxTorrentFileSize) + ":" + strings.Repeat("a", MaxTorrentFileSize) + "e",
+	} {
+		_, err := ParseTorrentFile([]byte(data))
+		var fileErr *TorrentFileError
+		if !errors.As(err, &fileErr) {
+			t.Errorf("Expected a TorrentFileError for %s, got %v", name, err)
+		}
+	}
+}
+
+// FuzzParseTorrentFile checks that any data either fails to decode, or yields
+// well-formed hashes
+func FuzzParseTorrentFile(f *testing.F) {
+	for _, data := range []string{
+		"d8:announce23:udp://tracker.test:69694:info" + v1Info + "e",
+		"d4:info" + hybridInfo + "e",
+		"d4:info" + v1Info[:20],
+		"d4:infod6:lengthi-0eee",
+		"d4:info" + strings.Repeat("l", 40) + strings.Repeat("e", 40) + "e",
+	} {
+		f.Add([]byte(data))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		file, err := ParseTorrentFile(data)
+		if err != nil {
+			var fileErr *TorrentFileError
+			if !errors.As(err, &fileErr) {
+				t.Fatalf("Expected a TorrentFileError, got %v", err)
+			}
+			return
+		}
+
+		if file.InfoHashes.V1 == "" && file.InfoHashes.V2 == "" {
+			t.Fatalf("Expected a hash for %q", data)
+		}
+		for hash, length := range map[string]int{file.InfoHashes.V1: v1HashLength, file.InfoHashes.V2: v2HashLength} {
+			if hash != "" && len(hash) != length {
+				t.Fatalf("Expected a hash of %d characters for %q, got '%s'", length, data, hash)
+			}
+		}
+	})
+}
@@ -0,0 +1,235 @@
+package qbittorrent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/guidonguido/qbittorrent-operator/internal/logging"
+)
+
+// Defaults of the HTTP capture
+const (
+	DefaultHTTPCaptureSize = 100
+	// maxCapturedBody is the number of bytes of the bodies kept
+	maxCapturedBody = 4 << 10
+)
+
+// HTTPExchange is a request to qbittorrent and its response, with the
+// secrets redacted as in the logs and the bodies truncated
+type HTTPExchange struct {
+	Time            time.Time           `json:"time"`
+	Method          string              `json:"method"`
+	URL             string              `json:"url"`
+	RequestHeaders  map[string][]string `json:"requestHeaders,omitempty"`
+	RequestBody     string              `json:"requestBody,omitempty"`
+	Status          int                 `json:"status,omitempty"`
+	ResponseHeaders map[string][]string `json:"responseHeaders,omitempty"`
+	ResponseBody    string              `json:"responseBody,omitempty"`
+	// Duration is the time until the response headers were received
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// HTTPCapture keeps the last exchanges of the clients it is set on, in a
+// ring buffer, for the bug reports about incompatible qbittorrent versions
+type HTTPCapture struct {
+	mu        sync.Mutex
+	exchanges []HTTPExchange
+	next      int
+	full      bool
+}
+
+// NewHTTPCapture creates a capture keeping the last size exchanges
+func NewHTTPCapture(size int) *HTTPCapture {
+	if size <= 0 {
+		size = DefaultHTTPCaptureSize
+	}
+	return &HTTPCapture{exchanges: make([]HTTPExchange, size)}
+}
+
+// Exchanges returns the captured exchanges, the oldest first
+func (c *HTTPCapture) Exchanges() []HTTPExchange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.full {
+		return append([]HTTPExchange(nil), c.exchanges[:c.next]...)
+	}
+	return append(append([]HTTPExchange(nil), c.exchanges[c.next:]...), c.exchanges[:c.next]...)
+}
+
+// ServeHTTP serves the captured exchanges as JSON
+func (c *HTTPCapture) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(c.Exchanges())
+}
+
+func (c *HTTPCapture) add(exchange HTTPExchange) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.exchanges[c.next] = exchange
+	c.next = (c.next + 1) % len(c.exchanges)
+	if c.next == 0 {
+		c.full = true
+	}
+}
+
+// SetHTTPCapture records the requests of the client and their responses in
+// the capture. Nil stops the capture.
+func (c *Client) SetHTTPCapture(capture *HTTPCapture) {
+	c.transport.capture.Store(capture)
+}
+
+// roundTrip makes the request, capturing it once its response body is
+// closed, or on failure
+func (c *HTTPCapture) roundTrip(next http.RoundTripper, req *http.Request, secrets http.Header) (*http.Response, error) {
+	exchange := HTTPExchange{
+		Time:           time.Now().UTC(),
+		Method:         req.Method,
+		URL:            redactURL(req.URL),
+		RequestHeaders: redactHeaders(req.Header, secrets),
+		RequestBody:    requestBody(req),
+	}
+	resp, err := next.RoundTrip(req)
+	exchange.Duration = time.Since(exchange.Time)
+	if err != nil {
+		exchange.Error = err.Error()
+		c.add(exchange)
+		return resp, err
+	}
+	exchange.Status = resp.StatusCode
+	exchange.ResponseHeaders = redactHeaders(resp.Header, secrets)
+	resp.Body = &capturedBody{ReadCloser: resp.Body, done: func(body *bytes.Buffer, total int) {
+		exchange.ResponseBody = capturedText(resp.Header.Get("Content-Type"), body.Bytes(), total)
+		c.add(exchange)
+	}}
+	return resp, nil
+}
+
+// capturedBody keeps the first bytes of the body read
+type capturedBody struct {
+	io.ReadCloser
+	buf   bytes.Buffer
+	total int
+	once  sync.Once
+	done  func(body *bytes.Buffer, total int)
+}
+
+func (b *capturedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if keep := min(n, maxCapturedBody-b.buf.Len()); keep > 0 {
+		b.buf.Write(p[:keep])
+	}
+	b.total += n
+	return n, err
+}
+
+// Close captures the body, reading its first bytes if the client did not,
+// e.g. on an unexpected status
+func (b *capturedBody) Close() error {
+	b.once.Do(func() {
+		if b.buf.Len() < maxCapturedBody {
+			n, _ := io.CopyN(&b.buf, b.ReadCloser, int64(maxCapturedBody-b.buf.Len()))
+			b.total += int(n)
+		}
+		b.done(&b.buf, b.total)
+	})
+	return b.ReadCloser.Close()
+}
+
+// requestBody returns the captured body of the request, read from a copy
+func requestBody(req *http.Request) string {
+	if req.Body == nil || req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = body.Close() }()
+	data, _ := io.ReadAll(io.LimitReader(body, maxCapturedBody))
+	total := len(data)
+	if req.ContentLength > int64(total) {
+		total = int(req.ContentLength)
+	}
+	return capturedText(req.Header.Get("Content-Type"), data, total)
+}
+
+// capturedText redacts and truncates a body, the binary ones such as the
+// .torrent files uploaded are only described. The magnet URIs and tracker
+// URLs of the torrent lists have their passkeys redacted.
+func capturedText(contentType string, data []byte, total int) string {
+	if total == 0 {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	var text string
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		text = redactForm(string(data))
+	case mediaType == "application/json" || strings.HasPrefix(mediaType, "text/") || mediaType == "":
+		text = logging.RedactJSON(string(data))
+	default:
+		return fmt.Sprintf("<%d bytes of %s>", total, mediaType)
+	}
+	if total > len(data) {
+		text += fmt.Sprintf("... <%d bytes truncated>", total-len(data))
+	}
+	return text
+}
+
+// redactForm redacts the sensitive fields of a form, and the secrets of
+// every other value: the passkeys of the magnet URIs and URLs added, and the
+// sensitive JSON members of the preferences set. A form that does not parse
+// is redacted as a whole.
+func redactForm(form string) string {
+	values, err := url.ParseQuery(form)
+	if err != nil {
+		return logging.Redact(form)
+	}
+	for key, vals := range values {
+		for i := range vals {
+			if logging.IsSensitiveName(key) {
+				vals[i] = logging.Redacted
+			} else {
+				vals[i] = logging.RedactJSON(vals[i])
+			}
+		}
+	}
+	return values.Encode()
+}
+
+// redactURL redacts the sensitive query parameters and path passkeys of the URL
+func redactURL(u *url.URL) string {
+	return logging.RedactURL(u.String())
+}
+
+// redactHeaders copies the headers, with the sensitive ones such as the
+// cookies and the ones set on the client, which may authenticate to a reverse
+// proxy, redacted. The secrets of the other values, e.g. a Location URL, are.
+func redactHeaders(headers, secrets http.Header) map[string][]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	copied := make(map[string][]string, len(headers))
+	for name, values := range headers {
+		if _, secret := secrets[name]; secret || logging.IsSensitiveName(name) {
+			copied[name] = []string{logging.Redacted}
+			continue
+		}
+		copied[name] = make([]string, len(values))
+		for i, value := range values {
+			copied[name][i] = logging.Redact(value)
+		}
+	}
+	return copied
+}
@@ -0,0 +1,122 @@
+package qbittorrent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/guidonguido/qbittorrent-operator/internal/logging"
+)
+
+func TestClient_HTTPCapture(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/auth/login":
+			http.SetCookie(w, &http.Cookie{Name: "SID", Value: "session"})
+			_, _ = w.Write([]byte("Ok."))
+		case "/api/v2/app/version":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("v5.0.4" + strings.Repeat(" ", maxCapturedBody)))
+		}
+	}))
+	defer server.Close()
+
+	capture := NewHTTPCapture(2)
+	client := NewClient(server.URL)
+	client.SetHeaders(http.Header{"X-Proxy-Auth": {"proxy-secret"}})
+	client.SetHTTPCapture(capture)
+	ctx := context.Background()
+
+	if err := client.Login(ctx, "admin", "hunter2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.SetPreferences(ctx, map[string]any{"web_ui_password": "hunter3", "dht": true}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.GetVersion(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The ring buffer keeps the last exchanges
+	exchanges := capture.Exchanges()
+	if len(exchanges) != 2 || !strings.HasSuffix(exchanges[0].URL, "/api/v2/app/setPreferences") ||
+		!strings.HasSuffix(exchanges[1].URL, "/api/v2/app/version") {
+		t.Fatalf("Expected the last 2 exchanges, got %+v", exchanges)
+	}
+	preferences, version := exchanges[0], exchanges[1]
+	if strings.Contains(preferences.RequestBody, "hunter3") || !strings.Contains(preferences.RequestBody, "dht") {
+		t.Errorf("Expected the password redacted, got %s", preferences.RequestBody)
+	}
+	for _, exchange := range exchanges {
+		if exchange.RequestHeaders["Cookie"][0] != logging.Redacted || exchange.RequestHeaders["X-Proxy-Auth"][0] != logging.Redacted {
+			t.Errorf("Expected the cookie and the headers of the client redacted, got %v", exchange.RequestHeaders)
+		}
+	}
+	if version.Status != http.StatusOK || !strings.HasPrefix(version.ResponseBody, "v5.0.4") ||
+		!strings.HasSuffix(version.ResponseBody, "... <6 bytes truncated>") {
+		t.Errorf("Expected the version truncated, got %d %q", version.Status, version.ResponseBody)
+	}
+
+	capture = NewHTTPCapture(10)
+	client.SetHTTPCapture(capture)
+	if err := client.Login(ctx, "admin", "hunter2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	login := capture.Exchanges()[0]
+	if strings.Contains(login.RequestBody, "hunter2") || !strings.Contains(login.RequestBody, "username=admin") ||
+		login.ResponseHeaders["Set-Cookie"][0] != logging.Redacted || login.ResponseBody != "Ok." {
+		t.Errorf("Expected the credentials redacted, got %+v", login)
+	}
+}
+
+func TestClient_HTTPCapturePasskeys(t *testing.T) {
+	const passkey = "0123456789abcdef0123456789abcdef"
+	tracker := "https://tracker.example/" + passkey + "/announce"
+	magnetURI := "magnet:?xt=urn:btih:c9e15763f722f23e98a29decdfae341b98d53056&tr=" + url.QueryEscape(tracker) +
+		"&tr=" + url.QueryEscape("http://tracker.example/announce.php?passkey="+passkey)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/torrents/add":
+			_, _ = w.Write([]byte("Ok."))
+		case "/api/v2/torrents/info":
+			w.Header().Set("Content-Type", "application/json")
+			body, _ := json.Marshal([]map[string]any{{
+				"hash":       "c9e15763f722f23e98a29decdfae341b98d53056",
+				"tracker":    tracker,
+				"magnet_uri": magnetURI,
+			}})
+			_, _ = w.Write(body)
+		}
+	}))
+	defer server.Close()
+
+	capture := NewHTTPCapture(10)
+	client := NewClient(server.URL)
+	client.SetHTTPCapture(capture)
+	ctx := context.Background()
+
+	if err := client.AddTorrent(ctx, magnetURI); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.GetTorrentsInfo(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	exchanges := capture.Exchanges()
+	if len(exchanges) != 2 {
+		t.Fatalf("Expected 2 exchanges, got %+v", exchanges)
+	}
+	for _, exchange := range exchanges {
+		if strings.Contains(exchange.URL+exchange.RequestBody+exchange.ResponseBody, passkey) {
+			t.Errorf("Expected the passkeys redacted, got %+v", exchange)
+		}
+	}
+	if !strings.Contains(exchanges[1].ResponseBody, "c9e15763f722f23e98a29decdfae341b98d53056") ||
+		!strings.Contains(exchanges[1].ResponseBody, "https://tracker.example/REDACTED/announce") {
+		t.Errorf("Expected the info hash kept and the tracker redacted, got %s", exchanges[1].ResponseBody)
+	}
+}
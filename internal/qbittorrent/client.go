@@ -3,14 +3,22 @@ package qbittorrent
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"golang.org/x/time/rate"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -18,35 +26,355 @@ import (
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
-	sessionID  string // SID obtained from login
+	transport  *transport
+	// fetchClient downloads the .torrent files and blocklists of third-party
+	// servers, without the TLS settings, proxy and headers of qbittorrent:
+	// the system roots verify them and the client certificate is not offered
+	fetchClient *http.Client
+	audit       *AuditLog
+	dryRun      bool
+	restarts    restartDetector
+	// listed is the size of the last list of all the torrents, the next one is
+	// allocated at once with this capacity
+	listed atomic.Int64
+	// pageSize is the number of torrents listed per call, all of them at once if 0
+	pageSize atomic.Int64
+	// limiter bounds the rate of the calls to qbittorrent, unlimited if nil
+	limiter atomic.Pointer[rate.Limiter]
+	// observer is told the outcome of every call, none if nil
+	observer atomic.Pointer[func(failed bool)]
+	// schema gathers the fields of the responses differing from the decoded ones
+	schema schemaChecker
+
+	// sessionMu guards the SID obtained from login, and the credentials
+	// used to log in again when the session expires
+	sessionMu sync.Mutex
+	sessionID string
+	username  string
+	password  string
+	loggedIn  bool
 }
 
 // Struct representing a torrent object returned by the qbittorrent API
 // from /api/v2/torrents/info
 // the struct maps only the fields we need
 type TorrentInfo struct {
-	AddedOn     int64  `json:"added_on"`
-	AmountLeft  int64  `json:"amount_left"`
-	ContentPath string `json:"content_path"`
-	Hash        string `json:"hash"`
-	MagnetURI   string `json:"magnet_uri"`
-	Name        string `json:"name"`
-	Size        int64  `json:"size"`
-	State       string `json:"state"`
-	TotalSize   int64  `json:"total_size"`
-	TimeActive  int64  `json:"time_active"`
+	AddedOn                  int64   `json:"added_on"`
+	AmountLeft               int64   `json:"amount_left"`
+	Category                 string  `json:"category"`
+	ContentPath              string  `json:"content_path"`
+	DLLimit                  int64   `json:"dl_limit"`
+	DLSpeed                  int64   `json:"dlspeed"`
+	Downloaded               int64   `json:"downloaded"`
+	Hash                     string  `json:"hash"`
+	InactiveSeedingTimeLimit *int64  `json:"inactive_seeding_time_limit"`
+	InfohashV1               string  `json:"infohash_v1"`
+	InfohashV2               string  `json:"infohash_v2"`
+	MagnetURI                string  `json:"magnet_uri"`
+	Name                     string  `json:"name"`
+	Private                  *bool   `json:"private"`
+	Ratio                    float64 `json:"ratio"`
+	RatioLimit               float64 `json:"ratio_limit"`
+	SavePath                 string  `json:"save_path"`
+	SeedingTimeLimit         int64   `json:"seeding_time_limit"`
+	Size                     int64   `json:"size"`
+	State                    string  `json:"state"`
+	Tags                     string  `json:"tags"`
+	TotalSize                int64   `json:"total_size"`
+	TimeActive               int64   `json:"time_active"`
+	UPLimit                  int64   `json:"up_limit"`
+	UPSpeed                  int64   `json:"upspeed"`
+	Uploaded                 int64   `json:"uploaded"`
+}
+
+// Struct representing the global state of qbittorrent returned by the
+// qbittorrent API in the server_state of /api/v2/sync/maindata
+// the struct maps only the fields we need
+type ServerState struct {
+	ConnectionStatus string `json:"connection_status"`
+	DHTNodes         int64  `json:"dht_nodes"`
+	DownloadSpeed    int64  `json:"dl_info_speed"`
+	UploadSpeed      int64  `json:"up_info_speed"`
+	SessionDownload  int64  `json:"dl_info_data"`
+	SessionUpload    int64  `json:"up_info_data"`
+	FreeSpaceOnDisk  int64  `json:"free_space_on_disk"`
+}
+
+// Struct representing the preferences of qbittorrent returned by the
+// qbittorrent API from /api/v2/app/preferences
+// the struct maps only the fields we need
+type Preferences struct {
+	ListenPort            int32 `json:"listen_port"`
+	RandomPort            bool  `json:"random_port"`
+	QueueingEnabled       bool  `json:"queueing_enabled"`
+	MaxActiveDownloads    int32 `json:"max_active_downloads"`
+	MaxActiveUploads      int32 `json:"max_active_uploads"`
+	MaxActiveTorrents     int32 `json:"max_active_torrents"`
+	DontCountSlowTorrents bool  `json:"dont_count_slow_torrents"`
+	Encryption            int32 `json:"encryption"`
+	AnonymousMode         bool  `json:"anonymous_mode"`
+	DHT                   bool  `json:"dht"`
+	PeX                   bool  `json:"pex"`
+	LSD                   bool  `json:"lsd"`
+}
+
+// Struct representing a file of a torrent returned by the qbittorrent API
+// from /api/v2/torrents/files
+// the struct maps only the fields we need
+type FileInfo struct {
+	Index    int     `json:"index"`
+	Name     string  `json:"name"`
+	Priority int     `json:"priority"`
+	Progress float64 `json:"progress"`
+	Size     int64   `json:"size"`
+}
+
+// Struct representing the properties of a torrent returned by the
+// qbittorrent API from /api/v2/torrents/properties
+// the struct maps only the fields we need
+type TorrentProperties struct {
+	TotalDownloaded        int64 `json:"total_downloaded"`
+	TotalDownloadedSession int64 `json:"total_downloaded_session"`
+	TotalUploaded          int64 `json:"total_uploaded"`
+	TotalUploadedSession   int64 `json:"total_uploaded_session"`
+	DownloadSpeed          int64 `json:"dl_speed"`
+	UploadSpeed            int64 `json:"up_speed"`
+}
+
+// Struct representing a peer of a torrent returned by the qbittorrent API
+// in the peers of /api/v2/sync/torrentPeers
+// the struct maps only the fields we need
+type PeerInfo struct {
+	Client      string `json:"client"`
+	CountryCode string `json:"country_code"`
+	Flags       string `json:"flags"`
+}
+
+// Encrypted reports whether the connection to the peer is encrypted, flagged
+// E for the traffic or e for the handshake
+func (p PeerInfo) Encrypted() bool {
+	return strings.ContainsAny(p.Flags, "Ee")
+}
+
+// APIError is returned when a qbittorrent API call fails with an unexpected status
+type APIError struct {
+	Path       string
+	StatusCode int
+	Status     string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("failed to call %s. Status: %s", e.Path, e.Status)
+}
+
+// AddTorrentOptions are the optional parameters of /api/v2/torrents/add
+type AddTorrentOptions struct {
+	// SavePath is the download folder, qBittorrent default is used if empty
+	SavePath string
+	// Category is created by qBittorrent if it does not exist yet
+	Category string
+	// Tags are assigned to the torrent once added
+	Tags []string
+	// SkipChecking skips the hash check of existing content
+	SkipChecking bool
+	// Stopped adds the torrent without starting it
+	Stopped bool
+	// StopCondition stops the torrent once it is reached, e.g.
+	// StopConditionMetadataReceived. Requires qBittorrent 4.6 or later.
+	StopCondition StopCondition
+	// DownloadLimit and UploadLimit in bytes per second, 0 means unlimited
+	DownloadLimit int64
+	UploadLimit   int64
+	// RatioLimit and SeedingTimeLimit stop seeding once reached, the global
+	// qBittorrent limits are used if nil
+	RatioLimit       *float64
+	SeedingTimeLimit *time.Duration
 }
 
+// StopCondition is the stage of a torrent qBittorrent stops it at
+type StopCondition string
+
+const (
+	// StopConditionMetadataReceived stops a magnet once its metadata is
+	// fetched, before anything of its content is downloaded
+	StopConditionMetadataReceived StopCondition = "MetadataReceived"
+	// StopConditionFilesChecked stops the torrent once its files are checked
+	StopConditionFilesChecked StopCondition = "FilesChecked"
+)
+
+// DefaultRequestTimeout bounds each call to qbittorrent, including the read
+// of the response. The context of the call may end it earlier.
+const DefaultRequestTimeout = 30 * time.Second
+
+// maxPagingAttempts bounds the listings started over because the torrents
+// changed while paging
+const maxPagingAttempts = 3
+
+// MaxTorrentFileSize bounds the .torrent files fetched from URLs
+const MaxTorrentFileSize = 10 << 20
+
 // NewClient creates a new qbittorrent client
 func NewClient(baseURL string) *Client {
+	t := &transport{pool: DefaultPoolOptions()}
+	t.update(func(*transport) {})
+
 	return &Client{
 		baseURL: strings.TrimSuffix(baseURL, "/"),
 		httpClient: &http.Client{
-			Timeout: 5 * time.Second,
+			Timeout:   DefaultRequestTimeout,
+			Transport: t,
+		},
+		transport: t,
+		fetchClient: &http.Client{
+			Timeout:   DefaultRequestTimeout,
+			Transport: http.DefaultTransport.(*http.Transport).Clone(),
 		},
+		restarts: restartDetector{gracePeriod: DefaultRestartGracePeriod},
+	}
+}
+
+// session returns the SID obtained from login
+func (c *Client) session() string {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	return c.sessionID
+}
+
+// setSession stores the SID obtained from login and the credentials used
+func (c *Client) setSession(sessionID, username, password string) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	c.sessionID = sessionID
+	c.username = username
+	c.password = password
+	c.loggedIn = true
+}
+
+// credentials returns the credentials of the last successful login
+func (c *Client) credentials() (username, password string, ok bool) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	return c.username, c.password, c.loggedIn
+}
+
+// LoggedIn reports whether the client logged in to qbittorrent, and logs in
+// again with the same credentials when its session expires
+func (c *Client) LoggedIn() bool {
+	_, _, ok := c.credentials()
+	return ok
+}
+
+// OnCall sets the function told whether each call failed to reach
+// qbittorrent, e.g. to score the health of the server. Nil removes it.
+func (c *Client) OnCall(observe func(failed bool)) {
+	if observe == nil {
+		c.observer.Store(nil)
+		return
+	}
+	c.observer.Store(&observe)
+}
+
+// SetTLSConfig sets the TLS configuration used to connect to qbittorrent,
+// it can be changed while the client is in use
+func (c *Client) SetTLSConfig(config *tls.Config) {
+	c.transport.update(func(t *transport) {
+		t.tlsConfig = config
+	})
+}
+
+// SetRequestTimeout sets the timeout of each call to qbittorrent, and of
+// each file fetched. It must be set before the client is used.
+func (c *Client) SetRequestTimeout(timeout time.Duration) {
+	c.httpClient.Timeout = timeout
+	c.fetchClient.Timeout = timeout
+}
+
+// SetPageSize makes the client list the torrents of very large instances
+// pageSize at a time, bounding the size of each response. They are listed
+// at once if 0.
+func (c *Client) SetPageSize(pageSize int) {
+	c.pageSize.Store(int64(pageSize))
+}
+
+// SetRateLimit bounds the calls to qbittorrent to requestsPerSecond on
+// average, with bursts of up to burst calls. The calls are unlimited if
+// requestsPerSecond is 0. It can be changed while the client is in use.
+func (c *Client) SetRateLimit(requestsPerSecond, burst int) {
+	if requestsPerSecond <= 0 {
+		c.limiter.Store(nil)
+		return
+	}
+	if burst <= 0 {
+		burst = requestsPerSecond
+	}
+	c.limiter.Store(rate.NewLimiter(rate.Limit(requestsPerSecond), burst))
+}
+
+// RateLimitDelay returns how long a call made now would wait for the rate
+// limit, the calls already waiting included. Zero when unlimited.
+func (c *Client) RateLimitDelay() time.Duration {
+	limiter := c.limiter.Load()
+	if limiter == nil {
+		return 0
+	}
+	tokens := limiter.Tokens()
+	if tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - tokens) / float64(limiter.Limit()) * float64(time.Second))
+}
+
+// SetPoolOptions tunes the connections kept open to qbittorrent, it can be
+// changed while the client is in use
+func (c *Client) SetPoolOptions(opts PoolOptions) {
+	c.transport.update(func(t *transport) {
+		t.pool = opts
+	})
+}
+
+// SetProxy sets the HTTP, HTTPS or SOCKS5 proxy used to reach qbittorrent,
+// it can be changed while the client is in use. The proxy environment
+// variables are used when nil.
+func (c *Client) SetProxy(proxyURL *url.URL) {
+	c.transport.update(func(t *transport) {
+		t.proxyURL = proxyURL
+	})
+}
+
+// SetHeaders sets headers added to every request to qbittorrent, e.g. for
+// the authentication of a reverse proxy. They can be changed while the client
+// is in use, and are not sent to other servers.
+func (c *Client) SetHeaders(headers http.Header) {
+	host := ""
+	if u, err := url.Parse(c.baseURL); err == nil {
+		host = u.Host
 	}
+	c.transport.update(func(t *transport) {
+		t.headers = headers.Clone()
+		t.host = host
+	})
+}
+
+// SetAuditLog records the mutating calls of the client to the audit log
+func (c *Client) SetAuditLog(audit *AuditLog) {
+	c.audit = audit
+}
+
+// SetDryRun makes the client log the calls changing qbittorrent instead of
+// making them. Read-only calls are still made.
+func (c *Client) SetDryRun(dryRun bool) {
+	c.dryRun = dryRun
 }
 
+// DryRun reports whether the calls changing qbittorrent are skipped
+func (c *Client) DryRun() bool {
+	return c.dryRun
+}
+
+// ErrLoginRejected is returned by Login when qbittorrent rejects the
+// credentials, or the operator after too many failed logins
+var ErrLoginRejected = errors.New("qbittorrent rejected the credentials")
+
 // Authenticate with qbittorrent and store the session ID
 func (c *Client) Login(ctx context.Context, username, password string) error {
 	logger := log.FromContext(ctx).WithName("qbittorrent-client")
@@ -60,7 +388,12 @@ func (c *Client) Login(ctx context.Context, username, password string) error {
 	loginData.Set("username", username)
 	loginData.Set("password", password)
 
-	resp, err := c.httpClient.PostForm(loginURL, loginData)
+	req, err := http.NewRequestWithContext(ctx, "POST", loginURL, strings.NewReader(loginData.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		logger.Error(err, "Failed to login to qbittorrent")
 		return fmt.Errorf("failed to login to qbittorrent: %w", err)
@@ -76,24 +409,38 @@ func (c *Client) Login(ctx context.Context, username, password string) error {
 	if resp.StatusCode != http.StatusOK {
 		logger.Error(nil, "Failed to login to qbittorrent",
 			"status", resp.StatusCode)
+		// qbittorrent answers 403 Forbidden to the IPs banned after failed logins
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return fmt.Errorf("failed to login to qbittorrent. Status: %s: %w", resp.Status, ErrLoginRejected)
+		}
 		return fmt.Errorf("failed to login to qbittorrent. Status: %s", resp.Status)
 	}
 
 	// Get the session ID from the response
+	sessionID := ""
 	for _, cookie := range resp.Cookies() {
 		if cookie.Name == "SID" {
-			c.sessionID = cookie.Value
+			sessionID = cookie.Value
 			break
 		}
 	}
+	if sessionID == "" {
+		// qbittorrent bypasses the authentication of the clients in its
+		// whitelisted subnets or on localhost, and returns no session to them
+		if _, err := c.get(ctx, "/api/v2/app/version"); err == nil {
+			c.setSession(sessionID, username, password)
+			logger.Info("No session returned by qbittorrent, authentication is bypassed for the operator")
+			return nil
+		}
 
-	if c.sessionID == "" {
+		// qbittorrent answers 200 OK with Fails. to wrong credentials
 		logger.Error(nil, "Failed to get session ID from qbittorrent response")
-		return fmt.Errorf("failed to get session ID from qbittorrent response")
+		return fmt.Errorf("failed to get session ID from qbittorrent response: %w", ErrLoginRejected)
 	}
 
+	c.setSession(sessionID, username, password)
+
 	logger.V(1).Info("Successfully logged in to qbittorrent",
-		"sessionID", c.sessionID,
 		"username", username,
 	)
 
@@ -102,26 +449,102 @@ func (c *Client) Login(ctx context.Context, username, password string) error {
 
 // Retrieve Torrents info list
 func (c *Client) GetTorrentsInfo(ctx context.Context) ([]TorrentInfo, error) {
+	torrentsInfo, err := c.getTorrentsInfo(ctx, url.Values{})
+	if err == nil {
+		c.restarts.observeTorrents(ctx, len(torrentsInfo))
+	}
+	return torrentsInfo, err
+}
+
+// Retrieve the info list of the torrents having the given tag
+func (c *Client) GetTorrentsInfoByTag(ctx context.Context, tag string) ([]TorrentInfo, error) {
+	query := url.Values{}
+	query.Set("tag", tag)
+	return c.getTorrentsInfo(ctx, query)
+}
+
+func (c *Client) getTorrentsInfo(ctx context.Context, query url.Values) ([]TorrentInfo, error) {
+	var torrentsInfo []TorrentInfo
+	var err error
+	if pageSize := int(c.pageSize.Load()); pageSize > 0 {
+		torrentsInfo, err = c.pageTorrentsInfo(ctx, query, pageSize)
+	} else {
+		torrentsInfo, err = c.getTorrentsPage(ctx, query, int(c.listed.Load()))
+	}
+	if err == nil && len(query) == 0 {
+		c.listed.Store(int64(len(torrentsInfo)))
+	}
+	return torrentsInfo, err
+}
+
+// pageTorrentsInfo lists the torrents a page at a time, sorted by hash. Each
+// page starts with the last torrent of the previous one, which tells that no
+// torrent was added or removed before it in the meantime, shifting the pages.
+// The listing is then started over.
+func (c *Client) pageTorrentsInfo(ctx context.Context, query url.Values, pageSize int) ([]TorrentInfo, error) {
+	for attempt := 1; ; attempt++ {
+		torrentsInfo := make([]TorrentInfo, 0, c.listed.Load())
+		shifted := false
+		for !shifted {
+			pageQuery := url.Values{}
+			for name, values := range query {
+				pageQuery[name] = values
+			}
+			pageQuery.Set("sort", "hash")
+
+			overlap := 0
+			if len(torrentsInfo) > 0 {
+				overlap = 1
+			}
+			pageQuery.Set("limit", strconv.Itoa(pageSize+overlap))
+			pageQuery.Set("offset", strconv.Itoa(len(torrentsInfo)-overlap))
+
+			page, err := c.getTorrentsPage(ctx, pageQuery, pageSize+overlap)
+			if err != nil {
+				return nil, err
+			}
+			if overlap > 0 {
+				if len(page) == 0 || page[0].Hash != torrentsInfo[len(torrentsInfo)-1].Hash {
+					shifted = true
+					continue
+				}
+				page = page[1:]
+			}
+			torrentsInfo = append(torrentsInfo, page...)
+			if len(page) < pageSize {
+				return torrentsInfo, nil
+			}
+		}
+
+		if attempt == maxPagingAttempts {
+			return nil, fmt.Errorf("failed to get torrents info list: torrents changed while paging %d times", attempt)
+		}
+		log.FromContext(ctx).WithName("qbittorrent-client").V(1).Info("Torrents changed while paging, listing again")
+	}
+}
+
+// getTorrentsPage makes a single call to /api/v2/torrents/info
+func (c *Client) getTorrentsPage(ctx context.Context, query url.Values, capacity int) ([]TorrentInfo, error) {
 	logger := log.FromContext(ctx).WithName("qbittorrent-client")
 	torrentsInfoURL := c.baseURL + "/api/v2/torrents/info"
+	if len(query) > 0 {
+		torrentsInfoURL += "?" + query.Encode()
+	}
 
 	logger.V(1).Info("Getting torrents info list",
 		"URL", torrentsInfoURL,
 	)
 
 	// Add the session ID to the request
-	req, err := http.NewRequest("GET", torrentsInfoURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", torrentsInfoURL, nil)
 	if err != nil {
 		logger.Error(err, "Failed to create request")
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	// The list is large on instances with thousands of torrents
+	req.Header.Set("Accept-Encoding", "gzip")
 
-	req.AddCookie(&http.Cookie{
-		Name:  "SID",
-		Value: c.sessionID,
-	})
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		logger.Error(err, "Failed to get torrents info list")
 		return nil, fmt.Errorf("failed to get torrents info list: %w", err)
@@ -149,12 +572,23 @@ func (c *Client) GetTorrentsInfo(ctx context.Context) ([]TorrentInfo, error) {
 	}
 
 	// Parse the response body
-	var torrentsInfo []TorrentInfo
-	err = json.NewDecoder(resp.Body).Decode(&torrentsInfo)
+	body, release, err := responseReader(resp)
+	if err != nil {
+		logger.Error(err, "Failed to read torrents info list")
+		return nil, err
+	}
+	defer release()
+	var sample json.RawMessage
+	torrentsInfo, err := decodeTorrents(body, capacity, &sample)
 	if err != nil {
 		logger.Error(err, "Failed to parse torrents info list")
 		return nil, fmt.Errorf("failed to parse torrents info list: %w", err)
 	}
+	c.schema.check(ctx, "/api/v2/torrents/info", sample, &TorrentInfo{})
+
+	for i := range torrentsInfo {
+		torrentsInfo[i].normalizeHashes()
+	}
 
 	logger.V(1).Info("Successfully got torrents info list",
 		"count", len(torrentsInfo),
@@ -163,8 +597,8 @@ func (c *Client) GetTorrentsInfo(ctx context.Context) ([]TorrentInfo, error) {
 	return torrentsInfo, nil
 }
 
-// Get a torrent info from qbittorrent
-func (c *Client) GetTorrentInfo(ctx context.Context, hash string) (*TorrentInfo, error) {
+// Get a torrent info from qbittorrent, listed under any of its info hashes
+func (c *Client) GetTorrentInfo(ctx context.Context, hashes InfoHashes) (*TorrentInfo, error) {
 	logger := log.FromContext(ctx).WithName("qbittorrent-client")
 
 	torrentsInfo, err := c.GetTorrentsInfo(ctx)
@@ -174,43 +608,184 @@ func (c *Client) GetTorrentInfo(ctx context.Context, hash string) (*TorrentInfo,
 	}
 
 	for _, torrent := range torrentsInfo {
-		if torrent.Hash == hash {
+		if hashes.Matches(&torrent) {
 			return &torrent, nil
 		}
 	}
 
-	logger.V(1).Info("Torrent not found", "hash", hash)
+	logger.V(1).Info("Torrent not found", "infohashV1", hashes.V1, "infohashV2", hashes.V2)
 	return nil, nil
 }
 
+// TorrentIndex indexes a list of the torrents by hash, answering the lookups
+// of many torrents from a single list instead of listing them for each
+type TorrentIndex map[string]*TorrentInfo
+
+// NewTorrentIndex indexes the torrents under each of the hashes they may be
+// listed under
+func NewTorrentIndex(torrents []TorrentInfo) TorrentIndex {
+	index := make(TorrentIndex, len(torrents))
+	for i := range torrents {
+		for _, hash := range []string{torrents[i].Hash, torrents[i].InfohashV1, torrents[i].InfohashV2} {
+			if _, ok := index[hash]; hash != "" && !ok {
+				index[hash] = &torrents[i]
+			}
+		}
+	}
+	return index
+}
+
+// Lookup returns the torrent matching the hashes, as GetTorrentInfo, or nil
+// when it is not in the list
+func (i TorrentIndex) Lookup(hashes InfoHashes) *TorrentInfo {
+	keys := []string{hashes.V1, hashes.V2}
+	if hashes.V2 != "" {
+		keys = append(keys, hashes.V2[:v1HashLength])
+	}
+	for _, key := range keys {
+		if torrent, ok := i[key]; key != "" && ok {
+			return torrent
+		}
+	}
+	return nil
+}
+
 // Add a torrent to qbittorrent
 func (c *Client) AddTorrent(ctx context.Context, magnetURI string) error {
+	return c.AddTorrentWithOptions(ctx, magnetURI, AddTorrentOptions{})
+}
+
+// Add a torrent to qbittorrent from a magnet URI or a .torrent URL,
+// setting the given add parameters
+func (c *Client) AddTorrentWithOptions(ctx context.Context, magnetURI string, opts AddTorrentOptions) error {
+	hashes, _ := GetInfoHashes(magnetURI)
+	return c.mutate(ctx, "/api/v2/torrents/add", hashes.ID(), addAuditParameters("magnetURI", magnetURI, opts),
+		func() error {
+			return c.addTorrent(ctx, magnetURI, nil, opts)
+		})
+}
+
+// Add a torrent to qbittorrent uploading the content of a .torrent file,
+// setting the given add parameters
+func (c *Client) AddTorrentFileWithOptions(ctx context.Context, torrentFile []byte, opts AddTorrentOptions) error {
+	// The file is recorded by the name of its content
+	file, err := ParseTorrentFile(torrentFile)
+	if err != nil {
+		file = &TorrentFile{}
+	}
+	return c.mutate(ctx, "/api/v2/torrents/add", file.InfoHashes.ID(), addAuditParameters("torrentFile", file.Name, opts),
+		func() error {
+			return c.addTorrent(ctx, "", torrentFile, opts)
+		})
+}
+
+// mutate makes a call changing the state of qbittorrent and audits it. In
+// dry-run the call is only logged.
+func (c *Client) mutate(ctx context.Context, path, hashes string, parameters map[string]string,
+	call func() error) error {
+	if c.dryRun {
+		log.FromContext(ctx).WithName("qbittorrent-client").Info("Dry run, skipping qbittorrent call",
+			"path", path,
+			"hashes", hashes,
+			"parameters", parameters,
+		)
+		c.audit.record(ctx, path, hashes, parameters, true, nil)
+		return nil
+	}
+
+	err := call()
+	c.audit.record(ctx, path, hashes, parameters, false, err)
+	return err
+}
+
+// addAuditParameters returns the audited parameters of /api/v2/torrents/add
+func addAuditParameters(source, value string, opts AddTorrentOptions) map[string]string {
+	parameters := map[string]string{source: value}
+	if opts.SavePath != "" {
+		parameters["savePath"] = opts.SavePath
+	}
+	if opts.Category != "" {
+		parameters["category"] = opts.Category
+	}
+	return parameters
+}
+
+// addTorrent posts /api/v2/torrents/add with either the URLs or the .torrent file set
+func (c *Client) addTorrent(ctx context.Context, magnetURI string, torrentFile []byte, opts AddTorrentOptions) error {
 	logger := log.FromContext(ctx).WithName("qbittorrent-client")
 	torrentsAddURL := c.baseURL + "/api/v2/torrents/add"
 
 	logger.Info("Adding torrent to qbittorrent",
 		"URL", torrentsAddURL,
 		"magnetURI", magnetURI,
+		"torrentFileSize", len(torrentFile),
+		"savePath", opts.SavePath,
+		"category", opts.Category,
+		"tags", opts.Tags,
+		"skipChecking", opts.SkipChecking,
+		"stopped", opts.Stopped,
+		"stopCondition", opts.StopCondition,
 	)
 
 	// Buffer to store the multi-part form data
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
 
-	if err := writer.WriteField("urls", magnetURI); err != nil {
-		logger.Error(err, "Failed to write form field")
-		return fmt.Errorf("failed to write form field: %w", err)
+	fields := map[string]string{}
+	if magnetURI != "" {
+		fields["urls"] = magnetURI
+	}
+	if opts.SavePath != "" {
+		fields["savepath"] = opts.SavePath
+	}
+	if opts.Category != "" {
+		fields["category"] = opts.Category
+	}
+	if len(opts.Tags) > 0 {
+		fields["tags"] = strings.Join(opts.Tags, ",")
+	}
+	if opts.SkipChecking {
+		fields["skip_checking"] = "true"
+	}
+	if opts.Stopped {
+		// qBittorrent 5 renamed paused to stopped
+		fields["stopped"] = "true"
+		fields["paused"] = "true"
+	}
+	if opts.StopCondition != "" {
+		fields["stopCondition"] = string(opts.StopCondition)
+	}
+	if opts.DownloadLimit > 0 {
+		fields["dlLimit"] = strconv.FormatInt(opts.DownloadLimit, 10)
+	}
+	if opts.UploadLimit > 0 {
+		fields["upLimit"] = strconv.FormatInt(opts.UploadLimit, 10)
+	}
+	if opts.RatioLimit != nil {
+		fields["ratioLimit"] = strconv.FormatFloat(*opts.RatioLimit, 'f', -1, 64)
+	}
+	if opts.SeedingTimeLimit != nil {
+		// qBittorrent expects minutes
+		fields["seedingTimeLimit"] = strconv.FormatInt(int64(opts.SeedingTimeLimit.Minutes()), 10)
 	}
 
-	urlFields, err := writer.CreateFormField("urls")
-	if err != nil {
-		logger.Error(err, "Failed to create form field")
-		return fmt.Errorf("failed to create form field: %w", err)
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			logger.Error(err, "Failed to write form field", "field", name)
+			return fmt.Errorf("failed to write form field %s: %w", name, err)
+		}
 	}
 
-	if _, err := urlFields.Write([]byte(magnetURI)); err != nil {
-		logger.Error(err, "Failed to write form field")
-		return fmt.Errorf("failed to write form field: %w", err)
+	if torrentFile != nil {
+		part, err := writer.CreateFormFile("torrents", "source.torrent")
+		if err != nil {
+			logger.Error(err, "Failed to create form file")
+			return fmt.Errorf("failed to create form file: %w", err)
+		}
+		if _, err := part.Write(torrentFile); err != nil {
+			logger.Error(err, "Failed to write torrent file")
+			return fmt.Errorf("failed to write torrent file: %w", err)
+		}
 	}
 
 	// Close the writer to finalize the form data
@@ -219,19 +794,14 @@ func (c *Client) AddTorrent(ctx context.Context, magnetURI string) error {
 		return fmt.Errorf("failed to close writer: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", torrentsAddURL, body)
+	req, err := http.NewRequestWithContext(ctx, "POST", torrentsAddURL, body)
 	if err != nil {
 		logger.Error(err, "Failed to create request")
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.AddCookie(&http.Cookie{
-		Name:  "SID",
-		Value: c.sessionID,
-	})
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		logger.Error(err, "Failed to add torrent")
 		return fmt.Errorf("failed to add torrent: %w", err)
@@ -259,13 +829,71 @@ func (c *Client) AddTorrent(ctx context.Context, magnetURI string) error {
 
 	logger.Info("Successfully added torrent",
 		"magnetURI", magnetURI,
+		"torrentFileSize", len(torrentFile),
 	)
 
 	return nil
 }
 
+// Download a .torrent file, qBittorrent is not involved. Files larger than
+// MaxTorrentFileSize are rejected.
+func (c *Client) FetchTorrentFile(ctx context.Context, torrentURL string) ([]byte, error) {
+	return c.fetch(ctx, torrentURL, "torrent file", MaxTorrentFileSize)
+}
+
+// Download an IP filter blocklist, qBittorrent is not involved. Blocklists
+// larger than maxSize bytes are rejected.
+func (c *Client) FetchBlocklist(ctx context.Context, blocklistURL string, maxSize int) ([]byte, error) {
+	return c.fetch(ctx, blocklistURL, "blocklist", maxSize)
+}
+
+// fetch downloads a file of at most maxSize bytes from a third-party server
+func (c *Client) fetch(ctx context.Context, fileURL, what string, maxSize int) ([]byte, error) {
+	logger := log.FromContext(ctx).WithName("qbittorrent-client")
+
+	logger.V(1).Info("Fetching "+what, "URL", fileURL)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fileURL, nil)
+	if err != nil {
+		logger.Error(err, "Failed to create request")
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.fetchClient.Do(req)
+	if err != nil {
+		logger.Error(err, "Failed to fetch "+what)
+		return nil, fmt.Errorf("failed to fetch %s: %w", what, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Error(err, "Failed to close response body")
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s. Status: %s", what, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxSize)+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", what, err)
+	}
+	if len(data) > maxSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", what, maxSize)
+	}
+
+	return data, nil
+}
+
 // Delete a torrent from qbittorrent
 func (c *Client) DeleteTorrent(ctx context.Context, hash string, deleteFiles bool) error {
+	return c.mutate(ctx, "/api/v2/torrents/delete", hash, map[string]string{"deleteFiles": strconv.FormatBool(deleteFiles)},
+		func() error {
+			return c.deleteTorrent(ctx, hash, deleteFiles)
+		})
+}
+
+func (c *Client) deleteTorrent(ctx context.Context, hash string, deleteFiles bool) error {
 	logger := log.FromContext(ctx).WithName("qbittorrent-client")
 	torrentsDeleteURL := c.baseURL + "/api/v2/torrents/delete"
 
@@ -280,19 +908,14 @@ func (c *Client) DeleteTorrent(ctx context.Context, hash string, deleteFiles boo
 	data.Set("hashes", hash)
 	data.Set("deleteFiles", fmt.Sprintf("%t", deleteFiles))
 
-	req, err := http.NewRequest("POST", torrentsDeleteURL, bytes.NewBufferString(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", torrentsDeleteURL, bytes.NewBufferString(data.Encode()))
 	if err != nil {
 		logger.Error(err, "Failed to create request")
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.AddCookie(&http.Cookie{
-		Name:  "SID",
-		Value: c.sessionID,
-	})
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		logger.Error(err, "Failed to delete torrent")
 		return fmt.Errorf("failed to delete torrent: %w", err)
@@ -321,3 +944,554 @@ func (c *Client) DeleteTorrent(ctx context.Context, hash string, deleteFiles boo
 	)
 	return nil
 }
+
+// Add tags to a torrent
+func (c *Client) AddTags(ctx context.Context, hash string, tags []string) error {
+	data := url.Values{}
+	data.Set("hashes", hash)
+	data.Set("tags", strings.Join(tags, ","))
+	return c.postForm(ctx, "/api/v2/torrents/addTags", data)
+}
+
+// Remove tags from a torrent
+func (c *Client) RemoveTags(ctx context.Context, hash string, tags []string) error {
+	data := url.Values{}
+	data.Set("hashes", hash)
+	data.Set("tags", strings.Join(tags, ","))
+	return c.postForm(ctx, "/api/v2/torrents/removeTags", data)
+}
+
+// Set the category of a torrent, an empty category removes it
+func (c *Client) SetCategory(ctx context.Context, hash, category string) error {
+	data := url.Values{}
+	data.Set("hashes", hash)
+	data.Set("category", category)
+	return c.postForm(ctx, "/api/v2/torrents/setCategory", data)
+}
+
+// Get the names of the categories of qbittorrent, sorted
+func (c *Client) GetCategories(ctx context.Context) ([]string, error) {
+	logger := log.FromContext(ctx).WithName("qbittorrent-client")
+
+	body, err := c.get(ctx, "/api/v2/torrents/categories")
+	if err != nil {
+		return nil, err
+	}
+
+	var categories map[string]json.RawMessage
+	if err := json.Unmarshal(body, &categories); err != nil {
+		logger.Error(err, "Failed to parse categories")
+		return nil, fmt.Errorf("failed to parse categories: %w", err)
+	}
+	names := make([]string, 0, len(categories))
+	for name := range categories {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// Remove categories from qbittorrent, the torrents in them are left without category
+func (c *Client) RemoveCategories(ctx context.Context, categories []string) error {
+	data := url.Values{}
+	data.Set("categories", strings.Join(categories, "\n"))
+	return c.postForm(ctx, "/api/v2/torrents/removeCategories", data)
+}
+
+// Get the tags of qbittorrent
+func (c *Client) GetTags(ctx context.Context) ([]string, error) {
+	logger := log.FromContext(ctx).WithName("qbittorrent-client")
+
+	body, err := c.get(ctx, "/api/v2/torrents/tags")
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	if err := json.Unmarshal(body, &tags); err != nil {
+		logger.Error(err, "Failed to parse tags")
+		return nil, fmt.Errorf("failed to parse tags: %w", err)
+	}
+	return tags, nil
+}
+
+// Delete tags from qbittorrent, removing them from the torrents
+func (c *Client) DeleteTags(ctx context.Context, tags []string) error {
+	data := url.Values{}
+	data.Set("tags", strings.Join(tags, ","))
+	return c.postForm(ctx, "/api/v2/torrents/deleteTags", data)
+}
+
+// Set the download limit of a torrent in bytes per second, 0 means unlimited
+func (c *Client) SetDownloadLimit(ctx context.Context, hash string, limit int64) error {
+	data := url.Values{}
+	data.Set("hashes", hash)
+	data.Set("limit", strconv.FormatInt(limit, 10))
+	return c.postForm(ctx, "/api/v2/torrents/setDownloadLimit", data)
+}
+
+// Set the upload limit of a torrent in bytes per second, 0 means unlimited
+func (c *Client) SetUploadLimit(ctx context.Context, hash string, limit int64) error {
+	data := url.Values{}
+	data.Set("hashes", hash)
+	data.Set("limit", strconv.FormatInt(limit, 10))
+	return c.postForm(ctx, "/api/v2/torrents/setUploadLimit", data)
+}
+
+// Set the share limits of a torrent. The seeding time limits are in minutes,
+// for all limits -2 means the global limit is used and -1 no limit.
+func (c *Client) SetShareLimits(ctx context.Context, hash string, ratioLimit float64,
+	seedingTimeLimit, inactiveSeedingTimeLimit int64) error {
+	data := url.Values{}
+	data.Set("hashes", hash)
+	data.Set("ratioLimit", strconv.FormatFloat(ratioLimit, 'f', -1, 64))
+	data.Set("seedingTimeLimit", strconv.FormatInt(seedingTimeLimit, 10))
+	data.Set("inactiveSeedingTimeLimit", strconv.FormatInt(inactiveSeedingTimeLimit, 10))
+	return c.postForm(ctx, "/api/v2/torrents/setShareLimits", data)
+}
+
+// ErrQueueingDisabled is returned when moving a torrent in the queue while
+// torrent queueing is disabled in qbittorrent
+var ErrQueueingDisabled = errors.New("torrent queueing is disabled in qbittorrent")
+
+// Move a torrent to the top of the download queue
+func (c *Client) TopPriority(ctx context.Context, hash string) error {
+	return c.setQueuePosition(ctx, "/api/v2/torrents/topPrio", hash)
+}
+
+// Move a torrent to the bottom of the download queue
+func (c *Client) BottomPriority(ctx context.Context, hash string) error {
+	return c.setQueuePosition(ctx, "/api/v2/torrents/bottomPrio", hash)
+}
+
+func (c *Client) setQueuePosition(ctx context.Context, path, hash string) error {
+	data := url.Values{}
+	data.Set("hashes", hash)
+	err := c.postForm(ctx, path, data)
+
+	// qbittorrent answers 409 Conflict when queueing is disabled
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+		return ErrQueueingDisabled
+	}
+	return err
+}
+
+// Stop a torrent, paused by the API of qbittorrent older than 5.0
+func (c *Client) StopTorrent(ctx context.Context, hash string) error {
+	return c.postRenamed(ctx, "/api/v2/torrents/stop", "/api/v2/torrents/pause", hash)
+}
+
+// Start a stopped torrent, resumed by the API of qbittorrent older than 5.0
+func (c *Client) StartTorrent(ctx context.Context, hash string) error {
+	return c.postRenamed(ctx, "/api/v2/torrents/start", "/api/v2/torrents/resume", hash)
+}
+
+// Recheck the downloaded content of a torrent against its pieces
+func (c *Client) RecheckTorrent(ctx context.Context, hash string) error {
+	data := url.Values{}
+	data.Set("hashes", hash)
+	return c.postForm(ctx, "/api/v2/torrents/recheck", data)
+}
+
+// Add trackers, given as announce URLs, to a torrent
+func (c *Client) AddTrackers(ctx context.Context, hash string, urls []string) error {
+	data := url.Values{}
+	data.Set("hash", hash)
+	data.Set("urls", strings.Join(urls, "\n"))
+	return c.postForm(ctx, "/api/v2/torrents/addTrackers", data)
+}
+
+// Reannounce a torrent to its trackers and the DHT
+func (c *Client) Reannounce(ctx context.Context, hash string) error {
+	data := url.Values{}
+	data.Set("hashes", hash)
+	return c.postForm(ctx, "/api/v2/torrents/reannounce", data)
+}
+
+// postRenamed posts the hash to a path qbittorrent 5.0 renamed, falling back
+// to its old path when qbittorrent answers 404 Not Found
+func (c *Client) postRenamed(ctx context.Context, path, oldPath, hash string) error {
+	data := url.Values{}
+	data.Set("hashes", hash)
+	err := c.postForm(ctx, path, data)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return c.postForm(ctx, oldPath, data)
+	}
+	return err
+}
+
+// ErrWebSeedsUnsupported is returned when adding or removing web seeds on a
+// qbittorrent older than 5.0, whose API lacks them
+var ErrWebSeedsUnsupported = errors.New("web seeds require qbittorrent 5.0 or later")
+
+// Add web seeds, HTTP URLs serving the content, to a torrent
+func (c *Client) AddWebSeeds(ctx context.Context, hash string, urls []string) error {
+	return c.editWebSeeds(ctx, "/api/v2/torrents/addWebSeeds", hash, urls)
+}
+
+// Remove web seeds from a torrent
+func (c *Client) RemoveWebSeeds(ctx context.Context, hash string, urls []string) error {
+	return c.editWebSeeds(ctx, "/api/v2/torrents/removeWebSeeds", hash, urls)
+}
+
+func (c *Client) editWebSeeds(ctx context.Context, path, hash string, urls []string) error {
+	data := url.Values{}
+	data.Set("hash", hash)
+	data.Set("urls", strings.Join(urls, "|"))
+	err := c.postForm(ctx, path, data)
+
+	// qbittorrent before 5.0 answers 404 Not Found
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return ErrWebSeedsUnsupported
+	}
+	return err
+}
+
+// Ban peers, given as host:port. qBittorrent bans their IP addresses,
+// whatever the port.
+func (c *Client) BanPeers(ctx context.Context, peers []string) error {
+	data := url.Values{}
+	data.Set("peers", strings.Join(peers, "|"))
+	return c.postForm(ctx, "/api/v2/transfer/banPeers", data)
+}
+
+// Get the preferences of qbittorrent
+func (c *Client) GetPreferences(ctx context.Context) (*Preferences, error) {
+	logger := log.FromContext(ctx).WithName("qbittorrent-client")
+
+	body, err := c.get(ctx, "/api/v2/app/preferences")
+	if err != nil {
+		return nil, err
+	}
+
+	var preferences Preferences
+	if err := json.Unmarshal(body, &preferences); err != nil {
+		logger.Error(err, "Failed to parse preferences")
+		return nil, fmt.Errorf("failed to parse preferences: %w", err)
+	}
+	c.schema.check(ctx, "/api/v2/app/preferences", body, &preferences)
+	return &preferences, nil
+}
+
+// Set qBittorrent preferences, by their name in /api/v2/app/preferences.
+// Only the given preferences are changed.
+func (c *Client) SetPreferences(ctx context.Context, preferences map[string]any) error {
+	encoded, err := json.Marshal(preferences)
+	if err != nil {
+		return fmt.Errorf("failed to encode preferences: %w", err)
+	}
+	data := url.Values{}
+	data.Set("json", string(encoded))
+	return c.postForm(ctx, "/api/v2/app/setPreferences", data)
+}
+
+// TagList returns the tags of the torrent, qBittorrent reports them comma separated
+func (t *TorrentInfo) TagList() []string {
+	var tags []string
+	for _, tag := range strings.Split(t.Tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// normalizeHashes puts the hashes reported by qBittorrent in normalized form,
+// the ones that are not valid info hashes are left untouched
+func (t *TorrentInfo) normalizeHashes() {
+	for _, hash := range []*string{&t.Hash, &t.InfohashV1, &t.InfohashV2} {
+		if normalized, err := NormalizeHash(*hash); err == nil {
+			*hash = normalized
+		}
+	}
+}
+
+// Get the qbittorrent application version, e.g. v5.0.4
+func (c *Client) GetVersion(ctx context.Context) (string, error) {
+	body, err := c.get(ctx, "/api/v2/app/version")
+	if err != nil {
+		return "", err
+	}
+	version := strings.TrimSpace(string(body))
+	c.restarts.observeVersion(ctx, version)
+	return version, nil
+}
+
+// Get the qbittorrent WebUI API version, e.g. 2.11.2
+func (c *Client) GetWebAPIVersion(ctx context.Context) (string, error) {
+	body, err := c.get(ctx, "/api/v2/app/webapiVersion")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// Get the files of a torrent, with their progress and priority
+func (c *Client) GetTorrentFiles(ctx context.Context, hash string) ([]FileInfo, error) {
+	logger := log.FromContext(ctx).WithName("qbittorrent-client")
+
+	query := url.Values{}
+	query.Set("hash", hash)
+	body, err := c.get(ctx, "/api/v2/torrents/files?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var files []json.RawMessage
+	if err := json.Unmarshal(body, &files); err != nil {
+		logger.Error(err, "Failed to parse torrent files")
+		return nil, fmt.Errorf("failed to parse torrent files: %w", err)
+	}
+	fileInfos := make([]FileInfo, len(files))
+	for i, file := range files {
+		if err := json.Unmarshal(file, &fileInfos[i]); err != nil {
+			logger.Error(err, "Failed to parse torrent files")
+			return nil, fmt.Errorf("failed to parse torrent files: %w", err)
+		}
+	}
+	if len(files) > 0 {
+		c.schema.check(ctx, "/api/v2/torrents/files", files[0], &FileInfo{})
+	}
+	return fileInfos, nil
+}
+
+// Set the priority of the files of a torrent, by index. Priority 0 does not
+// download the files, 1 is normal, 6 high and 7 maximal.
+func (c *Client) SetFilePriority(ctx context.Context, hash string, indexes []int, priority int) error {
+	ids := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		ids = append(ids, strconv.Itoa(index))
+	}
+	data := url.Values{}
+	data.Set("hash", hash)
+	data.Set("id", strings.Join(ids, "|"))
+	data.Set("priority", strconv.Itoa(priority))
+	return c.postForm(ctx, "/api/v2/torrents/filePrio", data)
+}
+
+// Get the URLs of the web seeds of a torrent
+func (c *Client) GetWebSeeds(ctx context.Context, hash string) ([]string, error) {
+	logger := log.FromContext(ctx).WithName("qbittorrent-client")
+
+	query := url.Values{}
+	query.Set("hash", hash)
+	body, err := c.get(ctx, "/api/v2/torrents/webseeds?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var webSeeds []struct {
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(body, &webSeeds); err != nil {
+		logger.Error(err, "Failed to parse torrent web seeds")
+		return nil, fmt.Errorf("failed to parse torrent web seeds: %w", err)
+	}
+	urls := make([]string, 0, len(webSeeds))
+	for _, webSeed := range webSeeds {
+		urls = append(urls, webSeed.URL)
+	}
+	return urls, nil
+}
+
+// Get the properties of a torrent, with its transfer totals
+func (c *Client) GetTorrentProperties(ctx context.Context, hash string) (*TorrentProperties, error) {
+	logger := log.FromContext(ctx).WithName("qbittorrent-client")
+
+	query := url.Values{}
+	query.Set("hash", hash)
+	body, err := c.get(ctx, "/api/v2/torrents/properties?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var properties TorrentProperties
+	if err := json.Unmarshal(body, &properties); err != nil {
+		logger.Error(err, "Failed to parse torrent properties")
+		return nil, fmt.Errorf("failed to parse torrent properties: %w", err)
+	}
+	c.schema.check(ctx, "/api/v2/torrents/properties", body, &properties)
+	return &properties, nil
+}
+
+// Get the peers connected for a torrent, by address. A full sync is
+// requested so that all the peers are listed.
+func (c *Client) GetTorrentPeers(ctx context.Context, hash string) (map[string]PeerInfo, error) {
+	logger := log.FromContext(ctx).WithName("qbittorrent-client")
+
+	query := url.Values{}
+	query.Set("hash", hash)
+	query.Set("rid", "0")
+	body, err := c.get(ctx, "/api/v2/sync/torrentPeers?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var torrentPeers struct {
+		Peers map[string]json.RawMessage `json:"peers"`
+	}
+	if err := json.Unmarshal(body, &torrentPeers); err != nil {
+		logger.Error(err, "Failed to parse torrent peers")
+		return nil, fmt.Errorf("failed to parse torrent peers: %w", err)
+	}
+	peers := make(map[string]PeerInfo, len(torrentPeers.Peers))
+	for address, raw := range torrentPeers.Peers {
+		var peer PeerInfo
+		if err := json.Unmarshal(raw, &peer); err != nil {
+			logger.Error(err, "Failed to parse torrent peers")
+			return nil, fmt.Errorf("failed to parse torrent peers: %w", err)
+		}
+		if len(peers) == 0 {
+			c.schema.check(ctx, "/api/v2/sync/torrentPeers", raw, &PeerInfo{})
+		}
+		peers[address] = peer
+	}
+	return peers, nil
+}
+
+// Get the global state of qbittorrent. The free disk space is only reported
+// by the sync API, so a full sync is requested and the torrents are ignored.
+func (c *Client) GetServerState(ctx context.Context) (*ServerState, error) {
+	logger := log.FromContext(ctx).WithName("qbittorrent-client")
+
+	body, err := c.get(ctx, "/api/v2/sync/maindata?rid=0")
+	if err != nil {
+		return nil, err
+	}
+
+	var mainData struct {
+		ServerState json.RawMessage `json:"server_state"`
+	}
+	if err := json.Unmarshal(body, &mainData); err != nil {
+		logger.Error(err, "Failed to parse server state")
+		return nil, fmt.Errorf("failed to parse server state: %w", err)
+	}
+	var serverState ServerState
+	if len(mainData.ServerState) > 0 {
+		if err := json.Unmarshal(mainData.ServerState, &serverState); err != nil {
+			logger.Error(err, "Failed to parse server state")
+			return nil, fmt.Errorf("failed to parse server state: %w", err)
+		}
+		c.schema.check(ctx, "/api/v2/sync/maindata", mainData.ServerState, &serverState)
+	}
+
+	return &serverState, nil
+}
+
+// get calls a qbittorrent API endpoint and returns the response body
+func (c *Client) get(ctx context.Context, path string) ([]byte, error) {
+	logger := log.FromContext(ctx).WithName("qbittorrent-client")
+	endpointURL := c.baseURL + path
+
+	logger.V(1).Info("Calling qbittorrent API",
+		"URL", endpointURL,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpointURL, nil)
+	if err != nil {
+		logger.Error(err, "Failed to create request")
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		logger.Error(err, "Failed to call qbittorrent API", "path", path)
+		return nil, fmt.Errorf("failed to call %s: %w", path, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Error(err, "Failed to close response body")
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		logger.Error(nil, "Failed to call qbittorrent API",
+			"path", path,
+			"status", resp.StatusCode)
+
+		if resp.StatusCode == http.StatusUnauthorized {
+			logger.Error(nil, "Unauthorized access to qbittorrent",
+				"status", resp.StatusCode)
+			return nil, fmt.Errorf("unauthorized access to qbittorrent")
+		}
+
+		return nil, fmt.Errorf("failed to call %s. Status: %s", path, resp.Status)
+	}
+
+	reader, release, err := responseReader(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s response: %w", path, err)
+	}
+	defer release()
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s response: %w", path, err)
+	}
+	return body, nil
+}
+
+// BaseURL returns the URL of the qbittorrent WebUI
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// postForm sends an URL-encoded form to a qbittorrent API endpoint
+// and checks that the call succeeded
+func (c *Client) postForm(ctx context.Context, path string, data url.Values) error {
+	parameters := map[string]string{}
+	for name, values := range data {
+		if name != "hashes" {
+			parameters[name] = strings.Join(values, ",")
+		}
+	}
+	return c.mutate(ctx, path, data.Get("hashes"), parameters, func() error {
+		return c.doPostForm(ctx, path, data)
+	})
+}
+
+func (c *Client) doPostForm(ctx context.Context, path string, data url.Values) error {
+	logger := log.FromContext(ctx).WithName("qbittorrent-client")
+	endpointURL := c.baseURL + path
+
+	logger.V(1).Info("Calling qbittorrent API",
+		"URL", endpointURL,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpointURL, bytes.NewBufferString(data.Encode()))
+	if err != nil {
+		logger.Error(err, "Failed to create request")
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		logger.Error(err, "Failed to call qbittorrent API", "path", path)
+		return fmt.Errorf("failed to call %s: %w", path, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Error(err, "Failed to close response body")
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		logger.Error(nil, "Failed to call qbittorrent API",
+			"path", path,
+			"status", resp.StatusCode)
+
+		if resp.StatusCode == http.StatusUnauthorized {
+			logger.Error(nil, "Unauthorized access to qbittorrent",
+				"status", resp.StatusCode)
+			return fmt.Errorf("unauthorized access to qbittorrent")
+		}
+
+		return &APIError{Path: path, StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	return nil
+}
@@ -1,6 +1,14 @@
 package qbittorrent
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -32,3 +40,467 @@ func TestNewClient_TrimsTrailingSlash(t *testing.T) {
 		t.Errorf("Expected trailing slash to be trimmed, got '%s'", client.baseURL)
 	}
 }
+
+func TestTorrentInfo_TagList(t *testing.T) {
+	info := TorrentInfo{Tags: "k8s:team=media, cross-seed/default/a/b,,"}
+
+	tags := info.TagList()
+	if len(tags) != 2 || tags[0] != "k8s:team=media" || tags[1] != "cross-seed/default/a/b" {
+		t.Errorf("Expected two trimmed tags, got %v", tags)
+	}
+
+	if tags := (&TorrentInfo{}).TagList(); len(tags) != 0 {
+		t.Errorf("Expected no tags, got %v", tags)
+	}
+}
+
+func TestClient_AuditLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/torrents/removeTags" {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	client := NewClient(server.URL)
+	client.SetAuditLog(NewAuditLog(&out))
+	ctx := WithAuditObject(context.Background(), "Torrent default/test")
+
+	hash := "c9e15763f722f23e98a29decdfae341b98d53056"
+	if err := client.DeleteTorrent(ctx, hash, true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.RemoveTags(ctx, hash, []string{"a"}); err == nil {
+		t.Fatalf("Expected an error")
+	}
+
+	decoder := json.NewDecoder(&out)
+	var deleted, removed AuditEvent
+	if err := decoder.Decode(&deleted); err != nil {
+		t.Fatalf("Failed to decode audit event: %v", err)
+	}
+	if deleted.Object != "Torrent default/test" || deleted.Operation != "torrents/delete" ||
+		deleted.Hashes != hash || deleted.Parameters["deleteFiles"] != "true" || deleted.Result != "success" {
+		t.Errorf("Unexpected delete event %+v", deleted)
+	}
+	if err := decoder.Decode(&removed); err != nil {
+		t.Fatalf("Failed to decode audit event: %v", err)
+	}
+	if removed.Operation != "torrents/removeTags" || removed.Parameters["tags"] != "a" ||
+		removed.Result != "failure" || removed.Error == "" {
+		t.Errorf("Unexpected removeTags event %+v", removed)
+	}
+}
+
+func TestClient_NoAuditLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	if err := NewClient(server.URL).AddTags(context.Background(), "hash", []string{"a"}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestClient_DryRun(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	client := NewClient(server.URL)
+	client.SetDryRun(true)
+	client.SetAuditLog(NewAuditLog(&out))
+
+	ctx := context.Background()
+	magnetURI := "magnet:?xt=urn:btih:c9e15763f722f23e98a29decdfae341b98d53056" +
+		"&tr=https%3A%2F%2Ftracker.example%2F0123456789abcdef0123456789abcdef%2Fannounce"
+	if err := client.AddTorrentWithOptions(ctx, magnetURI, AddTorrentOptions{Category: "movies"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.DeleteTorrent(ctx, "c9e15763f722f23e98a29decdfae341b98d53056", true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("Expected no call to qbittorrent, got %d", calls)
+	}
+
+	audited := out.String()
+	var event AuditEvent
+	if err := json.NewDecoder(&out).Decode(&event); err != nil {
+		t.Fatalf("Failed to decode audit event: %v", err)
+	}
+	if event.Operation != "torrents/add" || event.Result != "dryRun" ||
+		event.Hashes != "c9e15763f722f23e98a29decdfae341b98d53056" || event.Parameters["category"] != "movies" {
+		t.Errorf("Unexpected add event %+v", event)
+	}
+	if strings.Contains(audited, "0123456789abcdef") || !strings.Contains(event.Parameters["magnetURI"], "REDACTED") {
+		t.Errorf("Expected the tracker passkey redacted, got %+v", event)
+	}
+}
+
+func TestClient_LoginBypassed(t *testing.T) {
+	bypassed := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/auth/login":
+			_, _ = w.Write([]byte("Ok."))
+		case bypassed:
+			_, _ = w.Write([]byte("v5.0.4"))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	if err := client.Login(context.Background(), "", ""); err != nil {
+		t.Errorf("Expected the login to succeed when authentication is bypassed, got %v", err)
+	}
+
+	bypassed = false
+	if err := client.Login(context.Background(), "", ""); !errors.Is(err, ErrLoginRejected) {
+		t.Errorf("Expected the login to be rejected without session, got %v", err)
+	}
+}
+
+func TestClient_QueuePosition(t *testing.T) {
+	queueing := true
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if !queueing {
+			w.WriteHeader(http.StatusConflict)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := context.Background()
+	hash := "c9e15763f722f23e98a29decdfae341b98d53056"
+	if err := client.TopPriority(ctx, hash); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.BottomPriority(ctx, hash); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(paths, []string{"/api/v2/torrents/topPrio", "/api/v2/torrents/bottomPrio"}) {
+		t.Errorf("Unexpected calls %v", paths)
+	}
+
+	queueing = false
+	if err := client.TopPriority(ctx, hash); !errors.Is(err, ErrQueueingDisabled) {
+		t.Errorf("Expected ErrQueueingDisabled, got %v", err)
+	}
+}
+
+func TestClient_StopStartTorrent(t *testing.T) {
+	renamed := true
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v2/torrents/stop", "/api/v2/torrents/start":
+			if !renamed {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := context.Background()
+	hash := "c9e15763f722f23e98a29decdfae341b98d53056"
+	if err := client.StopTorrent(ctx, hash); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.RecheckTorrent(ctx, hash); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(paths, []string{"/api/v2/torrents/stop", "/api/v2/torrents/recheck"}) {
+		t.Errorf("Unexpected calls %v", paths)
+	}
+
+	// qbittorrent older than 5.0 only has the old paths
+	renamed = false
+	paths = nil
+	if err := client.StartTorrent(ctx, hash); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(paths, []string{"/api/v2/torrents/start", "/api/v2/torrents/resume"}) {
+		t.Errorf("Unexpected calls %v", paths)
+	}
+}
+
+func TestClient_GetTorrentFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/torrents/files" || r.URL.Query().Get("hash") != "c9e15763f722f23e98a29decdfae341b98d53056" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"index":0,"name":"big_buck_bunny/movie.mp4","priority":1,"progress":0.5,"size":276134947}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	files, err := client.GetTorrentFiles(context.Background(), "c9e15763f722f23e98a29decdfae341b98d53056")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(files) != 1 || files[0].Name != "big_buck_bunny/movie.mp4" || files[0].Progress != 0.5 || files[0].Priority != 1 {
+		t.Errorf("Unexpected files %+v", files)
+	}
+}
+
+func TestClient_GetTorrentProperties(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/torrents/properties" || r.URL.Query().Get("hash") != "c9e15763f722f23e98a29decdfae341b98d53056" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"total_downloaded":276445467,"total_downloaded_session":1048576,` +
+			`"total_uploaded":552890934,"total_uploaded_session":2097152,"dl_speed":4096,"up_speed":512,"dl_speed_avg":1024}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	properties, err := client.GetTorrentProperties(context.Background(), "c9e15763f722f23e98a29decdfae341b98d53056")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if properties.TotalDownloaded != 276445467 || properties.TotalDownloadedSession != 1048576 ||
+		properties.TotalUploaded != 552890934 || properties.TotalUploadedSession != 2097152 ||
+		properties.DownloadSpeed != 4096 || properties.UploadSpeed != 512 {
+		t.Errorf("Unexpected properties %+v", properties)
+	}
+}
+
+func TestClient_GetTorrentPeers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/sync/torrentPeers" || r.URL.Query().Get("hash") != "c9e15763f722f23e98a29decdfae341b98d53056" ||
+			r.URL.Query().Get("rid") != "0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"full_update":true,"rid":1,"peers":{` +
+			`"203.0.113.1:51413":{"client":"qBittorrent 4.6.5","country_code":"de","flags":"D E"},` +
+			`"203.0.113.2:6881":{"client":"Transmission 4.0.6","country_code":"fr","flags":"U"}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	peers, err := client.GetTorrentPeers(context.Background(), "c9e15763f722f23e98a29decdfae341b98d53056")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(peers) != 2 || peers["203.0.113.1:51413"].Client != "qBittorrent 4.6.5" || peers["203.0.113.2:6881"].CountryCode != "fr" {
+		t.Errorf("Unexpected peers %+v", peers)
+	}
+	if !peers["203.0.113.1:51413"].Encrypted() || peers["203.0.113.2:6881"].Encrypted() {
+		t.Errorf("Unexpected encryption of peers %+v", peers)
+	}
+}
+
+func TestClient_BanPeers(t *testing.T) {
+	var peers string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/transfer/banPeers" || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		peers = r.PostFormValue("peers")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	if err := client.BanPeers(context.Background(), []string{"203.0.113.1:0", "[2001:db8::1]:6881"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if peers != "203.0.113.1:0|[2001:db8::1]:6881" {
+		t.Errorf("Unexpected peers %q", peers)
+	}
+}
+
+func TestClient_AddTrackers(t *testing.T) {
+	var hash, urls, reannounced string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/torrents/addTrackers":
+			hash, urls = r.PostFormValue("hash"), r.PostFormValue("urls")
+		case "/api/v2/torrents/reannounce":
+			reannounced = r.PostFormValue("hashes")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := context.Background()
+	torrentHash := "c9e15763f722f23e98a29decdfae341b98d53056"
+	trackers := []string{"udp://tracker.opentrackr.org:1337/announce", "https://tracker.example.com/announce"}
+	if err := client.AddTrackers(ctx, torrentHash, trackers); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if hash != torrentHash || urls != strings.Join(trackers, "\n") {
+		t.Errorf("Unexpected trackers %q added to %q", urls, hash)
+	}
+	if err := client.Reannounce(ctx, torrentHash); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if reannounced != torrentHash {
+		t.Errorf("Unexpected reannounced torrents %q", reannounced)
+	}
+}
+
+func TestClient_SetPreferences(t *testing.T) {
+	var preferences string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/app/setPreferences" || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		preferences = r.PostFormValue("json")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	err := client.SetPreferences(context.Background(), map[string]any{
+		"ip_filter_enabled": true,
+		"ip_filter_path":    "/blocklists/blocklist.p2p",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if preferences != `{"ip_filter_enabled":true,"ip_filter_path":"/blocklists/blocklist.p2p"}` {
+		t.Errorf("Unexpected preferences %s", preferences)
+	}
+}
+
+func TestClient_FetchBlocklist(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("Bad peers:203.0.113.0-203.0.113.255\n"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	blocklist, err := client.FetchBlocklist(context.Background(), server.URL+"/blocklist.p2p", 1024)
+	if err != nil || string(blocklist) != "Bad peers:203.0.113.0-203.0.113.255\n" {
+		t.Errorf("Unexpected blocklist %q (%v)", blocklist, err)
+	}
+
+	if _, err := client.FetchBlocklist(context.Background(), server.URL+"/blocklist.p2p", 8); err == nil {
+		t.Error("Expected an error for a blocklist larger than the limit")
+	}
+}
+
+func TestClient_GetPreferences(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/app/preferences" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"listen_port":51413,"random_port":false,"ip_filter_enabled":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	preferences, err := client.GetPreferences(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if preferences.ListenPort != 51413 || preferences.RandomPort {
+		t.Errorf("Unexpected preferences %+v", preferences)
+	}
+}
+
+func TestClient_WebSeeds(t *testing.T) {
+	supported := true
+	var urls string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/torrents/webseeds":
+			_, _ = w.Write([]byte(`[{"url":"https://mirror.example.com/big_buck_bunny/"}]`))
+		case r.URL.Path == "/api/v2/torrents/addWebSeeds" && supported:
+			urls = r.PostFormValue("urls")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := context.Background()
+	hash := "c9e15763f722f23e98a29decdfae341b98d53056"
+	webSeeds, err := client.GetWebSeeds(ctx, hash)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(webSeeds, []string{"https://mirror.example.com/big_buck_bunny/"}) {
+		t.Errorf("Unexpected web seeds %v", webSeeds)
+	}
+
+	err = client.AddWebSeeds(ctx, hash, []string{"https://a.example.com/", "https://b.example.com/"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if urls != "https://a.example.com/|https://b.example.com/" {
+		t.Errorf("Unexpected urls %q", urls)
+	}
+
+	supported = false
+	if err := client.AddWebSeeds(ctx, hash, []string{"https://a.example.com/"}); !errors.Is(err, ErrWebSeedsUnsupported) {
+		t.Errorf("Expected ErrWebSeedsUnsupported, got %v", err)
+	}
+}
+
+func TestClient_CategoriesAndTags(t *testing.T) {
+	var removed, deleted string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/torrents/categories":
+			_, _ = w.Write([]byte(`{"tv":{"name":"tv","savePath":""},"movies":{"name":"movies","savePath":"/movies"}}`))
+		case "/api/v2/torrents/removeCategories":
+			removed = r.PostFormValue("categories")
+		case "/api/v2/torrents/tags":
+			_, _ = w.Write([]byte(`["k8s:app=sonarr","archive"]`))
+		case "/api/v2/torrents/deleteTags":
+			deleted = r.PostFormValue("tags")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := context.Background()
+	categories, err := client.GetCategories(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(categories, []string{"movies", "tv"}) {
+		t.Errorf("Unexpected categories %v", categories)
+	}
+	if err := client.RemoveCategories(ctx, []string{"movies", "tv"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if removed != "movies\ntv" {
+		t.Errorf("Unexpected removed categories %q", removed)
+	}
+
+	tags, err := client.GetTags(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(tags, []string{"k8s:app=sonarr", "archive"}) {
+		t.Errorf("Unexpected tags %v", tags)
+	}
+	if err := client.DeleteTags(ctx, []string{"k8s:app=sonarr", "archive"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if deleted != "k8s:app=sonarr,archive" {
+		t.Errorf("Unexpected deleted tags %q", deleted)
+	}
+}
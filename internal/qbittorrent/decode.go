@@ -0,0 +1,72 @@
+package qbittorrent
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// gzipReaders reuses the gzip readers, whose window and tables are most of
+// the allocations of a decompression
+var gzipReaders sync.Pool
+
+// responseReader returns the body of a response, decompressed when gzip
+// encoded, and a function releasing the reader once read. The transport only
+// decompresses the responses of the requests not asking for gzip themselves.
+func responseReader(resp *http.Response) (io.Reader, func(), error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp.Body, func() {}, nil
+	}
+
+	zr, _ := gzipReaders.Get().(*gzip.Reader)
+	var err error
+	if zr == nil {
+		zr, err = gzip.NewReader(resp.Body)
+	} else {
+		err = zr.Reset(resp.Body)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decompress response: %w", err)
+	}
+	return zr, func() { gzipReaders.Put(zr) }, nil
+}
+
+// decodeTorrents decodes a JSON array of torrents one at a time, so that the
+// whole response is never held in memory next to the decoded list. The list
+// is allocated with the given capacity, the size of the previous one. The
+// first torrent is kept raw in sample, if not nil, for the schema checks.
+func decodeTorrents(r io.Reader, capacity int, sample *json.RawMessage) ([]TorrentInfo, error) {
+	decoder := json.NewDecoder(r)
+	if token, err := decoder.Token(); err != nil {
+		return nil, err
+	} else if token == nil {
+		return nil, nil
+	} else if token != json.Delim('[') {
+		return nil, fmt.Errorf("expected a list of torrents, got %v", token)
+	}
+
+	torrents := make([]TorrentInfo, 0, capacity)
+	for decoder.More() {
+		torrents = append(torrents, TorrentInfo{})
+		if sample != nil && len(torrents) == 1 {
+			if err := decoder.Decode(sample); err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(*sample, &torrents[0]); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := decoder.Decode(&torrents[len(torrents)-1]); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	return torrents, nil
+}
@@ -0,0 +1,109 @@
+package qbittorrent
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestClient_CompressedTorrentsInfo(t *testing.T) {
+	torrents := `[{"hash":"c9e15763f722f23e98a29decdfae341b98d53056","name":"first"},` +
+		`{"hash":"a1b2c3d4e5f60718293a4b5c6d7e8f9011223344","name":"second"}]`
+	var acceptEncoding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		_, _ = zw.Write([]byte(torrents))
+		_ = zw.Close()
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	for range 2 {
+		torrentsInfo, err := client.GetTorrentsInfo(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(torrentsInfo) != 2 || torrentsInfo[1].Name != "second" {
+			t.Errorf("Expected the compressed list to be decoded, got %+v", torrentsInfo)
+		}
+	}
+	if acceptEncoding != "gzip" {
+		t.Errorf("Expected the list to be requested compressed, got %q", acceptEncoding)
+	}
+	if client.listed.Load() != 2 {
+		t.Errorf("Expected the size of the list to be recorded, got %d", client.listed.Load())
+	}
+}
+
+func TestDecodeTorrents(t *testing.T) {
+	torrents, err := decodeTorrents(strings.NewReader(`[]`), 4, nil)
+	if err != nil || torrents == nil || len(torrents) != 0 {
+		t.Errorf("Expected an empty list, got %v, %v", torrents, err)
+	}
+
+	torrents, err = decodeTorrents(strings.NewReader(`null`), 4, nil)
+	if err != nil || torrents != nil {
+		t.Errorf("Expected no list, got %v, %v", torrents, err)
+	}
+
+	if _, err := decodeTorrents(strings.NewReader(`{"hash":"abc"}`), 0, nil); err == nil {
+		t.Errorf("Expected an object to be rejected")
+	}
+	if _, err := decodeTorrents(strings.NewReader(`[{"hash":"abc"},`), 0, nil); err == nil {
+		t.Errorf("Expected a truncated list to be rejected")
+	}
+}
+
+func TestClient_PagedTorrentsInfo(t *testing.T) {
+	hashes := []string{"a1", "b2", "c3", "d4", "e5"}
+	var calls []string
+	removeOnce := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		calls = append(calls, query.Encode())
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		if query.Get("sort") != "hash" {
+			t.Errorf("Expected the pages to be sorted by hash, got %s", query.Encode())
+		}
+		// A torrent is removed from the first page while the second one is listed
+		if removeOnce && offset > 0 {
+			hashes = hashes[1:]
+			removeOnce = false
+		}
+
+		var page []string
+		for i := offset; i < len(hashes) && i < offset+limit; i++ {
+			page = append(page, fmt.Sprintf(`{"hash":%q}`, hashes[i]))
+		}
+		_, _ = fmt.Fprintf(w, "[%s]", strings.Join(page, ","))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetPageSize(2)
+	torrentsInfo, err := client.GetTorrentsInfo(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(torrentsInfo) != 5 || torrentsInfo[4].Hash != "e5" || len(calls) != 3 {
+		t.Errorf("Expected the 5 torrents in 3 pages, got %+v in %v", torrentsInfo, calls)
+	}
+
+	removeOnce = true
+	calls = nil
+	torrentsInfo, err = client.GetTorrentsInfo(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(torrentsInfo) != 4 || torrentsInfo[0].Hash != "b2" {
+		t.Errorf("Expected the listing to start over once shifted, got %+v in %v", torrentsInfo, calls)
+	}
+}
@@ -0,0 +1,25 @@
+package qbittorrent
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultKeepAliveInterval is how often the session is used while the
+// operator is idle. qbittorrent expires the sessions unused for the WebUI
+// session timeout, 3600 seconds by default.
+const DefaultKeepAliveInterval = 5 * time.Minute
+
+// Ping uses the session, so that it does not expire while the operator is
+// idle. An expired session is replaced by logging in again, before a
+// reconcile needs it. A failure is only logged, the session is used again at
+// the next ping.
+func (c *Client) Ping(ctx context.Context) {
+	// A session rejected with 403 Forbidden is renewed by the request
+	if _, err := c.GetVersion(ctx); err != nil {
+		log.FromContext(ctx).WithName("qbittorrent-client").V(1).Info(
+			"Keep-alive of the qbittorrent session failed, retrying at the next interval", "error", err.Error())
+	}
+}
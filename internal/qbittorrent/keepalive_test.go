@@ -0,0 +1,48 @@
+package qbittorrent
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_Ping(t *testing.T) {
+	var sessionID atomic.Value
+	sessionID.Store("first")
+	var pings atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/auth/login" {
+			http.SetCookie(w, &http.Cookie{Name: "SID", Value: sessionID.Load().(string)})
+			return
+		}
+		if cookie, err := r.Cookie("SID"); err != nil || cookie.Value != sessionID.Load().(string) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		pings.Add(1)
+		_, _ = w.Write([]byte("v5.0.4"))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := NewClient(server.URL)
+	if err := client.Login(ctx, "admin", "secret"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The session expired while the operator was idle
+	sessionID.Store("second")
+
+	client.Ping(ctx)
+	client.Ping(ctx)
+	cancel()
+
+	if pings.Load() != 2 {
+		t.Fatalf("Expected the session to be pinged, got %d pings", pings.Load())
+	}
+	if client.session() != "second" {
+		t.Errorf("Expected the expired session to be renewed, got %s", client.session())
+	}
+}
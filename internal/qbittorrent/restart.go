@@ -0,0 +1,170 @@
+package qbittorrent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultRestartGracePeriod is how long the torrents missing from qbittorrent
+// are not taken as removed after a restart, while it loads them
+const DefaultRestartGracePeriod = 2 * time.Minute
+
+// connectionErrorBurst is the number of consecutive connection errors taken
+// as a restart of qbittorrent
+const connectionErrorBurst = 3
+
+// restartDetector detects the restarts of qbittorrent from the responses of
+// the API calls, and tracks the grace period following them
+type restartDetector struct {
+	mu               sync.Mutex
+	gracePeriod      time.Duration
+	restartedAt      time.Time
+	connectionErrors int
+	version          string
+	torrents         int
+}
+
+// restarted starts, or extends, the grace period
+func (d *restartDetector) restarted(ctx context.Context, reason string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.restartedAt.IsZero() || time.Since(d.restartedAt) >= d.gracePeriod {
+		log.FromContext(ctx).WithName("qbittorrent-client").Info("Restart of qbittorrent detected, entering grace period",
+			"reason", reason, "gracePeriod", d.gracePeriod)
+	}
+	d.restartedAt = time.Now()
+}
+
+// inGracePeriod reports whether qbittorrent restarted during the grace period
+func (d *restartDetector) inGracePeriod() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return !d.restartedAt.IsZero() && time.Since(d.restartedAt) < d.gracePeriod
+}
+
+// observeConnection records whether a call reached qbittorrent, a burst of
+// connection errors means it is restarting
+func (d *restartDetector) observeConnection(ctx context.Context, failed bool) {
+	d.mu.Lock()
+	if !failed {
+		d.connectionErrors = 0
+		d.mu.Unlock()
+		return
+	}
+	d.connectionErrors++
+	burst := d.connectionErrors >= connectionErrorBurst
+	d.mu.Unlock()
+
+	if burst {
+		d.restarted(ctx, "connection errors")
+	}
+}
+
+// observeVersion records the qbittorrent version, a new one means it was
+// restarted to be upgraded
+func (d *restartDetector) observeVersion(ctx context.Context, version string) {
+	d.mu.Lock()
+	changed := d.version != "" && d.version != version
+	d.version = version
+	d.mu.Unlock()
+
+	if changed {
+		d.restarted(ctx, "version changed")
+	}
+}
+
+// observeTorrents records the number of torrents of qbittorrent, all of them
+// missing at once means it is loading them after a restart
+func (d *restartDetector) observeTorrents(ctx context.Context, count int) {
+	d.mu.Lock()
+	emptied := d.torrents > 0 && count == 0
+	d.torrents = count
+	d.mu.Unlock()
+
+	if emptied {
+		d.restarted(ctx, "all torrents missing")
+	}
+}
+
+type noReloginKey struct{}
+
+// do sends a request with the session of the client. When qbittorrent
+// rejects the session, after a restart, the client logs in again and the
+// request is sent once more.
+func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	resp, err := c.send(ctx, req)
+	if err != nil || resp.StatusCode != http.StatusForbidden {
+		return resp, err
+	}
+
+	username, password, ok := c.credentials()
+	// The body of the request must be sent again
+	if !ok || ctx.Value(noReloginKey{}) != nil || (req.Body != nil && req.GetBody == nil) {
+		return resp, nil
+	}
+
+	// qbittorrent answers 403 Forbidden to the sessions it does not know
+	c.restarts.restarted(ctx, "session expired")
+	if err := c.Login(context.WithValue(ctx, noReloginKey{}, true), username, password); err != nil {
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+
+	retry := req.Clone(ctx)
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	retry.Header.Del("Cookie")
+	return c.send(ctx, retry)
+}
+
+// send sends a request with the session of the client, recording the
+// connection errors. It waits for the rate limit of the client first.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if limiter := c.limiter.Load(); limiter != nil {
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("rate limit of qbittorrent calls: %w", err)
+		}
+	}
+	if sessionID := c.session(); sessionID != "" {
+		req.AddCookie(&http.Cookie{Name: "SID", Value: sessionID})
+	}
+
+	resp, err := c.httpClient.Do(req)
+	var failed bool
+	if err != nil {
+		// The calls ended by their context did not fail to reach qbittorrent
+		failed = !errors.Is(err, context.Canceled) && ctx.Err() == nil
+	} else {
+		// A reverse proxy answers 502 or 503 while qbittorrent is down
+		failed = resp.StatusCode >= http.StatusInternalServerError
+	}
+	c.restarts.observeConnection(ctx, failed)
+	if observe := c.observer.Load(); observe != nil {
+		(*observe)(failed)
+	}
+	return resp, err
+}
+
+// SetRestartGracePeriod sets how long the torrents missing from qbittorrent
+// are not taken as removed after a restart
+func (c *Client) SetRestartGracePeriod(gracePeriod time.Duration) {
+	c.restarts.mu.Lock()
+	defer c.restarts.mu.Unlock()
+	c.restarts.gracePeriod = gracePeriod
+}
+
+// InRestartGracePeriod reports whether qbittorrent restarted recently and may
+// still be loading its torrents. The torrents missing from it must not be
+// taken as removed until the grace period ends.
+func (c *Client) InRestartGracePeriod() bool {
+	return c.restarts.inGracePeriod()
+}
@@ -0,0 +1,105 @@
+package qbittorrent
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_ReloginAfterRestart(t *testing.T) {
+	sessionID := "first"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/auth/login" {
+			http.SetCookie(w, &http.Cookie{Name: "SID", Value: sessionID})
+			return
+		}
+		if cookie, err := r.Cookie("SID"); err != nil || cookie.Value != sessionID {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("v5.0.4"))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.URL)
+	if err := client.Login(ctx, "admin", "secret"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The restarted qbittorrent does not know the session anymore
+	sessionID = "second"
+	if _, err := client.GetVersion(ctx); err != nil {
+		t.Fatalf("Expected the client to log in again, got %v", err)
+	}
+	if client.session() != "second" {
+		t.Errorf("Expected the new session to be stored, got %s", client.session())
+	}
+	if !client.InRestartGracePeriod() {
+		t.Errorf("Expected the expired session to start the grace period")
+	}
+}
+
+func TestClient_RestartGracePeriod(t *testing.T) {
+	torrents := `[{"hash":"c9e15763f722f23e98a29decdfae341b98d53056"}]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(torrents))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.URL)
+	if _, err := client.GetTorrentsInfo(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if client.InRestartGracePeriod() {
+		t.Fatalf("Expected no grace period before a restart")
+	}
+
+	// qbittorrent lists no torrents while loading them after a restart
+	torrents = `[]`
+	if _, err := client.GetTorrentsInfo(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !client.InRestartGracePeriod() {
+		t.Fatalf("Expected all torrents missing to start the grace period")
+	}
+
+	client.SetRestartGracePeriod(time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if client.InRestartGracePeriod() {
+		t.Errorf("Expected the grace period to end")
+	}
+}
+
+func TestRestartDetector(t *testing.T) {
+	ctx := context.Background()
+	d := restartDetector{gracePeriod: time.Minute}
+
+	d.observeVersion(ctx, "v5.0.4")
+	if d.inGracePeriod() {
+		t.Fatalf("Expected the first version not to be taken as a restart")
+	}
+	d.observeVersion(ctx, "v5.1.0")
+	if !d.inGracePeriod() {
+		t.Fatalf("Expected a version change to be taken as a restart")
+	}
+
+	d = restartDetector{gracePeriod: time.Minute}
+	for range connectionErrorBurst - 1 {
+		d.observeConnection(ctx, true)
+	}
+	d.observeConnection(ctx, false)
+	d.observeConnection(ctx, true)
+	if d.inGracePeriod() {
+		t.Fatalf("Expected isolated connection errors not to be taken as a restart")
+	}
+	for range connectionErrorBurst {
+		d.observeConnection(ctx, true)
+	}
+	if !d.inGracePeriod() {
+		t.Errorf("Expected a burst of connection errors to be taken as a restart")
+	}
+}
@@ -0,0 +1,116 @@
+package qbittorrent
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// SchemaDrift is the difference between the fields of a qBittorrent response
+// and the ones the client decodes, so that the fields renamed or removed by
+// a new qBittorrent are noticed rather than silently left at zero
+type SchemaDrift struct {
+	// Response is the API path of the response, e.g. /api/v2/torrents/info
+	Response string
+	// Missing are the decoded fields absent from the response
+	Missing []string
+	// Unknown are the fields of the response the client does not decode
+	Unknown []string
+	// Sample is the raw JSON object the drift was first noticed in
+	Sample json.RawMessage
+}
+
+// schemaChecker gathers the drifts of the responses, each new field being
+// logged once at debug level
+type schemaChecker struct {
+	mu     sync.Mutex
+	drifts map[string]*SchemaDrift
+}
+
+// structFields caches the JSON fields decoded into each struct type
+var structFields sync.Map
+
+// jsonFields returns the JSON names of the fields of the struct type
+func jsonFields(t reflect.Type) []string {
+	if fields, ok := structFields.Load(t); ok {
+		return fields.([]string)
+	}
+	var fields []string
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields = append(fields, name)
+		}
+	}
+	slices.Sort(fields)
+	structFields.Store(t, fields)
+	return fields
+}
+
+// check compares the fields of a JSON object of the response with the ones
+// of the struct v decodes it into
+func (s *schemaChecker) check(ctx context.Context, response string, object json.RawMessage, v any) {
+	var keys map[string]json.RawMessage
+	if len(object) == 0 || json.Unmarshal(object, &keys) != nil {
+		return
+	}
+	expected := jsonFields(reflect.TypeOf(v).Elem())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.drifts == nil {
+		s.drifts = map[string]*SchemaDrift{}
+	}
+	drift, ok := s.drifts[response]
+	if !ok {
+		drift = &SchemaDrift{Response: response}
+	}
+
+	var missing, unknown []string
+	for _, field := range expected {
+		if _, ok := keys[field]; !ok && !slices.Contains(drift.Missing, field) {
+			missing = append(missing, field)
+		}
+	}
+	for key := range keys {
+		if _, found := slices.BinarySearch(expected, key); !found && !slices.Contains(drift.Unknown, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(missing) == 0 && len(unknown) == 0 {
+		return
+	}
+
+	slices.Sort(unknown)
+	drift.Missing = append(drift.Missing, missing...)
+	drift.Unknown = append(drift.Unknown, unknown...)
+	if drift.Sample == nil {
+		drift.Sample = slices.Clone(object)
+	}
+	s.drifts[response] = drift
+	log.FromContext(ctx).WithName("qbittorrent-client").V(1).Info("qBittorrent response fields differ from the decoded ones",
+		"response", response, "missing", missing, "unknown", unknown, "sample", string(drift.Sample))
+}
+
+// SchemaDrifts returns the drifts noticed in the responses of qBittorrent, by
+// API path
+func (c *Client) SchemaDrifts() []SchemaDrift {
+	c.schema.mu.Lock()
+	defer c.schema.mu.Unlock()
+	drifts := make([]SchemaDrift, 0, len(c.schema.drifts))
+	for _, drift := range c.schema.drifts {
+		drifts = append(drifts, SchemaDrift{
+			Response: drift.Response,
+			Missing:  slices.Clone(drift.Missing),
+			Unknown:  slices.Clone(drift.Unknown),
+			Sample:   slices.Clone(drift.Sample),
+		})
+	}
+	slices.SortFunc(drifts, func(a, b SchemaDrift) int { return strings.Compare(a.Response, b.Response) })
+	return drifts
+}
@@ -0,0 +1,54 @@
+package qbittorrent
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_SchemaDrifts(t *testing.T) {
+	var response atomic.Value
+	response.Store(`[{"hash":"c9e15763f722f23e98a29decdfae341b98d53056","amountLeft":100},{"hash":"other","extra":1}]`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(response.Load().(string)))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	torrents, err := client.GetTorrentsInfo(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(torrents) != 2 || torrents[0].Hash != "c9e15763f722f23e98a29decdfae341b98d53056" || torrents[0].AmountLeft != 0 {
+		t.Fatalf("Expected the torrents decoded leniently, got %+v", torrents)
+	}
+
+	drifts := client.SchemaDrifts()
+	if len(drifts) != 1 || drifts[0].Response != "/api/v2/torrents/info" {
+		t.Fatalf("Expected the drift of the torrents info, got %+v", drifts)
+	}
+	first := drifts[0]
+	if !slices.Contains(first.Missing, "amount_left") || slices.Contains(first.Missing, "hash") {
+		t.Errorf("Expected amount_left missing, got %v", first.Missing)
+	}
+	// Only the first torrent of a list is checked
+	if !slices.Equal(first.Unknown, []string{"amountLeft"}) {
+		t.Errorf("Expected amountLeft unknown, got %v", first.Unknown)
+	}
+
+	// The new fields are noted, the first sample is kept
+	response.Store(`[{"hash":"c9e15763f722f23e98a29decdfae341b98d53056","amountLeft":100,"eta":10}]`)
+	if _, err := client.GetTorrentsInfo(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	next := client.SchemaDrifts()[0]
+	if !slices.Equal(next.Unknown, []string{"amountLeft", "eta"}) {
+		t.Errorf("Expected eta noted, got %v", next.Unknown)
+	}
+	if string(next.Sample) != string(first.Sample) || len(next.Missing) != len(first.Missing) {
+		t.Errorf("Expected the first sample kept, got %s", next.Sample)
+	}
+}
@@ -0,0 +1,165 @@
+package qbittorrent
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Defaults of the connection pool to qbittorrent. Concurrent reconciles share
+// the idle connections instead of opening new ones, which http.DefaultTransport
+// limits to 2 per host.
+const (
+	DefaultMaxIdleConns    = 10
+	DefaultIdleConnTimeout = 90 * time.Second
+)
+
+// PoolOptions tunes the connections kept open to qbittorrent
+type PoolOptions struct {
+	// MaxIdleConns is the number of idle connections kept open
+	MaxIdleConns int
+	// IdleConnTimeout closes the connections idle for longer
+	IdleConnTimeout time.Duration
+	// DisableKeepAlives opens a new connection for every call
+	DisableKeepAlives bool
+}
+
+// DefaultPoolOptions returns the default connection pool to qbittorrent
+func DefaultPoolOptions() PoolOptions {
+	return PoolOptions{MaxIdleConns: DefaultMaxIdleConns, IdleConnTimeout: DefaultIdleConnTimeout}
+}
+
+// transport forwards the requests to the current transport, so that the TLS
+// configuration, the proxy and the headers can be replaced while requests are made
+type transport struct {
+	current atomic.Pointer[transportState]
+	// capture records the requests and their responses, none if nil
+	capture atomic.Pointer[HTTPCapture]
+
+	mu        sync.Mutex
+	tlsConfig *tls.Config
+	proxyURL  *url.URL
+	pool      PoolOptions
+	headers   http.Header
+	// host the headers are sent to, so that they do not leak to other
+	// servers such as the ones of the fetched .torrent files
+	host string
+}
+
+type transportState struct {
+	transport *http.Transport
+	headers   http.Header
+	host      string
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	state := t.current.Load()
+	if len(state.headers) > 0 && req.URL.Host == state.host {
+		// A RoundTripper must not modify the request
+		req = req.Clone(req.Context())
+		for name, values := range state.headers {
+			req.Header[name] = values
+		}
+	}
+	if capture := t.capture.Load(); capture != nil {
+		return capture.roundTrip(state.transport, req, state.headers)
+	}
+	return state.transport.RoundTrip(req)
+}
+
+// update changes the settings of the transport and replaces the current one
+func (t *transport) update(change func(*transport)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	change(t)
+
+	next := http.DefaultTransport.(*http.Transport).Clone()
+	next.TLSClientConfig = t.tlsConfig
+	if t.proxyURL != nil {
+		next.Proxy = http.ProxyURL(t.proxyURL)
+	}
+	next.MaxIdleConns = t.pool.MaxIdleConns
+	next.MaxIdleConnsPerHost = t.pool.MaxIdleConns
+	next.IdleConnTimeout = t.pool.IdleConnTimeout
+	next.DisableKeepAlives = t.pool.DisableKeepAlives
+	previous := t.current.Swap(&transportState{transport: next, headers: t.headers, host: t.host})
+	if previous != nil {
+		previous.transport.CloseIdleConnections()
+	}
+}
+
+// ParseHeaders parses headers formatted as "Name: value", one per line.
+// Empty lines and lines starting with # are ignored.
+func ParseHeaders(data []byte) (http.Header, error) {
+	headers := http.Header{}
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid header on line %d, expected Name: value", i+1)
+		}
+		headers.Add(name, strings.TrimSpace(value))
+	}
+	return headers, nil
+}
+
+// TLSOptions configure the TLS connection to qbittorrent
+type TLSOptions struct {
+	// CABundles hold PEM encoded CA certificates trusted in addition to the system ones
+	CABundles [][]byte
+	// InsecureSkipVerify disables the verification of the qbittorrent certificate
+	InsecureSkipVerify bool
+	// ClientCertificate and ClientKey are the PEM encoded certificate and key
+	// presented to qbittorrent, or to the reverse proxy in front of it
+	ClientCertificate []byte
+	ClientKey         []byte
+}
+
+// Config returns the TLS configuration of the options
+func (o TLSOptions) Config() (*tls.Config, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	for _, caBundle := range o.CABundles {
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, errors.New("no PEM encoded certificate found in CA bundle")
+		}
+	}
+	config := &tls.Config{
+		RootCAs:            pool,
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: o.InsecureSkipVerify, // nolint:gosec
+	}
+
+	if len(o.ClientCertificate) > 0 || len(o.ClientKey) > 0 {
+		certificate, err := tls.X509KeyPair(o.ClientCertificate, o.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
+
+// Equal reports whether the options configure the same TLS connection
+func (o TLSOptions) Equal(other TLSOptions) bool {
+	return o.InsecureSkipVerify == other.InsecureSkipVerify &&
+		slices.EqualFunc(o.CABundles, other.CABundles, bytes.Equal) &&
+		bytes.Equal(o.ClientCertificate, other.ClientCertificate) &&
+		bytes.Equal(o.ClientKey, other.ClientKey)
+}
@@ -1,30 +1,243 @@
 package qbittorrent
 
 import (
+	"encoding/base32"
+	"encoding/hex"
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
 	"strings"
 )
 
-func GetTorrentHash(magnetURI string) (string, error) {
-	// Find the btih: prefix
-	btihIndex := strings.Index(magnetURI, "btih:")
-	if btihIndex == -1 {
-		return "", fmt.Errorf("'btih:' not found")
+const (
+	magnetScheme = "magnet"
+	btihPrefix   = "urn:btih:"
+	btmhPrefix   = "urn:btmh:"
+
+	// A 20 bytes SHA-1 info hash is 32 characters in base32, 40 in hex
+	base32HashLength = 32
+	v1HashLength     = 40
+	v2HashLength     = 64
+
+	// Multihash code of sha2-256 (0x12) followed by the digest length (0x20)
+	sha256MultihashPrefix = "1220"
+)
+
+// MaxMagnetURILength bounds the magnet links parsed, as validated by the
+// Torrent CRD
+const MaxMagnetURILength = 8192
+
+// Magnet is a parsed magnet link
+type Magnet struct {
+	// ExactTopics are the xt parameters, e.g. urn:btih:<hash>, in link order
+	ExactTopics []string
+	// DisplayName is the dn parameter, empty if missing
+	DisplayName string
+	// Trackers are the tr parameters, in link order
+	Trackers []string
+}
+
+// MagnetParseError is returned when a magnet link cannot be parsed
+type MagnetParseError struct {
+	URI    string
+	Reason string
+	Err    error
+}
+
+func (e *MagnetParseError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("invalid magnet URI: %s: %v", e.Reason, e.Err)
+	}
+	return fmt.Sprintf("invalid magnet URI: %s", e.Reason)
+}
+
+func (e *MagnetParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseMagnet parses a magnet link, decoding the query parameters.
+// The link must have at least one xt parameter.
+func ParseMagnet(magnetURI string) (*Magnet, error) {
+	if len(magnetURI) > MaxMagnetURILength {
+		return nil, &MagnetParseError{URI: magnetURI, Reason: fmt.Sprintf("longer than %d bytes", MaxMagnetURILength)}
+	}
+	u, err := url.Parse(magnetURI)
+	if err != nil {
+		return nil, &MagnetParseError{URI: magnetURI, Reason: "malformed URI", Err: err}
+	}
+	if !strings.EqualFold(u.Scheme, magnetScheme) {
+		return nil, &MagnetParseError{URI: magnetURI, Reason: fmt.Sprintf("unexpected scheme %q", u.Scheme)}
+	}
+
+	// magnet:?xt=... is an opaque URI, the query is parsed like any other
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, &MagnetParseError{URI: magnetURI, Reason: "malformed query", Err: err}
+	}
+
+	magnet := &Magnet{
+		ExactTopics: query["xt"],
+		DisplayName: query.Get("dn"),
+		Trackers:    query["tr"],
+	}
+	if len(magnet.ExactTopics) == 0 {
+		return nil, &MagnetParseError{URI: magnetURI, Reason: "no xt parameter"}
+	}
+
+	return magnet, nil
+}
+
+// InfoHashes returns the BitTorrent v1 and v2 info hashes of the first
+// urn:btih and urn:btmh exact topics. Hybrid torrents have both.
+func (m *Magnet) InfoHashes() (InfoHashes, error) {
+	var hashes InfoHashes
+	for _, xt := range m.ExactTopics {
+		lower := strings.ToLower(xt)
+		switch {
+		case strings.HasPrefix(lower, btihPrefix) && hashes.V1 == "":
+			hash := xt[len(btihPrefix):]
+			if hash == "" {
+				return hashes, fmt.Errorf("no hash after 'btih:'")
+			}
+			// qBittorrent reports hex hashes, base32 ones would never match
+			if len(hash) == base32HashLength {
+				var err error
+				if hash, err = base32ToHex(hash); err != nil {
+					return hashes, err
+				}
+			}
+			// A SHA-256 digest is no v1 info hash
+			if len(hash) != v1HashLength {
+				return hashes, fmt.Errorf("invalid info hash %q: expected %d hex characters after 'btih:'", hash, v1HashLength)
+			}
+			normalized, err := NormalizeHash(hash)
+			if err != nil {
+				return hashes, err
+			}
+			hashes.V1 = normalized
+		case strings.HasPrefix(lower, btmhPrefix) && hashes.V2 == "":
+			multihash := xt[len(btmhPrefix):]
+			// Only sha2-256 multihashes are used by BitTorrent v2
+			if !strings.HasPrefix(multihash, sha256MultihashPrefix) {
+				return hashes, fmt.Errorf("unsupported multihash %q, expected a sha2-256 digest", multihash)
+			}
+			// The digest length is in the prefix, it must match
+			digest := multihash[len(sha256MultihashPrefix):]
+			if len(digest) != v2HashLength {
+				return hashes, fmt.Errorf("invalid info hash %q: expected %d hex characters of sha2-256 digest", digest, v2HashLength)
+			}
+			normalized, err := NormalizeHash(digest)
+			if err != nil {
+				return hashes, err
+			}
+			hashes.V2 = normalized
+		}
+	}
+	if hashes.V1 == "" && hashes.V2 == "" {
+		return hashes, fmt.Errorf("neither 'btih:' nor 'btmh:' found")
 	}
+	return hashes, nil
+}
 
-	// Start after "btih:"
-	hashStart := btihIndex + 5
-	if hashStart >= len(magnetURI) {
-		return "", fmt.Errorf("no hash after 'btih:'")
+// base32ToHex converts a base32 encoded v1 info hash to its hex form
+func base32ToHex(hash string) (string, error) {
+	raw, err := base32.StdEncoding.DecodeString(strings.ToUpper(hash))
+	if err != nil {
+		return "", fmt.Errorf("invalid base32 info hash %q: %w", hash, err)
 	}
+	return hex.EncodeToString(raw), nil
+}
 
-	// Find the end of the hash (next & or end of string)
-	hashEnd := strings.Index(magnetURI[hashStart:], "&")
-	if hashEnd == -1 {
-		// No & found, hash goes to end of string
-		return magnetURI[hashStart:], nil
+// NormalizeHash validates a hex v1 or v2 info hash and returns it in
+// lowercase, the form qBittorrent reports. Hashes are only compared or stored
+// once normalized.
+func NormalizeHash(hash string) (string, error) {
+	if len(hash) != v1HashLength && len(hash) != v2HashLength {
+		return "", fmt.Errorf("invalid info hash %q: expected %d or %d hex characters", hash, v1HashLength, v2HashLength)
 	}
+	if _, err := hex.DecodeString(hash); err != nil {
+		return "", fmt.Errorf("invalid info hash %q: %w", hash, err)
+	}
+	return strings.ToLower(hash), nil
+}
 
-	// Extract hash between btih: and next &
-	return magnetURI[hashStart : hashStart+hashEnd], nil
+// InfoHashes identify a torrent on qBittorrent. Either hash may be empty,
+// hybrid torrents have both.
+type InfoHashes struct {
+	// V1 is the normalized hex SHA-1 info hash
+	V1 string
+	// V2 is the normalized hex SHA-256 info hash
+	V2 string
+}
+
+// ID returns the hash qBittorrent uses as torrent ID: the v1 info hash, or
+// the v2 one truncated to the length of a v1 hash for v2-only torrents. It is
+// empty without hashes.
+func (h InfoHashes) ID() string {
+	if h.V1 != "" || len(h.V2) < v1HashLength {
+		return h.V1
+	}
+	return h.V2[:v1HashLength]
+}
+
+// Matches reports whether the torrent is listed under one of the hashes.
+// qBittorrent may list hybrid torrents under either of them.
+func (h InfoHashes) Matches(torrent *TorrentInfo) bool {
+	for _, hash := range []string{torrent.Hash, torrent.InfohashV1, torrent.InfohashV2} {
+		if hash == "" {
+			continue
+		}
+		if hash == h.V1 || hash == h.V2 || (h.V2 != "" && hash == h.V2[:v1HashLength]) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetInfoHashes returns the info hashes of a magnet link
+func GetInfoHashes(magnetURI string) (InfoHashes, error) {
+	magnet, err := ParseMagnet(magnetURI)
+	if err != nil {
+		return InfoHashes{}, err
+	}
+	hashes, err := magnet.InfoHashes()
+	if err != nil {
+		return InfoHashes{}, &MagnetParseError{URI: magnetURI, Reason: "no usable info hash", Err: err}
+	}
+	return hashes, nil
+}
+
+// GetTorrentHash returns the ID qBittorrent knows the torrent of a magnet link by
+func GetTorrentHash(magnetURI string) (string, error) {
+	hashes, err := GetInfoHashes(magnetURI)
+	if err != nil {
+		return "", err
+	}
+	return hashes.ID(), nil
+}
+
+// NormalizePeer puts a peer given as an IP address or as host:port in the
+// host:port form of /api/v2/transfer/banPeers. qBittorrent bans the IP
+// address whatever the port, 0 is used when it is missing.
+func NormalizePeer(peer string) (string, error) {
+	peer = strings.TrimSpace(peer)
+	host, port := peer, "0"
+	if ip := net.ParseIP(strings.Trim(peer, "[]")); ip == nil {
+		var err error
+		if host, port, err = net.SplitHostPort(peer); err != nil {
+			return "", fmt.Errorf("invalid peer %q: %w", peer, err)
+		}
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return "", fmt.Errorf("invalid port of peer %q", peer)
+		}
+	} else {
+		host = ip.String()
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return "", fmt.Errorf("invalid IP address of peer %q", peer)
+	}
+	return net.JoinHostPort(ip.String(), port), nil
 }
@@ -0,0 +1,276 @@
+package qbittorrent
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseMagnet(t *testing.T) {
+	magnet, err := ParseMagnet("magnet:?xt=urn:btih:dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c" +
+		"&xt=urn:btmh:1220caf1e1c30e81cb361b9ee167c4aa64228a7fa4fa9f6105232b28ad099f3a302e" +
+		"&dn=Big+Buck+Bunny&tr=udp%3A%2F%2Fexplodie.org%3A6969&tr=wss%3A%2F%2Ftracker.btorrent.xyz")
+	if err != nil {
+		t.Fatalf("Expected magnet to parse, got %v", err)
+	}
+
+	if len(magnet.ExactTopics) != 2 {
+		t.Errorf("Expected 2 exact topics, got %v", magnet.ExactTopics)
+	}
+	if magnet.DisplayName != "Big Buck Bunny" {
+		t.Errorf("Expected decoded display name, got '%s'", magnet.DisplayName)
+	}
+	if !slices.Equal(magnet.Trackers, []string{"udp://explodie.org:6969", "wss://tracker.btorrent.xyz"}) {
+		t.Errorf("Expected decoded trackers, got %v", magnet.Trackers)
+	}
+}
+
+func TestParseMagnet_Errors(t *testing.T) {
+	for _, uri := range []string{
+		"",
+		"https://example.com/file.torrent",
+		"magnet:?dn=no-topic",
+		"magnet:?xt=urn:btih:%zz",
+		"magnet:?xt=urn:btih:dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c&dn=" + strings.Repeat("a", MaxMagnetURILength),
+	} {
+		_, err := ParseMagnet(uri)
+		var parseErr *MagnetParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("Expected a MagnetParseError for '%s', got %v", uri, err)
+		}
+	}
+}
+
+func TestGetTorrentHash(t *testing.T) {
+	tests := map[string]string{
+		"magnet:?xt=urn:btih:dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c":                 "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c",
+		"magnet:?dn=Sintel&xt=urn:btih:08ada5a7a6183aae1e09d831df6748d566095a10&tr=x":  "08ada5a7a6183aae1e09d831df6748d566095a10",
+		"magnet:?xt=urn:sha1:abc&xt=URN:BTIH:08ADA5A7A6183AAE1E09D831DF6748D566095A10": "08ada5a7a6183aae1e09d831df6748d566095a10",
+	}
+	for uri, expected := range tests {
+		hash, err := GetTorrentHash(uri)
+		if err != nil {
+			t.Errorf("Expected hash for '%s', got %v", uri, err)
+			continue
+		}
+		if hash != expected {
+			t.Errorf("Expected hash '%s' for '%s', got '%s'", expected, uri, hash)
+		}
+	}
+
+	if _, err := GetTorrentHash("magnet:?xt=urn:sha1:abc"); err == nil {
+		t.Errorf("Expected an error for a magnet without btih")
+	}
+}
+
+func TestGetTorrentHash_Base32(t *testing.T) {
+	for _, uri := range []string{
+		"magnet:?xt=urn:btih:3WBFL3G4PSSV7MF37AJSHWDQMLNR63I4",
+		"magnet:?xt=urn:btih:3wbfl3g4pssv7mf37ajshwdqmlnr63i4&dn=Big+Buck+Bunny",
+	} {
+		hash, err := GetTorrentHash(uri)
+		if err != nil {
+			t.Errorf("Expected hash for '%s', got %v", uri, err)
+			continue
+		}
+		if hash != "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c" {
+			t.Errorf("Expected base32 hash converted to hex for '%s', got '%s'", uri, hash)
+		}
+	}
+
+	if _, err := GetTorrentHash("magnet:?xt=urn:btih:1WBFL3G4PSSV7MF37AJSHWDQMLNR63I4"); err == nil {
+		t.Errorf("Expected an error for a magnet without btih")
+	}
+}
+
+const v2Hash = "caf1e1c30e81cb361b9ee167c4aa64228a7fa4fa9f6105232b28ad099f3a302e"
+
+func TestGetInfoHashes_V2(t *testing.T) {
+	hashes, err := GetInfoHashes("magnet:?xt=urn:btmh:1220" + v2Hash + "&dn=bittorrent-v2-test")
+	if err != nil {
+		t.Fatalf("Expected hashes, got %v", err)
+	}
+	if hashes.V1 != "" || hashes.V2 != v2Hash {
+		t.Errorf("Expected only the v2 hash, got %+v", hashes)
+	}
+	if hashes.ID() != v2Hash[:40] {
+		t.Errorf("Expected the truncated v2 hash as ID, got '%s'", hashes.ID())
+	}
+
+	if _, err := GetInfoHashes("magnet:?xt=urn:btmh:1114" + v2Hash); err == nil {
+		t.Errorf("Expected an error for a non sha2-256 multihash")
+	}
+}
+
+func TestGetInfoHashes_Hybrid(t *testing.T) {
+	hashes, err := GetInfoHashes("magnet:?xt=urn:btih:631a31dd0a46257d5078c0dee4e66e26f73e42ac" +
+		"&xt=urn:btmh:1220" + v2Hash)
+	if err != nil {
+		t.Fatalf("Expected hashes, got %v", err)
+	}
+	if hashes.V1 != "631a31dd0a46257d5078c0dee4e66e26f73e42ac" || hashes.V2 != v2Hash {
+		t.Errorf("Expected both hashes, got %+v", hashes)
+	}
+	if hashes.ID() != hashes.V1 {
+		t.Errorf("Expected the v1 hash as ID, got '%s'", hashes.ID())
+	}
+}
+
+func TestGetInfoHashes_NoHash(t *testing.T) {
+	_, err := GetInfoHashes("magnet:?xt=urn:sha1:abc")
+	var parseErr *MagnetParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("Expected a MagnetParseError, got %v", err)
+	}
+}
+
+func TestGetInfoHashes_WrongLength(t *testing.T) {
+	for _, uri := range []string{
+		"magnet:?xt=urn:btih:" + v2Hash,
+		"magnet:?xt=urn:btmh:1220dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c",
+	} {
+		if _, err := GetInfoHashes(uri); err == nil {
+			t.Errorf("Expected an error for a hash of the wrong length in '%s'", uri)
+		}
+	}
+}
+
+func TestInfoHashes_IDWithoutHash(t *testing.T) {
+	if id := (InfoHashes{}).ID(); id != "" {
+		t.Errorf("Expected no ID without hashes, got '%s'", id)
+	}
+}
+
+func TestInfoHashes_Matches(t *testing.T) {
+	hybrid := InfoHashes{V1: "631a31dd0a46257d5078c0dee4e66e26f73e42ac", V2: v2Hash}
+	v2Only := InfoHashes{V2: v2Hash}
+
+	listedByV1 := &TorrentInfo{Hash: hybrid.V1}
+	listedByV2 := &TorrentInfo{Hash: v2Hash[:40], InfohashV2: v2Hash}
+	other := &TorrentInfo{Hash: "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"}
+
+	if !hybrid.Matches(listedByV1) || !hybrid.Matches(listedByV2) {
+		t.Errorf("Expected a hybrid torrent to match under either hash")
+	}
+	if !v2Only.Matches(listedByV2) {
+		t.Errorf("Expected a v2 torrent to match its truncated hash")
+	}
+	if hybrid.Matches(other) || v2Only.Matches(other) {
+		t.Errorf("Expected no match for another torrent")
+	}
+}
+
+func TestTorrentIndex_Lookup(t *testing.T) {
+	hybrid := InfoHashes{V1: "631a31dd0a46257d5078c0dee4e66e26f73e42ac", V2: v2Hash}
+	index := NewTorrentIndex([]TorrentInfo{
+		{Hash: "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"},
+		{Hash: v2Hash[:40], InfohashV2: v2Hash},
+	})
+
+	if torrent := index.Lookup(hybrid); torrent == nil || torrent.InfohashV2 != v2Hash {
+		t.Errorf("Expected a hybrid torrent listed under its v2 hash, got %v", torrent)
+	}
+	if torrent := index.Lookup(InfoHashes{V2: v2Hash}); torrent == nil {
+		t.Errorf("Expected a v2 torrent found by its truncated hash")
+	}
+	if torrent := index.Lookup(InfoHashes{V1: hybrid.V1}); torrent != nil {
+		t.Errorf("Expected no torrent, got %v", torrent)
+	}
+}
+
+func TestNormalizeHash(t *testing.T) {
+	for hash, expected := range map[string]string{
+		"DD8255ECDC7CA55FB0BBF81323D87062DB1F6D1C": "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c",
+		strings.ToUpper(v2Hash):                    v2Hash,
+	} {
+		normalized, err := NormalizeHash(hash)
+		if err != nil || normalized != expected {
+			t.Errorf("Expected '%s' for '%s', got '%s' (%v)", expected, hash, normalized, err)
+		}
+	}
+
+	for _, hash := range []string{"", "not-a-hash", "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1", "zz8255ecdc7ca55fb0bbf81323d87062db1f6d1c"} {
+		if _, err := NormalizeHash(hash); err == nil {
+			t.Errorf("Expected an error for '%s'", hash)
+		}
+	}
+}
+
+func TestInfoHashes_MatchesMixedCase(t *testing.T) {
+	hashes, err := GetInfoHashes("magnet:?xt=urn:btih:DD8255ECDC7CA55FB0BBF81323D87062DB1F6D1C")
+	if err != nil {
+		t.Fatalf("Expected hashes, got %v", err)
+	}
+
+	info := TorrentInfo{Hash: "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"}
+	info.normalizeHashes()
+	if !hashes.Matches(&info) {
+		t.Errorf("Expected a mixed case magnet to match the lowercase hash reported by qBittorrent")
+	}
+}
+
+func TestNormalizePeer(t *testing.T) {
+	for peer, expected := range map[string]string{
+		"203.0.113.1":            "203.0.113.1:0",
+		" 203.0.113.1:51413 ":    "203.0.113.1:51413",
+		"2001:db8::1":            "[2001:db8::1]:0",
+		"[2001:db8::1]":          "[2001:db8::1]:0",
+		"[2001:db8::1]:6881":     "[2001:db8::1]:6881",
+		"2001:0db8:0000::0001":   "[2001:db8::1]:0",
+		"::ffff:203.0.113.1":     "203.0.113.1:0",
+		"[::ffff:203.0.113.1]:1": "203.0.113.1:1",
+	} {
+		normalized, err := NormalizePeer(peer)
+		if err != nil || normalized != expected {
+			t.Errorf("Expected '%s' for '%s', got '%s' (%v)", expected, peer, normalized, err)
+		}
+	}
+
+	for _, peer := range []string{"", "tracker.example.com:6881", "203.0.113.1:port", "203.0.113.1:70000", "203.0.113"} {
+		if _, err := NormalizePeer(peer); err == nil {
+			t.Errorf("Expected an error for '%s'", peer)
+		}
+	}
+}
+
+// FuzzGetInfoHashes checks that any magnet link either fails to parse, or
+// yields well-formed hashes
+func FuzzGetInfoHashes(f *testing.F) {
+	for _, uri := range []string{
+		"magnet:?xt=urn:btih:dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c&dn=Big+Buck+Bunny",
+		"magnet:?xt=urn:btih:3CCQFAEQAJEE2SHDC3WLOHVWY3YHXMVA",
+		"magnet:?xt=urn:btmh:1220caf1e1c30e81cb361b9ee167c4aa64228a7fa4fa9f6105232b28ad099f3a302e",
+		"magnet:?xt=urn:btih:&xt=urn:btmh:1220",
+		"magnet:?xt=urn:sha1:abc",
+		"magnet:?xt=urn:btih:%zz",
+	} {
+		f.Add(uri)
+	}
+
+	f.Fuzz(func(t *testing.T, uri string) {
+		hashes, err := GetInfoHashes(uri)
+		if err != nil {
+			var parseErr *MagnetParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Expected a MagnetParseError for '%s', got %v", uri, err)
+			}
+			return
+		}
+
+		if hashes.V1 == "" && hashes.V2 == "" {
+			t.Fatalf("Expected a hash for '%s'", uri)
+		}
+		for hash, length := range map[string]int{hashes.V1: v1HashLength, hashes.V2: v2HashLength} {
+			if hash == "" {
+				continue
+			}
+			if normalized, err := NormalizeHash(hash); err != nil || normalized != hash || len(hash) != length {
+				t.Fatalf("Expected a normalized hash of %d characters for '%s', got '%s'", length, uri, hash)
+			}
+		}
+		if len(hashes.ID()) != v1HashLength {
+			t.Fatalf("Expected an ID of %d characters for '%s', got '%s'", v1HashLength, uri, hashes.ID())
+		}
+	})
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package torrentapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+// EventsPath is the path of the Server-Sent Events stream of the changes of
+// the Torrent statuses
+const EventsPath = "/api/v1/torrents/events"
+
+// The types of the events
+const (
+	EventAdded   = "added"
+	EventUpdated = "updated"
+	EventDeleted = "deleted"
+)
+
+const (
+	// eventBuffer is the number of events queued for a stream, a stream
+	// falling further behind is closed for its client to reconnect
+	eventBuffer = 64
+	// heartbeatInterval keeps the idle streams open through the proxies
+	heartbeatInterval = 30 * time.Second
+	// defaultReviewInterval is how long the token and the permissions of
+	// the caller are trusted before a stream reviews them again
+	defaultReviewInterval = 5 * time.Minute
+)
+
+// Event is a change of the status of a Torrent
+type Event struct {
+	Type    string      `json:"type"`
+	Torrent TorrentView `json:"torrent"`
+}
+
+// watchTorrents publishes the changes of the Torrent statuses written by the
+// reconciler on each sync with qBittorrent
+func (s *Server) watchTorrents(ctx context.Context) error {
+	informer, err := s.Informers.GetInformer(ctx, &torrentv1beta1.Torrent{})
+	if err != nil {
+		return err
+	}
+	_, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			if torrent, ok := obj.(*torrentv1beta1.Torrent); ok {
+				s.publish(EventAdded, torrent)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj any) {
+			old, ok := oldObj.(*torrentv1beta1.Torrent)
+			torrent, ok2 := newObj.(*torrentv1beta1.Torrent)
+			if ok && ok2 && !equality.Semantic.DeepEqual(old.Status, torrent.Status) {
+				s.publish(EventUpdated, torrent)
+			}
+		},
+		DeleteFunc: func(obj any) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if torrent, ok := obj.(*torrentv1beta1.Torrent); ok {
+				s.publish(EventDeleted, torrent)
+			}
+		},
+	})
+	return err
+}
+
+// publish sends the event to the streams being served
+func (s *Server) publish(eventType string, torrent *torrentv1beta1.Torrent) {
+	event := Event{Type: eventType, Torrent: statusView(torrent)}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for events := range s.subscribers {
+		select {
+		case events <- event:
+		default:
+			log.V(1).Info("Closing a torrent event stream falling behind")
+			delete(s.subscribers, events)
+			close(events)
+		}
+	}
+}
+
+// subscribe returns the channel of the events published from now on
+func (s *Server) subscribe() chan Event {
+	events := make(chan Event, eventBuffer)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subscribers == nil {
+		s.subscribers = map[chan Event]struct{}{}
+	}
+	s.subscribers[events] = struct{}{}
+	return events
+}
+
+// unsubscribe stops the events of the channel, unless closed by publish
+func (s *Server) unsubscribe(events chan Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.subscribers[events]; ok {
+		delete(s.subscribers, events)
+		close(events)
+	}
+}
+
+// serveEvents streams the changes of the Torrents of the namespaces the
+// caller may list Torrents in, of the namespace query parameter only if set
+func (s *Server) serveEvents(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.Informers == nil {
+		http.Error(w, "torrent events not served", http.StatusNotFound)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	ctx := req.Context()
+
+	user, err := s.authenticate(ctx, req)
+	if err != nil {
+		log.V(1).Info("Unauthenticated torrent API request", "reason", err.Error())
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	namespace := req.URL.Query().Get("namespace")
+
+	events := s.subscribe()
+	defer s.unsubscribe(events)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	interval := s.reviewInterval
+	if interval <= 0 {
+		interval = defaultReviewInterval
+	}
+	review := time.NewTicker(interval)
+	defer review.Stop()
+	// The permissions are reviewed once per namespace until the next review
+	// of the token, a revoked token or binding ending the stream in time
+	allowed := map[string]bool{}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case <-review.C:
+			if user, err = s.authenticate(ctx, req); err != nil {
+				log.V(1).Info("Closing a torrent event stream of an unauthenticated caller", "reason", err.Error())
+				return
+			}
+			allowed = map[string]bool{}
+			continue
+		case event, open := <-events:
+			if !open {
+				return
+			}
+			if namespace != "" && event.Torrent.Namespace != namespace {
+				continue
+			}
+			ok, reviewed := allowed[event.Torrent.Namespace]
+			if !reviewed {
+				if ok, err = s.authorize(ctx, user, event.Torrent.Namespace); err != nil {
+					log.Error(err, "Failed to review the permissions of the caller", "user", user.Username)
+					return
+				}
+				allowed[event.Torrent.Namespace] = ok
+			}
+			if !ok {
+				continue
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				log.Error(err, "Failed to encode the torrent event")
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package torrentapi serves a read-only JSON view of the managed Torrents of
+// all the namespaces, with their live stats from qBittorrent, for the
+// dashboards of users without credentials to the WebUI. The callers
+// authenticate with a Kubernetes token, and only see the Torrents of the
+// namespaces they may list Torrents in.
+package torrentapi
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/clientpool"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// TorrentsPath is the path of the aggregated view of the Torrents
+const TorrentsPath = "/api/v1/torrents"
+
+// shutdownTimeout bounds the wait for the requests in flight on shutdown
+const shutdownTimeout = 10 * time.Second
+
+var log = logf.Log.WithName("torrent-api")
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// Server serves the aggregated view of the Torrents. It is a manager
+// Runnable, serving on every replica.
+type Server struct {
+	// Addr is the address the server binds to
+	Addr string
+	// TLSConfig serves HTTPS, plain HTTP is served if nil
+	TLSConfig *tls.Config
+
+	// Reader lists the Torrents, from the cache of the manager
+	Reader client.Reader
+	// Clientset reviews the tokens and the permissions of the callers
+	Clientset kubernetes.Interface
+	// Clients give the qBittorrent the live stats of each Torrent are read
+	// from, the one of ServerName for the Torrents without spec.serverName
+	Clients    *clientpool.Pool
+	ServerName string
+	// Informers streams the changes of the Torrent statuses, the events are
+	// not served if nil
+	Informers cache.Informers
+
+	// reviewInterval overrides defaultReviewInterval, for the tests
+	reviewInterval time.Duration
+
+	// subscribers are the channels of the event streams being served
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+// TorrentView is a Torrent in the aggregated view
+type TorrentView struct {
+	Namespace string                      `json:"namespace"`
+	Name      string                      `json:"name"`
+	Hash      string                      `json:"hash,omitempty"`
+	Phase     torrentv1beta1.TorrentPhase `json:"phase,omitempty"`
+	State     torrentv1beta1.TorrentState `json:"state,omitempty"`
+	// Progress goes from 0 to 1 once downloaded
+	Progress      float64 `json:"progress"`
+	TotalSize     int64   `json:"totalSize"`
+	AmountLeft    int64   `json:"amountLeft"`
+	DownloadSpeed int64   `json:"downloadSpeed"`
+	UploadSpeed   int64   `json:"uploadSpeed"`
+	Downloaded    int64   `json:"downloaded"`
+	Uploaded      int64   `json:"uploaded"`
+	Ratio         float64 `json:"ratio"`
+	// Live is true when the stats were read from qBittorrent for this
+	// response, false when they are the ones of the status
+	Live bool `json:"live"`
+}
+
+// TorrentList is the response of TorrentsPath
+type TorrentList struct {
+	Torrents []TorrentView `json:"torrents"`
+	// Live is false when the qBittorrent of a Torrent could not be reached,
+	// the stats of its Torrents are then the ones of their status
+	Live bool `json:"live"`
+}
+
+// NeedLeaderElection serves the view on every replica
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the view until the context ends
+func (s *Server) Start(ctx context.Context) error {
+	if s.Informers != nil {
+		if err := s.watchTorrents(ctx); err != nil {
+			return err
+		}
+	}
+
+	server := &http.Server{
+		Handler:           s.handler(),
+		TLSConfig:         s.TLSConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	listener, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+	if s.TLSConfig != nil {
+		listener = tls.NewListener(listener, s.TLSConfig)
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Error(err, "Failed to shut down the torrent API")
+		}
+	}()
+
+	log.Info("Serving the torrent API", "address", listener.Addr().String(), "tls", s.TLSConfig != nil)
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// handler routes the requests of the API
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(TorrentsPath, s.serveTorrents)
+	mux.HandleFunc(EventsPath, s.serveEvents)
+	return mux
+}
+
+// serveTorrents serves the Torrents of the namespaces the caller may list
+// Torrents in, of the namespace query parameter only if set
+func (s *Server) serveTorrents(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := req.Context()
+
+	user, err := s.authenticate(ctx, req)
+	if err != nil {
+		log.V(1).Info("Unauthenticated torrent API request", "reason", err.Error())
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	torrents := &torrentv1beta1.TorrentList{}
+	var opts []client.ListOption
+	if namespace := req.URL.Query().Get("namespace"); namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	if err := s.Reader.List(ctx, torrents, opts...); err != nil {
+		log.Error(err, "Failed to list the Torrents")
+		http.Error(w, "failed to list the Torrents", http.StatusInternalServerError)
+		return
+	}
+
+	// The permissions are reviewed once per namespace
+	allowed := map[string]bool{}
+	var visible []torrentv1beta1.Torrent
+	for _, torrent := range torrents.Items {
+		ok, reviewed := allowed[torrent.Namespace]
+		if !reviewed {
+			if ok, err = s.authorize(ctx, user, torrent.Namespace); err != nil {
+				log.Error(err, "Failed to review the permissions of the caller", "user", user.Username)
+				http.Error(w, "failed to review the permissions", http.StatusInternalServerError)
+				return
+			}
+			allowed[torrent.Namespace] = ok
+		}
+		if ok {
+			visible = append(visible, torrent)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.view(ctx, visible)); err != nil {
+		log.Error(err, "Failed to write the torrent API response")
+	}
+}
+
+// view merges the Torrents with their live stats from qBittorrent, read
+// from a single list of its torrents
+func (s *Server) view(ctx context.Context, torrents []torrentv1beta1.Torrent) TorrentList {
+	list := TorrentList{Torrents: make([]TorrentView, 0, len(torrents))}
+
+	// The torrents of each server are listed once
+	indexes := map[string]qbittorrent.TorrentIndex{}
+	list.Live = len(torrents) > 0
+	for i := range torrents {
+		name := cmp.Or(torrents[i].Spec.ServerName, s.ServerName)
+		if _, ok := indexes[name]; ok {
+			continue
+		}
+		index, err := s.index(ctx, name)
+		if err != nil {
+			log.V(1).Info("qBittorrent unreachable, serving the status of its Torrents",
+				"server", name, "error", err.Error())
+			list.Live = false
+		}
+		indexes[name] = index
+	}
+
+	for i := range torrents {
+		torrent := &torrents[i]
+		index := indexes[cmp.Or(torrent.Spec.ServerName, s.ServerName)]
+		if info := index.Lookup(qbittorrent.InfoHashes{V1: torrent.Status.Hash}); info != nil && torrent.Status.Hash != "" {
+			list.Torrents = append(list.Torrents, liveView(torrent, info))
+		} else {
+			list.Torrents = append(list.Torrents, statusView(torrent))
+		}
+	}
+	slices.SortFunc(list.Torrents, func(a, b TorrentView) int {
+		if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return list
+}
+
+// index lists the torrents of the qBittorrent of a QBittorrentServer
+func (s *Server) index(ctx context.Context, name string) (qbittorrent.TorrentIndex, error) {
+	qbt, err := s.Clients.Client(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := qbt.GetTorrentsInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return qbittorrent.NewTorrentIndex(infos), nil
+}
+
+// statusView is the view of the Torrent with the stats of its status
+func statusView(torrent *torrentv1beta1.Torrent) TorrentView {
+	view := TorrentView{
+		Namespace:  torrent.Namespace,
+		Name:       torrent.Name,
+		Hash:       torrent.Status.Hash,
+		Phase:      torrent.Status.Phase,
+		State:      torrent.Status.State,
+		TotalSize:  torrent.Status.TotalSize,
+		AmountLeft: torrent.Status.AmountLeft,
+	}
+	if transfer := torrent.Status.Transfer; transfer != nil {
+		view.DownloadSpeed, view.UploadSpeed = transfer.DownloadSpeed, transfer.UploadSpeed
+		view.Downloaded, view.Uploaded = transfer.Downloaded, transfer.Uploaded
+	}
+	if view.Downloaded > 0 {
+		view.Ratio = float64(view.Uploaded) / float64(view.Downloaded)
+	}
+	if view.TotalSize > 0 {
+		view.Progress = float64(view.TotalSize-view.AmountLeft) / float64(view.TotalSize)
+	}
+	return view
+}
+
+// liveView is the view of the Torrent with the live stats of qBittorrent
+func liveView(torrent *torrentv1beta1.Torrent, info *qbittorrent.TorrentInfo) TorrentView {
+	view := statusView(torrent)
+	view.State = torrentv1beta1.TorrentState(info.State)
+	view.TotalSize, view.AmountLeft = info.TotalSize, info.AmountLeft
+	view.DownloadSpeed, view.UploadSpeed = info.DLSpeed, info.UPSpeed
+	view.Downloaded, view.Uploaded = info.Downloaded, info.Uploaded
+	view.Ratio = info.Ratio
+	view.Progress = 0
+	if view.TotalSize > 0 {
+		view.Progress = float64(view.TotalSize-view.AmountLeft) / float64(view.TotalSize)
+	}
+	view.Live = true
+	return view
+}
+
+// authenticate reviews the bearer token of the request
+func (s *Server) authenticate(ctx context.Context, req *http.Request) (authenticationv1.UserInfo, error) {
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return authenticationv1.UserInfo{}, errors.New("no bearer token")
+	}
+	review, err := s.Clientset.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return authenticationv1.UserInfo{}, err
+	}
+	if !review.Status.Authenticated {
+		return authenticationv1.UserInfo{}, errors.New("token not authenticated: " + review.Status.Error)
+	}
+	return review.Status.User, nil
+}
+
+// authorize reviews whether the user may list the Torrents of the namespace
+func (s *Server) authorize(ctx context.Context, user authenticationv1.UserInfo, namespace string) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, values := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(values)
+	}
+	review, err := s.Clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "list",
+				Group:     torrentv1beta1.GroupVersion.Group,
+				Resource:  "torrents",
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package torrentapi
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/clientpool"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+const hash = "c9e15763f722f23e98a29decdfae341b98d53056"
+
+func newServer(t *testing.T, qbtURL string) *Server {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := torrentv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: "movie", Namespace: "media"},
+			Status: torrentv1beta1.TorrentStatus{
+				Hash: hash, TotalSize: 400, AmountLeft: 400,
+				Transfer: &torrentv1beta1.TransferStatus{Downloaded: 10, Uploaded: 5},
+			},
+		},
+		&torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "private"},
+		},
+	).Build()
+
+	clientset := kubefake.NewClientset()
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == "valid" {
+			review.Status = authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				User:          authenticationv1.UserInfo{Username: "alice"},
+			}
+		}
+		return true, review, nil
+	})
+	clientset.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		review.Status.Allowed = review.Spec.User == "alice" && attributes.Namespace == "media" &&
+			attributes.Verb == "list" && attributes.Resource == "torrents"
+		return true, review, nil
+	})
+
+	pool := clientpool.New()
+	pool.Add("default", qbittorrent.NewClient(qbtURL), nil)
+	return &Server{Reader: reader, Clientset: clientset, Clients: pool, ServerName: "default"}
+}
+
+func get(t *testing.T, s *Server, target, token string) (*httptest.ResponseRecorder, TorrentList) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	s.serveTorrents(rec, req)
+	var list TorrentList
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+			t.Fatalf("Unexpected response %s: %v", rec.Body, err)
+		}
+	}
+	return rec, list
+}
+
+func TestServeTorrents(t *testing.T) {
+	qbt := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"hash":"` + hash + `","state":"uploading","total_size":400,"amount_left":0,` +
+			`"dlspeed":0,"upspeed":2048,"downloaded":400,"uploaded":800,"ratio":2}]`))
+	}))
+	defer qbt.Close()
+	s := newServer(t, qbt.URL)
+
+	for _, token := range []string{"", "invalid"} {
+		if rec, _ := get(t, s, TorrentsPath, token); rec.Code != http.StatusUnauthorized {
+			t.Errorf("Expected token %q unauthorized, got %d", token, rec.Code)
+		}
+	}
+
+	rec, list := get(t, s, TorrentsPath, "valid")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected OK, got %d: %s", rec.Code, rec.Body)
+	}
+	// Only the Torrents of the namespaces the caller may list are served
+	if !list.Live || len(list.Torrents) != 1 {
+		t.Fatalf("Expected the live Torrent of media only, got %+v", list)
+	}
+	view := list.Torrents[0]
+	if view.Name != "movie" || !view.Live || view.State != "uploading" || view.Progress != 1 ||
+		view.UploadSpeed != 2048 || view.Ratio != 2 {
+		t.Errorf("Expected the live stats of qBittorrent, got %+v", view)
+	}
+
+	if _, list := get(t, s, TorrentsPath+"?namespace=private", "valid"); len(list.Torrents) != 0 {
+		t.Errorf("Expected no Torrents of private, got %+v", list)
+	}
+
+	rec = httptest.NewRecorder()
+	s.serveTorrents(rec, httptest.NewRequest(http.MethodPost, TorrentsPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected POST not allowed, got %d", rec.Code)
+	}
+}
+
+func TestServeTorrents_Unreachable(t *testing.T) {
+	qbt := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer qbt.Close()
+	s := newServer(t, qbt.URL)
+
+	_, list := get(t, s, TorrentsPath, "valid")
+	if list.Live || len(list.Torrents) != 1 {
+		t.Fatalf("Expected the status of the Torrent, got %+v", list)
+	}
+	view := list.Torrents[0]
+	if view.Live || view.Progress != 0 || view.Downloaded != 10 || view.Ratio != 0.5 {
+		t.Errorf("Expected the stats of the status, got %+v", view)
+	}
+}
+
+func TestServeEvents(t *testing.T) {
+	s := newServer(t, "http://127.0.0.1:0")
+	s.Informers = &informertest.FakeInformers{}
+	server := httptest.NewServer(s.handler())
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+EventsPath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := http.DefaultClient.Do(req); err != nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Expected unauthorized, got %v, %v", resp, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer valid")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Expected an event stream, got %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	// The headers are written once subscribed
+	private := &torrentv1beta1.Torrent{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "private"}}
+	movie := &torrentv1beta1.Torrent{
+		ObjectMeta: metav1.ObjectMeta{Name: "movie", Namespace: "media"},
+		Status:     torrentv1beta1.TorrentStatus{Hash: hash, State: "downloading", TotalSize: 400, AmountLeft: 100},
+	}
+	s.publish(EventUpdated, private)
+	s.publish(EventUpdated, movie)
+
+	// Only the events of the namespaces the caller may list are streamed
+	lines := bufio.NewScanner(resp.Body)
+	var stream []string
+	for len(stream) < 2 && lines.Scan() {
+		if lines.Text() != "" {
+			stream = append(stream, lines.Text())
+		}
+	}
+	if len(stream) != 2 || stream[0] != "event: updated" {
+		t.Fatalf("Expected the update of movie, got %v", stream)
+	}
+	var event Event
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(stream[1], "data: ")), &event); err != nil {
+		t.Fatalf("Unexpected event %s: %v", stream[1], err)
+	}
+	if event.Torrent.Name != "movie" || event.Torrent.State != "downloading" || event.Torrent.Progress != 0.75 {
+		t.Errorf("Expected the status of movie, got %+v", event)
+	}
+}
+
+func TestServeEvents_Review(t *testing.T) {
+	s := newServer(t, "http://127.0.0.1:0")
+	s.Informers = &informertest.FakeInformers{}
+	s.reviewInterval = 10 * time.Millisecond
+	server := httptest.NewServer(s.handler())
+	defer server.Close()
+
+	// The reactors are switched rather than prepended while serving
+	var reviews atomic.Int32
+	var unbound, revoked atomic.Bool
+	clientset := s.Clientset.(*kubefake.Clientset)
+	clientset.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return unbound.Load(), action.(k8stesting.CreateAction).GetObject(), nil
+	})
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		reviews.Add(1)
+		return revoked.Load(), action.(k8stesting.CreateAction).GetObject(), nil
+	})
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+EventsPath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer valid")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// The permissions revoked after the stream opened are reviewed again
+	unbound.Store(true)
+	reviewed := reviews.Load() + 1
+	deadline := time.Now().Add(5 * time.Second)
+	for reviews.Load() <= reviewed {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the token to be reviewed again")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	s.publish(EventUpdated, &torrentv1beta1.Torrent{ObjectMeta: metav1.ObjectMeta{Name: "movie", Namespace: "media"}})
+
+	// The revoked token ends the stream
+	revoked.Store(true)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(body), "movie") {
+		t.Errorf("Expected no event once the permissions are revoked, got %s", body)
+	}
+}
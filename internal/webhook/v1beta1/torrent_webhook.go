@@ -0,0 +1,520 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+	"github.com/guidonguido/qbittorrent-operator/internal/qbittorrent"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var torrentlog = logf.Log.WithName("torrent-resource")
+
+// SetupTorrentWebhookWithManager registers the webhook for Torrent in the manager.
+func SetupTorrentWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&torrentv1beta1.Torrent{}).
+		WithValidator(&TorrentCustomValidator{}).
+		WithDefaulter(&TorrentCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-torrent-qbittorrent-io-v1beta1-torrent,mutating=true,failurePolicy=fail,sideEffects=None,groups=torrent.qbittorrent.io,resources=torrents,verbs=create,versions=v1beta1,name=mtorrent-v1beta1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:rbac:groups=torrent.qbittorrent.io,resources=torrentpolicies;torrentcategories,verbs=get;list;watch
+
+// TorrentCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind Torrent when those are created. Defaults come from the TorrentCategory of the category of the
+// Torrent, then from the TorrentPolicies of the namespace.
+type TorrentCustomDefaulter struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomDefaulter = &TorrentCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind Torrent.
+func (d *TorrentCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	torrent, ok := obj.(*torrentv1beta1.Torrent)
+	if !ok {
+		return fmt.Errorf("expected a Torrent object but got %T", obj)
+	}
+	torrentlog.Info("Defaulting for Torrent", "name", torrent.GetName())
+
+	policies := &torrentv1beta1.TorrentPolicyList{}
+	if err := d.Client.List(ctx, policies, client.InNamespace(torrent.Namespace)); err != nil {
+		return fmt.Errorf("failed to list torrent policies: %w", err)
+	}
+
+	// The first policy setting a field wins
+	slices.SortFunc(policies.Items, func(a, b torrentv1beta1.TorrentPolicy) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	// The defaults of the category win over the ones of the policies, the
+	// category left unset is the one of the policies
+	for _, policy := range policies.Items {
+		if torrent.Spec.Category == "" {
+			torrent.Spec.Category = policy.Spec.Defaults.Category
+		}
+	}
+	category, err := d.torrentCategory(ctx, torrent)
+	if err != nil {
+		return err
+	}
+	if category != nil {
+		applyCategoryDefaults(&torrent.Spec, &category.Spec)
+	}
+
+	for _, policy := range policies.Items {
+		applyTorrentDefaults(&torrent.Spec, &policy.Spec.Defaults)
+	}
+
+	return nil
+}
+
+// torrentCategory returns the TorrentCategory of the category of the torrent,
+// nil if none. The first one in name order wins.
+func (d *TorrentCustomDefaulter) torrentCategory(ctx context.Context,
+	torrent *torrentv1beta1.Torrent) (*torrentv1beta1.TorrentCategory, error) {
+	if torrent.Spec.Category == "" {
+		return nil, nil
+	}
+	categories := &torrentv1beta1.TorrentCategoryList{}
+	if err := d.Client.List(ctx, categories, client.InNamespace(torrent.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list torrent categories: %w", err)
+	}
+	slices.SortFunc(categories.Items, func(a, b torrentv1beta1.TorrentCategory) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for i, category := range categories.Items {
+		name := category.Spec.Category
+		if name == "" {
+			name = category.Name
+		}
+		if name == torrent.Spec.Category {
+			return &categories.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// applyCategoryDefaults fills the unset fields of spec from the TorrentCategory
+func applyCategoryDefaults(spec *torrentv1beta1.TorrentSpec, category *torrentv1beta1.TorrentCategorySpec) {
+	if goal := category.SeedGoal; goal != nil {
+		applyLimitDefaults(spec, &torrentv1beta1.TorrentLimits{
+			RatioLimit:       goal.Ratio,
+			SeedingTimeLimit: goal.SeedingTime,
+		})
+	}
+	if category.Limits != nil {
+		applyLimitDefaults(spec, category.Limits)
+	}
+
+	onComplete := category.OnComplete
+	if onComplete == nil {
+		return
+	}
+	if spec.ContentVolume == nil {
+		spec.ContentVolume = onComplete.ContentVolume
+	}
+	if spec.Checksums == nil {
+		spec.Checksums = onComplete.Checksums
+	}
+	if spec.ContentVerification == nil {
+		spec.ContentVerification = onComplete.ContentVerification
+	}
+}
+
+// applyTorrentDefaults fills the unset fields of spec from defaults
+func applyTorrentDefaults(spec *torrentv1beta1.TorrentSpec, defaults *torrentv1beta1.TorrentDefaults) {
+	if spec.Category == "" {
+		spec.Category = defaults.Category
+	}
+	if spec.SavePath == "" {
+		spec.SavePath = defaults.SavePath
+	}
+	if spec.DeletionPolicy == "" {
+		spec.DeletionPolicy = defaults.DeletionPolicy
+	}
+
+	if defaults.Limits != nil {
+		applyLimitDefaults(spec, defaults.Limits)
+	}
+}
+
+// applyLimitDefaults fills the unset limits of spec from limits
+func applyLimitDefaults(spec *torrentv1beta1.TorrentSpec, limits *torrentv1beta1.TorrentLimits) {
+	if spec.Limits == nil {
+		spec.Limits = &torrentv1beta1.TorrentLimits{}
+	}
+	if spec.Limits.DownloadLimit == nil {
+		spec.Limits.DownloadLimit = limits.DownloadLimit
+	}
+	if spec.Limits.UploadLimit == nil {
+		spec.Limits.UploadLimit = limits.UploadLimit
+	}
+	if spec.Limits.RatioLimit == "" {
+		spec.Limits.RatioLimit = limits.RatioLimit
+	}
+	if spec.Limits.SeedingTimeLimit == nil {
+		spec.Limits.SeedingTimeLimit = limits.SeedingTimeLimit
+	}
+}
+
+// +kubebuilder:webhook:path=/validate-torrent-qbittorrent-io-v1beta1-torrent,mutating=false,failurePolicy=fail,sideEffects=None,groups=torrent.qbittorrent.io,resources=torrents,verbs=create;update,versions=v1beta1,name=vtorrent-v1beta1.kb.io,admissionReviewVersions=v1
+
+// TorrentCustomValidator struct is responsible for validating the Torrent resource
+// when it is created, updated, or deleted.
+type TorrentCustomValidator struct{}
+
+var _ webhook.CustomValidator = &TorrentCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Torrent.
+func (v *TorrentCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	torrent, ok := obj.(*torrentv1beta1.Torrent)
+	if !ok {
+		return nil, fmt.Errorf("expected a Torrent object but got %T", obj)
+	}
+	torrentlog.Info("Validation for Torrent upon creation", "name", torrent.GetName())
+
+	return nil, validateTorrent(torrent)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Torrent.
+func (v *TorrentCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	torrent, ok := newObj.(*torrentv1beta1.Torrent)
+	if !ok {
+		return nil, fmt.Errorf("expected a Torrent object for the newObj but got %T", newObj)
+	}
+	oldTorrent, ok := oldObj.(*torrentv1beta1.Torrent)
+	if !ok {
+		return nil, fmt.Errorf("expected a Torrent object for the oldObj but got %T", oldObj)
+	}
+	torrentlog.Info("Validation for Torrent upon update", "name", torrent.GetName())
+
+	// The finalizer of a Torrent being deleted must always be removable
+	if torrent.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	// Only the fields changed by the update are checked, so the Torrents stored
+	// before a rule existed can still be updated by the controller
+	allErrs := changedErrors(torrentErrors(torrent), torrentErrors(oldTorrent))
+	allErrs = append(allErrs, validateTorrentSpecUpdate(&torrent.Spec, &oldTorrent.Spec, field.NewPath("spec"))...)
+
+	return nil, invalidTorrent(torrent, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Torrent.
+func (v *TorrentCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	// Deletion is always allowed, the finalizer takes care of the cleanup
+	return nil, nil
+}
+
+// validateTorrent returns an Invalid error listing every problem of the Torrent spec
+func validateTorrent(torrent *torrentv1beta1.Torrent) error {
+	return invalidTorrent(torrent, torrentErrors(torrent))
+}
+
+// torrentErrors lists every problem of the Torrent spec
+func torrentErrors(torrent *torrentv1beta1.Torrent) field.ErrorList {
+	allErrs := validateTorrentSpec(&torrent.Spec, field.NewPath("spec"))
+	return append(allErrs, validateDependsOn(torrent.Name, torrent.Spec.DependsOn, field.NewPath("spec", "dependsOn"))...)
+}
+
+// changedErrors drops the errors already reported, for the same value, by the
+// old object: the fields left untouched by an update are not rejected again
+func changedErrors(allErrs, oldErrs field.ErrorList) field.ErrorList {
+	var changed field.ErrorList
+	for _, err := range allErrs {
+		if !slices.ContainsFunc(oldErrs, func(oldErr *field.Error) bool {
+			return oldErr.Type == err.Type && oldErr.Field == err.Field &&
+				reflect.DeepEqual(oldErr.BadValue, err.BadValue)
+		}) {
+			changed = append(changed, err)
+		}
+	}
+	return changed
+}
+
+// validateDependsOn rejects the dependencies which cannot name a Torrent, and
+// the Torrent depending on itself
+func validateDependsOn(name string, dependsOn []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, dependency := range dependsOn {
+		if dependency == name {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), dependency, "a Torrent cannot depend on itself"))
+			continue
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(dependency) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), dependency, msg))
+		}
+	}
+	return allErrs
+}
+
+// invalidTorrent wraps the validation errors of a Torrent into an Invalid error
+func invalidTorrent(torrent *torrentv1beta1.Torrent, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(
+		torrentv1beta1.GroupVersion.WithKind("Torrent").GroupKind(),
+		torrent.Name, allErrs)
+}
+
+func validateTorrentSpec(spec *torrentv1beta1.TorrentSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, validateTorrentSource(&spec.Source, fldPath.Child("source"))...)
+
+	if spec.Category != "" {
+		if err := validateCategory(spec.Category); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("category"), spec.Category, err.Error()))
+		}
+	}
+
+	if spec.Limits != nil {
+		allErrs = append(allErrs, validateTorrentLimits(spec.Limits, fldPath.Child("limits"))...)
+	}
+	if spec.DailyTransferBudget != nil && spec.DailyTransferBudget.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("dailyTransferBudget"), spec.DailyTransferBudget.String(),
+			"must be greater than 0"))
+	}
+
+	allErrs = append(allErrs, validateContentVolume(spec, fldPath)...)
+
+	for i, pattern := range spec.ExcludeFiles {
+		if _, err := path.Match(pattern, ""); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("excludeFiles").Index(i), pattern,
+				"must be a valid glob pattern"))
+		}
+	}
+
+	allErrs = append(allErrs, validateCrossSeed(spec.CrossSeed, fldPath.Child("crossSeed"))...)
+
+	return allErrs
+}
+
+// validateContentVolume rejects a content volume without a claim, and the
+// checks of the content which cannot run without one
+func validateContentVolume(spec *torrentv1beta1.TorrentSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.ContentVolume != nil {
+		volPath := fldPath.Child("contentVolume")
+		if spec.ContentVolume.ClaimName == "" {
+			allErrs = append(allErrs, field.Required(volPath.Child("claimName"), "a PersistentVolumeClaim name is required"))
+		}
+		if mountPath := spec.ContentVolume.MountPath; mountPath != "" && !path.IsAbs(mountPath) {
+			allErrs = append(allErrs, field.Invalid(volPath.Child("mountPath"), mountPath, "must be an absolute path"))
+		}
+	}
+
+	if spec.Checksums != nil && spec.Checksums.Enabled && spec.ContentVolume == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("contentVolume"),
+			"checksums require the content volume to be set"))
+	}
+	if spec.ContentVerification != nil && spec.ContentVerification.Enabled && spec.ContentVolume == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("contentVolume"),
+			"content verification requires the content volume to be set"))
+	}
+	if spec.HardlinkCheck != nil && spec.HardlinkCheck.Enabled && spec.ContentVolume == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("contentVolume"),
+			"the hardlink check requires the content volume to be set"))
+	}
+
+	return allErrs
+}
+
+// validateCrossSeed rejects the cross-seed sources without a unique name or
+// with an invalid source
+func validateCrossSeed(sources []torrentv1beta1.CrossSeedSource, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	names := map[string]bool{}
+	for i, source := range sources {
+		srcPath := fldPath.Index(i)
+
+		if source.Name == "" {
+			allErrs = append(allErrs, field.Required(srcPath.Child("name"), "a name is required"))
+		} else if names[source.Name] {
+			allErrs = append(allErrs, field.Duplicate(srcPath.Child("name"), source.Name))
+		}
+		names[source.Name] = true
+
+		switch {
+		case source.MagnetURI == "" && source.TorrentURL == "":
+			allErrs = append(allErrs, field.Required(srcPath,
+				"exactly one of magnetURI or torrentURL must be set"))
+		case source.MagnetURI != "" && source.TorrentURL != "":
+			allErrs = append(allErrs, field.Forbidden(srcPath,
+				"magnetURI and torrentURL are mutually exclusive"))
+		case source.MagnetURI != "":
+			if err := validateMagnetURI(source.MagnetURI); err != nil {
+				allErrs = append(allErrs, field.Invalid(srcPath.Child("magnetURI"), source.MagnetURI, err.Error()))
+			}
+		default:
+			if err := validateTorrentURL(source.TorrentURL); err != nil {
+				allErrs = append(allErrs, field.Invalid(srcPath.Child("torrentURL"), source.TorrentURL, err.Error()))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateTorrentSpecUpdate rejects changes to the torrent sources. Changing them
+// would leave the previous torrent orphaned in qBittorrent.
+func validateTorrentSpecUpdate(spec, oldSpec *torrentv1beta1.TorrentSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := apivalidation.ValidateImmutableField(spec.Source, oldSpec.Source, fldPath.Child("source"))
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(spec.ServerName, oldSpec.ServerName,
+		fldPath.Child("serverName"))...)
+
+	oldSources := map[string]torrentv1beta1.CrossSeedSource{}
+	for _, source := range oldSpec.CrossSeed {
+		oldSources[source.Name] = source
+	}
+	for i, source := range spec.CrossSeed {
+		if oldSource, ok := oldSources[source.Name]; ok {
+			allErrs = append(allErrs, apivalidation.ValidateImmutableField(source, oldSource,
+				fldPath.Child("crossSeed").Index(i))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateTorrentSource(source *torrentv1beta1.TorrentSource, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	set := 0
+	for _, value := range []bool{source.MagnetURI != "", source.TorrentURL != "", len(source.TorrentData) > 0} {
+		if value {
+			set++
+		}
+	}
+	switch {
+	case set == 0:
+		return append(allErrs, field.Required(fldPath,
+			"exactly one of magnetURI, torrentURL or torrentData must be set"))
+	case set > 1:
+		return append(allErrs, field.Forbidden(fldPath,
+			"magnetURI, torrentURL and torrentData are mutually exclusive"))
+	}
+
+	switch {
+	case source.MagnetURI != "":
+		if err := validateMagnetURI(source.MagnetURI); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("magnetURI"), source.MagnetURI, err.Error()))
+		}
+	case source.TorrentURL != "":
+		if err := validateTorrentURL(source.TorrentURL); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("torrentURL"), source.TorrentURL, err.Error()))
+		}
+	default:
+		// The info hash is computed from the file, reject it now rather than
+		// failing every reconcile
+		if _, err := qbittorrent.ParseTorrentFile(source.TorrentData); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("torrentData"), "<torrent file>", err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+func validateTorrentLimits(limits *torrentv1beta1.TorrentLimits, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if limits.DownloadLimit != nil && *limits.DownloadLimit < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("downloadLimit"), *limits.DownloadLimit,
+			"must be greater than or equal to 0"))
+	}
+	if limits.UploadLimit != nil && *limits.UploadLimit < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("uploadLimit"), *limits.UploadLimit,
+			"must be greater than or equal to 0"))
+	}
+	if limits.RatioLimit != "" {
+		if ratio, err := strconv.ParseFloat(limits.RatioLimit, 64); err != nil || ratio < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ratioLimit"), limits.RatioLimit,
+				"must be a decimal number greater than or equal to 0"))
+		}
+	}
+	if limits.SeedingTimeLimit != nil && limits.SeedingTimeLimit.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("seedingTimeLimit"), limits.SeedingTimeLimit.Duration.String(),
+			"must not be negative"))
+	}
+
+	return allErrs
+}
+
+// validateCategory mirrors the category name rules of qBittorrent: no
+// backslashes, no leading or trailing slash and no empty subcategory
+func validateCategory(category string) error {
+	if strings.Contains(category, "\\") {
+		return fmt.Errorf("must not contain backslashes")
+	}
+	if strings.HasPrefix(category, "/") || strings.HasSuffix(category, "/") {
+		return fmt.Errorf("must not start or end with a slash")
+	}
+	if strings.Contains(category, "//") {
+		return fmt.Errorf("must not contain empty subcategories")
+	}
+	return nil
+}
+
+// validateMagnetURI checks the magnet link carries a BitTorrent v1 or v2 info
+// hash, parsed the same way the controller does
+func validateMagnetURI(magnetURI string) error {
+	_, err := qbittorrent.GetInfoHashes(magnetURI)
+	return err
+}
+
+// validateTorrentURL checks the .torrent URL can be fetched by qBittorrent
+func validateTorrentURL(torrentURL string) error {
+	u, err := url.Parse(torrentURL)
+	if err != nil {
+		return fmt.Errorf("malformed URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("must be an http or https URL")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("must include a host")
+	}
+	return nil
+}
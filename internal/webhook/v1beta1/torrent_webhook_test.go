@@ -0,0 +1,507 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	torrentv1alpha1 "github.com/guidonguido/qbittorrent-operator/api/v1alpha1"
+	torrentv1beta1 "github.com/guidonguido/qbittorrent-operator/api/v1beta1"
+)
+
+const (
+	validMagnet      = "magnet:?xt=urn:btih:dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c&dn=Big+Buck+Bunny"
+	validTorrentFile = "d4:infod6:lengthi5e4:name4:test12:piece lengthi16384e6:pieces20:aaaaaaaaaaaaaaaaaaaaee"
+)
+
+var _ = Describe("Torrent Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *torrentv1beta1.Torrent
+		oldObj    *torrentv1beta1.Torrent
+		validator TorrentCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &torrentv1beta1.Torrent{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-torrent", Namespace: "default"},
+			Spec: torrentv1beta1.TorrentSpec{
+				Source: torrentv1beta1.TorrentSource{MagnetURI: validMagnet},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = TorrentCustomValidator{}
+	})
+
+	Context("When creating or updating Torrent under Validating Webhook", func() {
+		It("Should admit a valid magnet URI", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should admit base32 and v2 info hashes", func() {
+			obj.Spec.Source.MagnetURI = "magnet:?xt=urn:btih:3WBFL3G4PSSV7MF37AJSHWDQMLNR63I4"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Source.MagnetURI = "magnet:?xt=urn:btmh:1220" +
+				"caf1e1c30e81cb361b9ee167c4aa64228a7fa4fa9f6105232b28ad099f3a302e"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a missing magnet URI", func() {
+			obj.Spec.Source.MagnetURI = ""
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+		})
+
+		It("Should deny a magnet URI without a valid info hash", func() {
+			for _, magnet := range []string{
+				"http://example.com/file.torrent",
+				"magnet:?dn=no-hash",
+				"magnet:?xt=urn:btih:not-a-hash",
+				"magnet:?xt=urn:btih:dd8255ecdc7ca55fb0bbf81323d87062db1f6d1",
+			} {
+				obj.Spec.Source.MagnetURI = magnet
+				Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred(), magnet)
+			}
+		})
+
+		It("Should admit .torrent sources", func() {
+			obj.Spec.Source = torrentv1beta1.TorrentSource{TorrentURL: "https://tracker.example.com/file.torrent"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Source = torrentv1beta1.TorrentSource{TorrentData: []byte(validTorrentFile)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny sources with more than one or an invalid source set", func() {
+			obj.Spec.Source.TorrentURL = "https://tracker.example.com/file.torrent"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+
+			obj.Spec.Source = torrentv1beta1.TorrentSource{TorrentURL: "ftp://tracker.example.com/file.torrent"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+
+			obj.Spec.Source = torrentv1beta1.TorrentSource{TorrentData: []byte("not a torrent file")}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+		})
+
+		It("Should deny cross-seed sources with both or no source set", func() {
+			obj.Spec.CrossSeed = []torrentv1beta1.CrossSeedSource{{
+				Name:       "both",
+				MagnetURI:  validMagnet,
+				TorrentURL: "https://tracker.example.com/file.torrent",
+			}}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+
+			obj.Spec.CrossSeed = []torrentv1beta1.CrossSeedSource{{Name: "none"}}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+		})
+
+		It("Should deny cross-seed sources with an invalid torrent URL", func() {
+			obj.Spec.CrossSeed = []torrentv1beta1.CrossSeedSource{{
+				Name:       "ftp",
+				TorrentURL: "ftp://tracker.example.com/file.torrent",
+			}}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+		})
+
+		It("Should deny checksums without a content volume", func() {
+			obj.Spec.Checksums = &torrentv1beta1.ChecksumSpec{Enabled: true}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+
+			obj.Spec.ContentVolume = &torrentv1beta1.ContentVolume{ClaimName: "downloads"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny content verification without a content volume", func() {
+			obj.Spec.ContentVerification = &torrentv1beta1.ContentVerificationSpec{Enabled: true}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+
+			obj.Spec.ContentVolume = &torrentv1beta1.ContentVolume{ClaimName: "downloads"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny the hardlink check without a content volume", func() {
+			obj.Spec.HardlinkCheck = &torrentv1beta1.HardlinkCheckSpec{Enabled: true}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+
+			obj.Spec.ContentVolume = &torrentv1beta1.ContentVolume{ClaimName: "downloads"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a daily transfer budget that is not positive", func() {
+			obj.Spec.DailyTransferBudget = ptr.To(resource.MustParse("0"))
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+
+			obj.Spec.DailyTransferBudget = ptr.To(resource.MustParse("10Gi"))
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny changing the magnet URI", func() {
+			obj.Spec.Source.MagnetURI = "magnet:?xt=urn:btih:3WBFL3G4PSSV7MF37AJSHWDQMLNR63I4"
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(HaveOccurred())
+		})
+
+		It("Should deny moving the Torrent to another server", func() {
+			obj.Spec.ServerName = "seedbox"
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(HaveOccurred())
+		})
+
+		It("Should deny changing a cross-seed source but allow adding and removing them", func() {
+			oldObj.Spec.CrossSeed = []torrentv1beta1.CrossSeedSource{{
+				Name:       "other-tracker",
+				TorrentURL: "https://tracker.example.com/file.torrent",
+			}}
+
+			obj.Spec.CrossSeed = []torrentv1beta1.CrossSeedSource{{
+				Name:       "other-tracker",
+				TorrentURL: "https://tracker.example.com/other.torrent",
+			}}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(HaveOccurred())
+
+			obj.Spec.CrossSeed = []torrentv1beta1.CrossSeedSource{{
+				Name:       "third-tracker",
+				TorrentURL: "https://tracker.example.com/other.torrent",
+			}}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny negative limits and an invalid ratio", func() {
+			obj.Spec.Limits = &torrentv1beta1.TorrentLimits{DownloadLimit: ptr.To[int64](-1)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+
+			obj.Spec.Limits = &torrentv1beta1.TorrentLimits{RatioLimit: "two"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+
+			obj.Spec.Limits = &torrentv1beta1.TorrentLimits{
+				DownloadLimit: ptr.To[int64](0),
+				UploadLimit:   ptr.To[int64](1024),
+				RatioLimit:    "1.5",
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny invalid category names", func() {
+			for _, category := range []string{"/movies", "movies/", "movies//hd", `movies\hd`} {
+				obj.Spec.Category = category
+				Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred(), category)
+			}
+
+			obj.Spec.Category = "movies/hd"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny invalid excluded file patterns", func() {
+			obj.Spec.ExcludeFiles = []string{"*.nfo", "[sample"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+
+			obj.Spec.ExcludeFiles = []string{"*.nfo", "*.sample.*", "Extras/*"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny invalid dependencies and a Torrent depending on itself", func() {
+			for _, dependency := range []string{"test-torrent", "Part_1", ""} {
+				obj.Spec.DependsOn = []string{"part-1", dependency}
+				Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred(), dependency)
+				Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(HaveOccurred(), dependency)
+			}
+
+			obj.Spec.DependsOn = []string{"part-1", "part-2"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should only deny the invalid fields changed by an update", func() {
+			oldObj.Spec.Category = "/movies"
+			oldObj.Spec.Limits = &torrentv1beta1.TorrentLimits{RatioLimit: "two"}
+
+			obj = oldObj.DeepCopy()
+			obj.Annotations = map[string]string{"example.com/note": "stored before the rules"}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Category = "movies/"
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(HaveOccurred())
+
+			obj.Spec.Category = "movies"
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should admit any update of a Torrent being deleted", func() {
+			oldObj.Spec.Category = "/movies"
+			oldObj.Finalizers = []string{"torrent.qbittorrent.io/finalizer"}
+			oldObj.DeletionTimestamp = ptr.To(metav1.Now())
+
+			obj = oldObj.DeepCopy()
+			obj.Finalizers = nil
+			obj.Spec.Category = "movies//hd"
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should always admit deletion", func() {
+			obj.Spec.Source.MagnetURI = ""
+			Expect(validator.ValidateDelete(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When converting Torrent under Conversion Webhook", func() {
+		It("Should round-trip through v1alpha1 without losing fields", func() {
+			obj.Spec.ServerName = "seedbox"
+			obj.Spec.Category = "movies"
+			obj.Spec.DeletionPolicy = torrentv1beta1.DeletionPolicyKeepFiles
+			obj.Spec.DeletionProtection = torrentv1beta1.DeletionProtectionWhileIncomplete
+			obj.Spec.MissingFilesPolicy = torrentv1beta1.MissingFilesPolicyRedownload
+			obj.Spec.Priority = torrentv1beta1.TorrentPriorityHigh
+			obj.Spec.StatusDetail = torrentv1beta1.StatusDetailFiles
+			obj.Spec.ReportPeers = true
+			obj.Spec.WebSeeds = []string{"https://mirror.example.com/big_buck_bunny/"}
+			obj.Spec.DailyTransferBudget = ptr.To(resource.MustParse("10Gi"))
+			obj.Spec.Limits = &torrentv1beta1.TorrentLimits{
+				UploadLimit:      ptr.To[int64](1024),
+				SeedingTimeLimit: &metav1.Duration{Duration: time.Hour},
+			}
+			obj.Spec.DriftPolicy = &torrentv1beta1.DriftPolicy{Limits: torrentv1beta1.DriftActionEnforce}
+			obj.Spec.ContentVolume = &torrentv1beta1.ContentVolume{ClaimName: "downloads", MountPath: "/downloads"}
+			obj.Spec.Checksums = &torrentv1beta1.ChecksumSpec{Enabled: true, Image: "busybox:1.36"}
+			obj.Spec.ContentVerification = &torrentv1beta1.ContentVerificationSpec{Enabled: true, Image: "busybox:1.36"}
+			obj.Spec.HardlinkCheck = &torrentv1beta1.HardlinkCheckSpec{Enabled: true, Image: "busybox:1.36"}
+			obj.Spec.CrossSeed = []torrentv1beta1.CrossSeedSource{{
+				Name:       "other-tracker",
+				TorrentURL: "https://tracker.example.com/file.torrent",
+			}}
+			obj.Spec.DependsOn = []string{"part-1"}
+			obj.Spec.ApprovalRequired = true
+			obj.Spec.MetadataOnly = true
+			obj.Spec.ExcludeFiles = []string{"*.nfo"}
+			obj.Spec.CompletionDeadline = &metav1.Duration{Duration: 6 * time.Hour}
+			obj.Spec.DeadlineEscalation = &torrentv1beta1.DeadlineEscalation{
+				Before:        metav1.Duration{Duration: time.Hour},
+				BoostPriority: true,
+				RemoveLimits:  true,
+			}
+			obj.Status = torrentv1beta1.TorrentStatus{
+				Hash:                "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c",
+				State:               "uploading",
+				Phase:               torrentv1beta1.TorrentPhaseSeeding,
+				ContentPath:         "/downloads/Big Buck Bunny",
+				TotalSize:           276445467,
+				LastSyncedTime:      &metav1.Time{Time: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)},
+				Tags:                []string{"k8s:team=media"},
+				ChecksumConfigMap:   "test-torrent-checksums",
+				ContentVerifiedTime: &metav1.Time{Time: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)},
+				QueuedPriority:      torrentv1beta1.TorrentPriorityHigh,
+				Preempted:           true,
+				Drift:               []torrentv1beta1.FieldDrift{{Field: "category", Desired: "movies", Actual: "tv"}},
+				CrossSeeds:          []torrentv1beta1.CrossSeedStatus{{Name: "other-tracker", State: "stalledUP"}},
+				Files:               []torrentv1beta1.TorrentFileStatus{{Name: "movie.mp4", Size: 276134947, Progress: 100, Priority: 1}},
+				Transfer:            &torrentv1beta1.TransferStatus{Downloaded: 276445467, Uploaded: 1024, UploadSpeed: 512, LastActivityTime: &metav1.Time{Time: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}},
+				TruncatedFiles:      3,
+				Peers: &torrentv1beta1.PeerSummary{Connected: 2, Encrypted: 1,
+					Clients: []torrentv1beta1.PeerCount{{Name: "qBittorrent 4.6.5", Count: 2}}, Countries: []torrentv1beta1.PeerCount{{Name: "DE", Count: 2}}},
+				WebSeeds: []string{"https://mirror.example.com/big_buck_bunny/"},
+				TrackerRecovery: &torrentv1beta1.TrackerRecoveryStatus{AddedTrackers: []string{"udp://tracker.example.com:1337/announce"},
+					LastAddedTime: &metav1.Time{Time: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}, RevivedBy: "udp://tracker.example.com:1337/announce"},
+				MissingFilesRedownloadTime: &metav1.Time{Time: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)},
+				Deadline: &torrentv1beta1.DeadlineStatus{DeadlineTime: metav1.Time{Time: time.Date(2025, 6, 1, 18, 0, 0, 0, time.UTC)},
+					CompletionTime: &metav1.Time{Time: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}},
+				Throttle: &torrentv1beta1.ThrottleStatus{DownloadLimit: 524288, UploadLimit: 131072,
+					Since: metav1.Time{Time: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}},
+				ReconcileStats: &torrentv1beta1.ReconcileStats{ConsecutiveFailures: 2, LastError: "connection refused",
+					LastReconcileTime: &metav1.Time{Time: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}},
+				SpecHash:   "3f2a9c1d7e4b8a60",
+				Conditions: []metav1.Condition{{Type: "Available", Status: metav1.ConditionTrue}},
+			}
+
+			spoke := &torrentv1alpha1.Torrent{}
+			Expect(spoke.ConvertFrom(obj)).To(Succeed())
+			Expect(spoke.Spec.MagnetURI).To(Equal(validMagnet))
+			Expect(spoke.Status.ContentPath).To(Equal(obj.Status.ContentPath))
+
+			hub := &torrentv1beta1.Torrent{}
+			Expect(spoke.ConvertTo(hub)).To(Succeed())
+			Expect(hub).To(Equal(obj))
+		})
+
+		It("Should keep .torrent sources through v1alpha1 in an annotation", func() {
+			obj.Spec.Source = torrentv1beta1.TorrentSource{TorrentData: []byte(validTorrentFile)}
+
+			spoke := &torrentv1alpha1.Torrent{}
+			Expect(spoke.ConvertFrom(obj)).To(Succeed())
+			Expect(spoke.Annotations).To(HaveKey(torrentv1alpha1.AnnotationSource))
+			Expect(obj.Annotations).NotTo(HaveKey(torrentv1alpha1.AnnotationSource))
+
+			hub := &torrentv1beta1.Torrent{}
+			Expect(spoke.ConvertTo(hub)).To(Succeed())
+			Expect(hub.Spec.Source).To(Equal(obj.Spec.Source))
+			Expect(hub.Annotations).NotTo(HaveKey(torrentv1alpha1.AnnotationSource))
+		})
+
+		It("Should round-trip TorrentPolicy through v1alpha1", func() {
+			policy := &torrentv1beta1.TorrentPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"},
+				Spec: torrentv1beta1.TorrentPolicySpec{
+					Defaults: torrentv1beta1.TorrentDefaults{
+						Category:       "movies",
+						SavePath:       "/downloads/movies",
+						DeletionPolicy: torrentv1beta1.DeletionPolicyOrphan,
+						Limits:         &torrentv1beta1.TorrentLimits{RatioLimit: "2.0"},
+					},
+					StalledRecovery: &torrentv1beta1.StalledRecovery{
+						FallbackTrackers: []string{"udp://tracker.example.com:1337/announce"},
+						StalledFor:       &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			}
+
+			spoke := &torrentv1alpha1.TorrentPolicy{}
+			Expect(spoke.ConvertFrom(policy)).To(Succeed())
+			hub := &torrentv1beta1.TorrentPolicy{}
+			Expect(spoke.ConvertTo(hub)).To(Succeed())
+			Expect(hub).To(Equal(policy))
+		})
+	})
+
+	Context("When creating Torrent under Defaulting Webhook", func() {
+		newDefaulter := func(policies ...client.Object) *TorrentCustomDefaulter {
+			scheme := runtime.NewScheme()
+			Expect(torrentv1beta1.AddToScheme(scheme)).To(Succeed())
+			return &TorrentCustomDefaulter{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(policies...).Build(),
+			}
+		}
+
+		It("Should leave the spec untouched without policies", func() {
+			Expect(newDefaulter().Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec).To(Equal(oldObj.Spec))
+		})
+
+		It("Should fill the unset fields from the namespace policies", func() {
+			defaulter := newDefaulter(
+				&torrentv1beta1.TorrentPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "a-policy", Namespace: "default"},
+					Spec: torrentv1beta1.TorrentPolicySpec{Defaults: torrentv1beta1.TorrentDefaults{
+						Category: "movies",
+						Limits:   &torrentv1beta1.TorrentLimits{UploadLimit: ptr.To[int64](1024)},
+					}},
+				},
+				&torrentv1beta1.TorrentPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "b-policy", Namespace: "default"},
+					Spec: torrentv1beta1.TorrentPolicySpec{Defaults: torrentv1beta1.TorrentDefaults{
+						Category:       "ignored",
+						SavePath:       "/downloads/movies",
+						DeletionPolicy: torrentv1beta1.DeletionPolicyKeepFiles,
+						Limits: &torrentv1beta1.TorrentLimits{
+							UploadLimit: ptr.To[int64](2048),
+							RatioLimit:  "2.0",
+						},
+					}},
+				},
+				&torrentv1beta1.TorrentPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "other"},
+					Spec: torrentv1beta1.TorrentPolicySpec{Defaults: torrentv1beta1.TorrentDefaults{
+						DeletionPolicy: torrentv1beta1.DeletionPolicyOrphan,
+					}},
+				},
+			)
+			obj.Spec.SavePath = "/downloads/custom"
+
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Category).To(Equal("movies"))
+			Expect(obj.Spec.SavePath).To(Equal("/downloads/custom"))
+			Expect(obj.Spec.DeletionPolicy).To(Equal(torrentv1beta1.DeletionPolicyKeepFiles))
+			Expect(obj.Spec.Limits.UploadLimit).To(Equal(ptr.To[int64](1024)))
+			Expect(obj.Spec.Limits.RatioLimit).To(Equal("2.0"))
+			Expect(obj.Spec.Limits.DownloadLimit).To(BeNil())
+		})
+
+		It("Should fill the unset fields from the TorrentCategory before the policies", func() {
+			defaulter := newDefaulter(
+				&torrentv1beta1.TorrentPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"},
+					Spec: torrentv1beta1.TorrentPolicySpec{Defaults: torrentv1beta1.TorrentDefaults{
+						Category: "tv",
+						SavePath: "/downloads",
+						Limits: &torrentv1beta1.TorrentLimits{
+							DownloadLimit: ptr.To[int64](4096),
+							RatioLimit:    "1.0",
+						},
+					}},
+				},
+				&torrentv1beta1.TorrentCategory{
+					ObjectMeta: metav1.ObjectMeta{Name: "tv", Namespace: "default"},
+					Spec: torrentv1beta1.TorrentCategorySpec{
+						SeedGoal: &torrentv1beta1.SeedGoal{
+							Ratio:       "3.0",
+							SeedingTime: &metav1.Duration{Duration: 720 * time.Hour},
+						},
+						Limits: &torrentv1beta1.TorrentLimits{
+							UploadLimit: ptr.To[int64](1024),
+							RatioLimit:  "2.0",
+						},
+						OnComplete: &torrentv1beta1.OnComplete{
+							ContentVolume: &torrentv1beta1.ContentVolume{ClaimName: "media", MountPath: "/downloads"},
+							Checksums:     &torrentv1beta1.ChecksumSpec{Enabled: true, Image: "busybox:1.36"},
+						},
+					},
+				},
+				&torrentv1beta1.TorrentCategory{
+					ObjectMeta: metav1.ObjectMeta{Name: "tv-hd", Namespace: "default"},
+					Spec: torrentv1beta1.TorrentCategorySpec{
+						Category: "tv/hd",
+						SeedGoal: &torrentv1beta1.SeedGoal{Ratio: "5.0"},
+					},
+				},
+				&torrentv1beta1.TorrentCategory{
+					ObjectMeta: metav1.ObjectMeta{Name: "tv", Namespace: "other"},
+					Spec: torrentv1beta1.TorrentCategorySpec{
+						SeedGoal: &torrentv1beta1.SeedGoal{Ratio: "9.0"},
+					},
+				},
+			)
+
+			By("picking the TorrentCategory of the category defaulted by the policies")
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Category).To(Equal("tv"))
+			Expect(obj.Spec.SavePath).To(Equal("/downloads"))
+			Expect(obj.Spec.Limits.RatioLimit).To(Equal("3.0"))
+			Expect(obj.Spec.Limits.SeedingTimeLimit.Duration).To(Equal(720 * time.Hour))
+			Expect(obj.Spec.Limits.UploadLimit).To(Equal(ptr.To[int64](1024)))
+			Expect(obj.Spec.Limits.DownloadLimit).To(Equal(ptr.To[int64](4096)))
+			Expect(obj.Spec.ContentVolume.ClaimName).To(Equal("media"))
+			Expect(obj.Spec.Checksums.Enabled).To(BeTrue())
+			Expect(obj.Spec.ContentVerification).To(BeNil())
+
+			By("picking the TorrentCategory of a subcategory set in its spec")
+			hd := oldObj.DeepCopy()
+			hd.Spec.Category = "tv/hd"
+			hd.Spec.Limits = &torrentv1beta1.TorrentLimits{SeedingTimeLimit: &metav1.Duration{Duration: time.Hour}}
+			Expect(defaulter.Default(ctx, hd)).To(Succeed())
+			Expect(hd.Spec.Limits.RatioLimit).To(Equal("5.0"))
+			Expect(hd.Spec.Limits.SeedingTimeLimit.Duration).To(Equal(time.Hour))
+			Expect(hd.Spec.ContentVolume).To(BeNil())
+		})
+	})
+})
@@ -17,11 +17,13 @@ limitations under the License.
 package e2e
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -39,6 +41,9 @@ const serviceAccountName = "qbittorrent-operator-controller-manager"
 // metricsServiceName is the name of the metrics service of the project
 const metricsServiceName = "qbittorrent-operator-controller-manager-metrics-service"
 
+// torrentsNamespace is where the Torrents of the lifecycle tests are created
+const torrentsNamespace = "e2e-torrents"
+
 // metricsRoleBindingName is the name of the RBAC that will be created to allow get the metrics data
 const metricsRoleBindingName = "qbittorrent-operator-metrics-binding"
 
@@ -65,6 +70,9 @@ var _ = Describe("Manager", Ordered, func() {
 		_, err = utils.Run(cmd)
 		Expect(err).NotTo(HaveOccurred(), "Failed to install CRDs")
 
+		By("deploying qBittorrent")
+		Expect(deployQBittorrent()).To(Succeed(), "Failed to deploy qBittorrent")
+
 		By("deploying the controller-manager")
 		cmd = exec.Command("make", "deploy", fmt.Sprintf("IMG=%s", projectImage))
 		_, err = utils.Run(cmd)
@@ -89,6 +97,9 @@ var _ = Describe("Manager", Ordered, func() {
 		By("removing manager namespace")
 		cmd = exec.Command("kubectl", "delete", "ns", namespace)
 		_, _ = utils.Run(cmd)
+
+		By("undeploying qBittorrent")
+		undeployQBittorrent()
 	})
 
 	// After each test, check for failures and collect logs, events,
@@ -256,19 +267,154 @@ var _ = Describe("Manager", Ordered, func() {
 			))
 		})
 
+		It("should provisioned cert-manager", func() {
+			By("validating that cert-manager has the certificate Secret")
+			verifyCertManager := func(g Gomega) {
+				cmd := exec.Command("kubectl", "get", "secrets", "webhook-server-cert", "-n", namespace)
+				_, err := utils.Run(cmd)
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			Eventually(verifyCertManager).Should(Succeed())
+		})
+
+		It("should have CA injection for validating webhooks", func() {
+			By("checking CA injection for validating webhooks")
+			verifyCAInjection := func(g Gomega) {
+				cmd := exec.Command("kubectl", "get",
+					"validatingwebhookconfigurations.admissionregistration.k8s.io",
+					"qbittorrent-operator-validating-webhook-configuration",
+					"-o", "go-template={{ range .webhooks }}{{ .clientConfig.caBundle }}{{ end }}")
+				vwhOutput, err := utils.Run(cmd)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(len(vwhOutput)).To(BeNumerically(">", 10))
+			}
+			Eventually(verifyCAInjection).Should(Succeed())
+		})
+
+		It("should have CA injection for mutating webhooks", func() {
+			By("checking CA injection for mutating webhooks")
+			verifyCAInjection := func(g Gomega) {
+				cmd := exec.Command("kubectl", "get",
+					"mutatingwebhookconfigurations.admissionregistration.k8s.io",
+					"qbittorrent-operator-mutating-webhook-configuration",
+					"-o", "go-template={{ range .webhooks }}{{ .clientConfig.caBundle }}{{ end }}")
+				mwhOutput, err := utils.Run(cmd)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(len(mwhOutput)).To(BeNumerically(">", 10))
+			}
+			Eventually(verifyCAInjection).Should(Succeed())
+		})
+
+		It("should have CA injection for Torrent conversion webhook", func() {
+			By("checking CA injection for Torrent conversion webhook")
+			verifyCAInjection := func(g Gomega) {
+				cmd := exec.Command("kubectl", "get",
+					"customresourcedefinitions.apiextensions.k8s.io",
+					"torrents.torrent.qbittorrent.io",
+					"-o", "go-template={{ .spec.conversion.webhook.clientConfig.caBundle }}")
+				crdOutput, err := utils.Run(cmd)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(len(crdOutput)).To(BeNumerically(">", 10))
+			}
+			Eventually(verifyCAInjection).Should(Succeed())
+		})
+
+		It("should have CA injection for TorrentPolicy conversion webhook", func() {
+			By("checking CA injection for TorrentPolicy conversion webhook")
+			verifyCAInjection := func(g Gomega) {
+				cmd := exec.Command("kubectl", "get",
+					"customresourcedefinitions.apiextensions.k8s.io",
+					"torrentpolicies.torrent.qbittorrent.io",
+					"-o", "go-template={{ .spec.conversion.webhook.clientConfig.caBundle }}")
+				crdOutput, err := utils.Run(cmd)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(len(crdOutput)).To(BeNumerically(">", 10))
+			}
+			Eventually(verifyCAInjection).Should(Succeed())
+		})
+
 		// +kubebuilder:scaffold:e2e-webhooks-checks
+	})
+
+	Context("Torrent lifecycle", Ordered, func() {
+		BeforeAll(func() {
+			By("creating the Torrents namespace")
+			cmd := exec.Command("kubectl", "create", "ns", torrentsNamespace)
+			_, err := utils.Run(cmd)
+			Expect(err).NotTo(HaveOccurred(), "Failed to create namespace")
+		})
+
+		AfterAll(func() {
+			By("removing the Torrents namespace")
+			cmd := exec.Command("kubectl", "delete", "ns", torrentsNamespace, "--timeout=2m")
+			_, _ = utils.Run(cmd)
+		})
 
-		// TODO: Customize the e2e test suite with scenarios specific to your project.
-		// Consider applying sample/CR(s) and check their status and/or verifying
-		// the reconciliation by using the metrics, i.e.:
-		// metricsOutput := getMetricsOutput()
-		// Expect(metricsOutput).To(ContainSubstring(
-		//    fmt.Sprintf(`controller_runtime_reconcile_total{controller="%s",result="success"} 1`,
-		//    strings.ToLower(<Kind>),
-		// ))
+		It("should manage a torrent added from a magnet URI", func() {
+			const hash = "dd8255ecdc7ca55fb0bbf81323d87062db1f6d1c"
+			verifyTorrentLifecycle("big-buck-bunny", hash, fmt.Sprintf(
+				`magnetURI: "magnet:?xt=urn:btih:%s&dn=Big+Buck+Bunny"`, hash))
+		})
+
+		It("should manage a torrent added from a .torrent file", func() {
+			data, hash := singleFileTorrent("e2e.txt", []byte("qbittorrent-operator e2e\n"))
+			verifyTorrentLifecycle("e2e-file", hash, fmt.Sprintf(
+				"torrentData: %s", base64.StdEncoding.EncodeToString(data)))
+		})
 	})
 })
 
+// verifyTorrentLifecycle creates a Torrent with the source, and verifies that
+// the torrent is added to qBittorrent, that its status is synced, and that it
+// is removed from qBittorrent with the Torrent.
+func verifyTorrentLifecycle(name, hash, source string) {
+	By("creating the Torrent")
+	Expect(applyManifest(fmt.Sprintf(`apiVersion: torrent.qbittorrent.io/v1beta1
+kind: Torrent
+metadata:
+  name: %s
+  namespace: %s
+spec:
+  source:
+    %s
+  deletionPolicy: Delete
+`, name, torrentsNamespace, source))).To(Succeed(), "Failed to create the Torrent")
+
+	By("verifying that the torrent is added to qBittorrent")
+	verifyTorrentAdded := func(g Gomega) {
+		hashes, err := qbittorrentHashes()
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(hashes).To(ContainElement(hash))
+	}
+	Eventually(verifyTorrentAdded).Should(Succeed())
+
+	By("verifying that the Torrent status is synced")
+	verifyStatusSynced := func(g Gomega) {
+		cmd := exec.Command("kubectl", "get", "torrents.torrent.qbittorrent.io", name,
+			"-n", torrentsNamespace, "-o", "jsonpath={.status.hash} {.status.state}")
+		output, err := utils.Run(cmd)
+		g.Expect(err).NotTo(HaveOccurred())
+		fields := strings.Fields(output)
+		g.Expect(fields).To(HaveLen(2), "Torrent status not yet synced")
+		g.Expect(fields[0]).To(Equal(hash))
+	}
+	Eventually(verifyStatusSynced).Should(Succeed())
+
+	By("deleting the Torrent")
+	cmd := exec.Command("kubectl", "delete", "torrents.torrent.qbittorrent.io", name,
+		"-n", torrentsNamespace, "--timeout=2m")
+	_, err := utils.Run(cmd)
+	Expect(err).NotTo(HaveOccurred(), "Failed to delete the Torrent")
+
+	By("verifying that the torrent is removed from qBittorrent")
+	verifyTorrentRemoved := func(g Gomega) {
+		hashes, err := qbittorrentHashes()
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(hashes).NotTo(ContainElement(hash))
+	}
+	Eventually(verifyTorrentRemoved).Should(Succeed())
+}
+
 // serviceAccountToken returns a token for the specified service account in the given namespace.
 // It uses the Kubernetes TokenRequest API to generate a token by directly sending a request
 // and parsing the resulting token from the API response.
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/guidonguido/qbittorrent-operator/test/utils"
+)
+
+// qbittorrentNamespace is where qBittorrent is deployed, the operator
+// connects to it with the URL of config/default
+const qbittorrentNamespace = "media-server"
+
+// qbittorrentManifest deploys qBittorrent with the WebUI authentication bypassed
+const qbittorrentManifest = "test/e2e/testdata/qbittorrent.yaml"
+
+// qbTorrent is a torrent listed by qBittorrent
+type qbTorrent struct {
+	Hash  string `json:"hash"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+// deployQBittorrent deploys qBittorrent and waits for its WebUI to be ready
+func deployQBittorrent() error {
+	cmd := exec.Command("kubectl", "apply", "-f", qbittorrentManifest)
+	if _, err := utils.Run(cmd); err != nil {
+		return err
+	}
+	cmd = exec.Command("kubectl", "rollout", "status", "deployment/qbittorrent",
+		"-n", qbittorrentNamespace, "--timeout=5m")
+	_, err := utils.Run(cmd)
+	return err
+}
+
+// undeployQBittorrent removes qBittorrent and its namespace
+func undeployQBittorrent() {
+	cmd := exec.Command("kubectl", "delete", "--ignore-not-found", "-f", qbittorrentManifest)
+	_, _ = utils.Run(cmd)
+}
+
+// listQBittorrentTorrents lists the torrents of qBittorrent from its WebUI API
+func listQBittorrentTorrents() ([]qbTorrent, error) {
+	cmd := exec.Command("kubectl", "exec", "deployment/qbittorrent", "-n", qbittorrentNamespace,
+		"-c", "qbittorrent", "--", "curl", "-sf", "http://localhost:8080/api/v2/torrents/info")
+	output, err := utils.Run(cmd)
+	if err != nil {
+		return nil, err
+	}
+	var torrents []qbTorrent
+	if err := json.Unmarshal([]byte(output), &torrents); err != nil {
+		return nil, fmt.Errorf("invalid torrent list %q: %w", output, err)
+	}
+	return torrents, nil
+}
+
+// qbittorrentHashes returns the hashes of the torrents of qBittorrent
+func qbittorrentHashes() ([]string, error) {
+	torrents, err := listQBittorrentTorrents()
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]string, 0, len(torrents))
+	for _, torrent := range torrents {
+		hashes = append(hashes, torrent.Hash)
+	}
+	return hashes, nil
+}
+
+// applyManifest applies a manifest passed on the standard input of kubectl
+func applyManifest(manifest string) error {
+	cmd := exec.Command("kubectl", "apply", "-f", "-")
+	cmd.Stdin = strings.NewReader(manifest)
+	_, err := utils.Run(cmd)
+	return err
+}
+
+// singleFileTorrent builds the .torrent file of a single file torrent with the
+// content, and returns it with its info hash
+func singleFileTorrent(name string, content []byte) ([]byte, string) {
+	const pieceLength = 16384
+	var pieces []byte
+	for start := 0; start < len(content); start += pieceLength {
+		piece := sha1.Sum(content[start:min(start+pieceLength, len(content))])
+		pieces = append(pieces, piece[:]...)
+	}
+
+	info := fmt.Sprintf("d6:lengthi%de4:name%d:%s12:piece lengthi%de6:pieces%d:%se",
+		len(content), len(name), name, pieceLength, len(pieces), pieces)
+	hash := sha1.Sum([]byte(info))
+	return []byte("d4:info" + info + "e"), hex.EncodeToString(hash[:])
+}